@@ -7,10 +7,12 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"ssamai/internal/collector"
 	"ssamai/internal/config"
+	"ssamai/internal/exporter"
 	"ssamai/internal/service"
 	"ssamai/pkg/models"
 
@@ -24,6 +26,17 @@ var (
 	collectDateTo    string
 	collectIncludeFiles bool
 	collectIncludeCmds  bool
+	collectExcludeKeywords []string
+	collectWorkers      int
+	collectReadRate     float64
+	collectFSRetries    int
+	collectReportRejected bool
+	collectMergeConversations bool
+	collectCaptureEnv   bool
+	collectSourcesFile  string
+	collectExcludeSources []string
+	collectFiles        []string
+	collectCompactJSON  bool
 )
 
 // NewCollectCmd는 서비스 레이어를 주입받아 collect 명령어를 생성합니다.
@@ -46,7 +59,16 @@ func NewCollectCmd(collectSvc *service.CollectService) *cobra.Command {
   ssamai collect --all --from 2024-01-01 --to 2024-01-31
 
   # 파일과 명령어 정보 포함하여 수집
-  ssamai collect --all --include-files --include-commands`,
+  ssamai collect --all --include-files --include-commands
+
+  # 특정 키워드가 포함된 세션은 저장하지 않고 제외
+  ssamai collect --all --exclude-keyword password --exclude-keyword internal
+
+  # 느린 디스크에서 전체 동시 파일 처리 수를 2개로 제한
+  ssamai collect --all --workers 2
+
+  # 디렉토리를 탐색하지 않고 지정한 파일만 파싱
+  ssamai collect --sources gemini_cli --files a.json,b.jsonl`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runCollectWithService(cmd, args, collectSvc)
 		},
@@ -65,9 +87,33 @@ func NewCollectCmd(collectSvc *service.CollectService) *cobra.Command {
 		"파일 참조 정보 포함")
 	cmd.Flags().BoolVar(&collectIncludeCmds, "include-commands", false,
 		"실행된 명령어 정보 포함")
+	cmd.Flags().StringSliceVar(&collectExcludeKeywords, "exclude-keyword", []string{},
+		"메시지에 포함된 경우 세션을 제외할 키워드 (대소문자 무시, 반복 지정 가능)")
+	cmd.Flags().IntVar(&collectWorkers, "workers", 0,
+		"모든 수집기가 공유하는 최대 동시 파일 처리 수 (0이면 수집기별 기본값)")
+	cmd.Flags().Float64Var(&collectReadRate, "read-rate", 0,
+		"초당 최대 파일 읽기 수 (네트워크 파일 시스템용, 0이면 제한 없음)")
+	cmd.Flags().IntVar(&collectFSRetries, "fs-retries", 0,
+		"일시적인 파일 읽기/탐색 오류 시 재시도 횟수 (지수 백오프와 지터 적용, 파일 없음은 재시도 안 함, 0이면 재시도 안 함)")
+	cmd.Flags().BoolVar(&collectReportRejected, "report-rejected", false,
+		"파싱에 실패해 건너뛴 히스토리 라인 수와 라인 번호를 수집 결과 에러로 보고")
+	cmd.Flags().BoolVar(&collectMergeConversations, "merge-conversations", false,
+		"같은 소스에서 conversation_id가 같은 세션(히스토리 항목, 세션 파일)을 하나의 대화로 병합")
+	cmd.Flags().BoolVar(&collectCaptureEnv, "capture-env", false,
+		"호스트, OS, 도구 버전, 설정 파일 경로를 수집 결과 메타데이터에 기록 (재현성 확인용)")
+	cmd.Flags().StringVar(&collectSourcesFile, "sources-from-file", "",
+		"수집할 데이터 소스 목록 파일 (한 줄에 하나, '#' 주석 허용)")
+	cmd.Flags().StringSliceVar(&collectExcludeSources, "exclude-source", []string{},
+		"수집에서 제외할 데이터 소스 (--all과 함께 사용, 반복 지정 가능)")
+	cmd.Flags().StringSliceVar(&collectFiles, "files", []string{},
+		"디렉토리 탐색과 패턴 없이 파싱할 파일 목록 (.jsonl은 히스토리 라인, 그 밖은 세션 파일, 하나의 소스와 함께 사용)")
+	cmd.Flags().BoolVar(&collectCompactJSON, "compact-json", false,
+		"수집 결과와 latest.json을 들여쓰기 없이 저장해 파일 크기를 줄임")
 
 	// 플래그 검증
 	cmd.MarkFlagsMutuallyExclusive("all", "sources")
+	cmd.MarkFlagsMutuallyExclusive("all", "sources-from-file")
+	cmd.MarkFlagsMutuallyExclusive("sources", "sources-from-file")
 	
 	return cmd
 }
@@ -79,7 +125,7 @@ func runCollectWithService(cmd *cobra.Command, args []string, collectSvc *servic
 	}
 
 	// 설정 로드 (필요시)
-	cfg, err := config.LoadConfig(cfgFile)
+	cfg, err := loadProjectConfig(cfgFile)
 	if err != nil {
 		return fmt.Errorf("설정 로드 실패: %w", err)
 	}
@@ -121,7 +167,7 @@ func runCollect(cmd *cobra.Command, args []string) error {
 	}
 
 	// 설정 로드
-	cfg, err := config.LoadConfig(cfgFile)
+	cfg, err := loadProjectConfig(cfgFile)
 	if err != nil {
 		return fmt.Errorf("설정 로드 실패: %w", err)
 	}
@@ -156,6 +202,11 @@ func runCollect(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// writeDataFile은 수집 데이터 파일을 원자적으로 씁니다 (테스트에서 쓰기 실패를 흉내 낼 수 있도록 변수로 둠)
+var writeDataFile = func(name string, data []byte) error {
+	return exporter.WriteFileAtomic(name, data, 0644)
+}
+
 // saveCollectedData는 수집된 데이터를 파일로 저장합니다
 func saveCollectedData(result *models.CollectionResult) error {
 	// 데이터 저장 디렉토리 생성
@@ -164,19 +215,23 @@ func saveCollectedData(result *models.CollectionResult) error {
 		return fmt.Errorf("데이터 디렉토리 생성 실패: %w", err)
 	}
 
+	// 목록/통계 명령어가 재처리 없이 사용할 수 있도록 통계 스냅샷 포함
+	result.Statistics = models.NewCollectionStatistics(result.Sessions)
+	result.SchemaVersion = models.CurrentSchemaVersion
+
 	// 파일명 생성 (타임스탬프 기반)
 	timestamp := result.CollectedAt.Format("20060102-150405")
 	filename := fmt.Sprintf("collection-%s.json", timestamp)
 	filePath := filepath.Join(dataDir, filename)
 
 	// JSON 데이터 생성
-	data, err := json.MarshalIndent(result, "", "  ")
+	data, err := marshalCollectionResult(result, collectCompactJSON)
 	if err != nil {
 		return fmt.Errorf("JSON 직렬화 실패: %w", err)
 	}
 
-	// 파일 저장
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
+	// 파일 저장 (중단되어도 잘린 데이터 파일이 남지 않도록 임시 파일에 쓴 뒤 이름 변경)
+	if err := writeDataFile(filePath, data); err != nil {
 		return fmt.Errorf("파일 저장 실패: %w", err)
 	}
 
@@ -186,13 +241,9 @@ func saveCollectedData(result *models.CollectionResult) error {
 
 	// 최신 데이터 심볼릭 링크 또는 파일 생성
 	latestPath := filepath.Join(dataDir, "latest.json")
-	// 기존 파일이 있으면 삭제
-	if _, err := os.Stat(latestPath); err == nil {
-		os.Remove(latestPath)
-	}
-	
-	// 최신 데이터 복사 (심볼릭 링크 대신 복사 사용 - 더 안전함)
-	if err := os.WriteFile(latestPath, data, 0644); err != nil {
+
+	// 최신 데이터 복사 (심볼릭 링크 대신 복사 사용 - 더 안전함, 기존 파일은 이름 변경으로 교체)
+	if err := writeDataFile(latestPath, data); err != nil {
 		if verbose {
 			fmt.Printf("경고: 최신 데이터 링크 생성 실패 - %v\n", err)
 		}
@@ -201,6 +252,15 @@ func saveCollectedData(result *models.CollectionResult) error {
 	return nil
 }
 
+// marshalCollectionResult는 수집 결과를 JSON으로 직렬화합니다
+// 기본은 읽기 쉬운 들여쓰기 형식이며, compact이면 들여쓰기 없이 작은 파일을 만듭니다
+func marshalCollectionResult(result *models.CollectionResult, compact bool) ([]byte, error) {
+	if compact {
+		return json.Marshal(result)
+	}
+	return json.MarshalIndent(result, "", "  ")
+}
+
 // getDataDirectory는 데이터 저장 디렉토리 경로를 반환합니다
 func getDataDirectory() string {
 	return filepath.Join(".", ".ssamai", "data")
@@ -212,6 +272,14 @@ func buildCollectionConfig(cfg *config.Config) (*models.CollectionConfig, error)
 		IncludeCommands: collectIncludeCmds,
 		OutputPath:      outputPath,
 		Template:        cfg.OutputSettings.DefaultTemplate,
+		ExcludeKeywords: collectExcludeKeywords,
+		Workers:         collectWorkers,
+		ReadRate:        collectReadRate,
+		FSRetries:       collectFSRetries,
+		ReportRejected:  collectReportRejected,
+		MergeConversations: collectMergeConversations,
+		CaptureEnv:      collectCaptureEnv,
+		ConfigPath:      cfgFile,
 	}
 
 	// 소스 결정
@@ -222,50 +290,167 @@ func buildCollectionConfig(cfg *config.Config) (*models.CollectionConfig, error)
 			models.SourceAmazonQ,
 		}
 	} else if len(collectSources) > 0 {
-		sources := make([]models.CollectionSource, 0, len(collectSources))
-		for _, source := range collectSources {
-			switch source {
-			case "claude_code":
-				sources = append(sources, models.SourceClaudeCode)
-			case "gemini_cli":
-				sources = append(sources, models.SourceGeminiCLI)
-			case "amazon_q":
-				sources = append(sources, models.SourceAmazonQ)
-			default:
-				return nil, fmt.Errorf("알 수 없는 데이터 소스: %s", source)
-			}
+		sources, err := parseSourceNames(collectSources)
+		if err != nil {
+			return nil, err
+		}
+		collectCfg.Sources = sources
+	} else if collectSourcesFile != "" {
+		names, err := readSourcesFile(collectSourcesFile)
+		if err != nil {
+			return nil, err
+		}
+		sources, err := parseSourceNames(names)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", collectSourcesFile, err)
 		}
 		collectCfg.Sources = sources
 	} else {
-		return nil, fmt.Errorf("--all 또는 --sources 플래그를 지정해야 합니다")
+		return nil, fmt.Errorf("--all 또는 --sources 플래그를 지정해야 합니다 (또는 --sources-from-file)")
 	}
 
-	// 날짜 범위 설정
-	if collectDateFrom != "" || collectDateTo != "" {
-		dateRange := &models.DateRange{}
-		
-		if collectDateFrom != "" {
-			from, err := time.Parse("2006-01-02", collectDateFrom)
-			if err != nil {
-				return nil, fmt.Errorf("시작 날짜 형식 오류: %w", err)
-			}
-			dateRange.Start = from
+	// 제외 소스 적용
+	if len(collectExcludeSources) > 0 {
+		excluded, err := parseSourceNames(collectExcludeSources)
+		if err != nil {
+			return nil, fmt.Errorf("--exclude-source: %w", err)
 		}
-		
-		if collectDateTo != "" {
-			to, err := time.Parse("2006-01-02", collectDateTo)
+		collectCfg.Sources = excludeSources(collectCfg.Sources, excluded)
+		if len(collectCfg.Sources) == 0 {
+			return nil, fmt.Errorf("모든 데이터 소스가 제외되었습니다")
+		}
+	}
+
+	if collectWorkers < 0 {
+		return nil, fmt.Errorf("--workers는 0 이상이어야 합니다: %d", collectWorkers)
+	}
+
+	if collectReadRate < 0 {
+		return nil, fmt.Errorf("--read-rate는 0 이상이어야 합니다: %g", collectReadRate)
+	}
+
+	if collectFSRetries < 0 {
+		return nil, fmt.Errorf("--fs-retries는 0 이상이어야 합니다: %d", collectFSRetries)
+	}
+
+	// 지정한 파일만 수집 (파일 형식이 소스마다 다르므로 소스는 하나만 허용)
+	if len(collectFiles) > 0 {
+		if len(collectCfg.Sources) != 1 {
+			return nil, fmt.Errorf("--files는 하나의 소스와 함께 사용해야 합니다 (예: --sources gemini_cli)")
+		}
+		// ~로 시작하는 경로는 확장한 뒤 확인
+		files := make([]string, 0, len(collectFiles))
+		for _, file := range collectFiles {
+			expanded, err := config.ExpandPath(file)
 			if err != nil {
-				return nil, fmt.Errorf("종료 날짜 형식 오류: %w", err)
+				return nil, fmt.Errorf("--files: %w", err)
+			}
+			if _, err := os.Stat(expanded); err != nil {
+				return nil, fmt.Errorf("--files: 파일을 확인할 수 없습니다: %w", err)
 			}
-			dateRange.End = to.Add(24 * time.Hour - time.Second) // 해당 날짜의 끝까지
+			files = append(files, expanded)
 		}
-		
-		collectCfg.DateRange = dateRange
+		collectCfg.Files = files
 	}
 
+	// 날짜 범위 설정
+	dateRange, err := parseDateRange(collectDateFrom, collectDateTo)
+	if err != nil {
+		return nil, err
+	}
+	collectCfg.DateRange = dateRange
+
 	return collectCfg, nil
 }
 
+// parseDateRange는 --from/--to 값(YYYY-MM-DD)으로 날짜 범위를 만듭니다
+// 둘 다 비어 있으면 nil을 반환하고, 시작 날짜가 종료 날짜보다 늦으면 에러를 반환합니다
+func parseDateRange(from, to string) (*models.DateRange, error) {
+	if from == "" && to == "" {
+		return nil, nil
+	}
+
+	dateRange := &models.DateRange{}
+
+	if from != "" {
+		start, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			return nil, fmt.Errorf("시작 날짜 형식 오류: %w", err)
+		}
+		dateRange.Start = start
+	}
+
+	if to != "" {
+		end, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			return nil, fmt.Errorf("종료 날짜 형식 오류: %w", err)
+		}
+		dateRange.End = end.Add(24*time.Hour - time.Second) // 해당 날짜의 끝까지
+	}
+
+	// 뒤바뀐 범위는 조용히 빈 결과를 내므로 명시적으로 거부
+	if !dateRange.Start.IsZero() && !dateRange.End.IsZero() && dateRange.Start.After(dateRange.End) {
+		return nil, fmt.Errorf("시작 날짜(%s)가 종료 날짜(%s)보다 늦습니다", from, to)
+	}
+
+	return dateRange, nil
+}
+
+// parseSourceNames는 소스 이름 목록을 수집 소스로 변환합니다
+func parseSourceNames(names []string) ([]models.CollectionSource, error) {
+	sources := make([]models.CollectionSource, 0, len(names))
+	for _, name := range names {
+		source, err := models.ParseSource(name)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+// excludeSources는 소스 목록에서 제외 대상 소스를 제거합니다
+func excludeSources(sources, excluded []models.CollectionSource) []models.CollectionSource {
+	skip := make(map[models.CollectionSource]bool, len(excluded))
+	for _, source := range excluded {
+		skip[source] = true
+	}
+
+	filtered := make([]models.CollectionSource, 0, len(sources))
+	for _, source := range sources {
+		if !skip[source] {
+			filtered = append(filtered, source)
+		}
+	}
+	return filtered
+}
+
+// readSourcesFile은 한 줄에 하나씩 나열된 소스 이름을 파일에서 읽습니다
+// 빈 줄과 '#' 이후의 주석은 무시합니다
+func readSourcesFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("소스 목록 파일 읽기 실패: %w", err)
+	}
+
+	var names []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		names = append(names, line)
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("소스 목록 파일에 소스가 없습니다: %s", path)
+	}
+	return names, nil
+}
+
 func executeCollection(cfg *models.CollectionConfig) (*models.CollectionResult, error) {
 	startTime := time.Now()
 	result := &models.CollectionResult{
@@ -274,6 +459,9 @@ func executeCollection(cfg *models.CollectionConfig) (*models.CollectionResult,
 		Sessions:    make([]models.SessionData, 0),
 		Errors:      make([]string, 0),
 	}
+	if cfg.CaptureEnv {
+		result.Metadata = service.CaptureEnvironment(cfg.ConfigPath)
+	}
 
 	if verbose {
 		fmt.Printf("수집 대상 소스: %v\n", cfg.Sources)
@@ -288,7 +476,12 @@ func executeCollection(cfg *models.CollectionConfig) (*models.CollectionResult,
 		sessions, err := collectFromSource(source, cfg)
 		if err != nil {
 			errMsg := fmt.Sprintf("소스 '%s' 수집 실패: %v", source, err)
-			result.Errors = append(result.Errors, errMsg)
+			result.AddIssue(models.CollectionIssue{
+				Source:   source,
+				Phase:    models.IssuePhaseCollect,
+				Severity: models.IssueSeverityError,
+				Message:  errMsg,
+			})
 			log.Printf("경고: %s\n", errMsg)
 			continue
 		}
@@ -301,6 +494,7 @@ func executeCollection(cfg *models.CollectionConfig) (*models.CollectionResult,
 
 	result.TotalCount = len(result.Sessions)
 	result.Duration = time.Since(startTime)
+	result.UpdateFallback()
 
 	return result, nil
 }
@@ -327,7 +521,7 @@ func collectClaudeCodeData(cfg *models.CollectionConfig) ([]models.SessionData,
 	}
 	
 	// 설정 로드
-	appConfig, err := config.LoadConfig(cfgFile)
+	appConfig, err := loadProjectConfig(cfgFile)
 	if err != nil {
 		return nil, fmt.Errorf("설정 로드 실패: %w", err)
 	}
@@ -379,7 +573,7 @@ func collectGeminiCLIData(cfg *models.CollectionConfig) ([]models.SessionData, e
 	}
 	
 	// 설정에서 Gemini CLI 설정 가져오기
-	appConfig, err := config.LoadConfig(cfgFile)
+	appConfig, err := loadProjectConfig(cfgFile)
 	if err != nil {
 		return nil, fmt.Errorf("설정 로드 실패: %w", err)
 	}
@@ -430,7 +624,7 @@ func collectAmazonQData(cfg *models.CollectionConfig) ([]models.SessionData, err
 	}
 	
 	// 설정 로드
-	appConfig, err := config.LoadConfig(cfgFile)
+	appConfig, err := loadProjectConfig(cfgFile)
 	if err != nil {
 		return nil, fmt.Errorf("설정 로드 실패: %w", err)
 	}
@@ -476,20 +670,51 @@ func collectAmazonQData(cfg *models.CollectionConfig) ([]models.SessionData, err
 	return sessions, nil
 }
 
+// printScanStats는 세션 디렉토리별 스캔 카운터와 세션을 얻지 못한 이유를 출력합니다
+func printScanStats(stats []models.DirScanStats) {
+	fmt.Println("\n세션 디렉토리 스캔:")
+	for _, s := range stats {
+		fmt.Printf("  - [%s] %s: 파일 %d개 발견, %d개 일치, %d개 파싱\n",
+			s.Source, s.Dir, s.FilesScanned, s.FilesMatched, s.FilesParsed)
+		if diagnosis := s.Diagnosis(); diagnosis != "" {
+			fmt.Printf("    → %s\n", diagnosis)
+		}
+	}
+}
+
+// formatScanSummary는 소스 하나의 파일 처리 결과를 한 줄로 요약합니다
+// 예: gemini_cli: 120 files scanned, 95 parsed, 20 skipped (pattern), 5 errors
+func formatScanSummary(s models.DirScanStats) string {
+	return fmt.Sprintf("%s: %d files scanned, %d parsed, %d skipped (pattern), %d errors",
+		s.Source, s.FilesScanned, s.FilesParsed, s.FilesSkipped(), s.FilesErrored)
+}
+
 func printCollectionResult(result *models.CollectionResult) {
 	fmt.Println("\n=== 데이터 수집 완료 ===")
 	fmt.Printf("총 수집된 세션: %d개\n", result.TotalCount)
 	fmt.Printf("수집 대상 소스: %v\n", result.Sources)
 	fmt.Printf("수집 시간: %v\n", result.Duration.Round(time.Millisecond))
 	fmt.Printf("수집 완료 시각: %s\n", result.CollectedAt.Format("2006-01-02 15:04:05"))
+	if result.IsFallback {
+		fmt.Println("주의: 실제로 수집된 데이터가 없어 모든 세션이 대체(더미) 데이터입니다 (is_fallback: true)")
+	}
 
-	if len(result.Errors) > 0 {
-		fmt.Printf("\n경고 (%d개):\n", len(result.Errors))
-		for i, err := range result.Errors {
+	if messages := result.ErrorMessages(); len(messages) > 0 {
+		fmt.Printf("\n경고 (%d개):\n", len(messages))
+		for i, err := range messages {
 			fmt.Printf("  %d. %s\n", i+1, err)
 		}
 	}
 
+	if verbose && len(result.ScanStats) > 0 {
+		printScanStats(result.ScanStats)
+
+		fmt.Println("\n소스별 파일 처리:")
+		for _, summary := range models.SummarizeScanStats(result.ScanStats) {
+			fmt.Printf("  %s\n", formatScanSummary(summary))
+		}
+	}
+
 	if verbose && len(result.Sessions) > 0 {
 		fmt.Println("\n수집된 세션 목록:")
 		for _, session := range result.Sessions {
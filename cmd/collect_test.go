@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -9,6 +11,7 @@ import (
 	"time"
 
 	"ssamai/internal/config"
+	"ssamai/internal/exporter"
 	"ssamai/pkg/models"
 
 	"github.com/spf13/cobra"
@@ -127,6 +130,36 @@ func TestBuildCollectionConfig(t *testing.T) {
 			config:        &config.Config{},
 			expectedError: "시작 날짜 형식 오류",
 		},
+		{
+			name: "inverted date range",
+			setupFlags: func() {
+				collectAll = true
+				collectDateFrom = "2024-02-01"
+				collectDateTo = "2024-01-01"
+			},
+			config:        &config.Config{},
+			expectedError: "시작 날짜(2024-02-01)가 종료 날짜(2024-01-01)보다 늦습니다",
+		},
+		{
+			name: "same start and end date",
+			setupFlags: func() {
+				collectAll = true
+				collectDateFrom = "2024-01-15"
+				collectDateTo = "2024-01-15"
+			},
+			config: &config.Config{},
+			expectedConfig: &models.CollectionConfig{
+				Sources: []models.CollectionSource{
+					models.SourceClaudeCode,
+					models.SourceGeminiCLI,
+					models.SourceAmazonQ,
+				},
+				DateRange: &models.DateRange{
+					Start: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+					End:   time.Date(2024, 1, 15, 23, 59, 59, 0, time.UTC),
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -404,6 +437,14 @@ func TestSaveCollectedData(t *testing.T) {
 	assert.Equal(t, result.TotalCount, savedResult.TotalCount)
 	assert.Equal(t, result.Sessions[0].ID, savedResult.Sessions[0].ID)
 
+	assert.Equal(t, models.CurrentSchemaVersion, savedResult.SchemaVersion)
+
+	// Verify statistics snapshot was saved
+	require.NotNil(t, savedResult.Statistics)
+	assert.Equal(t, 1, savedResult.Statistics.TotalSessions)
+	assert.Equal(t, 1, savedResult.Statistics.TotalMessages)
+	assert.Equal(t, 1, savedResult.Statistics.SourceCounts[models.SourceClaudeCode])
+
 	// Verify latest.json was created
 	latestPath := filepath.Join(dataDir, "latest.json")
 	_, err = os.Stat(latestPath)
@@ -638,6 +679,17 @@ func TestPrintCollectionResult(t *testing.T) {
 	})
 }
 
+func TestFormatScanSummary(t *testing.T) {
+	summary := formatScanSummary(models.DirScanStats{
+		Source:       models.SourceGeminiCLI,
+		FilesScanned: 120,
+		FilesMatched: 100,
+		FilesParsed:  95,
+		FilesErrored: 5,
+	})
+	assert.Equal(t, "gemini_cli: 120 files scanned, 95 parsed, 20 skipped (pattern), 5 errors", summary)
+}
+
 // Test helpers
 func setupTestEnvironment(t *testing.T) (string, func()) {
 	tempDir, err := os.MkdirTemp("", "collect_test")
@@ -694,4 +746,300 @@ func BenchmarkExecuteCollection(b *testing.B) {
 			b.Fatal("No sessions collected")
 		}
 	}
-}
\ No newline at end of file
+}
+func TestBuildCollectionConfig_SourcesFromFile(t *testing.T) {
+	defer func() {
+		collectAll = false
+		collectSources = nil
+		collectSourcesFile = ""
+	}()
+	collectAll = false
+	collectSources = nil
+
+	writeSources := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "sources.txt")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	t.Run("valid sources with comments", func(t *testing.T) {
+		collectSourcesFile = writeSources(t, "# 수집 대상\nclaude_code\n\n  amazon_q  # 업무용\n")
+
+		result, err := buildCollectionConfig(&config.Config{})
+		require.NoError(t, err)
+		assert.Equal(t, []models.CollectionSource{models.SourceClaudeCode, models.SourceAmazonQ}, result.Sources)
+	})
+
+	t.Run("invalid source name", func(t *testing.T) {
+		collectSourcesFile = writeSources(t, "gemini_cli\ncursor\n")
+
+		result, err := buildCollectionConfig(&config.Config{})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "알 수 없는 데이터 소스: cursor")
+	})
+
+	t.Run("only comments", func(t *testing.T) {
+		collectSourcesFile = writeSources(t, "# nothing here\n\n")
+
+		_, err := buildCollectionConfig(&config.Config{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "소스 목록 파일에 소스가 없습니다")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		collectSourcesFile = filepath.Join(t.TempDir(), "missing.txt")
+
+		_, err := buildCollectionConfig(&config.Config{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "소스 목록 파일 읽기 실패")
+	})
+}
+
+func TestNewCollectCmd_SourcesFromFileExclusive(t *testing.T) {
+	cmd := NewCollectCmd(nil)
+	cmd.SetArgs([]string{"--all", "--sources-from-file", "sources.txt"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "none of the others can be")
+}
+
+func TestBuildCollectionConfig_ExcludeSource(t *testing.T) {
+	defer func() {
+		collectAll = false
+		collectSources = nil
+		collectExcludeSources = nil
+	}()
+
+	t.Run("all except amazon_q", func(t *testing.T) {
+		collectAll = true
+		collectExcludeSources = []string{"amazon_q"}
+
+		result, err := buildCollectionConfig(&config.Config{})
+		require.NoError(t, err)
+		assert.Equal(t, []models.CollectionSource{models.SourceClaudeCode, models.SourceGeminiCLI}, result.Sources)
+	})
+
+	t.Run("unknown excluded source", func(t *testing.T) {
+		collectAll = true
+		collectExcludeSources = []string{"cursor"}
+
+		result, err := buildCollectionConfig(&config.Config{})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "알 수 없는 데이터 소스: cursor")
+	})
+
+	t.Run("every source excluded", func(t *testing.T) {
+		collectAll = false
+		collectSources = []string{"gemini_cli"}
+		collectExcludeSources = []string{"gemini_cli"}
+
+		_, err := buildCollectionConfig(&config.Config{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "모든 데이터 소스가 제외되었습니다")
+	})
+}
+
+func TestBuildCollectionConfig_ReadRate(t *testing.T) {
+	collectAll = true
+	defer func() {
+		collectAll = false
+		collectReadRate = 0
+	}()
+
+	collectReadRate = 2.5
+	result, err := buildCollectionConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, 2.5, result.ReadRate)
+
+	collectReadRate = -1
+	_, err = buildCollectionConfig(&config.Config{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "--read-rate는 0 이상이어야 합니다")
+}
+
+func TestBuildCollectionConfig_FSRetries(t *testing.T) {
+	collectAll = true
+	defer func() {
+		collectAll = false
+		collectFSRetries = 0
+	}()
+
+	collectFSRetries = 3
+	result, err := buildCollectionConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.FSRetries)
+
+	collectFSRetries = -1
+	_, err = buildCollectionConfig(&config.Config{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "--fs-retries는 0 이상이어야 합니다")
+}
+
+func TestBuildCollectionConfig_CaptureEnv(t *testing.T) {
+	collectAll = true
+	previousCfgFile := cfgFile
+	cfgFile = "./configs/config.yaml"
+	defer func() {
+		collectAll = false
+		collectCaptureEnv = false
+		cfgFile = previousCfgFile
+	}()
+
+	result, err := buildCollectionConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.False(t, result.CaptureEnv)
+
+	collectCaptureEnv = true
+	result, err = buildCollectionConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.True(t, result.CaptureEnv)
+	assert.Equal(t, "./configs/config.yaml", result.ConfigPath)
+}
+
+func TestBuildCollectionConfig_Files(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "session.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"id": "s1"}`), 0644))
+
+	collectSources = []string{"gemini_cli"}
+	defer func() {
+		collectSources = []string{}
+		collectAll = false
+		collectFiles = []string{}
+	}()
+
+	collectFiles = []string{file}
+	result, err := buildCollectionConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{file}, result.Files)
+
+	collectFiles = []string{filepath.Join(t.TempDir(), "missing.json")}
+	_, err = buildCollectionConfig(&config.Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--files")
+
+	// ~로 시작하는 경로는 홈 디렉토리 기준으로 확장
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	homeFile := filepath.Join(home, "history.jsonl")
+	require.NoError(t, os.WriteFile(homeFile, []byte(`{"prompt": "hi"}`), 0644))
+	collectFiles = []string{"~/history.jsonl"}
+	result, err = buildCollectionConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{homeFile}, result.Files)
+
+	collectSources = []string{}
+	collectAll = true
+	collectFiles = []string{file}
+	_, err = buildCollectionConfig(&config.Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "하나의 소스")
+}
+
+func TestSaveCollectedData_CompactJSON(t *testing.T) {
+	t.Chdir(t.TempDir())
+	defer func() { collectCompactJSON = false }()
+
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	result := &models.CollectionResult{
+		Sessions: []models.SessionData{
+			{
+				ID:        "session-1",
+				Source:    models.SourceGeminiCLI,
+				Timestamp: now,
+				Title:     "Compact",
+				Messages: []models.Message{
+					{ID: "m1", Role: "user", Content: "hello", Timestamp: now},
+					{ID: "m2", Role: "assistant", Content: "hi there", Timestamp: now.Add(time.Second)},
+				},
+				Metadata: map[string]string{"model": "gemini-pro"},
+			},
+		},
+		TotalCount:  1,
+		Sources:     []models.CollectionSource{models.SourceGeminiCLI},
+		CollectedAt: now,
+	}
+	latestPath := filepath.Join(getDataDirectory(), "latest.json")
+
+	// 기본값은 들여쓰기 형식
+	require.NoError(t, saveCollectedData(result))
+	indented, err := os.ReadFile(latestPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(indented), "\n  \"sessions\"")
+	indentedResult, err := loadDataFromFile(latestPath)
+	require.NoError(t, err)
+
+	collectCompactJSON = true
+	require.NoError(t, saveCollectedData(result))
+	compact, err := os.ReadFile(latestPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(compact), "\n")
+	assert.Less(t, len(compact), len(indented))
+
+	// 타임스탬프 파일도 같은 형식으로 저장
+	collectionFile := filepath.Join(getDataDirectory(), "collection-20240115-100000.json")
+	saved, err := os.ReadFile(collectionFile)
+	require.NoError(t, err)
+	assert.Equal(t, compact, saved)
+
+	// 두 형식 모두 같은 내용으로 로드
+	compactResult, err := loadDataFromFile(latestPath)
+	require.NoError(t, err)
+	assert.Equal(t, indentedResult, compactResult)
+}
+
+func TestSaveCollectedData_WriteFailureKeepsExistingFiles(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	result := &models.CollectionResult{
+		Sessions: []models.SessionData{
+			{ID: "s1", Source: models.SourceClaudeCode, Timestamp: now, Title: "Saved",
+				Messages: []models.Message{{ID: "m1", Role: "user", Content: "hello", Timestamp: now}}},
+		},
+		TotalCount:  1,
+		Sources:     []models.CollectionSource{models.SourceClaudeCode},
+		CollectedAt: now,
+	}
+	require.NoError(t, saveCollectedData(result))
+
+	dataDir := getDataDirectory()
+	collectionFile := filepath.Join(dataDir, "collection-20240115-100000.json")
+	original, err := os.ReadFile(collectionFile)
+	require.NoError(t, err)
+
+	// 내용의 절반만 쓰고 실패하는 쓰기 (디스크 부족 등)
+	oldWrite := writeDataFile
+	defer func() { writeDataFile = oldWrite }()
+	writeDataFile = func(name string, data []byte) error {
+		return exporter.WriteAtomic(name, 0644, func(w io.Writer) error {
+			w.Write(data[:len(data)/2])
+			return errors.New("no space left on device")
+		})
+	}
+
+	result.Sessions[0].Title = "Replacement that never lands"
+	err = saveCollectedData(result)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "파일 저장 실패")
+
+	// 기존 파일은 그대로이고 잘린 파일이나 임시 파일이 남지 않음
+	saved, err := os.ReadFile(collectionFile)
+	require.NoError(t, err)
+	assert.Equal(t, original, saved)
+	loaded, err := loadDataFromFile(collectionFile)
+	require.NoError(t, err)
+	assert.Equal(t, "Saved", loaded.Sessions[0].Title)
+
+	entries, err := os.ReadDir(dataDir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{"collection-20240115-100000.json", "latest.json"}, names)
+}
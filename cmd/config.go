@@ -1,11 +1,20 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
 
 	"ssamai/internal/config"
+	"ssamai/internal/exporter"
+	"ssamai/internal/processor"
+	"ssamai/pkg/models"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -16,8 +25,21 @@ var (
 	configInit     bool
 	configValidate bool
 	configPath     string
+	configRebuildLatest bool
+	configEdit     bool
+	configTemplateValidate string
 )
 
+// launchEditor는 편집기로 설정 파일을 열고 편집기가 종료될 때까지 기다립니다 (테스트에서 교체 가능)
+var launchEditor = func(editor, path string) error {
+	fields := strings.Fields(editor)
+	cmd := exec.Command(fields[0], append(fields[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
 // NewConfigCmd는 설정 관리 명령어를 생성합니다
 func NewConfigCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -37,7 +59,16 @@ func NewConfigCmd() *cobra.Command {
   ssamai config --validate
 
   # 특정 경로의 설정 파일 검증
-  ssamai config --validate --path ./my-config.yaml`,
+  ssamai config --validate --path ./my-config.yaml
+
+  # 가장 최근 수집 파일로 latest.json 재생성
+  ssamai config --rebuild-latest
+
+  # $EDITOR로 설정 파일 편집 (없으면 기본 설정 생성)
+  ssamai config --edit
+
+  # 사용자 정의 템플릿(template_dir/weekly.tmpl)을 예시 데이터로 검증
+  ssamai config --template-validate weekly`,
 		RunE: runConfig,
 	}
 
@@ -50,11 +81,20 @@ func NewConfigCmd() *cobra.Command {
 		"설정 파일 유효성을 검증합니다")
 	cmd.Flags().StringVar(&configPath, "path", "",
 		"설정 파일 경로 (기본값: 자동 탐지)")
+	cmd.Flags().BoolVar(&configRebuildLatest, "rebuild-latest", false,
+		"가장 최근 collection-*.json 파일로 latest.json을 재생성합니다")
+	cmd.Flags().BoolVar(&configEdit, "edit", false,
+		"$EDITOR로 설정 파일을 열고 종료 후 다시 검증합니다")
+	cmd.Flags().StringVar(&configTemplateValidate, "template-validate", "",
+		"사용자 정의 템플릿(template_dir의 NAME.tmpl 또는 파일 경로)을 예시 데이터로 파싱/실행해 검증합니다")
 
 	// 플래그 조합 검증
 	cmd.MarkFlagsMutuallyExclusive("show", "init")
 	cmd.MarkFlagsMutuallyExclusive("show", "validate")
 	cmd.MarkFlagsMutuallyExclusive("init", "validate")
+	cmd.MarkFlagsMutuallyExclusive("rebuild-latest", "show", "init", "validate")
+	cmd.MarkFlagsMutuallyExclusive("edit", "show", "init", "validate", "rebuild-latest")
+	cmd.MarkFlagsMutuallyExclusive("template-validate", "show", "init", "validate", "rebuild-latest", "edit")
 	
 	return cmd
 }
@@ -66,6 +106,12 @@ func runConfig(cmd *cobra.Command, args []string) error {
 		return initConfigFile()
 	} else if configValidate {
 		return validateConfig()
+	} else if configRebuildLatest {
+		return rebuildLatest()
+	} else if configEdit {
+		return editConfig()
+	} else if configTemplateValidate != "" {
+		return validateTemplate(configTemplateValidate)
 	}
 
 	// 기본 동작: 도움말 표시
@@ -79,8 +125,8 @@ func showConfig() error {
 		fmt.Printf("설정 파일 로드 중: %s\n", path)
 	}
 
-	// 설정 로드
-	cfg, err := config.LoadConfig(path)
+	// 설정 로드 (프로젝트 설정 포함)
+	cfg, err := loadProjectConfig(path)
 	if err != nil {
 		return fmt.Errorf("설정 로드 실패: %w", err)
 	}
@@ -161,6 +207,125 @@ func validateConfig() error {
 	return nil
 }
 
+// editConfig는 설정 파일을 편집기로 열고, 편집기가 종료되면 설정을 다시 검증합니다
+// 설정 파일이 없으면 기본 설정 파일을 먼저 생성합니다
+func editConfig() error {
+	path := getConfigPath()
+	if path == "" {
+		return fmt.Errorf("설정 파일 경로를 확인할 수 없습니다")
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := initConfigFile(); err != nil {
+			return err
+		}
+	}
+
+	editor := resolveEditor()
+	if verbose {
+		fmt.Printf("편집기 실행 중: %s %s\n", editor, path)
+	}
+
+	if err := launchEditor(editor, path); err != nil {
+		return fmt.Errorf("편집기 실행 실패 (%s): %w", editor, err)
+	}
+
+	// 편집 결과 재검증
+	if _, err := config.LoadConfig(path); err != nil {
+		fmt.Printf("❌ 편집한 설정이 유효하지 않습니다: %v\n", err)
+		return fmt.Errorf("편집한 설정 검증 실패: %w", err)
+	}
+
+	fmt.Printf("✅ 설정이 유효합니다: %s\n", path)
+	return nil
+}
+
+// resolveEditor는 $VISUAL, $EDITOR 순으로 편집기를 고르고 둘 다 없으면 OS 기본 편집기를 반환합니다
+func resolveEditor() string {
+	for _, key := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(key)); editor != "" {
+			return editor
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+// validateTemplate은 사용자 정의 템플릿을 더미 데이터로 만든 처리 결과에 실행해 보고 결과를 출력합니다
+// 출력 파일은 만들지 않으며, 파싱/실행 에러는 템플릿의 줄 번호와 함께 반환합니다
+func validateTemplate(name string) error {
+	cfg, err := config.LoadConfig(getConfigPath())
+	if err != nil {
+		return fmt.Errorf("설정 로드 실패: %w", err)
+	}
+
+	path := exporter.CustomTemplatePath(cfg.OutputSettings.TemplateDir, name)
+	if verbose {
+		fmt.Printf("템플릿 검증 중: %s\n", path)
+	}
+
+	exportConfig := &models.ExportConfig{
+		Template:          name,
+		IncludeMetadata:   true,
+		IncludeTimestamps: true,
+		GenerateTOC:       cfg.OutputSettings.GenerateTOC,
+	}
+	processedData, err := processor.NewProcessor(exportConfig).ProcessCollectionResult(context.Background(), generateDummyData(time.Now()))
+	if err != nil {
+		return fmt.Errorf("예시 데이터 처리 실패: %w", err)
+	}
+
+	if err := exporter.ValidateCustomTemplate(path, processedData); err != nil {
+		fmt.Printf("❌ 템플릿 검증 실패: %v\n", err)
+		return err
+	}
+
+	fmt.Printf("✅ 템플릿이 유효합니다: %s\n", path)
+	return nil
+}
+
+func rebuildLatest() error {
+	dataDir := getDataDirectory()
+
+	sourceFile, err := rebuildLatestFile(dataDir)
+	if err != nil {
+		return fmt.Errorf("latest.json 재생성 실패: %w", err)
+	}
+
+	fmt.Printf("✅ latest.json을 재생성했습니다: %s\n", filepath.Join(dataDir, "latest.json"))
+	fmt.Printf("  - 원본 파일: %s\n", sourceFile)
+	return nil
+}
+
+// rebuildLatestFile은 데이터 디렉토리의 가장 최신 수집 파일로 latest.json을 다시 작성하고
+// 선택된 원본 파일 경로를 반환합니다
+func rebuildLatestFile(dataDir string) (string, error) {
+	sourceFile, err := findLatestDataFile(dataDir)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(sourceFile)
+	if err != nil {
+		return "", fmt.Errorf("수집 파일 읽기 실패: %w", err)
+	}
+
+	// 손상된 파일을 latest.json으로 복사하지 않도록 검증
+	var result models.CollectionResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return "", fmt.Errorf("수집 파일 파싱 실패 (%s): %w", sourceFile, err)
+	}
+
+	latestPath := filepath.Join(dataDir, "latest.json")
+	if err := exporter.WriteFileAtomic(latestPath, data, 0644); err != nil {
+		return "", fmt.Errorf("latest.json 작성 실패: %w", err)
+	}
+
+	return sourceFile, nil
+}
+
 func getConfigPath() string {
 	if configPath != "" {
 		return configPath
@@ -170,29 +335,8 @@ func getConfigPath() string {
 
 func createDefaultConfig() *config.Config {
 	return &config.Config{
-		CollectionSettings: config.CollectionSettings{
-			ClaudeCode: config.CLIToolConfig{
-				ConfigDir:       "~/.claude",
-				SessionDir:      "~/.claude/sessions",
-				HistoryFile:     "~/.claude/history.json",
-				IncludePatterns: []string{"*.json", "*.md", "*.log"},
-				ExcludePatterns: []string{"*.tmp", "*.cache"},
-			},
-			GeminiCLI: config.CLIToolConfig{
-				ConfigDir:       "~/.config/gemini",
-				HistoryFile:     "~/.config/gemini/history.json",
-				LogsDir:         "~/.config/gemini/logs",
-				IncludePatterns: []string{"*.json", "*.log", "*.yaml"},
-				ExcludePatterns: []string{"*.tmp"},
-			},
-			AmazonQ: config.CLIToolConfig{
-				ConfigDir:       "~/.aws/amazonq",
-				HistoryFile:     "~/.aws/amazonq/history.json",
-				CacheDir:        "~/.aws/amazonq/cache",
-				IncludePatterns: []string{"*.json", "*.log"},
-				ExcludePatterns: []string{"*.tmp"},
-			},
-		},
+		// OS별 기본 경로 (Windows는 %APPDATA%, macOS는 Application Support 등)
+		CollectionSettings: config.DefaultCollectionSettings(),
 		OutputSettings: config.OutputSettings{
 			TemplateDir:       "./templates",
 			DefaultTemplate:   "comprehensive",
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCollectionFile은 세션 하나가 든 수집 파일을 dir에 만들고 수정 시각을 modTime으로 맞춥니다
+func writeCollectionFile(t *testing.T, dir, name, sessionID string, modTime time.Time) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	writeSessionsFile(t, path, models.SessionData{ID: sessionID, Source: models.SourceClaudeCode})
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	return path
+}
+
+func TestRebuildLatestFile(t *testing.T) {
+	dataDir := t.TempDir()
+	now := time.Now()
+
+	writeCollectionFile(t, dataDir, "collection-20240101-100000.json", "older", now.Add(-2*time.Hour))
+	newest := writeCollectionFile(t, dataDir, "collection-20240101-120000.json", "newer", now)
+
+	// latest.json이 없는 상태에서 재생성
+	chosen, err := rebuildLatestFile(dataDir)
+	require.NoError(t, err)
+	assert.Equal(t, newest, chosen)
+
+	latest, err := os.ReadFile(filepath.Join(dataDir, "latest.json"))
+	require.NoError(t, err)
+	expected, err := os.ReadFile(newest)
+	require.NoError(t, err)
+	assert.Equal(t, expected, latest)
+}
+
+func TestRebuildLatestFile_ReplacesCorrupted(t *testing.T) {
+	dataDir := t.TempDir()
+	newest := writeCollectionFile(t, dataDir, "collection-20240101-120000.json", "newer", time.Now())
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "latest.json"), []byte("{broken"), 0644))
+
+	chosen, err := rebuildLatestFile(dataDir)
+	require.NoError(t, err)
+	assert.Equal(t, newest, chosen)
+
+	var result models.CollectionResult
+	latest, err := os.ReadFile(filepath.Join(dataDir, "latest.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(latest, &result))
+	assert.Equal(t, "newer", result.Sessions[0].ID)
+
+	// 원자적 쓰기에 쓰인 임시 파일이 남지 않아야 함
+	leftovers, err := filepath.Glob(filepath.Join(dataDir, ".latest.json.tmp-*"))
+	require.NoError(t, err)
+	assert.Empty(t, leftovers)
+}
+
+func TestRebuildLatestFile_Errors(t *testing.T) {
+	t.Run("no collection files", func(t *testing.T) {
+		dataDir := t.TempDir()
+
+		_, err := rebuildLatestFile(dataDir)
+		assert.Error(t, err)
+		assert.NoFileExists(t, filepath.Join(dataDir, "latest.json"))
+	})
+
+	t.Run("invalid collection file", func(t *testing.T) {
+		dataDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dataDir, "collection-20240101-120000.json"), []byte("not json"), 0644))
+
+		_, err := rebuildLatestFile(dataDir)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "수집 파일 파싱 실패")
+		assert.NoFileExists(t, filepath.Join(dataDir, "latest.json"))
+	})
+}
+
+func stubConfigEditor(t *testing.T, path string, edit func(path string) error) *[]string {
+	t.Helper()
+
+	previousPath, previousEditor := configPath, launchEditor
+	t.Cleanup(func() { configPath, launchEditor = previousPath, previousEditor })
+
+	var opened []string
+	configPath = path
+	launchEditor = func(editor, path string) error {
+		opened = append(opened, editor+" "+path)
+		return edit(path)
+	}
+	return &opened
+}
+
+func TestEditConfig_CreatesDefaultAndRevalidates(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "fake-editor --wait")
+	path := filepath.Join(t.TempDir(), "nested", "config.yaml")
+
+	opened := stubConfigEditor(t, path, func(path string) error {
+		// 편집기가 열릴 때 기본 설정 파일이 이미 생성되어 있어야 함
+		_, err := os.Stat(path)
+		return err
+	})
+
+	require.NoError(t, editConfig())
+	assert.Equal(t, []string{"fake-editor --wait " + path}, *opened)
+	assert.FileExists(t, path)
+}
+
+func TestEditConfig_InvalidAfterEdit(t *testing.T) {
+	t.Setenv("VISUAL", "fake-visual")
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("output_settings:\n  default_template: basic\n"), 0644))
+
+	opened := stubConfigEditor(t, path, func(path string) error {
+		return os.WriteFile(path, []byte("collection_settings: [unclosed\n"), 0644)
+	})
+
+	err := editConfig()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "편집한 설정 검증 실패")
+	assert.Equal(t, []string{"fake-visual " + path}, *opened)
+}
+
+func TestEditConfig_EditorFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	stubConfigEditor(t, path, func(string) error { return os.ErrPermission })
+
+	err := editConfig()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, os.ErrPermission)
+}
+
+func TestResolveEditor(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	assert.NotEmpty(t, resolveEditor())
+
+	t.Setenv("EDITOR", "nano")
+	assert.Equal(t, "nano", resolveEditor())
+
+	t.Setenv("VISUAL", "code --wait")
+	assert.Equal(t, "code --wait", resolveEditor())
+}
+
+func TestValidateTemplate(t *testing.T) {
+	dir := t.TempDir()
+	templateDir := filepath.Join(dir, "templates")
+	require.NoError(t, os.MkdirAll(templateDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(templateDir, "weekly.tmpl"),
+		[]byte("{{ range .Sessions }}- {{ .Title }}\n{{ end }}"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(templateDir, "broken.tmpl"),
+		[]byte("# 주간 보고\n{{ range .Sessions }}\n- {{ .Missing }}\n{{ end }}"), 0644))
+
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("output_settings:\n  template_dir: "+templateDir+"\n"), 0644))
+	previous := configPath
+	configPath = path
+	defer func() { configPath = previous }()
+
+	assert.NoError(t, validateTemplate("weekly"))
+
+	err := validateTemplate("broken")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broken.tmpl:3")
+
+	err = validateTemplate("missing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "템플릿 파일을 읽을 수 없습니다")
+}
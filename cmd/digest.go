@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"ssamai/pkg/models"
+
+	"github.com/spf13/cobra"
+)
+
+var digestJSON bool
+
+// sessionKey는 수집 파일 사이에서 같은 세션을 식별하는 키입니다 (소스, 세션 ID)
+type sessionKey struct {
+	Source models.CollectionSource
+	ID     string
+}
+
+// sessionDiff는 이전 수집과 비교해 새로 생긴 세션과 새 메시지 수입니다
+type sessionDiff struct {
+	Added       []models.SessionData
+	NewMessages int // 새 세션의 메시지와 기존 세션에 늘어난 메시지 수의 합
+}
+
+// digestTitle은 다이제스트에 표시할 새 세션입니다
+type digestTitle struct {
+	Source models.CollectionSource `json:"source"`
+	ID     string                  `json:"id"`
+	Title  string                  `json:"title"`
+}
+
+// collectionDigest는 최근 두 수집 파일의 차이를 요약한 다이제스트입니다
+type collectionDigest struct {
+	Previous    string        `json:"previous"`
+	Current     string        `json:"current"`
+	NewSessions int           `json:"new_sessions"`
+	NewMessages int           `json:"new_messages"`
+	NewTitles   []digestTitle `json:"new_titles"`
+}
+
+// NewDigestCmd는 최근 두 수집 파일의 변경 사항을 요약하는 명령어를 생성합니다
+func NewDigestCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "digest",
+		Short: "최근 두 수집 사이의 변경 사항을 요약합니다",
+		Long: `digest 명령어는 데이터 디렉토리에서 가장 최근 두 collection-*.json 파일을 비교해
+새 세션 수, 새 메시지 수, 새로 추가된 세션 제목을 짧게 출력합니다.
+
+세션은 (소스, 세션 ID)로 식별하며, 이미 있던 세션에 메시지가 늘어난 경우
+늘어난 메시지만 새 메시지로 셉니다. 매일 수집한 뒤 메일 본문 등에 붙이기 좋습니다.`,
+		Example: `  # 어제 수집 이후 변경 사항
+  ssamai digest
+
+  # JSON으로 출력
+  ssamai digest --json`,
+		Args: cobra.NoArgs,
+		RunE: runDigest,
+	}
+
+	cmd.Flags().BoolVar(&digestJSON, "json", false,
+		"다이제스트를 JSON으로 출력")
+
+	return cmd
+}
+
+func runDigest(cmd *cobra.Command, args []string) error {
+	files, err := findDataFiles(getDataDirectory())
+	if err != nil {
+		return err
+	}
+	if len(files) < 2 {
+		return fmt.Errorf("비교할 수집 파일이 부족합니다 (2개 이상 필요, 현재 %d개)", len(files))
+	}
+
+	digest, err := buildDigest(files[len(files)-2], files[len(files)-1])
+	if err != nil {
+		return err
+	}
+
+	if digestJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(digest); err != nil {
+			return fmt.Errorf("다이제스트 JSON 출력 실패: %w", err)
+		}
+		return nil
+	}
+	writeDigestText(cmd.OutOrStdout(), digest)
+	return nil
+}
+
+// buildDigest는 두 수집 파일을 읽어 previous 이후 current에서 달라진 내용을 요약합니다
+func buildDigest(previousFile, currentFile string) (collectionDigest, error) {
+	previous, err := loadDataFromFile(previousFile)
+	if err != nil {
+		return collectionDigest{}, fmt.Errorf("데이터 파일 로드 실패: %w", err)
+	}
+	current, err := loadDataFromFile(currentFile)
+	if err != nil {
+		return collectionDigest{}, fmt.Errorf("데이터 파일 로드 실패: %w", err)
+	}
+
+	diff := diffSessions(previous.Sessions, current.Sessions)
+	digest := collectionDigest{
+		Previous:    filepath.Base(previousFile),
+		Current:     filepath.Base(currentFile),
+		NewSessions: len(diff.Added),
+		NewMessages: diff.NewMessages,
+		NewTitles:   make([]digestTitle, 0, len(diff.Added)),
+	}
+	for _, session := range diff.Added {
+		title := strings.Join(strings.Fields(session.Title), " ")
+		if title == "" {
+			title = fmt.Sprintf("세션 %s", session.ID)
+		}
+		digest.NewTitles = append(digest.NewTitles, digestTitle{Source: session.Source, ID: session.ID, Title: title})
+	}
+	return digest, nil
+}
+
+// diffSessions는 (소스, 세션 ID) 기준으로 previous에 없던 세션과 새 메시지 수를 구합니다
+// 새 세션은 current의 순서를 유지하며, 기존 세션은 메시지가 늘어난 만큼만 셉니다
+func diffSessions(previous, current []models.SessionData) sessionDiff {
+	known := make(map[sessionKey]int, len(previous))
+	for _, session := range previous {
+		known[sessionKey{session.Source, session.ID}] = len(session.Messages)
+	}
+
+	var diff sessionDiff
+	for _, session := range current {
+		count, exists := known[sessionKey{session.Source, session.ID}]
+		if !exists {
+			diff.Added = append(diff.Added, session)
+			diff.NewMessages += len(session.Messages)
+			continue
+		}
+		if grown := len(session.Messages) - count; grown > 0 {
+			diff.NewMessages += grown
+		}
+	}
+	return diff
+}
+
+// writeDigestText는 다이제스트를 메일 본문에 붙이기 좋은 짧은 텍스트로 출력합니다
+func writeDigestText(w io.Writer, digest collectionDigest) {
+	fmt.Fprintf(w, "=== 수집 다이제스트 (%s → %s) ===\n", digest.Previous, digest.Current)
+	fmt.Fprintf(w, "새 세션: %d개\n", digest.NewSessions)
+	fmt.Fprintf(w, "새 메시지: %d개\n", digest.NewMessages)
+	if len(digest.NewTitles) > 0 {
+		fmt.Fprintln(w, "새 세션 목록:")
+		for _, title := range digest.NewTitles {
+			fmt.Fprintf(w, "  - [%s] %s\n", title.Source, title.Title)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeDigestDataDir은 이틀에 걸친 수집 파일 세 개를 만듭니다 (가장 오래된 파일은 비교 대상이 아님)
+func writeDigestDataDir(t *testing.T) {
+	t.Helper()
+
+	dataDir := getDataDirectory()
+	at := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	messages := func(n int) []models.Message {
+		result := make([]models.Message, n)
+		for i := range result {
+			result[i] = models.Message{Role: "user", Content: "hi", Timestamp: at}
+		}
+		return result
+	}
+
+	writeSessionsFile(t, filepath.Join(dataDir, "collection-20240229-090000.json"),
+		models.SessionData{ID: "old", Source: models.SourceClaudeCode, Timestamp: at, Messages: messages(1)},
+	)
+	writeSessionsFile(t, filepath.Join(dataDir, "collection-20240301-090000.json"),
+		models.SessionData{ID: "a", Source: models.SourceClaudeCode, Title: "빌드 수정", Timestamp: at, Messages: messages(2)},
+		models.SessionData{ID: "b", Source: models.SourceGeminiCLI, Title: "테스트 추가", Timestamp: at, Messages: messages(3)},
+	)
+	writeSessionsFile(t, filepath.Join(dataDir, "collection-20240302-090000.json"),
+		// 메시지가 늘어난 기존 세션
+		models.SessionData{ID: "a", Source: models.SourceClaudeCode, Title: "빌드 수정", Timestamp: at, Messages: messages(4)},
+		models.SessionData{ID: "b", Source: models.SourceGeminiCLI, Title: "테스트 추가", Timestamp: at, Messages: messages(3)},
+		// 같은 ID라도 소스가 다르면 새 세션
+		models.SessionData{ID: "b", Source: models.SourceAmazonQ, Title: "배포  스크립트", Timestamp: at, Messages: messages(1)},
+		models.SessionData{ID: "c", Source: models.SourceClaudeCode, Timestamp: at, Messages: messages(2)},
+	)
+}
+
+func TestDiffSessions(t *testing.T) {
+	previous := []models.SessionData{
+		{ID: "a", Source: models.SourceClaudeCode, Messages: make([]models.Message, 3)},
+		{ID: "gone", Source: models.SourceClaudeCode, Messages: make([]models.Message, 5)},
+	}
+	current := []models.SessionData{
+		// 메시지가 줄어든 세션은 새 메시지로 세지 않음
+		{ID: "a", Source: models.SourceClaudeCode, Messages: make([]models.Message, 1)},
+		{ID: "new", Source: models.SourceGeminiCLI, Messages: make([]models.Message, 2)},
+	}
+
+	diff := diffSessions(previous, current)
+	require.Len(t, diff.Added, 1)
+	assert.Equal(t, "new", diff.Added[0].ID)
+	assert.Equal(t, 2, diff.NewMessages)
+}
+
+func TestRunDigest_Text(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeDigestDataDir(t)
+
+	cmd := NewDigestCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runDigest(cmd, nil))
+	assert.Equal(t, `=== 수집 다이제스트 (collection-20240301-090000.json → collection-20240302-090000.json) ===
+새 세션: 2개
+새 메시지: 5개
+새 세션 목록:
+  - [amazon_q] 배포 스크립트
+  - [claude_code] 세션 c
+`, out.String())
+}
+
+func TestRunDigest_JSON(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeDigestDataDir(t)
+
+	cmd := NewDigestCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	require.NoError(t, cmd.Flags().Set("json", "true"))
+	defer func() { digestJSON = false }()
+
+	require.NoError(t, runDigest(cmd, nil))
+
+	var digest collectionDigest
+	require.NoError(t, json.Unmarshal(out.Bytes(), &digest))
+	assert.Equal(t, collectionDigest{
+		Previous:    "collection-20240301-090000.json",
+		Current:     "collection-20240302-090000.json",
+		NewSessions: 2,
+		NewMessages: 5,
+		NewTitles: []digestTitle{
+			{Source: models.SourceAmazonQ, ID: "b", Title: "배포 스크립트"},
+			{Source: models.SourceClaudeCode, ID: "c", Title: "세션 c"},
+		},
+	}, digest)
+}
+
+func TestRunDigest_NeedsTwoFiles(t *testing.T) {
+	t.Chdir(t.TempDir())
+	dataDir := getDataDirectory()
+	require.NoError(t, os.MkdirAll(dataDir, 0755))
+	writeSessionsFile(t, filepath.Join(dataDir, "collection-20240301-090000.json"),
+		models.SessionData{ID: "a", Source: models.SourceClaudeCode})
+
+	err := runDigest(NewDigestCmd(), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "비교할 수집 파일이 부족합니다")
+}
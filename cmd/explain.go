@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"ssamai/internal/config"
+	"ssamai/pkg/models"
+
+	"github.com/spf13/pflag"
+)
+
+// 설정 값 출처 이름입니다
+const (
+	provenanceDefault = "기본값"
+	provenanceGlobal  = "전역 설정 파일"
+	provenanceProject = "프로젝트 설정 파일"
+	provenanceEnv     = "환경 변수"
+	provenanceFlag    = "플래그"
+)
+
+// settingProvenance는 내보내기 설정 하나의 최종 값과 그 값을 정한 출처입니다
+type settingProvenance struct {
+	Name   string
+	Value  string
+	Source string
+}
+
+// explainedFlags는 설정 파일이나 환경 변수와 함께 따로 설명하므로 일반 플래그 목록에서 제외하는 플래그입니다
+var explainedFlags = map[string]bool{
+	"output":       true,
+	"template":     true,
+	"no-toc":       true,
+	"no-meta":      true,
+	"no-timestamp": true,
+	"timezone":     true,
+	"explain":      true,
+}
+
+// explainExportConfig는 buildExportConfig가 만든 설정의 각 값이 어디서 왔는지 정리합니다
+// 출력 설정은 설정을 로드할 때 기록한 계층(전역 설정 파일, .ssamairc, SSAMAI_* 환경 변수)으로 표시하고,
+// 어느 계층에도 없으면 기본값으로 표시합니다
+func explainExportConfig(flags *pflag.FlagSet, cfg *config.Config, exportCfg *models.ExportConfig, getenv func(string) string) []settingProvenance {
+	flagSource := func(name string) (string, bool) {
+		if flag := flags.Lookup(name); flag != nil && flag.Changed {
+			return fmt.Sprintf("%s (--%s)", provenanceFlag, name), true
+		}
+		return "", false
+	}
+	configSource := func(key string) string {
+		source, ok := cfg.SourceOf("output_settings." + key)
+		if !ok {
+			return provenanceDefault
+		}
+		switch source.Layer {
+		case config.LayerEnv:
+			return fmt.Sprintf("%s (%s)", provenanceEnv, source.Origin)
+		case config.LayerProject:
+			return fmt.Sprintf("%s (%s: output_settings.%s)", provenanceProject, source.Origin, key)
+		default:
+			return fmt.Sprintf("%s (%s: output_settings.%s)", provenanceGlobal, source.Origin, key)
+		}
+	}
+	// 플래그가 설정되었으면 플래그, 아니면 설정 계층 출처
+	settingSource := func(flagName, key string) string {
+		if source, ok := flagSource(flagName); ok {
+			return source
+		}
+		return configSource(key)
+	}
+
+	var entries []settingProvenance
+
+	outputSource, ok := flagSource("output")
+	if !ok {
+		outputSource = provenanceDefault
+	}
+	entries = append(entries, settingProvenance{"output", exportCfg.OutputPath, outputSource})
+
+	entries = append(entries,
+		settingProvenance{"template", exportCfg.Template, settingSource("template", "default_template")},
+		settingProvenance{"generate_toc", fmt.Sprint(exportCfg.GenerateTOC), settingSource("no-toc", "generate_toc")},
+		settingProvenance{"include_metadata", fmt.Sprint(exportCfg.IncludeMetadata), settingSource("no-meta", "include_metadata")},
+		settingProvenance{"include_timestamps", fmt.Sprint(exportCfg.IncludeTimestamps), settingSource("no-timestamp", "include_timestamps")},
+		settingProvenance{"format_code_blocks", fmt.Sprint(exportCfg.FormatCodeBlocks), configSource("format_code_blocks")},
+	)
+
+	// Local 시간대는 TZ 환경 변수로 정해지므로 함께 표시
+	timezone, timezoneSource := exportCfg.DisplayTimezone, provenanceDefault
+	if source, ok := flagSource("timezone"); ok {
+		timezoneSource = source
+		if tz := getenv("TZ"); timezone == "Local" && tz != "" {
+			timezone = fmt.Sprintf("Local (%s)", tz)
+			timezoneSource += fmt.Sprintf(", %s (TZ)", provenanceEnv)
+		}
+	}
+	if timezone == "" {
+		timezone = "(기록된 시간대)"
+	}
+	entries = append(entries, settingProvenance{"timezone", timezone, timezoneSource})
+
+	// 나머지 설정은 플래그 값이 그대로 반영됨
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if explainedFlags[flag.Name] {
+			return
+		}
+		source, ok := flagSource(flag.Name)
+		if !ok {
+			source = provenanceDefault
+		}
+		entries = append(entries, settingProvenance{flag.Name, flag.Value.String(), source})
+	})
+
+	return entries
+}
+
+// printExportExplanation은 설정 출처를 이름, 값, 출처 열로 정렬해 출력합니다
+func printExportExplanation(w io.Writer, entries []settingProvenance) {
+	fmt.Fprintln(w, "내보내기 설정 출처:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, entry := range entries {
+		value := entry.Value
+		if strings.TrimSpace(value) == "" {
+			value = `""`
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", entry.Name, value, entry.Source)
+	}
+	tw.Flush()
+}
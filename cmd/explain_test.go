@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ssamai/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// provenanceByName은 설정 이름으로 출처 항목을 찾기 쉽게 맵으로 바꿉니다
+func provenanceByName(entries []settingProvenance) map[string]settingProvenance {
+	byName := make(map[string]settingProvenance, len(entries))
+	for _, entry := range entries {
+		byName[entry.Name] = entry
+	}
+	return byName
+}
+
+func TestExplainExportConfig_Provenance(t *testing.T) {
+	cmd := NewExportCmd()
+	// 플래그 변수는 전역이므로 테스트 후 새 명령으로 기본값을 되돌림
+	t.Cleanup(func() { NewExportCmd() })
+
+	require.NoError(t, cmd.Flags().Set("output", "report"))
+	require.NoError(t, cmd.Flags().Set("no-toc", "true"))
+	require.NoError(t, cmd.Flags().Set("mermaid", "true"))
+
+	// 전역 설정 파일 없이 .ssamairc가 템플릿만 바꿈
+	repo := t.TempDir()
+	rcPath := filepath.Join(repo, config.ProjectConfigFileName)
+	require.NoError(t, os.WriteFile(rcPath, []byte("output_settings:\n  default_template: minimal\n"), 0644))
+	cfg, err := config.LoadProjectConfig("", repo)
+	require.NoError(t, err)
+
+	exportCfg, err := buildExportConfig(cfg)
+	require.NoError(t, err)
+
+	entries := provenanceByName(explainExportConfig(cmd.Flags(), cfg, exportCfg, func(string) string { return "" }))
+
+	assert.Equal(t, settingProvenance{"output", "report.md", "플래그 (--output)"}, entries["output"])
+	assert.Equal(t, settingProvenance{"template", "minimal", "프로젝트 설정 파일 (" + rcPath + ": output_settings.default_template)"}, entries["template"])
+	assert.Equal(t, settingProvenance{"generate_toc", "false", "플래그 (--no-toc)"}, entries["generate_toc"])
+	assert.Equal(t, settingProvenance{"include_metadata", "true", "기본값"}, entries["include_metadata"])
+	assert.Equal(t, settingProvenance{"format_code_blocks", "true", "기본값"}, entries["format_code_blocks"])
+	assert.Equal(t, settingProvenance{"mermaid", "true", "플래그 (--mermaid)"}, entries["mermaid"])
+	assert.Equal(t, settingProvenance{"heatmap", "false", "기본값"}, entries["heatmap"])
+	assert.Equal(t, "기본값", entries["timezone"].Source)
+
+	_, listed := entries["no-toc"]
+	assert.False(t, listed, "no-toc은 generate_toc으로 설명되어야 함")
+}
+
+func TestExplainExportConfig_ConfigAndFlagOverrides(t *testing.T) {
+	cmd := NewExportCmd()
+	t.Cleanup(func() { NewExportCmd() })
+
+	require.NoError(t, cmd.Flags().Set("output", "report.md"))
+	require.NoError(t, cmd.Flags().Set("template", "detailed"))
+	require.NoError(t, cmd.Flags().Set("timezone", "Local"))
+
+	// 전역 설정 파일이 목차를 끄고 템플릿을 바꿨지만 템플릿은 플래그가 우선
+	globalPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(globalPath, []byte("output_settings:\n  default_template: minimal\n  generate_toc: false\n"), 0644))
+	cfg, err := config.LoadConfig(globalPath)
+	require.NoError(t, err)
+
+	exportCfg, err := buildExportConfig(cfg)
+	require.NoError(t, err)
+
+	env := map[string]string{"TZ": "Asia/Seoul"}
+	entries := provenanceByName(explainExportConfig(cmd.Flags(), cfg, exportCfg, func(key string) string { return env[key] }))
+
+	assert.Equal(t, settingProvenance{"template", "detailed", "플래그 (--template)"}, entries["template"])
+	assert.Equal(t, settingProvenance{"generate_toc", "false", "전역 설정 파일 (" + globalPath + ": output_settings.generate_toc)"}, entries["generate_toc"])
+	assert.Equal(t, settingProvenance{"timezone", "Local (Asia/Seoul)", "플래그 (--timezone), 환경 변수 (TZ)"}, entries["timezone"])
+}
+
+func TestExplainExportConfig_LayeredSources(t *testing.T) {
+	cmd := NewExportCmd()
+	t.Cleanup(func() { NewExportCmd() })
+	require.NoError(t, cmd.Flags().Set("output", "report.md"))
+
+	globalPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(globalPath, []byte(`
+output_settings:
+  default_template: detailed
+  include_metadata: true
+  include_timestamps: true
+  format_code_blocks: true
+  generate_toc: true
+`), 0644))
+
+	// .ssamairc가 전역 설정의 목차 값을, 환경 변수가 템플릿과 메타데이터 값을 덮어씀
+	repo := t.TempDir()
+	rcPath := filepath.Join(repo, config.ProjectConfigFileName)
+	require.NoError(t, os.WriteFile(rcPath, []byte("output_settings:\n  default_template: technical\n  generate_toc: false\n"), 0644))
+	t.Setenv(config.EnvDefaultTemplate, "minimal")
+	t.Setenv(config.EnvIncludeMetadata, "false")
+
+	cfg, err := config.LoadProjectConfig(globalPath, repo)
+	require.NoError(t, err)
+	exportCfg, err := buildExportConfig(cfg)
+	require.NoError(t, err)
+
+	entries := provenanceByName(explainExportConfig(cmd.Flags(), cfg, exportCfg, func(string) string { return "" }))
+
+	assert.Equal(t, settingProvenance{"template", "minimal", "환경 변수 (SSAMAI_DEFAULT_TEMPLATE)"}, entries["template"])
+	assert.Equal(t, settingProvenance{"include_metadata", "false", "환경 변수 (SSAMAI_INCLUDE_METADATA)"}, entries["include_metadata"])
+	assert.Equal(t, settingProvenance{"generate_toc", "false", "프로젝트 설정 파일 (" + rcPath + ": output_settings.generate_toc)"}, entries["generate_toc"])
+	assert.Equal(t, settingProvenance{"include_timestamps", "true", "전역 설정 파일 (" + globalPath + ": output_settings.include_timestamps)"}, entries["include_timestamps"])
+	assert.Equal(t, settingProvenance{"format_code_blocks", "true", "전역 설정 파일 (" + globalPath + ": output_settings.format_code_blocks)"}, entries["format_code_blocks"])
+}
+
+func TestPrintExportExplanation(t *testing.T) {
+	var buf bytes.Buffer
+	printExportExplanation(&buf, []settingProvenance{
+		{"output", "report.md", "플래그 (--output)"},
+		{"session-separator", "", "기본값"},
+	})
+
+	assert.Equal(t, "내보내기 설정 출처:\n"+
+		"  output             report.md  플래그 (--output)\n"+
+		"  session-separator  \"\"         기본값\n", buf.String())
+}
@@ -1,35 +1,84 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"ssamai/internal/config"
 	"ssamai/internal/exporter"
 	"ssamai/internal/processor"
-	"ssamai/internal/service"
 	"ssamai/pkg/models"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 var (
 	exportTemplate    string
 	exportNoTOC       bool
 	exportNoMeta      bool
+	exportNoFooter    bool
 	exportNoTimestamp bool
 	exportCustomFields map[string]string
 	exportDataFile    string
 	exportOutputFile  string
+	exportOutline     bool
+	exportPerSession  string
+	exportIncludeErrors bool
+	exportNormalizeWhitespace bool
+	exportRecentWindow time.Duration
+	exportSortMessages bool
+	exportPruneEmptyMetadata bool
+	exportThread      bool
+	exportCSVLevel    string
+	exportDateFrom    string
+	exportDateTo      string
+	exportRoleIcons   map[string]string
+	exportNoIcons     bool
+	exportContentHash bool
+	exportDetectLanguage bool
+	exportAnnotations string
+	exportMermaid     bool
+	exportSourceBudget int
+	exportTop         int
+	exportTopBy       string
+	exportMinSessions int
+	exportCodeCaptions bool
+	exportSinceLast   bool
+	exportResetState  bool
+	exportMetricsOut  string
+	exportSourceLinks bool
+	exportFormat      string
+	exportExtractCode bool
+	exportSessionSeparator string
+	exportCollapseOver int
+	exportAppend bool
+	exportTimezone string
+	exportInteractive bool
+	exportActiveMetric string
+	exportHeatmap     bool
+	exportGroupByMeta string
+	exportBackup      bool
+	exportNoClobber   bool
+	exportLatestPerSource bool
+	exportSourceOrder []string
+	exportNarrative   bool
+	exportExplain     bool
+	exportWorkers     int
+	exportCommandEnvKeys bool
+	exportANSIHTML    bool
+	exportBatch       string
 )
 
-// NewExportCmd는 서비스 레이어를 주입받아 export 명령어를 생성합니다.
-func NewExportCmd(exportSvc *service.ExportService) *cobra.Command {
+// NewExportCmd는 export 명령어를 생성합니다.
+func NewExportCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "export",
 		Short: "수집된 데이터를 마크다운 파일로 내보냅니다",
@@ -51,10 +100,38 @@ func NewExportCmd(exportSvc *service.ExportService) *cobra.Command {
   ssamai export --custom project=MyProject --custom version=1.0 --output ./project-summary.md
 
   # 저장된 데이터 파일에서 내보내기
-  ssamai export --data ./collected-data.json --output ./from-file.md`,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return runExportWithService(cmd, args, exportSvc)
-		},
+  ssamai export --data ./collected-data.json --output ./from-file.md
+
+  # 메시지 본문 없이 구조(목차와 제목)만 내보내기
+  ssamai export --outline --output ./outline.md
+
+  # 세션별 마크다운 파일과 index.md를 디렉토리에 내보내기
+  ssamai export --per-session ./wiki/
+
+  # 지난주에 해당하는 세션만 내보내기
+  ssamai export --from 2024-01-01 --to 2024-01-07 --output ./weekly.md
+
+  # 세션당 한 행의 CSV로 내보내기
+  ssamai export --output ./sessions.csv --csv-level session
+
+  # 세션당 한 줄 요약 목록으로 내보내기
+  ssamai export --format oneline --output ./index.txt
+
+  # 기존 활동 기록에 새 세션만 오늘 날짜 섹션으로 추가
+  ssamai export --append --output ./journal.md
+
+  # 목록에서 고른 세션만 내보내기
+  ssamai export --interactive --output ./curated.md
+
+  # 기존 보고서를 summary.md.bak-<시각>으로 백업한 뒤 새로 내보내기
+  ssamai export --backup --output ./summary.md
+
+  # 여러 수집 데이터 파일을 각각 reports/<입력 이름>.md로 한 번에 내보내기
+  ssamai export --batch './data/*.json' --output ./reports
+
+  # 각 설정이 어디서 왔는지 확인하며 내보내기
+  ssamai export --explain --no-toc --output ./summary.md`,
+		RunE: runExport,
 	}
 
 	// 플래그 정의
@@ -66,27 +143,126 @@ func NewExportCmd(exportSvc *service.ExportService) *cobra.Command {
 		"목차(Table of Contents) 생성 제외")
 	cmd.Flags().BoolVar(&exportNoMeta, "no-meta", false, 
 		"메타데이터 정보 제외")
+	cmd.Flags().BoolVar(&exportNoFooter, "no-footer", false,
+		"문서 끝 메타데이터(생성 도구, 생성 시간, 사용자 정의 필드) 제외 (세션 메타데이터는 유지)")
 	cmd.Flags().BoolVar(&exportNoTimestamp, "no-timestamp", false, 
 		"타임스탬프 정보 제외")
 	cmd.Flags().StringToStringVar(&exportCustomFields, "custom", map[string]string{}, 
 		"사용자 정의 메타데이터 필드 (key=value 형식)")
 	cmd.Flags().StringVarP(&exportDataFile, "data", "d", "", 
 		"저장된 데이터 파일에서 읽어서 내보내기")
+	cmd.Flags().BoolVar(&exportOutline, "outline", false, 
+		"메시지 본문 없이 목차와 제목만 내보내기")
+	cmd.Flags().StringVar(&exportPerSession, "per-session", "", 
+		"세션별 마크다운 파일과 index.md를 생성할 디렉토리")
+	cmd.Flags().BoolVar(&exportIncludeErrors, "include-errors", false, 
+		"수집 중 발생한 에러를 '수집 문제' 섹션으로 포함")
+	cmd.Flags().BoolVar(&exportNormalizeWhitespace, "normalize-whitespace", false, 
+		"메시지의 후행 공백, CRLF, 과도한 빈 줄 정리 (코드 블록 제외)")
+	cmd.Flags().DurationVar(&exportRecentWindow, "recent-window", 0, 
+		"개요에 최근 활동 요약을 표시할 기간 (예: 168h)")
+	cmd.Flags().BoolVar(&exportSortMessages, "sort-messages", false, 
+		"세션 내 메시지를 타임스탬프 순으로 정렬")
+	cmd.Flags().BoolVar(&exportPruneEmptyMetadata, "prune-empty-metadata", true, 
+		"값이 비어 있는 메타데이터 항목 제외 (--prune-empty-metadata=false로 비활성화)")
+	cmd.Flags().BoolVar(&exportThread, "thread", false, 
+		"메시지의 parent_id 메타데이터로 분기된 대화를 트리 형태로 표시")
+	cmd.Flags().StringVar(&exportDateFrom, "from", "", 
+		"내보낼 세션의 시작 날짜 (YYYY-MM-DD 형식)")
+	cmd.Flags().StringVar(&exportDateTo, "to", "", 
+		"내보낼 세션의 종료 날짜 (YYYY-MM-DD 형식)")
+	cmd.Flags().StringToStringVar(&exportRoleIcons, "role-icon", map[string]string{}, 
+		"역할별 메시지 아이콘 (role=아이콘 형식, 예: user=🧑)")
+	cmd.Flags().BoolVar(&exportNoIcons, "no-icons", false, 
+		"메시지 제목에서 역할 아이콘 제외 (일반 텍스트 렌더러용)")
+	cmd.Flags().BoolVar(&exportContentHash, "content-hash", false, 
+		"세션 메타데이터에 대화 내용 해시(content_hash) 추가 (변경 추적용)")
+	cmd.Flags().BoolVar(&exportDetectLanguage, "detect-language", false, 
+		"세션의 주 언어(ko, en, ja 등)를 감지해 메타데이터 language에 기록 (--group-by-meta language로 묶기 가능)")
+	cmd.Flags().StringVar(&exportAnnotations, "annotations", "", 
+		"세션 ID별 메모를 담은 JSON/YAML 파일 (각 세션 제목 아래에 표시)")
+	cmd.Flags().BoolVar(&exportMermaid, "mermaid", false, 
+		"소스별 세션을 시간축에 표시하는 Mermaid 타임라인 섹션 추가")
+	cmd.Flags().StringVar(&exportGroupByMeta, "group-by-meta", "", 
+		"소스 대신 지정한 메타데이터 키(예: service, model)의 값별로 세션 섹션을 나눔")
+	cmd.Flags().BoolVar(&exportNarrative, "narrative-overview", false, 
+		"개요에 기간, 도구 수, 가장 활발한 도구, 평균 메시지 수를 풀어 쓴 요약 문단 추가")
+	cmd.Flags().BoolVar(&exportExplain, "explain", false, 
+		"내보내기 전에 각 설정 값이 기본값, 설정 파일, 환경 변수, 플래그 중 어디서 왔는지 출력")
+	cmd.Flags().BoolVar(&exportHeatmap, "heatmap", false, 
+		"요일/시간대별 세션 수를 격자로 보여주는 활동 히트맵 섹션 추가")
+	cmd.Flags().StringSliceVar(&exportSourceOrder, "source-order", []string{}, 
+		"소스 섹션 출력 순서 (예: gemini_cli,claude_code,amazon_q, 나열하지 않은 소스는 뒤에 기본 순서로)")
+	cmd.Flags().BoolVar(&exportLatestPerSource, "latest-per-source", false, 
+		"소스별로 가장 최근 세션 하나만 내보내기 (최근 작업 스냅샷)")
+	cmd.Flags().IntVar(&exportTop, "top", 0, 
+		"가장 활발한 세션 N개만 내보내기 (통계는 전체 세션 기준, 0이면 사용 안 함)")
+	cmd.Flags().StringVar(&exportTopBy, "top-by", processor.TopByMessages, 
+		"--top 선택 기준 (messages: 메시지 수, duration: 세션 지속 시간)")
+	cmd.Flags().IntVar(&exportSourceBudget, "source-budget", 0, 
+		"소스별 메시지 내용 최대 글자 수 (초과분은 생략 안내와 함께 잘림, 0이면 제한 없음)")
+	cmd.Flags().BoolVar(&exportCodeCaptions, "code-captions", false, 
+		"어시스턴트 메시지에서 파일명이 언급된 코드 블록 위에 파일명 캡션 추가")
+	cmd.Flags().BoolVar(&exportSinceLast, "since-last-export", false, 
+		"마지막 내보내기 이후의 새 세션만 내보내기 (증분 내보내기)")
+	cmd.Flags().BoolVar(&exportResetState, "reset-export-state", false, 
+		"마지막 내보내기 기록을 초기화 (--since-last-export와 함께 쓰면 전체 내보내기)")
+	cmd.Flags().StringVar(&exportFormat, "format", "", 
+		"출력 형식 (markdown, csv, oneline; 기본값: 출력 파일 확장자로 결정)")
+	cmd.Flags().BoolVar(&exportExtractCode, "extract-code", false, 
+		"언어가 지정된 코드 블록을 출력 위치의 code/ 디렉토리에 별도 파일로 저장하고 링크로 대체")
+	cmd.Flags().StringVar(&exportActiveMetric, "active-source-metric", processor.ActiveSourceBySessions, 
+		"가장 활발한 도구를 고르는 기준 (sessions: 세션 수, messages: 메시지 수)")
+	cmd.Flags().BoolVar(&exportInteractive, "interactive", false, 
+		"세션 목록에서 내보낼 세션을 직접 선택 (터미널이 아니면 전체 내보내기)")
+	cmd.Flags().StringVar(&exportTimezone, "timezone", "", 
+		"시각을 표시할 시간대 (Local, UTC, Asia/Seoul 등; 기본값: 기록된 시간대 그대로)")
+	cmd.Flags().BoolVar(&exportBackup, "backup", false, 
+		"출력 파일이 이미 있으면 <이름>.bak-<시각>으로 옮긴 뒤 새로 쓰기")
+	cmd.Flags().BoolVar(&exportNoClobber, "no-clobber", false, 
+		"출력 파일이 이미 있으면 덮어쓰지 않고 실패")
+	cmd.Flags().BoolVar(&exportAppend, "append", false, 
+		"기존 마크다운 파일을 다시 쓰지 않고 아직 없는 세션(내용 해시 기준)만 날짜별 섹션으로 추가")
+	cmd.Flags().IntVar(&exportCollapseOver, "collapse-sessions-over", 0, 
+		"메시지가 이 수보다 많은 세션의 본문을 접을 수 있는 <details> 블록으로 감쌈 (0이면 사용 안 함)")
+	cmd.Flags().StringVar(&exportSessionSeparator, "session-separator", "", 
+		"마크다운 세션 사이 구분자 (기본값: ---, none: 생략, blank: 빈 줄, 그 외: 입력한 문자열 예: ***)")
+	cmd.Flags().BoolVar(&exportCommandEnvKeys, "command-env-keys", false, 
+		"명령어에 설정된 환경 변수 이름만 값 없이 표시 (메타데이터 포함 시)")
+	cmd.Flags().BoolVar(&exportANSIHTML, "ansi-html", false, 
+		"명령어 출력의 ANSI 색상 코드를 HTML <span style>로 변환 (기본값: 색상 코드 제거)")
+	cmd.Flags().IntVar(&exportWorkers, "workers", 0, 
+		"마크다운 소스 섹션을 동시에 렌더링할 최대 수 (대용량 내보내기용, 0 또는 1이면 순차)")
+	cmd.Flags().BoolVar(&exportSourceLinks, "source-links", false, 
+		"세션 메타데이터에 원본 파일(file_path)로 이동하는 링크 추가")
+	cmd.Flags().StringVar(&exportMetricsOut, "metrics-out", "", 
+		"처리된 통계를 Prometheus 텍스트 형식으로 저장할 파일 경로 (대시보드 수집용)")
+	cmd.Flags().IntVar(&exportMinSessions, "min-sessions", 0, 
+		"내보낼 세션이 이 수보다 적으면 출력 파일을 쓰지 않고 실패 (기존 보고서 보호, 0이면 검사 안 함)")
+	cmd.Flags().StringVar(&exportCSVLevel, "csv-level", exporter.CSVLevelMessage, 
+		"CSV 출력(.csv) 시 행 단위 (message: 메시지당 한 행, session: 세션당 한 행)")
+
+	cmd.Flags().StringVar(&exportBatch, "batch", "", 
+		"glob 패턴에 맞는 데이터 파일들을 각각 <입력 이름>.md(.csv, .txt)로 동시에 내보내기 (--output은 출력 디렉토리, 생략하면 입력 파일 옆)")
 
-	// 필수 플래그
-	cmd.MarkFlagRequired("output")
+	cmd.MarkFlagsMutuallyExclusive("backup", "no-clobber")
+	for _, flag := range []string{"data", "per-session", "append", "interactive", "since-last-export", "metrics-out"} {
+		cmd.MarkFlagsMutuallyExclusive("batch", flag)
+	}
+
+	// --output은 --per-session을 사용하지 않는 경우 필수 (buildExportConfig에서 검증)
 	
 	return cmd
 }
 
-// runExportWithService는 서비스를 사용하여 내보내기를 실행합니다
-func runExportWithService(cmd *cobra.Command, args []string, exportSvc *service.ExportService) error {
+// runExport는 설정을 구성한 뒤 출력 형태(일괄, 세션별, 추가, 한 줄 요약, CSV, 마크다운)에 맞는 내보내기를 실행합니다
+func runExport(cmd *cobra.Command, args []string) error {
 	if verbose {
 		fmt.Println("마크다운 내보내기를 시작합니다...")
 	}
 
-	// 설정 로드 (필요시)
-	cfg, err := config.LoadConfig(cfgFile)
+	// 설정 로드
+	cfg, err := loadProjectConfig(cfgFile)
 	if err != nil {
 		return fmt.Errorf("설정 로드 실패: %w", err)
 	}
@@ -97,93 +273,322 @@ func runExportWithService(cmd *cobra.Command, args []string, exportSvc *service.
 		return fmt.Errorf("내보내기 설정 구성 실패: %w", err)
 	}
 
+	if exportExplain {
+		printExportExplanation(cmd.OutOrStdout(), explainExportConfig(cmd.Flags(), cfg, exportConfig, os.Getenv))
+	}
+
 	if verbose {
 		fmt.Printf("내보내기 설정: 템플릿=%s, 출력=%s\n", 
 			exportConfig.Template, exportConfig.OutputPath)
 	}
 
-	// 서비스의 ExportFromFile 메서드 호출
-	err = exportSvc.ExportFromFile(cmd.Context(), exportDataFile, exportOutputFile, exportConfig)
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// 여러 데이터 파일 일괄 내보내기
+	if exportBatch != "" {
+		return runBatchExport(ctx, exportConfig, exportBatch)
+	}
+
+	// 세션별 파일 내보내기
+	if exportConfig.PerSessionDir != "" {
+		return runPerSessionExport(ctx, exportConfig)
+	}
+
+	// 기존 마크다운 파일에 새 세션 추가
+	if exportConfig.Append {
+		return runAppendExport(ctx, exportConfig)
+	}
+
+	// 한 줄 요약 내보내기
+	if exportConfig.Format == exporter.FormatOneline {
+		return runOnelineExport(ctx, exportConfig)
+	}
+
+	// CSV 파일 내보내기
+	if exportConfig.Format == exporter.FormatCSV || (exportConfig.Format == "" && isCSVOutput(exportConfig.OutputPath)) {
+		return runCSVExport(ctx, exportConfig)
+	}
+
+	// 단일 마크다운 파일 내보내기 (기간 필터, 대화형 선택 등 세션 선택을 모두 적용)
+	return runMarkdownExport(ctx, exportConfig)
+}
+
+// runMarkdownExport는 수집 데이터를 로드해 세션을 선택하고 단일 마크다운 파일로 내보냅니다
+func runMarkdownExport(ctx context.Context, exportConfig *models.ExportConfig) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// 데이터 로드, 세션 선택(--from/--to, --since-last-export, --interactive, --min-sessions), 처리
+	collectionResult, processedData, err := prepareExportData(ctx, exportConfig, exportDataFile, selectExportSessions)
 	if err != nil {
-		return fmt.Errorf("마크다운 내보내기 실패: %w", err)
+		return err
 	}
 
 	if verbose {
-		fmt.Printf("마크다운 파일 생성 완료: %s\n", exportOutputFile)
+		fmt.Printf("처리된 데이터: 세션 %d개, 소스 %d개\n",
+			len(processedData.Sessions), len(processedData.SourceGroups))
 	}
 
+
+	// 마크다운 내보내기
+	markdownExporter := exporter.NewMarkdownExporter(exportConfig)
+	if err := markdownExporter.Export(ctx, processedData); err != nil {
+		return fmt.Errorf("마크다운 내보내기 실패: %w", err)
+	}
+
+	if err := writeExportMetrics(exportConfig, processedData.Statistics); err != nil {
+		return err
+	}
+
+	rememberExport(collectionResult.Sessions)
+
+	// 결과 출력
+	printExportResult(exportConfig, collectionResult, &processedData)
+
 	return nil
 }
 
-func runExport(cmd *cobra.Command, args []string) error {
-	if verbose {
-		fmt.Println("마크다운 내보내기를 시작합니다...")
+// runPerSessionExport는 세션별 마크다운 파일과 index.md를 생성합니다
+func runPerSessionExport(ctx context.Context, exportConfig *models.ExportConfig) error {
+	if ctx == nil {
+		ctx = context.Background()
 	}
 
-	// 설정 로드
-	cfg, err := config.LoadConfig(cfgFile)
+	// 데이터 로드, 세션 선택(--from/--to, --since-last-export, --min-sessions), 처리
+	collectionResult, processedData, err := prepareExportData(ctx, exportConfig, exportDataFile, selectExportSessions)
 	if err != nil {
-		return fmt.Errorf("설정 로드 실패: %w", err)
+		return err
 	}
 
-	// 내보내기 설정 구성
-	exportConfig, err := buildExportConfig(cfg)
+	files, err := exporter.NewMarkdownExporter(exportConfig).ExportPerSession(ctx, processedData, exportConfig.PerSessionDir)
 	if err != nil {
-		return fmt.Errorf("내보내기 설정 구성 실패: %w", err)
+		return fmt.Errorf("세션별 내보내기 실패: %w", err)
 	}
 
-	if verbose {
-		fmt.Printf("내보내기 설정: 템플릿=%s, 출력=%s\n", 
-			exportConfig.Template, exportConfig.OutputPath)
+	if err := writeExportMetrics(exportConfig, processedData.Statistics); err != nil {
+		return err
 	}
 
-	// 데이터 로드
+	rememberExport(collectionResult.Sessions)
+
+	fmt.Printf("\n=== 세션별 마크다운 내보내기 완료 ===\n")
+	fmt.Printf("출력 디렉토리: %s\n", exportConfig.PerSessionDir)
+	fmt.Printf("생성된 파일: %d개 (index.md 포함)\n", len(files))
+
+	return nil
+}
+
+// prepareExportData는 단일 파일 내보내기의 공통 과정(데이터 로드, 세션 선택, 처리)을 실행합니다
+// input이 비어 있으면 최신 수집 데이터를 사용하며, selectSessions로 내보낼 세션을 고릅니다
+func prepareExportData(ctx context.Context, exportConfig *models.ExportConfig, input string, selectSessions func(*models.CollectionResult, *models.ExportConfig) error) (*models.CollectionResult, processor.ProcessedData, error) {
 	var collectionResult *models.CollectionResult
-	if exportDataFile != "" {
-		// 파일에서 데이터 로드
-		collectionResult, err = loadDataFromFile(exportDataFile)
+	var err error
+	if input != "" {
+		collectionResult, err = loadDataFromFile(input)
 		if err != nil {
-			return fmt.Errorf("데이터 파일 로드 실패: %w", err)
+			return nil, processor.ProcessedData{}, fmt.Errorf("데이터 파일 로드 실패: %w", err)
 		}
 	} else {
-		// 최신 수집된 데이터 로드 (임시로 더미 데이터 사용)
 		collectionResult, err = loadLatestCollectedData()
 		if err != nil {
-			return fmt.Errorf("최신 수집 데이터 로드 실패: %w", err)
+			return nil, processor.ProcessedData{}, fmt.Errorf("최신 수집 데이터 로드 실패: %w", err)
 		}
 	}
 
 	if len(collectionResult.Sessions) == 0 {
-		return fmt.Errorf("내보낼 데이터가 없습니다. 먼저 collect 명령어를 실행하세요")
+		return nil, processor.ProcessedData{}, fmt.Errorf("내보낼 데이터가 없습니다. 먼저 collect 명령어를 실행하세요")
+	}
+
+	if err := selectSessions(collectionResult, exportConfig); err != nil {
+		return nil, processor.ProcessedData{}, err
 	}
 
-	// 데이터 처리
-	dataProcessor := processor.NewProcessor(exportConfig)
-	processedDataInterface, err := dataProcessor.Process(context.Background(), collectionResult.Sessions)
+	// 데이터 처리 (수집 에러, 세션 정렬 포함)
+	processedData, err := processor.NewProcessor(exportConfig).ProcessCollectionResult(ctx, collectionResult)
 	if err != nil {
-		return fmt.Errorf("데이터 처리 실패: %w", err)
+		return nil, processor.ProcessedData{}, fmt.Errorf("데이터 처리 실패: %w", err)
 	}
+	return collectionResult, processedData, nil
+}
 
-	// processedData로 타입 캐스팅
-	processedData, ok := processedDataInterface.(processor.ProcessedData)
-	if !ok {
-		return fmt.Errorf("데이터 처리 결과 타입 변환 실패")
+// selectExportSessions는 기간, 증분, 대화형 선택 필터를 적용한 뒤 최소 세션 수를 검사합니다
+func selectExportSessions(result *models.CollectionResult, exportConfig *models.ExportConfig) error {
+	if err := filterExportSessions(result, exportConfig.DateRange); err != nil {
+		return err
 	}
 
-	if verbose {
-		fmt.Printf("처리된 데이터: 세션 %d개, 소스 %d개\n",
-			len(processedData.Sessions), len(processedData.SourceGroups))
+	if exportResetState {
+		if err := resetExportState(exportStatePath()); err != nil {
+			return err
+		}
+	}
+	if exportConfig.SinceLastExport {
+		if err := filterSinceLastExport(result, exportStatePath()); err != nil {
+			return err
+		}
+	}
+	if err := filterInteractiveSessions(result); err != nil {
+		return err
 	}
 
+	// 세션 수가 최소 기준보다 적으면 기존 출력을 보호
+	return exportConfig.CheckMinSessions(len(result.Sessions))
+}
 
-	// 마크다운 내보내기
-	markdownExporter := exporter.NewMarkdownExporter(exportConfig)
-	if err := markdownExporter.Export(context.Background(), processedData); err != nil {
-		return fmt.Errorf("마크다운 내보내기 실패: %w", err)
+// rememberExport는 다음 --since-last-export 실행을 위해 내보낸 세션 시각을 기록합니다
+// 기록 실패는 내보내기 결과에 영향을 주지 않도록 경고만 출력합니다
+func rememberExport(sessions []models.SessionData) {
+	if err := recordExportState(exportStatePath(), sessions); err != nil {
+		fmt.Printf("경고: %v\n", err)
 	}
+}
 
-	// 결과 출력
-	printExportResult(exportConfig, collectionResult, &processedData)
+// writeExportMetrics는 --metrics-out이 지정된 경우 처리된 통계를 Prometheus 텍스트 형식으로 저장합니다
+func writeExportMetrics(exportConfig *models.ExportConfig, stats processor.Statistics) error {
+	if exportConfig.MetricsOut == "" {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := exporter.WritePrometheusMetrics(&buf, stats); err != nil {
+		return err
+	}
+	if err := (&exporter.LocalSink{}).Write(exportConfig.MetricsOut, buf.Bytes()); err != nil {
+		return fmt.Errorf("메트릭 파일 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// filterExportSessions는 내보내기 전에 날짜 범위 밖의 세션을 제외합니다
+func filterExportSessions(result *models.CollectionResult, dateRange *models.DateRange) error {
+	if dateRange == nil {
+		return nil
+	}
+
+	result.Sessions = models.FilterSessionsByDateRange(result.Sessions, dateRange)
+	result.TotalCount = len(result.Sessions)
+	if len(result.Sessions) == 0 {
+		return fmt.Errorf("지정한 기간에 내보낼 세션이 없습니다")
+	}
+	return nil
+}
+
+// loadAnnotations는 세션 ID → 메모 매핑 파일을 읽습니다
+// 확장자가 .json이면 JSON으로, 그 외에는 YAML로 해석합니다
+func loadAnnotations(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("주석 파일을 읽을 수 없습니다: %w", err)
+	}
+
+	annotations := make(map[string]string)
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &annotations)
+	} else {
+		err = yaml.Unmarshal(data, &annotations)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("주석 파일 형식이 올바르지 않습니다 (%s): %w", path, err)
+	}
+
+	return annotations, nil
+}
+
+// isCSVOutput은 출력 경로가 CSV 파일인지 확인합니다
+func isCSVOutput(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".csv")
+}
+
+// runCSVExport는 수집 데이터를 CSV 파일로 내보냅니다
+func runCSVExport(ctx context.Context, exportConfig *models.ExportConfig) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// 데이터 로드, 세션 선택(--from/--to, --since-last-export, --min-sessions), 처리
+	collectionResult, processedData, err := prepareExportData(ctx, exportConfig, exportDataFile, selectExportSessions)
+	if err != nil {
+		return err
+	}
+
+	if err := exporter.NewCSVExporter(exportConfig).Export(ctx, processedData); err != nil {
+		return fmt.Errorf("CSV 내보내기 실패: %w", err)
+	}
+
+	if err := writeExportMetrics(exportConfig, processedData.Statistics); err != nil {
+		return err
+	}
+
+	rememberExport(collectionResult.Sessions)
+
+	fmt.Printf("\n=== CSV 내보내기 완료 ===\n")
+	fmt.Printf("출력 파일: %s (%s 단위)\n", exportConfig.OutputPath, exportConfig.CSVLevel)
+
+	return nil
+}
+
+// runAppendExport는 출력 파일에 아직 없는 세션만 오늘 날짜 섹션으로 추가합니다 (--append)
+func runAppendExport(ctx context.Context, exportConfig *models.ExportConfig) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// 데이터 로드, 세션 선택(--from/--to, --since-last-export, --min-sessions), 처리
+	collectionResult, processedData, err := prepareExportData(ctx, exportConfig, exportDataFile, selectExportSessions)
+	if err != nil {
+		return err
+	}
+
+	appended, err := exporter.NewMarkdownExporter(exportConfig).ExportAppend(ctx, processedData, time.Now())
+	if err != nil {
+		return fmt.Errorf("마크다운 추가 내보내기 실패: %w", err)
+	}
+
+	if err := writeExportMetrics(exportConfig, processedData.Statistics); err != nil {
+		return err
+	}
+
+	rememberExport(collectionResult.Sessions)
+
+	fmt.Printf("\n=== 마크다운 추가 내보내기 완료 ===\n")
+	if appended == 0 {
+		fmt.Printf("새 세션이 없어 파일을 변경하지 않았습니다: %s\n", exportConfig.OutputPath)
+	} else {
+		fmt.Printf("출력 파일: %s (새 세션 %d개 추가, 이미 있는 세션 %d개 건너뜀)\n",
+			exportConfig.OutputPath, appended, len(processedData.Sessions)-appended)
+	}
+
+	return nil
+}
+
+// runOnelineExport는 수집 데이터를 세션당 한 줄 요약 파일로 내보냅니다
+func runOnelineExport(ctx context.Context, exportConfig *models.ExportConfig) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// 데이터 로드, 세션 선택(--from/--to, --since-last-export, --min-sessions), 처리
+	collectionResult, processedData, err := prepareExportData(ctx, exportConfig, exportDataFile, selectExportSessions)
+	if err != nil {
+		return err
+	}
+
+	if err := exporter.NewOnelineExporter(exportConfig).Export(ctx, processedData); err != nil {
+		return fmt.Errorf("한 줄 요약 내보내기 실패: %w", err)
+	}
+
+	if err := writeExportMetrics(exportConfig, processedData.Statistics); err != nil {
+		return err
+	}
+
+	rememberExport(collectionResult.Sessions)
+
+	fmt.Printf("\n=== 한 줄 요약 내보내기 완료 ===\n")
+	fmt.Printf("출력 파일: %s (세션 %d개)\n", exportConfig.OutputPath, len(processedData.Sessions))
 
 	return nil
 }
@@ -191,11 +596,144 @@ func runExport(cmd *cobra.Command, args []string) error {
 func buildExportConfig(cfg *config.Config) (*models.ExportConfig, error) {
 	exportCfg := &models.ExportConfig{
 		OutputPath:        exportOutputFile,
-		IncludeMetadata:   !exportNoMeta,
-		IncludeTimestamps: !exportNoTimestamp,
+		IncludeMetadata:   cfg.OutputSettings.IncludeMetadata && !exportNoMeta,
+		NoFooter:          exportNoFooter,
+		IncludeTimestamps: cfg.OutputSettings.IncludeTimestamps && !exportNoTimestamp,
 		FormatCodeBlocks:  cfg.OutputSettings.FormatCodeBlocks,
 		GenerateTOC:       cfg.OutputSettings.GenerateTOC && !exportNoTOC,
 		CustomFields:      exportCustomFields,
+		OutlineOnly:       exportOutline,
+		PerSessionDir:     exportPerSession,
+		IncludeErrors:     exportIncludeErrors,
+		NormalizeWhitespace: exportNormalizeWhitespace,
+		RecentWindow:      exportRecentWindow,
+		SortMessages:      exportSortMessages,
+		PruneEmptyMetadata: exportPruneEmptyMetadata,
+		ThreadMessages:    exportThread,
+		CSVLevel:          exportCSVLevel,
+		RoleIcons:         exportRoleIcons,
+		NoIcons:           exportNoIcons,
+		ContentHash:       exportContentHash,
+		DetectLanguage:    exportDetectLanguage,
+		MermaidTimeline:   exportMermaid,
+		ActivityHeatmap:   exportHeatmap,
+		NarrativeOverview: exportNarrative,
+		GroupByMeta:       strings.TrimSpace(exportGroupByMeta),
+		SourceBudget:      exportSourceBudget,
+		LatestPerSource:   exportLatestPerSource,
+		Top:               exportTop,
+		TopBy:             exportTopBy,
+		MinSessions:       exportMinSessions,
+		CodeCaptions:      exportCodeCaptions,
+		SinceLastExport:   exportSinceLast,
+		MetricsOut:        exportMetricsOut,
+		IncludeSourceLinks: exportSourceLinks,
+		Format:            exportFormat,
+		ExtractCode:       exportExtractCode,
+		SessionSeparator:  exportSessionSeparator,
+		Workers:           exportWorkers,
+		CommandEnvKeysOnly: exportCommandEnvKeys,
+		ANSIHTML:          exportANSIHTML,
+		CollapseSessionsOver: exportCollapseOver,
+		Append:            exportAppend,
+		Backup:            exportBackup,
+		NoClobber:         exportNoClobber,
+		DisplayTimezone:   exportTimezone,
+		ActiveSourceMetric: exportActiveMetric,
+	}
+
+	dateRange, err := parseDateRange(exportDateFrom, exportDateTo)
+	if err != nil {
+		return nil, err
+	}
+	exportCfg.DateRange = dateRange
+
+	if exportAnnotations != "" {
+		annotations, err := loadAnnotations(exportAnnotations)
+		if err != nil {
+			return nil, err
+		}
+		exportCfg.Annotations = annotations
+	}
+
+	switch exportCfg.CSVLevel {
+	case "", exporter.CSVLevelMessage, exporter.CSVLevelSession:
+	default:
+		return nil, fmt.Errorf("--csv-level은 message 또는 session이어야 합니다: %s", exportCfg.CSVLevel)
+	}
+
+	switch exportCfg.Format {
+	case "", "markdown", exporter.FormatCSV, exporter.FormatOneline:
+	default:
+		return nil, fmt.Errorf("--format은 markdown, csv, oneline 중 하나여야 합니다: %s", exportCfg.Format)
+	}
+
+	switch exportCfg.ActiveSourceMetric {
+	case "", processor.ActiveSourceBySessions, processor.ActiveSourceByMessages:
+	default:
+		return nil, fmt.Errorf("--active-source-metric은 sessions 또는 messages여야 합니다: %s", exportCfg.ActiveSourceMetric)
+	}
+
+	if exportCfg.SourceBudget < 0 {
+		return nil, fmt.Errorf("--source-budget는 0 이상이어야 합니다: %d", exportCfg.SourceBudget)
+	}
+
+	if len(exportSourceOrder) > 0 {
+		sourceOrder, err := parseSourceNames(exportSourceOrder)
+		if err != nil {
+			return nil, fmt.Errorf("--source-order 값이 올바르지 않습니다: %w", err)
+		}
+		for i, source := range sourceOrder {
+			if slices.Contains(sourceOrder[:i], source) {
+				return nil, fmt.Errorf("--source-order에 같은 소스가 두 번 있습니다: %s", source)
+			}
+		}
+		exportCfg.SourceOrder = sourceOrder
+	}
+
+	if exportCfg.Top < 0 {
+		return nil, fmt.Errorf("--top은 0 이상이어야 합니다: %d", exportCfg.Top)
+	}
+	switch exportCfg.TopBy {
+	case "", processor.TopByMessages, processor.TopByDuration:
+	default:
+		return nil, fmt.Errorf("--top-by는 messages 또는 duration이어야 합니다: %s", exportCfg.TopBy)
+	}
+
+	if _, err := exportCfg.DisplayLocation(); err != nil {
+		return nil, fmt.Errorf("--timezone 값이 올바르지 않습니다: %w", err)
+	}
+
+	if exportCfg.Backup && exportCfg.NoClobber {
+		return nil, fmt.Errorf("--backup과 --no-clobber는 함께 사용할 수 없습니다")
+	}
+
+	if exportCfg.Append {
+		if exportCfg.NoClobber {
+			return nil, fmt.Errorf("--append는 기존 파일을 갱신하므로 --no-clobber와 함께 사용할 수 없습니다")
+		}
+		if exportCfg.PerSessionDir != "" {
+			return nil, fmt.Errorf("--append는 --per-session과 함께 사용할 수 없습니다")
+		}
+		if (exportCfg.Format != "" && exportCfg.Format != "markdown") || (exportCfg.Format == "" && isCSVOutput(exportCfg.OutputPath)) {
+			return nil, fmt.Errorf("--append는 마크다운 출력에서만 사용할 수 있습니다")
+		}
+	}
+
+	if exportCfg.CollapseSessionsOver < 0 {
+		return nil, fmt.Errorf("--collapse-sessions-over는 0 이상이어야 합니다: %d", exportCfg.CollapseSessionsOver)
+	}
+
+	if exportCfg.Workers < 0 {
+		return nil, fmt.Errorf("--workers는 0 이상이어야 합니다: %d", exportCfg.Workers)
+	}
+
+	if exportCfg.MinSessions < 0 {
+		return nil, fmt.Errorf("--min-sessions는 0 이상이어야 합니다: %d", exportCfg.MinSessions)
+	}
+
+	if exportCfg.RecentWindow < 0 {
+		return nil, fmt.Errorf("최근 활동 기간은 0 이상이어야 합니다: %s", exportCfg.RecentWindow)
 	}
 
 	// 템플릿 설정
@@ -205,6 +743,16 @@ func buildExportConfig(cfg *config.Config) (*models.ExportConfig, error) {
 		exportCfg.Template = cfg.OutputSettings.DefaultTemplate
 	}
 
+	// 세션별 내보내기는 단일 출력 파일이 필요 없음
+	if exportCfg.PerSessionDir != "" && exportCfg.OutputPath == "" {
+		return exportCfg, nil
+	}
+
+	// 일괄 내보내기에서 --output은 출력 디렉토리 (입력마다 파일 이름을 정함)
+	if exportBatch != "" {
+		return exportCfg, nil
+	}
+
 	// 출력 파일 경로 검증
 	if exportCfg.OutputPath == "" {
 		return nil, fmt.Errorf("출력 파일 경로가 지정되지 않았습니다")
@@ -233,6 +781,11 @@ func loadDataFromFile(dataFile string) (*models.CollectionResult, error) {
 		return nil, fmt.Errorf("데이터 파일 형식이 올바르지 않습니다: %w", err)
 	}
 
+	// 이전 버전 파일 마이그레이션
+	if err := result.Migrate(); err != nil {
+		return nil, fmt.Errorf("데이터 파일 마이그레이션 실패: %w", err)
+	}
+
 	return &result, nil
 }
 
@@ -268,9 +821,12 @@ func loadLatestCollectedData() (*models.CollectionResult, error) {
 		fmt.Println("실제 데이터를 원한다면 먼저 'collect' 명령어를 실행하세요.")
 	}
 
-	// 더미 데이터 생성 (기존 로직 유지)
-	now := time.Now()
-	result := &models.CollectionResult{
+	return generateDummyData(time.Now()), nil
+}
+
+// generateDummyData는 수집 데이터가 없을 때 사용하는 소스별 예시 세션 데이터를 now 기준으로 생성합니다
+func generateDummyData(now time.Time) *models.CollectionResult {
+	return &models.CollectionResult{
 		Sessions: []models.SessionData{
 			{
 				ID:        "claude-session-export-demo",
@@ -371,8 +927,11 @@ func loadLatestCollectedData() (*models.CollectionResult, error) {
 		Duration:    5 * time.Second,
 		Errors:      []string{"실제 수집 데이터가 없어 더미 데이터를 사용합니다."},
 	}
+}
 
-	return result, nil
+// isCollectionDataFile은 latest.json을 제외한 collection-*.json 수집 파일 이름인지 확인합니다
+func isCollectionDataFile(name string) bool {
+	return name != "latest.json" && strings.HasPrefix(name, "collection-") && strings.HasSuffix(name, ".json")
 }
 
 // findLatestDataFile은 데이터 디렉토리에서 가장 최신 데이터 파일을 찾습니다
@@ -398,8 +957,7 @@ func findLatestDataFile(dataDir string) (string, error) {
 		}
 
 		name := entry.Name()
-		// latest.json은 제외하고 collection-*.json 패턴만 검사
-		if name == "latest.json" || !strings.HasPrefix(name, "collection-") || !strings.HasSuffix(name, ".json") {
+		if !isCollectionDataFile(name) {
 			continue
 		}
 
@@ -443,16 +1001,7 @@ func printExportResult(cfg *models.ExportConfig, collectionResult *models.Collec
 	fmt.Printf("소스별 분포:\n")
 	
 	for source, sessions := range processedData.SourceGroups {
-		sourceName := ""
-		switch source {
-		case models.SourceClaudeCode:
-			sourceName = "Claude Code"
-		case models.SourceGeminiCLI:
-			sourceName = "Gemini CLI"
-		case models.SourceAmazonQ:
-			sourceName = "Amazon Q"
-		}
-		fmt.Printf("  - %s: %d개 세션\n", sourceName, len(sessions))
+		fmt.Printf("  - %s: %d개 세션\n", source.DisplayName(), len(sessions))
 	}
 
 	// 파일 크기 정보
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"ssamai/internal/exporter"
+	"ssamai/internal/interfaces"
+	"ssamai/pkg/models"
+)
+
+// batchExportResult는 일괄 내보내기에서 입력 파일 하나를 처리한 결과입니다
+type batchExportResult struct {
+	Input    string
+	Output   string
+	Sessions int
+	Err      error
+}
+
+// runBatchExport는 pattern에 맞는 데이터 파일을 각각 별도 출력 파일로 동시에 내보내고 결과 요약을 출력합니다 (--batch)
+// exportConfig.OutputPath가 있으면 출력 디렉토리로 사용하고, 없으면 입력 파일 옆에 씁니다
+func runBatchExport(ctx context.Context, exportConfig *models.ExportConfig, pattern string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	results, err := exportBatchFiles(ctx, exportConfig, pattern)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n=== 일괄 내보내기 완료 ===\n")
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			fmt.Printf("  ✗ %s: %v\n", result.Input, result.Err)
+			continue
+		}
+		fmt.Printf("  ✓ %s -> %s (세션 %d개)\n", result.Input, result.Output, result.Sessions)
+	}
+	fmt.Printf("생성된 파일: %d개, 실패: %d개\n", len(results)-failed, failed)
+
+	if failed > 0 {
+		return fmt.Errorf("%d개 파일 내보내기 실패", failed)
+	}
+	return nil
+}
+
+// exportBatchFiles는 pattern에 맞는 입력 파일들을 동시에 내보내고 입력 파일 순서대로 결과를 반환합니다
+// 파일별 실패는 결과에 기록하며, 패턴이 잘못됐거나 출력 경로가 겹치면 아무것도 쓰지 않고 에러를 반환합니다
+func exportBatchFiles(ctx context.Context, exportConfig *models.ExportConfig, pattern string) ([]batchExportResult, error) {
+	inputs, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("--batch 패턴이 올바르지 않습니다: %w", err)
+	}
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("--batch 패턴과 일치하는 데이터 파일이 없습니다: %s", pattern)
+	}
+
+	results := make([]batchExportResult, len(inputs))
+	owners := make(map[string]string, len(inputs))
+	for i, input := range inputs {
+		output := batchOutputPath(input, exportConfig.OutputPath, exportConfig.Format)
+		if previous, exists := owners[output]; exists {
+			return nil, fmt.Errorf("출력 파일 이름이 겹칩니다: %s와 %s -> %s", previous, input, output)
+		}
+		owners[output] = input
+		results[i] = batchExportResult{Input: input, Output: output}
+	}
+
+	semaphore := make(chan struct{}, runtime.NumCPU())
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(result *batchExportResult) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			// 입력마다 출력 경로만 다른 설정 사본을 사용
+			fileConfig := *exportConfig
+			fileConfig.OutputPath = result.Output
+			result.Sessions, result.Err = exportDataFileTo(ctx, &fileConfig, result.Input)
+		}(&results[i])
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// exportDataFileTo는 데이터 파일 하나를 단일 파일 내보내기와 같은 과정(prepareExportData)으로 처리해 출력합니다
+// 내보낸 세션 수를 반환합니다
+func exportDataFileTo(ctx context.Context, exportConfig *models.ExportConfig, input string) (int, error) {
+	_, processedData, err := prepareExportData(ctx, exportConfig, input, selectBatchSessions)
+	if err != nil {
+		return 0, err
+	}
+
+	var dataExporter interfaces.DataExporter
+	switch exportConfig.Format {
+	case exporter.FormatCSV:
+		dataExporter = exporter.NewCSVExporter(exportConfig)
+	case exporter.FormatOneline:
+		dataExporter = exporter.NewOnelineExporter(exportConfig)
+	default:
+		dataExporter = exporter.NewMarkdownExporter(exportConfig)
+	}
+	if err := dataExporter.Export(ctx, processedData); err != nil {
+		return 0, fmt.Errorf("내보내기 실패: %w", err)
+	}
+
+	return len(processedData.Sessions), nil
+}
+
+// selectBatchSessions는 일괄 내보내기에서 내보낼 세션을 고릅니다 (--from/--to, --min-sessions)
+// 여러 파일을 동시에 처리하므로 대화형 선택과 --since-last-export 상태는 사용하지 않습니다
+func selectBatchSessions(result *models.CollectionResult, exportConfig *models.ExportConfig) error {
+	if err := filterExportSessions(result, exportConfig.DateRange); err != nil {
+		return err
+	}
+	return exportConfig.CheckMinSessions(len(result.Sessions))
+}
+
+// batchOutputPath는 입력 파일 이름에서 확장자를 형식에 맞게 바꾼 출력 경로를 반환합니다
+// outputDir이 비어 있으면 입력 파일과 같은 디렉토리를 사용합니다
+func batchOutputPath(input, outputDir, format string) string {
+	ext := ".md"
+	switch format {
+	case exporter.FormatCSV:
+		ext = ".csv"
+	case exporter.FormatOneline:
+		ext = ".txt"
+	}
+
+	name := strings.TrimSuffix(filepath.Base(input), filepath.Ext(input)) + ext
+	if outputDir == "" {
+		outputDir = filepath.Dir(input)
+	}
+	return filepath.Join(outputDir, name)
+}
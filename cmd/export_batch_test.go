@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchSession은 일괄 내보내기 입력 파일마다 하나씩 넣는 세션입니다 (본문에 title이 들어감)
+func batchSession(id, title string) models.SessionData {
+	return models.SessionData{
+		ID:        id,
+		Source:    models.SourceClaudeCode,
+		Title:     title,
+		Timestamp: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+		Messages:  []models.Message{{Role: "user", Content: title + " 내용"}},
+	}
+}
+
+func TestExportBatchFiles_EachInputGetsOwnOutput(t *testing.T) {
+	dataDir := t.TempDir()
+	outDir := filepath.Join(t.TempDir(), "reports")
+	writeSessionsFile(t, filepath.Join(dataDir, "monday.json"), batchSession("monday", "월요일 작업"))
+	writeSessionsFile(t, filepath.Join(dataDir, "tuesday.json"), batchSession("tuesday", "화요일 작업"))
+	writeSessionsFile(t, filepath.Join(dataDir, "wednesday.json"), batchSession("wednesday", "수요일 작업"))
+
+	results, err := exportBatchFiles(context.Background(), &models.ExportConfig{OutputPath: outDir, IncludeMetadata: true},
+		filepath.Join(dataDir, "*.json"))
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	expected := map[string]string{"monday.md": "월요일 작업", "tuesday.md": "화요일 작업", "wednesday.md": "수요일 작업"}
+	for _, result := range results {
+		require.NoError(t, result.Err, result.Input)
+		assert.Equal(t, 1, result.Sessions)
+
+		content, err := os.ReadFile(result.Output)
+		require.NoError(t, err)
+		title := expected[filepath.Base(result.Output)]
+		require.NotEmpty(t, title, "unexpected output %s", result.Output)
+		assert.Contains(t, string(content), title)
+		for _, other := range expected {
+			if other != title {
+				assert.NotContains(t, string(content), other)
+			}
+		}
+	}
+}
+
+func TestExportBatchFiles_FormatAndFailures(t *testing.T) {
+	dataDir := t.TempDir()
+	writeSessionsFile(t, filepath.Join(dataDir, "good.json"), batchSession("good", "정상 파일"))
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "broken.json"), []byte("{not json"), 0644))
+
+	// 출력 디렉토리가 없으면 입력 파일 옆에 씀
+	results, err := exportBatchFiles(context.Background(), &models.ExportConfig{Format: "csv"}, filepath.Join(dataDir, "*.json"))
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	byInput := map[string]batchExportResult{}
+	for _, result := range results {
+		byInput[filepath.Base(result.Input)] = result
+	}
+	assert.Error(t, byInput["broken.json"].Err)
+	require.NoError(t, byInput["good.json"].Err)
+	assert.Equal(t, filepath.Join(dataDir, "good.csv"), byInput["good.json"].Output)
+	assert.FileExists(t, filepath.Join(dataDir, "good.csv"))
+}
+
+func TestExportBatchFiles_Errors(t *testing.T) {
+	_, err := exportBatchFiles(context.Background(), &models.ExportConfig{}, filepath.Join(t.TempDir(), "*.json"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "일치하는 데이터 파일이 없습니다")
+
+	// 서로 다른 디렉토리의 같은 이름 파일이 한 출력 디렉토리로 모이면 실패
+	root := t.TempDir()
+	for _, dir := range []string{"a", "b"} {
+		writeSessionsFile(t, filepath.Join(root, dir, "data.json"), batchSession(dir, dir))
+	}
+	_, err = exportBatchFiles(context.Background(), &models.ExportConfig{OutputPath: t.TempDir()}, filepath.Join(root, "*", "data.json"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "출력 파일 이름이 겹칩니다")
+}
+
+func TestBatchOutputPath(t *testing.T) {
+	assert.Equal(t, filepath.Join("data", "week1.md"), batchOutputPath(filepath.Join("data", "week1.json"), "", ""))
+	assert.Equal(t, filepath.Join("out", "week1.csv"), batchOutputPath(filepath.Join("data", "week1.json"), "out", "csv"))
+	assert.Equal(t, filepath.Join("out", "week1.txt"), batchOutputPath("week1.json", "out", "oneline"))
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"ssamai/pkg/models"
+)
+
+// sessionPrompter는 내보낼 세션을 사용자에게 고르게 합니다
+// 선택한 세션의 인덱스(0부터)를 반환합니다
+type sessionPrompter interface {
+	SelectSessions(sessions []models.SessionData) ([]int, error)
+}
+
+var (
+	// 테스트에서 교체할 수 있도록 프롬프터와 터미널 판별을 변수로 둠
+	exportPrompter      sessionPrompter = &terminalPrompter{in: os.Stdin, out: os.Stdout}
+	exportIsInteractive                 = stdinIsTerminal
+)
+
+// stdinIsTerminal은 표준 입력이 터미널에 연결되어 있는지 확인합니다
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// filterInteractiveSessions는 --interactive가 지정된 경우 프롬프터로 고른 세션만 남깁니다
+// 터미널이 아니면 안내만 출력하고 모든 세션을 내보냅니다
+func filterInteractiveSessions(result *models.CollectionResult) error {
+	if !exportInteractive {
+		return nil
+	}
+	if !exportIsInteractive() {
+		fmt.Println("터미널이 아니므로 --interactive를 무시하고 모든 세션을 내보냅니다")
+		return nil
+	}
+
+	indices, err := exportPrompter.SelectSessions(result.Sessions)
+	if err != nil {
+		return fmt.Errorf("세션 선택 실패: %w", err)
+	}
+
+	selected := make([]models.SessionData, 0, len(indices))
+	for _, index := range indices {
+		if index < 0 || index >= len(result.Sessions) {
+			return fmt.Errorf("잘못된 세션 번호입니다: %d", index+1)
+		}
+		selected = append(selected, result.Sessions[index])
+	}
+	if len(selected) == 0 {
+		return fmt.Errorf("선택된 세션이 없습니다")
+	}
+
+	result.Sessions = selected
+	result.TotalCount = len(selected)
+	return nil
+}
+
+// terminalPrompter는 세션 목록을 번호와 함께 출력하고 한 줄 입력으로 선택을 받습니다
+type terminalPrompter struct {
+	in  io.Reader
+	out io.Writer
+}
+
+// SelectSessions는 "1,3,5-7" 형식의 번호 목록을 읽습니다 (빈 입력이나 all은 전체 선택)
+func (p *terminalPrompter) SelectSessions(sessions []models.SessionData) ([]int, error) {
+	fmt.Fprintln(p.out, "내보낼 세션을 선택하세요:")
+	for i, session := range sessions {
+		date := "----------"
+		if !session.Timestamp.IsZero() {
+			date = session.Timestamp.Format("2006-01-02")
+		}
+		fmt.Fprintf(p.out, "%4d) %s | %s | %s | %s\n",
+			i+1, date, session.Source, session.ID, strings.Join(strings.Fields(session.Title), " "))
+	}
+	fmt.Fprint(p.out, "번호 (예: 1,3,5-7, 전체는 Enter): ")
+
+	line, err := bufio.NewReader(p.in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("입력 읽기 실패: %w", err)
+	}
+	return parseSessionSelection(line, len(sessions))
+}
+
+// parseSessionSelection은 "1,3,5-7" 형식의 1부터 시작하는 번호 목록을 중복 없는 0 기반 인덱스로 변환합니다
+// 빈 입력이나 "all"은 전체 선택입니다
+func parseSessionSelection(input string, count int) ([]int, error) {
+	input = strings.TrimSpace(input)
+	if input == "" || strings.EqualFold(input, "all") {
+		indices := make([]int, count)
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices, nil
+	}
+
+	seen := make(map[int]bool)
+	var indices []int
+	for _, part := range strings.Split(input, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		first, last := part, part
+		if from, to, ok := strings.Cut(part, "-"); ok {
+			first, last = strings.TrimSpace(from), strings.TrimSpace(to)
+		}
+		start, err := strconv.Atoi(first)
+		if err != nil {
+			return nil, fmt.Errorf("잘못된 번호입니다: %s", part)
+		}
+		end, err := strconv.Atoi(last)
+		if err != nil {
+			return nil, fmt.Errorf("잘못된 번호입니다: %s", part)
+		}
+		if start < 1 || end > count || start > end {
+			return nil, fmt.Errorf("번호 범위가 올바르지 않습니다: %s (1-%d)", part, count)
+		}
+
+		for n := start; n <= end; n++ {
+			if !seen[n-1] {
+				seen[n-1] = true
+				indices = append(indices, n-1)
+			}
+		}
+	}
+	return indices, nil
+}
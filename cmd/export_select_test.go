@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ssamai/internal/config"
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePrompter는 미리 정한 인덱스를 선택하고 표시된 세션 ID를 기록합니다
+type fakePrompter struct {
+	choice []int
+	shown  []string
+}
+
+func (p *fakePrompter) SelectSessions(sessions []models.SessionData) ([]int, error) {
+	for _, session := range sessions {
+		p.shown = append(p.shown, session.ID)
+	}
+	return p.choice, nil
+}
+
+// stubInteractiveExport는 --interactive와 프롬프터, 터미널 판별을 테스트 동안 교체합니다
+func stubInteractiveExport(t *testing.T, prompter sessionPrompter, terminal bool) {
+	t.Helper()
+	savedPrompter, savedIsInteractive := exportPrompter, exportIsInteractive
+	exportInteractive = true
+	exportPrompter = prompter
+	exportIsInteractive = func() bool { return terminal }
+	t.Cleanup(func() {
+		exportInteractive = false
+		exportPrompter = savedPrompter
+		exportIsInteractive = savedIsInteractive
+	})
+}
+
+func interactiveTestSessions() []models.SessionData {
+	session := func(id string, day int) models.SessionData {
+		return models.SessionData{ID: id, Source: models.SourceClaudeCode, Title: "Session " + id,
+			Timestamp: time.Date(2024, 1, day, 9, 0, 0, 0, time.UTC),
+			Messages:  []models.Message{{Role: "user", Content: "message " + id}}}
+	}
+	return []models.SessionData{session("alpha", 1), session("beta", 2), session("gamma", 3)}
+}
+
+func TestRunMarkdownExport_InteractiveSelection(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	exportDataFile = filepath.Join(dir, "data.json")
+	exportOutputFile = filepath.Join(dir, "curated.md")
+	defer func() {
+		exportDataFile = ""
+		exportOutputFile = ""
+	}()
+
+	prompter := &fakePrompter{choice: []int{0, 2}}
+	stubInteractiveExport(t, prompter, true)
+
+	writeSessionsFile(t, exportDataFile, interactiveTestSessions()...)
+	exportCfg, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	require.NoError(t, runMarkdownExport(context.Background(), exportCfg))
+
+	assert.Equal(t, []string{"alpha", "beta", "gamma"}, prompter.shown)
+
+	output, err := os.ReadFile(exportOutputFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "message alpha")
+	assert.Contains(t, string(output), "message gamma")
+	assert.NotContains(t, string(output), "message beta")
+}
+
+func TestFilterInteractiveSessions_NonInteractiveKeepsAll(t *testing.T) {
+	prompter := &fakePrompter{choice: []int{0}}
+	stubInteractiveExport(t, prompter, false)
+
+	result := &models.CollectionResult{Sessions: interactiveTestSessions()}
+	require.NoError(t, filterInteractiveSessions(result))
+	assert.Len(t, result.Sessions, 3)
+	assert.Empty(t, prompter.shown)
+}
+
+func TestFilterInteractiveSessions_EmptySelection(t *testing.T) {
+	stubInteractiveExport(t, &fakePrompter{}, true)
+
+	result := &models.CollectionResult{Sessions: interactiveTestSessions()}
+	err := filterInteractiveSessions(result)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "선택된 세션이 없습니다")
+}
+
+func TestTerminalPrompter_SelectSessions(t *testing.T) {
+	var out bytes.Buffer
+	prompter := &terminalPrompter{in: strings.NewReader("3, 1\n"), out: &out}
+
+	indices, err := prompter.SelectSessions(interactiveTestSessions())
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 0}, indices)
+	assert.Contains(t, out.String(), "   2) 2024-01-02 | claude_code | beta | Session beta\n")
+}
+
+func TestParseSessionSelection(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []int
+		wantErr bool
+	}{
+		{name: "empty selects all", input: "\n", want: []int{0, 1, 2, 3}},
+		{name: "all keyword", input: "ALL", want: []int{0, 1, 2, 3}},
+		{name: "list and range", input: "4,1-2", want: []int{3, 0, 1}},
+		{name: "duplicates removed", input: "2,1-3", want: []int{1, 0, 2}},
+		{name: "out of range", input: "5", wantErr: true},
+		{name: "inverted range", input: "3-1", wantErr: true},
+		{name: "not a number", input: "x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSessionSelection(tt.input, 4)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"ssamai/pkg/models"
+)
+
+// exportStateFileName은 마지막 내보내기 정보를 저장하는 파일 이름입니다
+const exportStateFileName = "export-state.json"
+
+// exportState는 증분 내보내기(--since-last-export)를 위한 마지막 내보내기 기록입니다
+type exportState struct {
+	LastSessionTime time.Time `json:"last_session_time"`
+	ExportedAt      time.Time `json:"exported_at"`
+}
+
+// exportStatePath는 데이터 디렉토리 아래의 내보내기 상태 파일 경로를 반환합니다
+func exportStatePath() string {
+	return filepath.Join(getDataDirectory(), exportStateFileName)
+}
+
+// loadExportState는 내보내기 상태 파일을 읽습니다. 파일이 없으면 nil을 반환합니다
+func loadExportState(path string) (*exportState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("내보내기 상태 파일을 읽을 수 없습니다: %w", err)
+	}
+
+	var state exportState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("내보내기 상태 파일 형식이 올바르지 않습니다 (%s): %w", path, err)
+	}
+	return &state, nil
+}
+
+// recordExportState는 내보낸 세션 중 가장 최근 타임스탬프를 상태 파일에 기록합니다
+// 이전 기록보다 오래된 값으로 되돌리지는 않습니다
+func recordExportState(path string, sessions []models.SessionData) error {
+	state := exportState{ExportedAt: time.Now()}
+	if previous, err := loadExportState(path); err == nil && previous != nil {
+		state.LastSessionTime = previous.LastSessionTime
+	}
+
+	for _, session := range sessions {
+		if session.Timestamp.After(state.LastSessionTime) {
+			state.LastSessionTime = session.Timestamp
+		}
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("내보내기 상태 직렬화 실패: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("데이터 디렉토리 생성 실패: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("내보내기 상태 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// resetExportState는 내보내기 상태 파일을 삭제해 다음 증분 내보내기가 전체를 대상으로 하게 합니다
+func resetExportState(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("내보내기 상태 초기화 실패: %w", err)
+	}
+	return nil
+}
+
+// filterSinceLastExport는 마지막 내보내기 이후의 세션만 남깁니다
+// 상태 파일이 없으면 모든 세션을 유지합니다
+func filterSinceLastExport(result *models.CollectionResult, statePath string) error {
+	state, err := loadExportState(statePath)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return nil
+	}
+
+	filtered := make([]models.SessionData, 0, len(result.Sessions))
+	for _, session := range result.Sessions {
+		if session.Timestamp.After(state.LastSessionTime) {
+			filtered = append(filtered, session)
+		}
+	}
+
+	result.Sessions = filtered
+	result.TotalCount = len(filtered)
+	if len(filtered) == 0 {
+		return fmt.Errorf("마지막 내보내기(%s) 이후 새 세션이 없습니다",
+			state.LastSessionTime.Format("2006-01-02 15:04:05"))
+	}
+	return nil
+}
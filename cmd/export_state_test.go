@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ssamai/internal/config"
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSessionsFile은 sessions를 담은 수집 데이터 파일을 path에 저장합니다 (상위 디렉토리가 없으면 생성)
+func writeSessionsFile(t *testing.T, path string, sessions ...models.SessionData) {
+	t.Helper()
+
+	data, err := json.Marshal(&models.CollectionResult{Sessions: sessions, TotalCount: len(sessions)})
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, data, 0644))
+}
+
+func TestRunCSVExport_SinceLastExport(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 12, 0, 0, 0, time.UTC) }
+	dataFile := filepath.Join(dir, "data.json")
+
+	exportDataFile = dataFile
+	exportOutputFile = filepath.Join(dir, "sessions.csv")
+	exportCSVLevel = "session"
+	exportSinceLast = true
+	defer func() {
+		exportDataFile = ""
+		exportOutputFile = ""
+		exportCSVLevel = ""
+		exportSinceLast = false
+		exportResetState = false
+	}()
+
+	export := func() (string, error) {
+		exportCfg, err := buildExportConfig(&config.Config{})
+		require.NoError(t, err)
+		if err := runCSVExport(context.Background(), exportCfg); err != nil {
+			return "", err
+		}
+		output, err := os.ReadFile(exportOutputFile)
+		require.NoError(t, err)
+		return string(output), nil
+	}
+
+	// 첫 내보내기: 기록이 없으므로 전체
+	writeSessionsFile(t, dataFile,
+		models.SessionData{ID: "old-1", Source: models.SourceClaudeCode, Timestamp: day(1)},
+		models.SessionData{ID: "old-2", Source: models.SourceGeminiCLI, Timestamp: day(2)},
+	)
+	output, err := export()
+	require.NoError(t, err)
+	assert.Contains(t, output, ",old-1,")
+	assert.Contains(t, output, ",old-2,")
+
+	state, err := loadExportState(exportStatePath())
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	assert.True(t, state.LastSessionTime.Equal(day(2)))
+
+	// 두 번째 내보내기: 이후에 추가된 세션만
+	writeSessionsFile(t, dataFile,
+		models.SessionData{ID: "old-1", Source: models.SourceClaudeCode, Timestamp: day(1)},
+		models.SessionData{ID: "old-2", Source: models.SourceGeminiCLI, Timestamp: day(2)},
+		models.SessionData{ID: "new-3", Source: models.SourceAmazonQ, Timestamp: day(3)},
+	)
+	output, err = export()
+	require.NoError(t, err)
+	assert.Contains(t, output, ",new-3,")
+	assert.NotContains(t, output, ",old-1,")
+	assert.NotContains(t, output, ",old-2,")
+
+	// 새 세션이 없으면 에러
+	_, err = export()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "이후 새 세션이 없습니다")
+
+	// 기록 초기화 후에는 다시 전체
+	exportResetState = true
+	output, err = export()
+	require.NoError(t, err)
+	assert.Contains(t, output, ",old-1,")
+	assert.Contains(t, output, ",new-3,")
+}
+
+func TestRecordExportState_KeepsLatest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", exportStateFileName)
+
+	state, err := loadExportState(path)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+
+	later := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, recordExportState(path, []models.SessionData{{Timestamp: later}}))
+	require.NoError(t, recordExportState(path, []models.SessionData{{Timestamp: later.AddDate(0, -1, 0)}}))
+
+	state, err = loadExportState(path)
+	require.NoError(t, err)
+	assert.True(t, state.LastSessionTime.Equal(later))
+
+	require.NoError(t, resetExportState(path))
+	require.NoError(t, resetExportState(path))
+	state, err = loadExportState(path)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+}
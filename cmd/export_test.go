@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -37,6 +39,8 @@ func TestBuildExportConfig(t *testing.T) {
 			config: &config.Config{
 				OutputSettings: config.OutputSettings{
 					DefaultTemplate:   "default",
+					IncludeMetadata:   true,
+					IncludeTimestamps: true,
 					FormatCodeBlocks:  true,
 					GenerateTOC:       true,
 				},
@@ -97,6 +101,8 @@ func TestBuildExportConfig(t *testing.T) {
 			config: &config.Config{
 				OutputSettings: config.OutputSettings{
 					DefaultTemplate:   "default",
+					IncludeMetadata:   true,
+					IncludeTimestamps: true,
 					FormatCodeBlocks:  true,
 					GenerateTOC:       true,
 				},
@@ -111,6 +117,30 @@ func TestBuildExportConfig(t *testing.T) {
 				CustomFields:      map[string]string{},
 			},
 		},
+		{
+			name: "config disables metadata and timestamps",
+			setupFlags: func() {
+				exportOutputFile = "output.md"
+			},
+			config: &config.Config{
+				OutputSettings: config.OutputSettings{
+					DefaultTemplate:   "default",
+					IncludeMetadata:   false,
+					IncludeTimestamps: false,
+					FormatCodeBlocks:  true,
+					GenerateTOC:       true,
+				},
+			},
+			expectedConfig: &models.ExportConfig{
+				Template:          "default",
+				OutputPath:        "output.md",
+				IncludeMetadata:   false,
+				IncludeTimestamps: false,
+				FormatCodeBlocks:  true,
+				GenerateTOC:       true,
+				CustomFields:      map[string]string{},
+			},
+		},
 		{
 			name: "missing output file",
 			setupFlags: func() {
@@ -157,6 +187,120 @@ func TestBuildExportConfig(t *testing.T) {
 	}
 }
 
+func TestBuildExportConfig_SourceOrder(t *testing.T) {
+	exportOutputFile = "output.md"
+	defer func() {
+		exportOutputFile = ""
+		exportSourceOrder = nil
+	}()
+
+	exportSourceOrder = []string{"gemini_cli", "claude_code", "amazon_q"}
+	result, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, []models.CollectionSource{models.SourceGeminiCLI, models.SourceClaudeCode, models.SourceAmazonQ}, result.SourceOrder)
+
+	exportSourceOrder = []string{"gemini_cli", "cursor"}
+	_, err = buildExportConfig(&config.Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--source-order 값이 올바르지 않습니다")
+	assert.Contains(t, err.Error(), "알 수 없는 데이터 소스: cursor")
+
+	exportSourceOrder = []string{"gemini_cli", "gemini_cli"}
+	_, err = buildExportConfig(&config.Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "같은 소스가 두 번 있습니다")
+
+	// 지정하지 않으면 기본 순서
+	exportSourceOrder = nil
+	result, err = buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.Nil(t, result.SourceOrder)
+}
+
+func TestBuildExportConfig_Top(t *testing.T) {
+	exportOutputFile = "output.md"
+	defer func() {
+		exportOutputFile = ""
+		exportTop = 0
+		exportTopBy = ""
+	}()
+
+	exportTop = 5
+	exportTopBy = processor.TopByDuration
+	result, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.Top)
+	assert.Equal(t, processor.TopByDuration, result.TopBy)
+
+	exportTopBy = "tokens"
+	_, err = buildExportConfig(&config.Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--top-by는 messages 또는 duration이어야 합니다")
+
+	exportTopBy = processor.TopByMessages
+	exportTop = -1
+	_, err = buildExportConfig(&config.Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--top은 0 이상이어야 합니다")
+}
+
+func TestBuildExportConfig_NoFooter(t *testing.T) {
+	exportOutputFile = "output.md"
+	defer func() {
+		exportOutputFile = ""
+		exportNoFooter = false
+		exportNoMeta = false
+	}()
+
+	cfg := &config.Config{OutputSettings: config.DefaultOutputSettings()}
+
+	// 기본값은 메타데이터를 포함할 때 푸터도 출력
+	result, err := buildExportConfig(cfg)
+	require.NoError(t, err)
+	assert.True(t, result.IncludeMetadata)
+	assert.False(t, result.NoFooter)
+
+	exportNoFooter = true
+	result, err = buildExportConfig(cfg)
+	require.NoError(t, err)
+	assert.True(t, result.IncludeMetadata)
+	assert.True(t, result.NoFooter)
+
+	// --no-meta는 IncludeMetadata만으로 푸터도 제외
+	exportNoFooter = false
+	exportNoMeta = true
+	result, err = buildExportConfig(cfg)
+	require.NoError(t, err)
+	assert.False(t, result.IncludeMetadata)
+	assert.False(t, result.NoFooter)
+}
+
+func TestBuildExportConfig_MetadataFromEnv(t *testing.T) {
+	exportOutputFile = "output.md"
+	defer func() {
+		exportOutputFile = ""
+		exportNoMeta = false
+	}()
+
+	// 환경 변수가 설정 파일 기본값을 덮어쓰고, 플래그가 없으면 그대로 반영
+	t.Setenv(config.EnvIncludeMetadata, "false")
+	t.Setenv(config.EnvIncludeTimestamps, "true")
+	cfg, err := config.LoadConfig("")
+	require.NoError(t, err)
+
+	result, err := buildExportConfig(cfg)
+	require.NoError(t, err)
+	assert.False(t, result.IncludeMetadata)
+	assert.True(t, result.IncludeTimestamps)
+
+	// --no-timestamp는 설정된 경우에만 덮어씀
+	exportNoTimestamp = true
+	defer func() { exportNoTimestamp = false }()
+	result, err = buildExportConfig(cfg)
+	require.NoError(t, err)
+	assert.False(t, result.IncludeTimestamps)
+}
+
 func TestLoadDataFromFile(t *testing.T) {
 	tempDir, err := os.MkdirTemp("", "export_test")
 	require.NoError(t, err)
@@ -574,6 +718,9 @@ output_settings:
 }
 
 func TestRunExport_ErrorCases(t *testing.T) {
+	// 내보내기가 진행되더라도 출력 파일이 패키지 디렉토리에 남지 않도록 임시 디렉토리에서 실행
+	t.Chdir(t.TempDir())
+
 	t.Run("config load failure", func(t *testing.T) {
 		cfgFile = "/nonexistent/config.yaml"
 		exportOutputFile = "output.md"
@@ -777,4 +924,624 @@ func createTestCollectionResult() *models.CollectionResult {
 		CollectedAt: now,
 		Duration:    time.Second * 10,
 	}
-}
\ No newline at end of file
+}
+func TestBuildExportConfig_Outline(t *testing.T) {
+	exportOutputFile = "outline.md"
+	exportOutline = true
+	defer func() {
+		exportOutputFile = ""
+		exportOutline = false
+	}()
+
+	result, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.True(t, result.OutlineOnly)
+}
+
+func TestBuildExportConfig_PerSession(t *testing.T) {
+	exportOutputFile = ""
+	exportPerSession = "./wiki"
+	defer func() {
+		exportPerSession = ""
+	}()
+
+	result, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, "./wiki", result.PerSessionDir)
+	assert.Empty(t, result.OutputPath)
+}
+
+func TestBuildExportConfig_IncludeErrors(t *testing.T) {
+	exportOutputFile = "report.md"
+	exportIncludeErrors = true
+	defer func() {
+		exportOutputFile = ""
+		exportIncludeErrors = false
+	}()
+
+	result, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.True(t, result.IncludeErrors)
+}
+
+func TestBuildExportConfig_NormalizeWhitespace(t *testing.T) {
+	exportOutputFile = "report.md"
+	exportNormalizeWhitespace = true
+	defer func() {
+		exportOutputFile = ""
+		exportNormalizeWhitespace = false
+	}()
+
+	result, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.True(t, result.NormalizeWhitespace)
+}
+
+func TestBuildExportConfig_RecentWindow(t *testing.T) {
+	exportOutputFile = "report.md"
+	defer func() {
+		exportOutputFile = ""
+		exportRecentWindow = 0
+	}()
+
+	exportRecentWindow = 7 * 24 * time.Hour
+	result, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, result.RecentWindow)
+
+	exportRecentWindow = -time.Hour
+	_, err = buildExportConfig(&config.Config{})
+	assert.Error(t, err)
+}
+
+func TestBuildExportConfig_SortMessages(t *testing.T) {
+	exportOutputFile = "report.md"
+	exportSortMessages = true
+	defer func() {
+		exportOutputFile = ""
+		exportSortMessages = false
+	}()
+
+	result, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.True(t, result.SortMessages)
+}
+
+func TestNewExportCmd_PruneEmptyMetadataDefault(t *testing.T) {
+	defer func() { exportPruneEmptyMetadata = false }()
+
+	cmd := NewExportCmd()
+	flag := cmd.Flags().Lookup("prune-empty-metadata")
+	require.NotNil(t, flag)
+	assert.Equal(t, "true", flag.DefValue)
+
+	exportOutputFile = "report.md"
+	defer func() { exportOutputFile = "" }()
+
+	result, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.True(t, result.PruneEmptyMetadata)
+}
+
+func TestLoadDataFromFile_MigratesUnversionedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "collection-legacy.json")
+	legacy := `{"sessions":[{"id":"legacy-1","source":"amazon_q","timestamp":"2024-01-01T00:00:00Z","messages":[]}]}`
+	require.NoError(t, os.WriteFile(path, []byte(legacy), 0644))
+
+	result, err := loadDataFromFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, models.CurrentSchemaVersion, result.SchemaVersion)
+	assert.Equal(t, 1, result.TotalCount)
+	assert.Equal(t, []models.CollectionSource{models.SourceAmazonQ}, result.Sources)
+}
+
+func TestLoadDataFromFile_UnsupportedSchemaVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "collection-future.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"schema_version":99,"sessions":[]}`), 0644))
+
+	_, err := loadDataFromFile(path)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "지원하지 않는 스키마 버전")
+}
+
+func TestBuildExportConfig_CSVLevel(t *testing.T) {
+	exportOutputFile = "sessions.csv"
+	defer func() {
+		exportOutputFile = ""
+		exportCSVLevel = ""
+	}()
+
+	exportCSVLevel = "session"
+	result, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, "session", result.CSVLevel)
+	assert.Equal(t, "sessions.csv", result.OutputPath)
+	assert.True(t, isCSVOutput(result.OutputPath))
+
+	exportCSVLevel = "turn"
+	_, err = buildExportConfig(&config.Config{})
+	assert.Error(t, err)
+}
+
+func TestRunCSVExport_DateWindow(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 12, 0, 0, 0, time.UTC) }
+
+	data, err := json.Marshal(&models.CollectionResult{
+		Sessions: []models.SessionData{
+			{ID: "before", Source: models.SourceClaudeCode, Timestamp: day(1)},
+			{ID: "inside", Source: models.SourceClaudeCode, Timestamp: day(8)},
+			{ID: "last-day", Source: models.SourceGeminiCLI, Timestamp: day(14)},
+			{ID: "after", Source: models.SourceAmazonQ, Timestamp: day(15)},
+		},
+		TotalCount: 4,
+	})
+	require.NoError(t, err)
+	dataFile := filepath.Join(dir, "data.json")
+	require.NoError(t, os.WriteFile(dataFile, data, 0644))
+
+	exportDataFile = dataFile
+	exportOutputFile = filepath.Join(dir, "sessions.csv")
+	exportCSVLevel = "session"
+	exportDateFrom = "2024-01-08"
+	exportDateTo = "2024-01-14"
+	defer func() {
+		exportDataFile = ""
+		exportOutputFile = ""
+		exportCSVLevel = ""
+		exportDateFrom = ""
+		exportDateTo = ""
+	}()
+
+	exportCfg, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	require.NoError(t, runCSVExport(context.Background(), exportCfg))
+
+	output, err := os.ReadFile(exportOutputFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(output), ",inside,")
+	assert.Contains(t, string(output), ",last-day,")
+	assert.NotContains(t, string(output), ",before,")
+	assert.NotContains(t, string(output), ",after,")
+
+	// 기간 안에 세션이 없으면 에러
+	exportDateFrom = "2023-01-01"
+	exportDateTo = "2023-01-31"
+	exportCfg, err = buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	err = runCSVExport(context.Background(), exportCfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "지정한 기간에 내보낼 세션이 없습니다")
+}
+
+func TestBuildExportConfig_InvalidDate(t *testing.T) {
+	exportOutputFile = "report.md"
+	exportDateFrom = "01/08/2024"
+	defer func() {
+		exportOutputFile = ""
+		exportDateFrom = ""
+	}()
+
+	_, err := buildExportConfig(&config.Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "시작 날짜 형식 오류")
+}
+
+func TestBuildExportConfig_InvertedDateRange(t *testing.T) {
+	exportOutputFile = "report.md"
+	defer func() {
+		exportOutputFile = ""
+		exportDateFrom = ""
+		exportDateTo = ""
+	}()
+
+	exportDateFrom = "2024-02-01"
+	exportDateTo = "2024-01-31"
+	_, err := buildExportConfig(&config.Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "종료 날짜(2024-01-31)보다 늦습니다")
+
+	// 같은 날짜는 그날 하루 범위로 허용
+	exportDateTo = "2024-02-01"
+	exportCfg, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	require.NotNil(t, exportCfg.DateRange)
+	assert.True(t, exportCfg.DateRange.Start.Before(exportCfg.DateRange.End))
+}
+
+func TestBuildExportConfig_RoleIcons(t *testing.T) {
+	exportOutputFile = "report.md"
+	exportRoleIcons = map[string]string{"user": "🧑"}
+	exportNoIcons = true
+	defer func() {
+		exportOutputFile = ""
+		exportRoleIcons = nil
+		exportNoIcons = false
+	}()
+
+	result, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"user": "🧑"}, result.RoleIcons)
+	assert.True(t, result.NoIcons)
+}
+
+func TestLoadAnnotations(t *testing.T) {
+	dir := t.TempDir()
+
+	jsonPath := filepath.Join(dir, "notes.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`{"claude-1": "json note"}`), 0644))
+	annotations, err := loadAnnotations(jsonPath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"claude-1": "json note"}, annotations)
+
+	yamlPath := filepath.Join(dir, "notes.yaml")
+	require.NoError(t, os.WriteFile(yamlPath, []byte("gemini-1: |\n  first line\n  second line\n"), 0644))
+	annotations, err = loadAnnotations(yamlPath)
+	require.NoError(t, err)
+	assert.Equal(t, "first line\nsecond line\n", annotations["gemini-1"])
+
+	badPath := filepath.Join(dir, "bad.json")
+	require.NoError(t, os.WriteFile(badPath, []byte(`["not", "a", "map"]`), 0644))
+	_, err = loadAnnotations(badPath)
+	assert.Error(t, err)
+
+	_, err = loadAnnotations(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestBuildExportConfig_Annotations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("claude-1: reviewed\n"), 0644))
+
+	exportOutputFile = "report.md"
+	exportAnnotations = path
+	defer func() {
+		exportOutputFile = ""
+		exportAnnotations = ""
+	}()
+
+	result, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"claude-1": "reviewed"}, result.Annotations)
+}
+
+func TestBuildExportConfig_SourceBudget(t *testing.T) {
+	exportOutputFile = "report.md"
+	defer func() {
+		exportOutputFile = ""
+		exportSourceBudget = 0
+	}()
+
+	exportSourceBudget = 5000
+	result, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, 5000, result.SourceBudget)
+
+	exportSourceBudget = -1
+	_, err = buildExportConfig(&config.Config{})
+	assert.Error(t, err)
+}
+
+func TestRunCSVExport_MinSessions(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	data, err := json.Marshal(&models.CollectionResult{
+		Sessions: []models.SessionData{
+			{ID: "first", Source: models.SourceClaudeCode, Timestamp: time.Now()},
+			{ID: "second", Source: models.SourceGeminiCLI, Timestamp: time.Now()},
+		},
+		TotalCount: 2,
+	})
+	require.NoError(t, err)
+	dataFile := filepath.Join(dir, "data.json")
+	require.NoError(t, os.WriteFile(dataFile, data, 0644))
+
+	outputFile := filepath.Join(dir, "sessions.csv")
+	require.NoError(t, os.WriteFile(outputFile, []byte("previous report"), 0644))
+
+	exportDataFile = dataFile
+	exportOutputFile = outputFile
+	exportCSVLevel = "session"
+	defer func() {
+		exportDataFile = ""
+		exportOutputFile = ""
+		exportCSVLevel = ""
+		exportMinSessions = 0
+	}()
+
+	// 기준 미만이면 기존 파일을 그대로 둠
+	exportMinSessions = 3
+	exportCfg, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	err = runCSVExport(context.Background(), exportCfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "최소 기준(3개)")
+
+	output, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.Equal(t, "previous report", string(output))
+
+	// 기준과 같으면 정상적으로 씀
+	exportMinSessions = 2
+	exportCfg, err = buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	require.NoError(t, runCSVExport(context.Background(), exportCfg))
+
+	output, err = os.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(output), ",first,")
+	assert.Contains(t, string(output), ",second,")
+}
+
+func TestBuildExportConfig_MinSessions(t *testing.T) {
+	exportOutputFile = "report.md"
+	defer func() {
+		exportOutputFile = ""
+		exportMinSessions = 0
+	}()
+
+	exportMinSessions = 5
+	result, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.MinSessions)
+
+	exportMinSessions = -1
+	_, err = buildExportConfig(&config.Config{})
+	assert.Error(t, err)
+}
+
+func TestRunCSVExport_MetricsOut(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	data, err := json.Marshal(&models.CollectionResult{
+		Sessions: []models.SessionData{
+			{ID: "first", Source: models.SourceClaudeCode, Timestamp: time.Now()},
+			{ID: "second", Source: models.SourceGeminiCLI, Timestamp: time.Now()},
+			{ID: "third", Source: models.SourceGeminiCLI, Timestamp: time.Now()},
+		},
+		TotalCount: 3,
+	})
+	require.NoError(t, err)
+	dataFile := filepath.Join(dir, "data.json")
+	require.NoError(t, os.WriteFile(dataFile, data, 0644))
+
+	metricsFile := filepath.Join(dir, "metrics", "ssamai.prom")
+	exportDataFile = dataFile
+	exportOutputFile = filepath.Join(dir, "sessions.csv")
+	exportCSVLevel = "session"
+	exportMetricsOut = metricsFile
+	defer func() {
+		exportDataFile = ""
+		exportOutputFile = ""
+		exportCSVLevel = ""
+		exportMetricsOut = ""
+	}()
+
+	exportCfg, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, metricsFile, exportCfg.MetricsOut)
+	require.NoError(t, runCSVExport(context.Background(), exportCfg))
+
+	metrics, err := os.ReadFile(metricsFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(metrics), `ssamai_total_sessions{source="claude_code"} 1`)
+	assert.Contains(t, string(metrics), `ssamai_total_sessions{source="gemini_cli"} 2`)
+}
+
+func TestRunOnelineExport(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	dataFile := filepath.Join(dir, "data.json")
+	writeSessionsFile(t, dataFile,
+		models.SessionData{ID: "old", Source: models.SourceClaudeCode, Title: "Old",
+			Timestamp: time.Date(2024, 1, 14, 9, 0, 0, 0, time.UTC)},
+		models.SessionData{ID: "new", Source: models.SourceGeminiCLI, Title: "New",
+			Timestamp: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC), Messages: make([]models.Message, 2)},
+	)
+
+	outputFile := filepath.Join(dir, "index.txt")
+	exportDataFile = dataFile
+	exportOutputFile = outputFile
+	exportFormat = "oneline"
+	defer func() {
+		exportDataFile = ""
+		exportOutputFile = ""
+		exportFormat = ""
+	}()
+
+	exportCfg, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	require.NoError(t, runOnelineExport(context.Background(), exportCfg))
+
+	output, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"2024-01-15 | gemini_cli  | New | 2 msgs\n"+
+			"2024-01-14 | claude_code | Old | 0 msgs\n",
+		string(output))
+}
+
+func TestBuildExportConfig_Format(t *testing.T) {
+	exportOutputFile = "index.txt"
+	defer func() {
+		exportOutputFile = ""
+		exportFormat = ""
+	}()
+
+	exportFormat = "yaml"
+	_, err := buildExportConfig(&config.Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--format")
+
+	exportFormat = "oneline"
+	exportCfg, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, "oneline", exportCfg.Format)
+}
+
+func TestBuildExportConfig_CollapseSessionsOver(t *testing.T) {
+	exportOutputFile = "output.md"
+	defer func() {
+		exportOutputFile = ""
+		exportCollapseOver = 0
+	}()
+
+	exportCollapseOver = -1
+	_, err := buildExportConfig(&config.Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--collapse-sessions-over")
+
+	exportCollapseOver = 50
+	exportCfg, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, 50, exportCfg.CollapseSessionsOver)
+}
+
+func TestRunAppendExport(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	dataFile := filepath.Join(dir, "data.json")
+	outputFile := filepath.Join(dir, "journal.md")
+
+	session := func(id, content string) models.SessionData {
+		return models.SessionData{ID: id, Source: models.SourceGeminiCLI, Title: "Session " + id,
+			Timestamp: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+			Messages:  []models.Message{{Role: "user", Content: content}}}
+	}
+
+	exportDataFile = dataFile
+	exportOutputFile = outputFile
+	exportAppend = true
+	defer func() {
+		exportDataFile = ""
+		exportOutputFile = ""
+		exportAppend = false
+	}()
+
+	writeSessionsFile(t, dataFile, session("one", "hello"))
+	exportCfg, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	require.NoError(t, runAppendExport(context.Background(), exportCfg))
+
+	writeSessionsFile(t, dataFile, session("one", "hello"), session("two", "world"))
+	require.NoError(t, runAppendExport(context.Background(), exportCfg))
+
+	output, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(output), "### Session one "))
+	assert.Equal(t, 1, strings.Count(string(output), "### Session two "))
+	assert.Equal(t, 2, strings.Count(string(output), "## "+time.Now().Format("2006-01-02")))
+}
+
+func TestBuildExportConfig_AppendConflicts(t *testing.T) {
+	exportAppend = true
+	defer func() {
+		exportAppend = false
+		exportOutputFile = ""
+		exportPerSession = ""
+	}()
+
+	exportOutputFile = "sessions.csv"
+	_, err := buildExportConfig(&config.Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--append")
+
+	exportOutputFile = ""
+	exportPerSession = t.TempDir()
+	_, err = buildExportConfig(&config.Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--per-session")
+}
+
+func TestBuildExportConfig_Timezone(t *testing.T) {
+	exportOutputFile = "report.md"
+	defer func() {
+		exportOutputFile = ""
+		exportTimezone = ""
+	}()
+
+	exportTimezone = "Mars/Olympus_Mons"
+	_, err := buildExportConfig(&config.Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--timezone")
+	assert.Contains(t, err.Error(), "Mars/Olympus_Mons")
+
+	exportTimezone = "Asia/Seoul"
+	exportCfg, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Seoul", exportCfg.DisplayTimezone)
+}
+
+func TestBuildExportConfig_ActiveSourceMetric(t *testing.T) {
+	exportOutputFile = "report.md"
+	defer func() {
+		exportOutputFile = ""
+		exportActiveMetric = ""
+	}()
+
+	exportActiveMetric = "tokens"
+	_, err := buildExportConfig(&config.Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--active-source-metric")
+
+	exportActiveMetric = "messages"
+	exportCfg, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, "messages", exportCfg.ActiveSourceMetric)
+}
+
+func TestBuildExportConfig_OverwriteGuards(t *testing.T) {
+	exportOutputFile = "report.md"
+	defer func() {
+		exportOutputFile = ""
+		exportBackup = false
+		exportNoClobber = false
+		exportAppend = false
+	}()
+
+	exportBackup = true
+	exportCfg, err := buildExportConfig(&config.Config{})
+	require.NoError(t, err)
+	assert.True(t, exportCfg.Backup)
+
+	exportNoClobber = true
+	_, err = buildExportConfig(&config.Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--backup")
+
+	exportBackup = false
+	exportAppend = true
+	_, err = buildExportConfig(&config.Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--no-clobber")
+}
+
+func TestExportCmd_DateRangeMarkdown(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(func() { NewExportCmd() })
+
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 12, 0, 0, 0, time.UTC) }
+	dataDir := getDataDirectory()
+	require.NoError(t, os.MkdirAll(dataDir, 0755))
+	writeSessionsFile(t, filepath.Join(dataDir, "latest.json"),
+		models.SessionData{ID: "in-range", Source: models.SourceClaudeCode, Title: "Weekly work", Timestamp: day(3),
+			Messages: []models.Message{{Role: "user", Content: "in range", Timestamp: day(3)}}},
+		models.SessionData{ID: "out-of-range", Source: models.SourceClaudeCode, Title: "Next week", Timestamp: day(10),
+			Messages: []models.Message{{Role: "user", Content: "out of range", Timestamp: day(10)}}},
+	)
+
+	// 문서에 있는 예시 그대로 cobra 명령으로 실행
+	cmd := NewExportCmd()
+	cmd.SetArgs([]string{"--from", "2024-01-01", "--to", "2024-01-07", "--output", "./weekly.md"})
+	require.NoError(t, cmd.Execute())
+
+	content, err := os.ReadFile("weekly.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Weekly work")
+	assert.NotContains(t, string(content), "Next week")
+}
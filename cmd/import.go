@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"ssamai/internal/clipboard"
+	"ssamai/pkg/models"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	importSource    string
+	importClipboard bool
+	importStdin     bool
+	importTitle     string
+
+	// 테스트에서 교체할 수 있도록 입력 소스를 변수로 둠
+	importClipboardReader clipboard.Reader = clipboard.NewSystemReader()
+	importStdinReader     io.Reader        = os.Stdin
+)
+
+// importRolePrefixes는 붙여넣은 대화에서 메시지 시작을 나타내는 접두사와 역할입니다
+var importRolePrefixes = []struct {
+	prefix string
+	role   string
+}{
+	{"user:", "user"},
+	{"you:", "user"},
+	{"사용자:", "user"},
+	{"assistant:", "assistant"},
+	{"ai:", "assistant"},
+	{"model:", "assistant"},
+	{"claude:", "assistant"},
+	{"gemini:", "assistant"},
+	{"amazon q:", "assistant"},
+}
+
+// NewImportCmd는 붙여넣은 대화를 수집 데이터로 가져오는 명령어를 생성합니다
+func NewImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "붙여넣은 대화를 수집 데이터로 가져옵니다",
+		Long: `import 명령어는 클립보드나 표준 입력의 대화 내용을 하나의 세션으로
+가져와 수집 데이터로 저장합니다. 저장된 데이터는 export 명령어로 내보낼 수 있습니다.
+
+"User:", "Assistant:" 등으로 시작하는 줄은 새 메시지로 인식합니다.`,
+		Example: `  # 클립보드의 대화 가져오기
+  ssamai import --clipboard --source gemini_cli
+
+  # 표준 입력으로 가져오기
+  pbpaste | ssamai import --stdin --source claude_code --title "리팩토링 논의"`,
+		RunE: runImport,
+	}
+
+	cmd.Flags().StringVar(&importSource, "source", "",
+		"대화의 데이터 소스 (claude_code, gemini_cli, amazon_q)")
+	cmd.Flags().BoolVar(&importClipboard, "clipboard", false,
+		"클립보드에서 대화 읽기")
+	cmd.Flags().BoolVar(&importStdin, "stdin", false,
+		"표준 입력에서 대화 읽기")
+	cmd.Flags().StringVar(&importTitle, "title", "",
+		"세션 제목 (기본값: 첫 사용자 메시지)")
+
+	cmd.MarkFlagRequired("source")
+	cmd.MarkFlagsMutuallyExclusive("clipboard", "stdin")
+	cmd.MarkFlagsOneRequired("clipboard", "stdin")
+
+	return cmd
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	sources, err := parseSourceNames([]string{importSource})
+	if err != nil {
+		return err
+	}
+
+	content, err := readImportContent()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	session, err := parseImportedConversation(sources[0], content, now)
+	if err != nil {
+		return err
+	}
+	if importTitle != "" {
+		session.Title = importTitle
+	}
+
+	result := &models.CollectionResult{
+		Sessions:    []models.SessionData{*session},
+		TotalCount:  1,
+		Sources:     sources,
+		CollectedAt: now,
+	}
+
+	if err := saveCollectedData(result); err != nil {
+		return fmt.Errorf("가져온 데이터 저장 실패: %w", err)
+	}
+
+	fmt.Printf("✅ 대화를 가져왔습니다: %s (메시지 %d개)\n", session.Title, len(session.Messages))
+	return nil
+}
+
+// readImportContent는 선택된 입력 소스에서 대화 내용을 읽습니다
+func readImportContent() (string, error) {
+	if importClipboard {
+		text, err := importClipboardReader.ReadText()
+		if err != nil {
+			return "", fmt.Errorf("클립보드 읽기 실패: %w", err)
+		}
+		return text, nil
+	}
+
+	data, err := io.ReadAll(importStdinReader)
+	if err != nil {
+		return "", fmt.Errorf("표준 입력 읽기 실패: %w", err)
+	}
+	return string(data), nil
+}
+
+// parseImportedConversation은 붙여넣은 대화 텍스트를 세션으로 변환합니다
+// 역할 접두사가 없으면 전체 내용을 하나의 사용자 메시지로 취급합니다
+func parseImportedConversation(source models.CollectionSource, content string, now time.Time) (*models.SessionData, error) {
+	content = strings.TrimSpace(strings.ReplaceAll(content, "\r\n", "\n"))
+	if content == "" {
+		return nil, fmt.Errorf("가져올 대화 내용이 비어 있습니다")
+	}
+
+	sessionID := fmt.Sprintf("%s-import-%s", source, now.Format("20060102-150405"))
+	session := &models.SessionData{
+		ID:        sessionID,
+		Source:    source,
+		Timestamp: now,
+		Metadata:  map[string]string{"source_type": "import"},
+	}
+
+	var role string
+	var lines []string
+	flush := func() {
+		text := strings.TrimSpace(strings.Join(lines, "\n"))
+		lines = nil
+		if text == "" {
+			return
+		}
+		if role == "" {
+			role = "user"
+		}
+		index := len(session.Messages)
+		session.Messages = append(session.Messages, models.Message{
+			ID:        fmt.Sprintf("%s-msg-%d", sessionID, index),
+			Role:      role,
+			Content:   text,
+			Timestamp: now.Add(time.Duration(index) * time.Second),
+		})
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		if nextRole, rest, ok := matchImportRole(line); ok {
+			flush()
+			role = nextRole
+			lines = append(lines, rest)
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+
+	session.Title = importSessionTitle(session.Messages)
+	return session, nil
+}
+
+// matchImportRole은 줄이 역할 접두사로 시작하면 역할과 나머지 내용을 반환합니다
+func matchImportRole(line string) (string, string, bool) {
+	trimmed := strings.TrimSpace(line)
+	for _, candidate := range importRolePrefixes {
+		n := len(candidate.prefix)
+		if len(trimmed) >= n && strings.EqualFold(trimmed[:n], candidate.prefix) {
+			return candidate.role, strings.TrimSpace(trimmed[len(candidate.prefix):]), true
+		}
+	}
+	return "", "", false
+}
+
+// importSessionTitle은 첫 사용자 메시지의 첫 줄로 제목을 만듭니다
+func importSessionTitle(messages []models.Message) string {
+	for _, message := range messages {
+		if message.Role != "user" {
+			continue
+		}
+		title := strings.TrimSpace(strings.SplitN(message.Content, "\n", 2)[0])
+		if utf8.RuneCountInString(title) > 50 {
+			title = string([]rune(title)[:47]) + "..."
+		}
+		if title != "" {
+			return title
+		}
+	}
+	return "가져온 대화"
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ssamai/internal/clipboard"
+	"ssamai/pkg/models"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClipboard는 테스트용 클립보드 리더
+type fakeClipboard struct {
+	text string
+	err  error
+}
+
+func (f *fakeClipboard) ReadText() (string, error) {
+	return f.text, f.err
+}
+
+func TestParseImportedConversation(t *testing.T) {
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	content := "User: How do I reverse a slice in Go?\r\n" +
+		"Keep it simple.\r\n" +
+		"Assistant: Use a loop:\r\n" +
+		"```go\r\nfor i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {}\r\n```\r\n" +
+		"user: thanks\n"
+
+	session, err := parseImportedConversation(models.SourceGeminiCLI, content, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, "gemini_cli-import-20240301-090000", session.ID)
+	assert.Equal(t, models.SourceGeminiCLI, session.Source)
+	assert.Equal(t, "How do I reverse a slice in Go?", session.Title)
+	require.Len(t, session.Messages, 3)
+
+	assert.Equal(t, "user", session.Messages[0].Role)
+	assert.Equal(t, "How do I reverse a slice in Go?\nKeep it simple.", session.Messages[0].Content)
+	assert.Equal(t, "assistant", session.Messages[1].Role)
+	assert.True(t, strings.HasPrefix(session.Messages[1].Content, "Use a loop:\n```go"))
+	assert.Equal(t, "thanks", session.Messages[2].Content)
+	assert.True(t, session.Messages[2].Timestamp.After(session.Messages[0].Timestamp))
+}
+
+func TestParseImportedConversation_PlainText(t *testing.T) {
+	session, err := parseImportedConversation(models.SourceClaudeCode, "just some notes\nsecond line", time.Now())
+	require.NoError(t, err)
+
+	require.Len(t, session.Messages, 1)
+	assert.Equal(t, "user", session.Messages[0].Role)
+	assert.Equal(t, "just some notes", session.Title)
+
+	_, err = parseImportedConversation(models.SourceClaudeCode, "  \n ", time.Now())
+	assert.Error(t, err)
+}
+
+func TestRunImport_Clipboard(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tempDir))
+	defer os.Chdir(oldWd)
+
+	oldReader := importClipboardReader
+	defer func() {
+		importClipboardReader = oldReader
+		importSource = ""
+		importClipboard = false
+		importTitle = ""
+	}()
+
+	importClipboardReader = &fakeClipboard{text: "User: summarize this\nAssistant: done"}
+	importSource = "amazon_q"
+	importClipboard = true
+	importTitle = "클립보드 대화"
+
+	require.NoError(t, runImport(&cobra.Command{}, nil))
+
+	data, err := os.ReadFile(filepath.Join(getDataDirectory(), "latest.json"))
+	require.NoError(t, err)
+
+	var result models.CollectionResult
+	require.NoError(t, json.Unmarshal(data, &result))
+	require.Len(t, result.Sessions, 1)
+	assert.Equal(t, models.SourceAmazonQ, result.Sessions[0].Source)
+	assert.Equal(t, "클립보드 대화", result.Sessions[0].Title)
+	assert.Len(t, result.Sessions[0].Messages, 2)
+}
+
+func TestRunImport_ClipboardUnavailable(t *testing.T) {
+	oldReader := importClipboardReader
+	defer func() {
+		importClipboardReader = oldReader
+		importSource = ""
+		importClipboard = false
+	}()
+
+	importClipboardReader = &fakeClipboard{err: clipboard.ErrUnavailable}
+	importSource = "gemini_cli"
+	importClipboard = true
+
+	err := runImport(&cobra.Command{}, nil)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, clipboard.ErrUnavailable))
+	assert.Contains(t, err.Error(), "클립보드 읽기 실패")
+}
+
+func TestRunImport_InvalidSource(t *testing.T) {
+	defer func() {
+		importSource = ""
+		importStdin = false
+	}()
+	importSource = "cursor"
+	importStdin = true
+
+	err := runImport(&cobra.Command{}, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "알 수 없는 데이터 소스")
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ssamai/pkg/models"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	replayDataFile string
+	replaySource   string
+	replayNoColor  bool
+)
+
+// ANSI 색상 코드
+const (
+	ansiReset   = "\033[0m"
+	ansiBold    = "\033[1m"
+	ansiDim     = "\033[2m"
+	ansiCyan    = "\033[36m"
+	ansiGreen   = "\033[32m"
+	ansiYellow  = "\033[33m"
+	ansiMagenta = "\033[35m"
+)
+
+// replayRoleColors는 역할별 라벨 색상입니다
+var replayRoleColors = map[string]string{
+	"user":      ansiCyan,
+	"assistant": ansiGreen,
+	"system":    ansiYellow,
+}
+
+// NewReplayCmd는 한 세션을 터미널에서 읽기 쉬운 대화록으로 출력하는 명령어를 생성합니다
+func NewReplayCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay <session-id>",
+		Short: "수집된 세션 하나를 터미널에 대화록으로 출력합니다",
+		Long: `replay 명령어는 최신(또는 --data로 지정한) 수집 데이터에서 세션을 찾아
+역할별로 구분된 대화록을 터미널에 출력합니다.
+
+같은 ID의 세션이 여러 소스에 있으면 --source로 소스를 지정해야 합니다.
+NO_COLOR 환경 변수가 설정되어 있거나 --no-color를 사용하면 색상 없이 출력합니다.`,
+		Example: `  # 최신 수집 데이터에서 세션 보기
+  ssamai replay claude-session-1
+
+  # 특정 데이터 파일과 소스 지정
+  ssamai replay session-1 --data ./.ssamai/data/collection-20240101-120000.json --source gemini_cli`,
+		Args: cobra.ExactArgs(1),
+		RunE: runReplay,
+	}
+
+	cmd.Flags().StringVarP(&replayDataFile, "data", "d", "",
+		"세션을 찾을 데이터 파일 (기본값: 최신 수집 데이터)")
+	cmd.Flags().StringVar(&replaySource, "source", "",
+		"세션의 데이터 소스 (claude_code, gemini_cli, amazon_q)")
+	cmd.Flags().BoolVar(&replayNoColor, "no-color", false,
+		"색상 없이 출력")
+
+	return cmd
+}
+
+func runReplay(cmd *cobra.Command, args []string) error {
+	var source models.CollectionSource
+	if replaySource != "" {
+		sources, err := parseSourceNames([]string{replaySource})
+		if err != nil {
+			return err
+		}
+		source = sources[0]
+	}
+
+	dataFile, err := resolveReplayDataFile(replayDataFile)
+	if err != nil {
+		return err
+	}
+
+	session, err := findReplaySession(dataFile, args[0], source)
+	if err != nil {
+		return err
+	}
+
+	color := !replayNoColor && os.Getenv("NO_COLOR") == ""
+	writeTranscript(cmd.OutOrStdout(), session, color)
+	return nil
+}
+
+// resolveReplayDataFile은 세션을 찾을 데이터 파일 경로를 결정합니다
+// 지정한 파일이 없으면 latest.json, 그다음 가장 최근 수집 파일을 사용합니다
+func resolveReplayDataFile(dataFile string) (string, error) {
+	if dataFile != "" {
+		return dataFile, nil
+	}
+
+	dataDir := getDataDirectory()
+	latestPath := filepath.Join(dataDir, "latest.json")
+	if _, err := os.Stat(latestPath); err == nil {
+		return latestPath, nil
+	}
+
+	latestFile, err := findLatestDataFile(dataDir)
+	if err != nil || latestFile == "" {
+		return "", fmt.Errorf("수집된 데이터가 없습니다. 먼저 collect 명령어를 실행하세요")
+	}
+	return latestFile, nil
+}
+
+// findReplaySession은 데이터 파일을 스트리밍하며 ID(와 소스)가 일치하는 세션을 찾습니다
+// 소스를 지정하지 않았는데 여러 소스에서 같은 ID가 발견되면 에러를 반환합니다
+func findReplaySession(dataFile, sessionID string, source models.CollectionSource) (*models.SessionData, error) {
+	var matches []models.SessionData
+	err := streamSessionsFromFile(dataFile, func(session models.SessionData) error {
+		if session.ID == sessionID && (source == "" || session.Source == source) {
+			matches = append(matches, session)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(matches) {
+	case 0:
+		if source != "" {
+			return nil, fmt.Errorf("소스 '%s'에서 세션을 찾을 수 없습니다: %s", source, sessionID)
+		}
+		return nil, fmt.Errorf("세션을 찾을 수 없습니다: %s", sessionID)
+	case 1:
+		return &matches[0], nil
+	default:
+		sources := make([]string, len(matches))
+		for i, match := range matches {
+			sources[i] = string(match.Source)
+		}
+		return nil, fmt.Errorf("ID가 %s인 세션이 여러 소스에 있습니다 (%s). --source로 지정하세요",
+			sessionID, strings.Join(sources, ", "))
+	}
+}
+
+// writeTranscript는 세션을 역할 라벨이 붙은 대화록으로 출력합니다
+func writeTranscript(w io.Writer, session *models.SessionData, color bool) {
+	paint := func(code, text string) string {
+		if !color || code == "" {
+			return text
+		}
+		return code + text + ansiReset
+	}
+
+	title := session.Title
+	if title == "" {
+		title = session.ID
+	}
+	fmt.Fprintf(w, "%s\n", paint(ansiBold, "=== "+title+" ==="))
+	fmt.Fprintf(w, "%s\n", paint(ansiDim, fmt.Sprintf("소스: %s | ID: %s | 시작: %s | 메시지: %d개",
+		session.Source, session.ID, session.Timestamp.Format("2006-01-02 15:04:05"), len(session.Messages))))
+
+	for _, message := range session.Messages {
+		roleColor, ok := replayRoleColors[message.Role]
+		if !ok {
+			roleColor = ansiMagenta
+		}
+
+		fmt.Fprintln(w)
+		label := paint(ansiBold+roleColor, strings.Title(message.Role))
+		if message.Timestamp.IsZero() {
+			fmt.Fprintf(w, "%s\n", label)
+		} else {
+			fmt.Fprintf(w, "%s %s\n", paint(ansiDim, "["+message.Timestamp.Format("15:04:05")+"]"), label)
+		}
+
+		for _, line := range strings.Split(strings.TrimRight(message.Content, "\n"), "\n") {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+	}
+}
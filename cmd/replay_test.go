@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeReplayData(t *testing.T) string {
+	t.Helper()
+
+	start := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "collection.json")
+	writeSessionsFile(t, path,
+		models.SessionData{
+			ID:        "shared-id",
+			Source:    models.SourceClaudeCode,
+			Title:     "리팩토링 논의",
+			Timestamp: start,
+			Messages: []models.Message{
+				{Role: "user", Content: "이 함수를 정리해줘", Timestamp: start},
+				{Role: "assistant", Content: "다음과 같이 바꿀 수 있습니다:\nfunc main() {}", Timestamp: start.Add(5 * time.Second)},
+			},
+		},
+		models.SessionData{ID: "shared-id", Source: models.SourceGeminiCLI, Title: "다른 세션", Timestamp: start},
+		models.SessionData{ID: "unique", Source: models.SourceAmazonQ, Timestamp: start},
+	)
+	return path
+}
+
+func runReplayForTest(t *testing.T, args []string, flags map[string]string) (string, error) {
+	t.Helper()
+
+	cmd := NewReplayCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	for name, value := range flags {
+		require.NoError(t, cmd.Flags().Set(name, value))
+	}
+	defer func() {
+		replayDataFile = ""
+		replaySource = ""
+		replayNoColor = false
+	}()
+
+	err := runReplay(cmd, args)
+	return out.String(), err
+}
+
+func TestRunReplay_Transcript(t *testing.T) {
+	dataFile := writeReplayData(t)
+
+	output, err := runReplayForTest(t, []string{"shared-id"}, map[string]string{
+		"data":     dataFile,
+		"source":   "claude_code",
+		"no-color": "true",
+	})
+	require.NoError(t, err)
+
+	expected := strings.Join([]string{
+		"=== 리팩토링 논의 ===",
+		"소스: claude_code | ID: shared-id | 시작: 2024-05-01 09:30:00 | 메시지: 2개",
+		"",
+		"[09:30:00] User",
+		"  이 함수를 정리해줘",
+		"",
+		"[09:30:05] Assistant",
+		"  다음과 같이 바꿀 수 있습니다:",
+		"  func main() {}",
+		"",
+	}, "\n")
+	assert.Equal(t, expected, output)
+	assert.NotContains(t, output, "\033[")
+}
+
+func TestRunReplay_Colorized(t *testing.T) {
+	dataFile := writeReplayData(t)
+	t.Setenv("NO_COLOR", "")
+
+	output, err := runReplayForTest(t, []string{"unique"}, map[string]string{"data": dataFile})
+	require.NoError(t, err)
+	assert.Contains(t, output, ansiBold+"=== unique ==="+ansiReset)
+}
+
+func TestRunReplay_NoColorEnv(t *testing.T) {
+	dataFile := writeReplayData(t)
+	t.Setenv("NO_COLOR", "1")
+
+	output, err := runReplayForTest(t, []string{"unique"}, map[string]string{"data": dataFile})
+	require.NoError(t, err)
+	assert.NotContains(t, output, "\033[")
+}
+
+func TestRunReplay_Errors(t *testing.T) {
+	dataFile := writeReplayData(t)
+
+	_, err := runReplayForTest(t, []string{"shared-id"}, map[string]string{"data": dataFile})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "claude_code, gemini_cli")
+
+	_, err = runReplayForTest(t, []string{"missing"}, map[string]string{"data": dataFile})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "세션을 찾을 수 없습니다")
+
+	_, err = runReplayForTest(t, []string{"unique"}, map[string]string{"data": dataFile, "source": "unknown"})
+	assert.Error(t, err)
+}
+
+func TestResolveReplayDataFile(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tempDir))
+	defer os.Chdir(oldWd)
+
+	_, err = resolveReplayDataFile("")
+	assert.Error(t, err)
+
+	require.NoError(t, os.MkdirAll(getDataDirectory(), 0755))
+	latest := filepath.Join(getDataDirectory(), "latest.json")
+	require.NoError(t, os.WriteFile(latest, []byte(`{"sessions": []}`), 0644))
+
+	path, err := resolveReplayDataFile("")
+	require.NoError(t, err)
+	assert.Equal(t, latest, path)
+
+	path, err = resolveReplayDataFile("custom.json")
+	require.NoError(t, err)
+	assert.Equal(t, "custom.json", path)
+}
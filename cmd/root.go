@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"ssamai/internal/config"
 	"ssamai/internal/service"
 
 	"github.com/spf13/cobra"
@@ -17,7 +18,7 @@ var (
 )
 
 // NewRootCmd는 서비스를 주입받아 루트 명령어를 생성합니다
-func NewRootCmd(collectSvc *service.CollectService, exportSvc *service.ExportService) *cobra.Command {
+func NewRootCmd(collectSvc *service.CollectService) *cobra.Command {
 	rootCmd := &cobra.Command{
 		Use:   "ssamai",
 		Short: "AI CLI 도구들의 작업 내용을 수집하고 마크다운으로 정리하는 도구",
@@ -46,10 +47,14 @@ func NewRootCmd(collectSvc *service.CollectService, exportSvc *service.ExportSer
 	// 로컬 플래그 정의
 	rootCmd.Flags().BoolP("version", "", false, "버전 정보 출력")
 
-	// NewCollectCmd와 NewExportCmd에 의존성 주입
+	// NewCollectCmd에 의존성 주입
 	rootCmd.AddCommand(NewCollectCmd(collectSvc))
-	rootCmd.AddCommand(NewExportCmd(exportSvc))
+	rootCmd.AddCommand(NewExportCmd())
 	rootCmd.AddCommand(NewConfigCmd())
+	rootCmd.AddCommand(NewImportCmd())
+	rootCmd.AddCommand(NewReplayCmd())
+	rootCmd.AddCommand(NewStatsCmd())
+	rootCmd.AddCommand(NewDigestCmd())
 	
 	return rootCmd
 }
@@ -105,6 +110,19 @@ func initConfig() {
 
 	if verbose {
 		fmt.Printf("설정 파일: %s\n", cfgFile)
+		if projectConfig := config.FindProjectConfig("."); projectConfig != "" {
+			fmt.Printf("프로젝트 설정: %s\n", projectConfig)
+		}
 		fmt.Printf("출력 경로: %s\n", outputPath)
 	}
+}
+
+// loadProjectConfig는 전역 설정 위에 현재 디렉토리부터 찾은 프로젝트 설정(.ssamairc)을 덮어써 로드합니다
+// 저장소별 설정을 따라야 하는 collect, export, config show 명령어에서 사용합니다
+func loadProjectConfig(path string) (*config.Config, error) {
+	workDir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("현재 디렉토리를 확인할 수 없습니다: %w", err)
+	}
+	return config.LoadProjectConfig(path, workDir)
 }
\ No newline at end of file
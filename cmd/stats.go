@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"ssamai/internal/processor"
+	"ssamai/pkg/models"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsDataFile string
+	statsAll      bool
+	statsJSON     bool
+)
+
+// NewStatsCmd는 수집 데이터의 통계만 출력하는 명령어를 생성합니다
+func NewStatsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "수집된 데이터의 통계를 출력합니다",
+		Long: `stats 명령어는 최신(또는 --data로 지정한) 수집 데이터의 통계를 출력합니다.
+
+--all을 사용하면 데이터 디렉토리의 모든 collection-*.json 파일을 하나씩 스트리밍하며
+하나의 통계로 합산합니다. 파일마다 세션을 메모리에 모두 올리지 않으므로
+수집 파일이 많아도 메모리 사용량이 크게 늘지 않습니다.`,
+		Example: `  # 최신 수집 데이터 통계
+  ssamai stats
+
+  # 모든 수집 파일을 합산한 통계를 JSON으로 출력 (모니터링용)
+  ssamai stats --all --json`,
+		Args: cobra.NoArgs,
+		RunE: runStats,
+	}
+
+	cmd.Flags().StringVarP(&statsDataFile, "data", "d", "",
+		"통계를 계산할 데이터 파일 (기본값: 최신 수집 데이터)")
+	cmd.Flags().BoolVar(&statsAll, "all", false,
+		"데이터 디렉토리의 모든 collection-*.json 파일을 합산")
+	cmd.Flags().BoolVar(&statsJSON, "json", false,
+		"통계를 JSON으로 출력")
+
+	return cmd
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	if statsAll && statsDataFile != "" {
+		return fmt.Errorf("--all과 --data는 함께 사용할 수 없습니다")
+	}
+
+	var files []string
+	if statsAll {
+		dataFiles, err := findDataFiles(getDataDirectory())
+		if err != nil {
+			return err
+		}
+		files = dataFiles
+	} else {
+		dataFile, err := resolveReplayDataFile(statsDataFile)
+		if err != nil {
+			return err
+		}
+		files = []string{dataFile}
+	}
+
+	stats, err := aggregateStatistics(files)
+	if err != nil {
+		return err
+	}
+
+	if statsJSON {
+		return writeStatisticsJSON(cmd.OutOrStdout(), stats)
+	}
+	writeStatisticsText(cmd.OutOrStdout(), stats, len(files))
+	return nil
+}
+
+// aggregateStatistics는 데이터 파일들을 차례로 스트리밍하며 모든 세션을 하나의 통계로 합산합니다
+// 여러 파일에 같은 세션이 있어도 중복을 제거하지 않으므로 결과는 파일별 통계의 합과 같습니다
+func aggregateStatistics(files []string) (processor.Statistics, error) {
+	accumulator := processor.NewStatisticsAccumulator(processor.ActiveSourceBySessions)
+	for _, file := range files {
+		err := streamSessionsFromFile(file, func(session models.SessionData) error {
+			accumulator.Add(session)
+			return nil
+		})
+		if err != nil {
+			return processor.Statistics{}, fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return accumulator.Statistics(), nil
+}
+
+// findDataFiles는 데이터 디렉토리의 collection-*.json 파일을 이름순으로 반환합니다 (latest.json 제외)
+func findDataFiles(dataDir string) ([]string, error) {
+	entries, err := os.ReadDir(dataDir)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("데이터 디렉토리가 존재하지 않습니다: %s", dataDir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("데이터 디렉토리 읽기 실패: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && isCollectionDataFile(entry.Name()) {
+			files = append(files, filepath.Join(dataDir, entry.Name()))
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("수집 데이터 파일을 찾을 수 없습니다")
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// writeStatisticsJSON은 통계를 들여쓴 JSON으로 출력합니다
+func writeStatisticsJSON(w io.Writer, stats processor.Statistics) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(stats); err != nil {
+		return fmt.Errorf("통계 JSON 출력 실패: %w", err)
+	}
+	return nil
+}
+
+// writeStatisticsText는 통계 요약을 사람이 읽기 쉬운 형식으로 출력합니다
+func writeStatisticsText(w io.Writer, stats processor.Statistics, fileCount int) {
+	fmt.Fprintf(w, "=== 수집 데이터 통계 (파일 %d개) ===\n", fileCount)
+	fmt.Fprintf(w, "세션: %d개\n", stats.TotalSessions)
+	fmt.Fprintf(w, "메시지: %d개\n", stats.TotalMessages)
+	fmt.Fprintf(w, "명령어: %d개\n", stats.TotalCommands)
+	fmt.Fprintf(w, "파일: %d개\n", stats.TotalFiles)
+	if stats.DateRange != nil {
+		fmt.Fprintf(w, "기간: %s ~ %s\n",
+			stats.DateRange.Start.Format("2006-01-02"), stats.DateRange.End.Format("2006-01-02"))
+	}
+
+	sources := make([]models.CollectionSource, 0, len(stats.SourceCounts))
+	for source := range stats.SourceCounts {
+		sources = append(sources, source)
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i] < sources[j] })
+	if len(sources) > 0 {
+		fmt.Fprintln(w, "소스별 분포:")
+		for _, source := range sources {
+			fmt.Fprintf(w, "  - %s: %d개 세션\n", source.DisplayName(), stats.SourceCounts[source])
+		}
+	}
+}
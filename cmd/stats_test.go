@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ssamai/internal/processor"
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeStatsDataDir은 현재 디렉토리 아래 데이터 디렉토리에 수집 파일 세 개를 만들고 경로를 반환합니다
+func writeStatsDataDir(t *testing.T) []string {
+	t.Helper()
+
+	dataDir := getDataDirectory()
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 10, 0, 0, 0, time.UTC) }
+	message := func(role, content string, at time.Time) models.Message {
+		return models.Message{Role: role, Content: content, Timestamp: at}
+	}
+
+	files := []string{
+		filepath.Join(dataDir, "collection-20240301-100000.json"),
+		filepath.Join(dataDir, "collection-20240302-100000.json"),
+		filepath.Join(dataDir, "collection-20240303-100000.json"),
+	}
+	writeSessionsFile(t, files[0],
+		models.SessionData{ID: "a", Source: models.SourceClaudeCode, Timestamp: day(1),
+			Messages: []models.Message{message("user", "빌드 고쳐줘", day(1)), message("assistant", "네", day(1).Add(3*time.Second))},
+			Commands: []models.Command{{Command: "go build"}}},
+		models.SessionData{ID: "b", Source: models.SourceGeminiCLI, Timestamp: day(2),
+			Messages: []models.Message{message("user", "테스트 추가", day(2))}},
+	)
+	writeSessionsFile(t, files[1],
+		models.SessionData{ID: "c", Source: models.SourceAmazonQ, Timestamp: day(5),
+			Messages: []models.Message{message("user", "빌드 고쳐줘", day(5)), message("assistant", "완료", day(5).Add(time.Second))},
+			Files:    []models.FileReference{{Path: "main.go"}}},
+	)
+	writeSessionsFile(t, files[2],
+		models.SessionData{ID: "d", Source: models.SourceClaudeCode, Timestamp: day(9)},
+	)
+
+	// latest.json은 합산 대상이 아님
+	writeSessionsFile(t, filepath.Join(dataDir, "latest.json"),
+		models.SessionData{ID: "latest", Source: models.SourceClaudeCode, Timestamp: day(9)})
+	return files
+}
+
+func TestAggregateStatistics_SumsPerFileTotals(t *testing.T) {
+	t.Chdir(t.TempDir())
+	files := writeStatsDataDir(t)
+
+	found, err := findDataFiles(getDataDirectory())
+	require.NoError(t, err)
+	assert.Equal(t, files, found)
+
+	combined, err := aggregateStatistics(found)
+	require.NoError(t, err)
+
+	var sessions, messages, commands, fileRefs int
+	sourceCounts := make(map[models.CollectionSource]int)
+	for _, file := range files {
+		stats, err := aggregateStatistics([]string{file})
+		require.NoError(t, err)
+		sessions += stats.TotalSessions
+		messages += stats.TotalMessages
+		commands += stats.TotalCommands
+		fileRefs += stats.TotalFiles
+		for source, count := range stats.SourceCounts {
+			sourceCounts[source] += count
+		}
+	}
+
+	assert.Equal(t, 4, combined.TotalSessions)
+	assert.Equal(t, sessions, combined.TotalSessions)
+	assert.Equal(t, messages, combined.TotalMessages)
+	assert.Equal(t, commands, combined.TotalCommands)
+	assert.Equal(t, fileRefs, combined.TotalFiles)
+	assert.Equal(t, sourceCounts, combined.SourceCounts)
+	assert.Equal(t, models.SourceClaudeCode, combined.MostActiveSource)
+
+	// 파일을 넘나드는 중복 프롬프트와 날짜 범위도 합산 기준으로 계산
+	assert.Equal(t, 2, combined.UniquePrompts)
+	require.NotNil(t, combined.DateRange)
+	assert.Equal(t, 1, combined.DateRange.Start.Day())
+	assert.Equal(t, 9, combined.DateRange.End.Day())
+}
+
+func TestRunStats_AllJSON(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeStatsDataDir(t)
+
+	cmd := NewStatsCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	require.NoError(t, cmd.Flags().Set("all", "true"))
+	require.NoError(t, cmd.Flags().Set("json", "true"))
+	defer func() {
+		statsAll = false
+		statsJSON = false
+	}()
+
+	require.NoError(t, runStats(cmd, nil))
+
+	var stats processor.Statistics
+	require.NoError(t, json.Unmarshal(out.Bytes(), &stats))
+	assert.Equal(t, 4, stats.TotalSessions)
+	assert.Equal(t, 5, stats.TotalMessages)
+}
+
+func TestRunStats_Errors(t *testing.T) {
+	t.Chdir(t.TempDir())
+	defer func() {
+		statsAll = false
+		statsDataFile = ""
+	}()
+
+	cmd := NewStatsCmd()
+	statsAll = true
+	err := runStats(cmd, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "데이터 디렉토리가 존재하지 않습니다")
+
+	statsDataFile = "collection.json"
+	err = runStats(cmd, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "함께 사용할 수 없습니다")
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"ssamai/pkg/models"
+)
+
+// streamSessionsFromFile은 수집 데이터 파일의 세션을 하나씩 읽어 fn에 전달합니다
+// 파일 전체를 메모리에 올리지 않으므로 큰 수집 파일을 조회할 때 사용합니다
+func streamSessionsFromFile(path string, fn func(models.SessionData) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("데이터 파일을 읽을 수 없습니다: %w", err)
+	}
+	defer file.Close()
+
+	return streamSessions(file, fn)
+}
+
+// streamSessions는 CollectionResult JSON의 sessions 배열을 요소 단위로 디코딩합니다
+// sessions 외의 필드는 건너뛰며, fn이 에러를 반환하면 즉시 중단합니다
+func streamSessions(r io.Reader, fn func(models.SessionData) error) error {
+	decoder := json.NewDecoder(r)
+
+	if err := expectDelim(decoder, '{'); err != nil {
+		return err
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return fmt.Errorf("데이터 파일 형식이 올바르지 않습니다: %w", err)
+		}
+		key, ok := token.(string)
+		if !ok {
+			return fmt.Errorf("데이터 파일 형식이 올바르지 않습니다: 예상하지 못한 토큰 %v", token)
+		}
+
+		if key != "sessions" {
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return fmt.Errorf("데이터 파일 형식이 올바르지 않습니다 (%s): %w", key, err)
+			}
+			continue
+		}
+
+		if err := streamSessionArray(decoder, fn); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(decoder, '}')
+}
+
+// streamSessionArray는 sessions 배열(또는 null)의 각 요소를 디코딩해 fn에 전달합니다
+func streamSessionArray(decoder *json.Decoder, fn func(models.SessionData) error) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return fmt.Errorf("데이터 파일 형식이 올바르지 않습니다: %w", err)
+	}
+	if token == nil {
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("데이터 파일 형식이 올바르지 않습니다: sessions가 배열이 아닙니다")
+	}
+
+	for index := 0; decoder.More(); index++ {
+		var session models.SessionData
+		if err := decoder.Decode(&session); err != nil {
+			return fmt.Errorf("세션 %d 디코딩 실패: %w", index, err)
+		}
+		if err := fn(session); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(decoder, ']')
+}
+
+// expectDelim은 다음 토큰이 주어진 구분자인지 확인합니다
+func expectDelim(decoder *json.Decoder, want json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return fmt.Errorf("데이터 파일 형식이 올바르지 않습니다: %w", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("데이터 파일 형식이 올바르지 않습니다: %q가 필요하지만 %v를 읽었습니다", want, token)
+	}
+	return nil
+}
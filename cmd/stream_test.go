@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingReader는 지금까지 읽은 바이트 수를 기록합니다
+type countingReader struct {
+	r    io.Reader
+	read int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.read += n
+	return n, err
+}
+
+func newLargeCollection(t *testing.T, count int) []byte {
+	t.Helper()
+
+	result := &models.CollectionResult{TotalCount: count, Errors: []string{"partial failure"}}
+	for i := 0; i < count; i++ {
+		result.Sessions = append(result.Sessions, models.SessionData{
+			ID:       fmt.Sprintf("session-%d", i),
+			Source:   models.SourceClaudeCode,
+			Messages: []models.Message{{Role: "user", Content: strings.Repeat("x", 1024)}},
+		})
+	}
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	return data
+}
+
+func TestStreamSessions_VisitsEverySessionIncrementally(t *testing.T) {
+	data := newLargeCollection(t, 200)
+	reader := &countingReader{r: bytes.NewReader(data)}
+
+	var ids []string
+	var readAtFirst int
+	err := streamSessions(reader, func(session models.SessionData) error {
+		if len(ids) == 0 {
+			readAtFirst = reader.read
+		}
+		ids = append(ids, session.ID)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, ids, 200)
+	assert.Equal(t, "session-0", ids[0])
+	assert.Equal(t, "session-199", ids[199])
+
+	// 첫 세션을 받을 때 파일의 일부만 읽은 상태여야 함
+	assert.Less(t, readAtFirst, len(data)/10)
+}
+
+func TestStreamSessions_StopsOnCallbackError(t *testing.T) {
+	data := newLargeCollection(t, 5)
+	stop := errors.New("stop")
+
+	visited := 0
+	err := streamSessions(bytes.NewReader(data), func(models.SessionData) error {
+		visited++
+		if visited == 2 {
+			return stop
+		}
+		return nil
+	})
+
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 2, visited)
+}
+
+func TestStreamSessions_NullAndInvalid(t *testing.T) {
+	visited := 0
+	err := streamSessions(strings.NewReader(`{"sessions": null, "total_count": 0}`), func(models.SessionData) error {
+		visited++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, visited)
+
+	err = streamSessions(strings.NewReader(`{"sessions": {"id": "x"}}`), func(models.SessionData) error { return nil })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sessions가 배열이 아닙니다")
+
+	err = streamSessions(strings.NewReader(`[]`), func(models.SessionData) error { return nil })
+	assert.Error(t, err)
+}
+
+func TestStreamSessionsFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "collection.json")
+	require.NoError(t, os.WriteFile(path, newLargeCollection(t, 3), 0644))
+
+	count := 0
+	require.NoError(t, streamSessionsFromFile(path, func(models.SessionData) error {
+		count++
+		return nil
+	}))
+	assert.Equal(t, 3, count)
+
+	err := streamSessionsFromFile(filepath.Join(t.TempDir(), "missing.json"), func(models.SessionData) error { return nil })
+	assert.Error(t, err)
+}
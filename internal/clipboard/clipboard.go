@@ -0,0 +1,74 @@
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// ErrUnavailable은 사용할 수 있는 클립보드 도구가 없을 때 반환됩니다
+var ErrUnavailable = errors.New("클립보드를 사용할 수 없습니다")
+
+// Reader는 클립보드 텍스트를 읽기 위한 인터페이스 (테스트용)
+type Reader interface {
+	ReadText() (string, error)
+}
+
+// command는 클립보드 읽기 명령어와 인자를 나타냅니다
+type command struct {
+	name string
+	args []string
+}
+
+// SystemReader는 OS별 클립보드 명령어를 사용하는 Reader의 기본 구현
+type SystemReader struct {
+	goos     string
+	lookPath func(file string) (string, error)
+	output   func(name string, args ...string) ([]byte, error)
+}
+
+// NewSystemReader는 현재 OS에 맞는 클립보드 리더를 생성합니다
+func NewSystemReader() *SystemReader {
+	return &SystemReader{
+		goos:     runtime.GOOS,
+		lookPath: exec.LookPath,
+		output: func(name string, args ...string) ([]byte, error) {
+			return exec.Command(name, args...).Output()
+		},
+	}
+}
+
+// ReadText는 설치된 첫 번째 클립보드 도구로 텍스트를 읽습니다
+func (r *SystemReader) ReadText() (string, error) {
+	for _, candidate := range r.candidates() {
+		path, err := r.lookPath(candidate.name)
+		if err != nil {
+			continue
+		}
+
+		data, err := r.output(path, candidate.args...)
+		if err != nil {
+			return "", fmt.Errorf("클립보드 읽기 실패 (%s): %w", candidate.name, err)
+		}
+		return string(data), nil
+	}
+
+	return "", fmt.Errorf("%w: %s에서 지원하는 클립보드 도구를 찾을 수 없습니다", ErrUnavailable, r.goos)
+}
+
+// candidates는 OS별로 시도할 클립보드 명령어 목록을 반환합니다
+func (r *SystemReader) candidates() []command {
+	switch r.goos {
+	case "darwin":
+		return []command{{name: "pbpaste"}}
+	case "windows":
+		return []command{{name: "powershell", args: []string{"-NoProfile", "-Command", "Get-Clipboard"}}}
+	default:
+		return []command{
+			{name: "wl-paste", args: []string{"--no-newline"}},
+			{name: "xclip", args: []string{"-selection", "clipboard", "-o"}},
+			{name: "xsel", args: []string{"--clipboard", "--output"}},
+		}
+	}
+}
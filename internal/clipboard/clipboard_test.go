@@ -0,0 +1,49 @@
+package clipboard
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+)
+
+func TestSystemReader_ReadText(t *testing.T) {
+	var called []string
+	reader := &SystemReader{
+		goos: "linux",
+		lookPath: func(file string) (string, error) {
+			if file == "xclip" {
+				return "/usr/bin/xclip", nil
+			}
+			return "", exec.ErrNotFound
+		},
+		output: func(name string, args ...string) ([]byte, error) {
+			called = append(called, name)
+			return []byte("User: hello"), nil
+		},
+	}
+
+	text, err := reader.ReadText()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text != "User: hello" {
+		t.Errorf("expected clipboard text, got %q", text)
+	}
+	if len(called) != 1 || called[0] != "/usr/bin/xclip" {
+		t.Errorf("expected xclip to be used, got %v", called)
+	}
+}
+
+func TestSystemReader_Unavailable(t *testing.T) {
+	reader := &SystemReader{
+		goos: "linux",
+		lookPath: func(file string) (string, error) {
+			return "", exec.ErrNotFound
+		},
+	}
+
+	_, err := reader.ReadText()
+	if !errors.Is(err, ErrUnavailable) {
+		t.Errorf("expected ErrUnavailable, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package collector
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
@@ -99,6 +100,12 @@ type AmazonQCollector struct {
 	config     config.CLIToolConfig
 	fileReader AmazonQFileReader
 	logger     AmazonQLogger
+	clock      func() time.Time
+	workerPool WorkerLimiter // 전역 동시성 제한 (nil이면 제한 없음)
+	rewriter   contentRewriter // 소스별 메시지 내용 치환 규칙
+
+	scanMu    sync.Mutex
+	scanStats []models.DirScanStats // 마지막 수집의 세션 디렉토리 스캔 결과
 }
 
 // NewAmazonQCollector는 새로운 Amazon Q CLI 데이터 수집기를 생성합니다
@@ -107,6 +114,8 @@ func NewAmazonQCollector(cfg config.CLIToolConfig) *AmazonQCollector {
 		config:     cfg,
 		fileReader: &DefaultAmazonQFileReader{},
 		logger:     &DefaultAmazonQLogger{},
+		clock:      time.Now,
+		rewriter:   newContentRewriter(cfg.ContentRewrites),
 	}
 }
 
@@ -122,24 +131,76 @@ func (a *AmazonQCollector) WithLogger(logger AmazonQLogger) *AmazonQCollector {
 	return a
 }
 
+// WithClock은 더미 데이터와 기본 타임스탬프 생성에 사용할 시계 의존성 주입 (테스트용)
+func (a *AmazonQCollector) WithClock(clock func() time.Time) *AmazonQCollector {
+	a.clock = clock
+	return a
+}
+
+// SetWorkerPool은 collector 간에 공유되는 워커 풀을 설정합니다
+func (a *AmazonQCollector) SetWorkerPool(pool WorkerLimiter) {
+	a.workerPool = pool
+}
+
+// SetRetryPolicy는 파일 읽기, 상태 확인, 디렉토리 탐색에 재시도 정책을 적용합니다
+func (a *AmazonQCollector) SetRetryPolicy(policy *RetryPolicy) {
+	a.fileReader = newRetryingAmazonQFileReader(a.fileReader, policy)
+}
+
+// ScanStats는 마지막 수집에서 세션 디렉토리를 스캔한 결과를 반환합니다
+func (a *AmazonQCollector) ScanStats() []models.DirScanStats {
+	a.scanMu.Lock()
+	defer a.scanMu.Unlock()
+	return append([]models.DirScanStats(nil), a.scanStats...)
+}
+
+// recordScanStats는 세션 디렉토리 스캔 결과를 기록합니다
+func (a *AmazonQCollector) recordScanStats(stats models.DirScanStats) {
+	a.scanMu.Lock()
+	a.scanStats = append(a.scanStats, stats)
+	a.scanMu.Unlock()
+}
+
 // Collect는 Amazon Q CLI에서 세션 데이터를 수집합니다
 func (a *AmazonQCollector) Collect(ctx context.Context, collectConfig *models.CollectionConfig) ([]models.SessionData, error) {
 	if collectConfig == nil {
 		return nil, fmt.Errorf("collection config is nil")
 	}
 
+	if a.rewriter.err != nil {
+		return nil, a.rewriter.err
+	}
+
+	// 이전 수집의 스캔 결과 초기화
+	a.scanMu.Lock()
+	a.scanStats = nil
+	a.scanMu.Unlock()
+
 	// 타임아웃이 설정된 컨텍스트 생성
 	ctx, cancel := context.WithTimeout(ctx, amazonQDefaultTimeout)
 	defer cancel()
 
+	// 파일이 지정되면 디렉토리 탐색 없이 해당 파일만 파싱
+	explicitFiles := hasExplicitFiles(collectConfig)
+
 	// 설정 디렉토리 검증
-	if err := a.validateConfigDirectory(); err != nil {
-		// Amazon Q CLI가 설치되지 않은 경우 더미 데이터 반환
-		a.logger.Warnf("Amazon Q CLI not found, returning dummy data: %v\n", err)
-		return a.generateDummyData(), nil
+	if !explicitFiles {
+		if err := a.validateConfigDirectory(); err != nil {
+			// Amazon Q CLI가 설치되지 않은 경우 더미 데이터 반환
+			a.logger.Warnf("Amazon Q CLI not found, returning dummy data: %v\n", err)
+			return a.generateDummyData(), nil
+		}
 	}
 
 	var allSessions []models.SessionData
+	if explicitFiles {
+		sessions, err := a.collectFromFiles(ctx, collectConfig)
+		if err != nil {
+			return nil, err
+		}
+		allSessions = sessions
+	}
+
 	var mu sync.Mutex
 	var wg sync.WaitGroup
 	errs := make([]error, 0)
@@ -153,7 +214,7 @@ func (a *AmazonQCollector) Collect(ctx context.Context, collectConfig *models.Co
 	}
 
 	// 히스토리 파일 처리
-	if a.config.HistoryFile != "" {
+	if a.config.HistoryFile != "" && !explicitFiles {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
@@ -168,8 +229,24 @@ func (a *AmazonQCollector) Collect(ctx context.Context, collectConfig *models.Co
 		}()
 	}
 
+	// SQLite 히스토리 데이터베이스 처리 (설정한 경우에만)
+	if a.config.HistoryDB != nil && !explicitFiles {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sessions, err := a.collectFromHistoryDB(ctx)
+			if err != nil {
+				addError(fmt.Errorf("Amazon Q history database collection failed: %w", err))
+				return
+			}
+			mu.Lock()
+			allSessions = append(allSessions, sessions...)
+			mu.Unlock()
+		}()
+	}
+
 	// 세션 디렉토리 처리
-	if a.config.SessionDir != "" {
+	if a.config.SessionDir != "" && !explicitFiles {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
@@ -185,18 +262,20 @@ func (a *AmazonQCollector) Collect(ctx context.Context, collectConfig *models.Co
 	}
 
 	// AWS 설정 파일에서 컨텍스트 정보 수집
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		sessions, err := a.collectFromAWSConfig(ctx, collectConfig)
-		if err != nil {
-			addError(fmt.Errorf("AWS config collection failed: %w", err))
-			return
-		}
-		mu.Lock()
-		allSessions = append(allSessions, sessions...)
-		mu.Unlock()
-	}()
+	if !explicitFiles {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sessions, err := a.collectFromAWSConfig(ctx, collectConfig)
+			if err != nil {
+				addError(fmt.Errorf("AWS config collection failed: %w", err))
+				return
+			}
+			mu.Lock()
+			allSessions = append(allSessions, sessions...)
+			mu.Unlock()
+		}()
+	}
 
 	wg.Wait()
 
@@ -205,12 +284,20 @@ func (a *AmazonQCollector) Collect(ctx context.Context, collectConfig *models.Co
 		a.logger.Warnf("Collection warning: %v\n", err)
 	}
 
-	// 데이터가 없으면 더미 데이터 생성
-	if len(allSessions) == 0 {
+	// 데이터가 없으면 더미 데이터 생성 (지정한 파일만 수집할 때는 제외)
+	if len(allSessions) == 0 && !explicitFiles {
 		a.logger.Printf("No Amazon Q CLI data found, generating dummy data\n")
 		allSessions = a.generateDummyData()
 	}
 
+	// 설정된 내용 치환 규칙 적용
+	a.rewriter.apply(allSessions)
+
+	// 설정에서 끈 경우 내부 source_type 메타데이터 제거
+	if !a.config.SourceTypeMetadataEnabled() {
+		omitSourceTypeMetadata(allSessions)
+	}
+
 	// 날짜 필터링
 	if collectConfig.DateRange != nil {
 		allSessions = a.filterByDateRange(allSessions, collectConfig.DateRange)
@@ -226,6 +313,10 @@ func (a *AmazonQCollector) GetSource() models.CollectionSource {
 
 // Validate는 수집기 설정이 유효한지 검증합니다
 func (a *AmazonQCollector) Validate() error {
+	if a.rewriter.err != nil {
+		return a.rewriter.err
+	}
+
 	if a.config.ConfigDir == "" {
 		return fmt.Errorf("config directory not specified")
 	}
@@ -264,6 +355,75 @@ func (a *AmazonQCollector) validateConfigDirectory() error {
 	return nil
 }
 
+// collectFromFiles는 collectConfig.Files에 지정된 파일만 파싱합니다 (포함/제외 패턴은 적용하지 않음)
+// .jsonl 파일은 히스토리 라인으로, 그 밖의 파일은 세션 파일로 처리합니다
+func (a *AmazonQCollector) collectFromFiles(ctx context.Context, collectConfig *models.CollectionConfig) ([]models.SessionData, error) {
+	var sessions []models.SessionData
+
+	for _, file := range collectConfig.Files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		path, info, err := resolveExplicitFile(a.fileReader.Stat, file)
+		if err != nil {
+			return nil, err
+		}
+
+		if isHistoryLinesFile(path) {
+			if info.Size() > amazonQMaxFileSize {
+				return nil, fmt.Errorf("history file too large: %d bytes (max: %d)", info.Size(), amazonQMaxFileSize)
+			}
+			parsed, err := a.parseHistoryFileStreaming(ctx, path, collectConfig)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse history file %s: %w", path, err)
+			}
+			sessions = append(sessions, parsed...)
+			continue
+		}
+
+		session, err := a.parseSessionFileSafe(path, collectConfig)
+		if errors.Is(err, errFileInProgress) {
+			a.logger.Printf("Skipping session file being written, will retry on next run: %s (%v)\n", path, err)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse session file %s: %w", path, err)
+		}
+		sessions = append(sessions, *session)
+	}
+
+	return sessions, nil
+}
+
+// collectFromHistoryDB는 SQLite 데이터베이스의 대화 기록을 세션 파일과 같은 방식으로 변환합니다
+func (a *AmazonQCollector) collectFromHistoryDB(ctx context.Context) ([]models.SessionData, error) {
+	dbSessions, dbPath, err := queryHistoryDB(ctx, *a.config.HistoryDB)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]models.SessionData, 0, len(dbSessions))
+	for _, dbSession := range dbSessions {
+		amazonQSession := AmazonQSessionData{ID: dbSession.ID, CreatedAt: dbSession.CreatedAt()}
+		for _, message := range dbSession.Messages {
+			amazonQSession.Messages = append(amazonQSession.Messages, AmazonQMessage{
+				Role:      message.Role,
+				Content:   message.Content,
+				Timestamp: message.Timestamp,
+			})
+			if amazonQSession.Title == "" && message.Role == "user" {
+				amazonQSession.Title = a.extractTitleFromQuery(message.Content)
+			}
+		}
+
+		session := a.convertAmazonQSessionToModel(amazonQSession, dbPath)
+		session.Metadata["source_type"] = "amazon_q_history_db"
+		sessions = append(sessions, *session)
+	}
+	return sessions, nil
+}
+
 // collectFromHistoryWithRetry는 재시도 로직이 있는 히스토리 수집
 func (a *AmazonQCollector) collectFromHistoryWithRetry(ctx context.Context, collectConfig *models.CollectionConfig) ([]models.SessionData, error) {
 	historyPath, err := config.ExpandPath(a.config.HistoryFile)
@@ -313,7 +473,7 @@ func (a *AmazonQCollector) parseHistoryFileStreaming(ctx context.Context, filePa
 			continue
 		}
 
-		session, err := a.parseHistoryLine(line, lineNum+1)
+		session, err := a.parseHistoryLine(filePath, line, lineNum+1)
 		if err != nil {
 			a.logger.Warnf("Failed to parse Amazon Q history line %d: %v\n", lineNum+1, err)
 			continue
@@ -334,26 +494,30 @@ func (a *AmazonQCollector) parseHistoryFileStreaming(ctx context.Context, filePa
 }
 
 // parseHistoryLine은 안전한 히스토리 라인 파싱
-func (a *AmazonQCollector) parseHistoryLine(line string, lineNum int) (*models.SessionData, error) {
+// filePath와 lineNum은 ID가 없는 항목의 ID를 만들 때 위치로 사용합니다
+func (a *AmazonQCollector) parseHistoryLine(filePath, line string, lineNum int) (*models.SessionData, error) {
 	// JSON 파싱 시도
 	if strings.HasPrefix(line, "{") {
-		return a.parseJSONHistoryEntry(line, lineNum)
+		return a.parseJSONHistoryEntry(filePath, line, lineNum)
 	}
 
 	// 텍스트로 처리
-	return a.parseTextHistoryEntry(line, lineNum), nil
+	return a.parseTextHistoryEntry(filePath, line, lineNum), nil
 }
 
 // parseJSONHistoryEntry는 안전한 JSON 히스토리 엔트리 파싱
-func (a *AmazonQCollector) parseJSONHistoryEntry(line string, lineNum int) (*models.SessionData, error) {
+func (a *AmazonQCollector) parseJSONHistoryEntry(filePath, line string, lineNum int) (*models.SessionData, error) {
 	var entry AmazonQHistoryEntry
+	if err := checkJSONDepth([]byte(line), maxJSONDepth); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
 	decoder := json.NewDecoder(strings.NewReader(line))
 
 	if err := decoder.Decode(&entry); err != nil {
 		return nil, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	return a.convertHistoryEntryToSession(entry, lineNum), nil
+	return a.convertHistoryEntryToSession(entry, filePath, lineNum), nil
 }
 
 // AmazonQHistoryEntry는 Amazon Q CLI 히스토리 엔트리 구조체
@@ -385,6 +549,7 @@ type AmazonQSessionData struct {
 	Context        map[string]interface{}   `json:"context"`
 	Settings       *AmazonQSessionSettings  `json:"settings"`
 	Metadata       map[string]interface{}   `json:"metadata"`
+	Files          []SessionFileEntry       `json:"files"`
 }
 
 // AmazonQMessage는 Amazon Q CLI 메시지 구조체
@@ -393,10 +558,13 @@ type AmazonQMessage struct {
 	Role        string                 `json:"role"`
 	Content     string                 `json:"content"`
 	Timestamp   string                 `json:"timestamp"`
+	Seq         *int                   `json:"seq"`
+	Index       *int                   `json:"index"`
 	MessageType string                 `json:"message_type"`
 	Service     string                 `json:"service"`
 	Context     map[string]interface{} `json:"context"`
 	Metadata    map[string]interface{} `json:"metadata"`
+	Attachments []SessionFileEntry     `json:"attachments"`
 }
 
 // AmazonQSessionSettings는 Amazon Q 세션 설정 구조체
@@ -408,26 +576,26 @@ type AmazonQSessionSettings struct {
 }
 
 // convertHistoryEntryToSession은 히스토리 엔트리를 세션으로 변환
-func (a *AmazonQCollector) convertHistoryEntryToSession(entry AmazonQHistoryEntry, index int) *models.SessionData {
+func (a *AmazonQCollector) convertHistoryEntryToSession(entry AmazonQHistoryEntry, filePath string, index int) *models.SessionData {
+	// 타임스탬프 파싱
+	recordedAt := parseRecordedTime(entry.Timestamp)
+
+	// ID가 없으면 파일 위치와 내용 기반 ID 사용 (같은 내용이 반복되어도 줄마다 구분)
 	sessionID := entry.ID
 	if sessionID == "" {
-		sessionID = fmt.Sprintf("amazonq-history-%d", index)
+		sessionID = positionalSessionID("amazonq-history", models.SourceAmazonQ, filePath, index, entry.Query, recordedAt)
 	}
 
 	session := &models.SessionData{
 		ID:        sessionID,
 		Source:    models.SourceAmazonQ,
-		Timestamp: time.Now(),
+		Timestamp: a.clock(),
 		Title:     a.extractTitleFromQuery(entry.Query),
 		Messages:  make([]models.Message, 0, 2),
 		Metadata:  make(map[string]string),
 	}
-
-	// 타임스탬프 파싱
-	if entry.Timestamp != "" {
-		if timestamp, err := time.Parse(time.RFC3339, entry.Timestamp); err == nil {
-			session.Timestamp = timestamp
-		}
+	if !recordedAt.IsZero() {
+		session.Timestamp = recordedAt
 	}
 
 	// 메타데이터 설정
@@ -470,23 +638,24 @@ func (a *AmazonQCollector) convertHistoryEntryToSession(entry AmazonQHistoryEntr
 }
 
 // parseTextHistoryEntry는 텍스트 히스토리 엔트리 파싱
-func (a *AmazonQCollector) parseTextHistoryEntry(line string, lineNum int) *models.SessionData {
+func (a *AmazonQCollector) parseTextHistoryEntry(filePath, line string, lineNum int) *models.SessionData {
 	if len(strings.TrimSpace(line)) == 0 {
 		return nil
 	}
 
-	sessionID := fmt.Sprintf("amazonq-text-%d", lineNum)
+	// 같은 텍스트 줄이 반복될 수 있으므로 파일 위치도 ID에 포함
+	sessionID := positionalSessionID("amazonq-text", models.SourceAmazonQ, filePath, lineNum, line, time.Time{})
 	return &models.SessionData{
 		ID:        sessionID,
 		Source:    models.SourceAmazonQ,
-		Timestamp: time.Now(),
+		Timestamp: a.clock(),
 		Title:     "Amazon Q CLI History Entry",
 		Messages: []models.Message{
 			{
 				ID:        fmt.Sprintf("%s-user", sessionID),
 				Role:      "user",
 				Content:   line,
-				Timestamp: time.Now(),
+				Timestamp: a.clock(),
 				Metadata:  map[string]string{"source_type": "amazon_q_text"},
 			},
 		},
@@ -504,13 +673,17 @@ func (a *AmazonQCollector) collectFromSessionDirConcurrent(ctx context.Context,
 		return nil, fmt.Errorf("failed to expand session directory path: %w", err)
 	}
 
+	stats := models.DirScanStats{Source: models.SourceAmazonQ, Dir: sessionDirPath}
+
 	// 디렉토리 존재 확인
 	if _, err := a.fileReader.Stat(sessionDirPath); os.IsNotExist(err) {
 		a.logger.Warnf("Amazon Q CLI session directory not found: %s\n", sessionDirPath)
+		stats.Missing = true
+		a.recordScanStats(stats)
 		return []models.SessionData{}, nil
 	}
 
-	// 파일 목록 수집
+	// 파일 목록 수집 (세션이 없을 때 원인을 구분할 수 있도록 스캔 결과를 기록)
 	var filePaths []string
 	err = a.fileReader.WalkDir(sessionDirPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -520,9 +693,11 @@ func (a *AmazonQCollector) collectFromSessionDirConcurrent(ctx context.Context,
 		if d.IsDir() {
 			return nil
 		}
+		stats.FilesScanned++
 
 		// Amazon Q CLI 파일 패턴 매칭
 		if a.isAmazonQFile(path) {
+			stats.FilesMatched++
 			filePaths = append(filePaths, path)
 		}
 
@@ -536,6 +711,7 @@ func (a *AmazonQCollector) collectFromSessionDirConcurrent(ctx context.Context,
 	// 워커 수 결정
 	numWorkers := minInts(amazonQMaxWorkers, len(filePaths), runtime.NumCPU())
 	if numWorkers == 0 {
+		a.recordScanStats(stats)
 		return []models.SessionData{}, nil
 	}
 
@@ -597,6 +773,13 @@ func (a *AmazonQCollector) collectFromSessionDirConcurrent(ctx context.Context,
 		}
 	}
 
+	// 워커 처리 순서와 무관하게 파일 순서대로 정렬
+	sortSessionsByFilePath(sessions, filePaths)
+
+	stats.FilesParsed = countParsedFiles(sessions, filePaths)
+	stats.FilesErrored = countErroredFiles(errors, sessions)
+	a.recordScanStats(stats)
+
 	// 에러 로깅
 	for _, err := range errors {
 		a.logger.Warnf("Amazon Q session file processing error: %v\n", err)
@@ -616,9 +799,22 @@ func (a *AmazonQCollector) sessionFileWorker(ctx context.Context, wg *sync.WaitG
 				return
 			}
 
+			// 공유 워커 풀에서 슬롯 획득
+			if a.workerPool != nil {
+				if err := a.workerPool.Acquire(ctx); err != nil {
+					return
+				}
+			}
 			session, err := a.parseSessionFileSafe(filePath, collectConfig)
+			if a.workerPool != nil {
+				a.workerPool.Release()
+			}
+			if errors.Is(err, errFileInProgress) {
+				a.logger.Printf("Skipping Amazon Q session file being written, will retry on next run: %s (%v)\n", filePath, err)
+				continue
+			}
 			if err != nil {
-				errorChan <- fmt.Errorf("failed to parse Amazon Q session file %s: %w", filePath, err)
+				errorChan <- newFileParseError(filePath, fmt.Errorf("failed to parse Amazon Q session file %s: %w", filePath, err))
 				continue
 			}
 
@@ -642,20 +838,32 @@ func (a *AmazonQCollector) parseSessionFileSafe(path string, collectConfig *mode
 		return nil, fmt.Errorf("file too large: %d bytes", info.Size())
 	}
 
+	// 도구가 아직 쓰고 있는 파일은 잘린 JSON일 수 있으므로 다음 수집으로 미룸
+	if err := checkWriteInProgress(info, a.clock(), writeGracePeriod(a.config)); err != nil {
+		return nil, err
+	}
+
 	// 파일 읽기
 	data, err := a.fileReader.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	// JSON 파싱 시도
+	// JSON 파싱 시도 (중첩이 너무 깊으면 텍스트로 처리하지 않고 거부)
 	var sessionData AmazonQSessionData
-	if err := json.Unmarshal(data, &sessionData); err != nil {
+	if err := unmarshalJSONLimited(data, &sessionData); err != nil {
+		if errors.Is(err, errJSONTooDeep) {
+			return nil, fmt.Errorf("failed to parse session: %w", err)
+		}
 		// JSON 파싱 실패 시 텍스트로 처리
 		return a.parseTextSession(string(data), path), nil
 	}
 
-	return a.convertAmazonQSessionToModel(sessionData, path), nil
+	session := a.convertAmazonQSessionToModel(sessionData, path)
+	if collectConfig.IncludeFiles {
+		session.Files = convertFileEntries(sessionData.Files)
+	}
+	return session, nil
 }
 
 // convertAmazonQSessionToModel은 Amazon Q 세션 데이터를 모델로 변환
@@ -663,7 +871,7 @@ func (a *AmazonQCollector) convertAmazonQSessionToModel(amazonQSession AmazonQSe
 	session := &models.SessionData{
 		ID:        amazonQSession.ID,
 		Source:    models.SourceAmazonQ,
-		Timestamp: time.Now(),
+		Timestamp: a.clock(),
 		Title:     amazonQSession.Title,
 		Messages:  make([]models.Message, 0, len(amazonQSession.Messages)),
 		Metadata:  make(map[string]string),
@@ -689,14 +897,27 @@ func (a *AmazonQCollector) convertAmazonQSessionToModel(amazonQSession AmazonQSe
 	session.Metadata["conversation_id"] = amazonQSession.ConversationID
 	session.Metadata["source_type"] = "amazon_q_session"
 
+	// 세션 설정 (서비스, 리전, temperature, max_tokens)
+	if settings := amazonQSession.Settings; settings != nil {
+		if session.Metadata["service"] == "" {
+			session.Metadata["service"] = settings.Service
+		}
+		if session.Metadata["region"] == "" {
+			session.Metadata["region"] = settings.Region
+		}
+		applyModelSettings(session.Metadata, "", settings.Temperature, settings.MaxTokens)
+	}
+
 	// 메시지 변환
 	for _, amazonQMsg := range amazonQSession.Messages {
 		msg := models.Message{
-			ID:        amazonQMsg.ID,
-			Role:      amazonQMsg.Role,
-			Content:   amazonQMsg.Content,
-			Timestamp: session.Timestamp,
-			Metadata:  make(map[string]string),
+			ID:          amazonQMsg.ID,
+			Role:        amazonQMsg.Role,
+			Content:     amazonQMsg.Content,
+			Timestamp:   session.Timestamp,
+			Sequence:    messageSequence(amazonQMsg.Seq, amazonQMsg.Index),
+			Metadata:    make(map[string]string),
+			Attachments: convertFileEntries(amazonQMsg.Attachments),
 		}
 
 		// 메시지 타임스탬프 파싱
@@ -724,14 +945,14 @@ func (a *AmazonQCollector) parseTextSession(content string, path string) *models
 	return &models.SessionData{
 		ID:        sessionID,
 		Source:    models.SourceAmazonQ,
-		Timestamp: time.Now(),
+		Timestamp: a.clock(),
 		Title:     fmt.Sprintf("Amazon Q CLI Session: %s", fileName),
 		Messages: []models.Message{
 			{
 				ID:        fmt.Sprintf("%s-content", sessionID),
 				Role:      "user",
 				Content:   content,
-				Timestamp: time.Now(),
+				Timestamp: a.clock(),
 				Metadata:  map[string]string{"source_type": "amazon_q_text"},
 			},
 		},
@@ -779,14 +1000,14 @@ func (a *AmazonQCollector) collectFromAWSConfig(ctx context.Context, collectConf
 		session := &models.SessionData{
 			ID:        fmt.Sprintf("amazonq-aws-config-%s", filepath.Base(expandedPath)),
 			Source:    models.SourceAmazonQ,
-			Timestamp: time.Now(),
+			Timestamp: a.clock(),
 			Title:     fmt.Sprintf("AWS Configuration: %s", filepath.Base(expandedPath)),
 			Messages: []models.Message{
 				{
 					ID:        fmt.Sprintf("aws-config-%s", filepath.Base(expandedPath)),
 					Role:      "system",
 					Content:   string(data),
-					Timestamp: time.Now(),
+					Timestamp: a.clock(),
 					Metadata: map[string]string{
 						"source_type": "aws_config",
 						"config_file": expandedPath,
@@ -853,34 +1074,17 @@ func (a *AmazonQCollector) extractTitleFromQuery(query string) string {
 
 // filterByDateRange는 날짜 범위 필터링
 func (a *AmazonQCollector) filterByDateRange(sessions []models.SessionData, dateRange *models.DateRange) []models.SessionData {
-	if dateRange == nil {
-		return sessions
-	}
-
-	filtered := make([]models.SessionData, 0, len(sessions))
-	for _, session := range sessions {
-		if a.isWithinDateRange(session.Timestamp, dateRange) {
-			filtered = append(filtered, session)
-		}
-	}
-
-	return filtered
+	return models.FilterSessionsByDateRange(sessions, dateRange)
 }
 
 // isWithinDateRange는 날짜가 범위 내에 있는지 확인
 func (a *AmazonQCollector) isWithinDateRange(timestamp time.Time, dateRange *models.DateRange) bool {
-	if !dateRange.Start.IsZero() && timestamp.Before(dateRange.Start) {
-		return false
-	}
-	if !dateRange.End.IsZero() && timestamp.After(dateRange.End) {
-		return false
-	}
-	return true
+	return dateRange.Contains(timestamp)
 }
 
 // generateDummyData는 Amazon Q CLI가 설치되지 않은 경우 더미 데이터를 생성합니다
 func (a *AmazonQCollector) generateDummyData() []models.SessionData {
-	now := time.Now()
+	now := a.clock()
 
 	return []models.SessionData{
 		{
@@ -908,6 +1112,7 @@ func (a *AmazonQCollector) generateDummyData() []models.SessionData {
 				"service":     "ec2",
 				"region":      "us-west-2",
 				"source_type": "amazon_q_dummy",
+				"fallback":    "true",
 				"user_id":     "demo-user",
 			},
 		},
@@ -936,6 +1141,7 @@ func (a *AmazonQCollector) generateDummyData() []models.SessionData {
 				"service":     "s3",
 				"region":      "us-east-1",
 				"source_type": "amazon_q_dummy",
+				"fallback":    "true",
 				"user_id":     "demo-user",
 			},
 		},
@@ -964,6 +1170,7 @@ func (a *AmazonQCollector) generateDummyData() []models.SessionData {
 				"service":     "lambda",
 				"region":      "eu-west-1",
 				"source_type": "amazon_q_dummy",
+				"fallback":    "true",
 				"user_id":     "demo-user",
 			},
 		},
@@ -2,6 +2,7 @@ package collector
 
 import (
 	"context"
+	"encoding/json"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -387,6 +388,50 @@ func TestAmazonQCollector_Collect_WithSessionDir(t *testing.T) {
 	}
 }
 
+func TestAmazonQCollector_Collect_WithFileReferences(t *testing.T) {
+	cfg := config.CLIToolConfig{
+		ConfigDir:  "/test/.amazon-q",
+		SessionDir: "/test/.amazon-q/sessions",
+	}
+
+	sessionContent := `{
+		"id": "session-files",
+		"title": "Files Session",
+		"created_at": "2024-01-01T00:00:00Z",
+		"files": [
+			{"path": "/work/infra/template.yaml", "name": "template.yaml", "size": 2048},
+			"/work/infra"
+		],
+		"messages": [{"id": "msg-1", "role": "user", "content": "Review my template"}]
+	}`
+
+	mockReader := NewMockAmazonQFileReader()
+	mockReader.AddDir("/test/.amazon-q")
+	mockReader.AddDir("/test/.amazon-q/sessions")
+	mockReader.AddFile("/test/.amazon-q/sessions/session1.json", []byte(sessionContent))
+
+	collector := NewAmazonQCollector(cfg).WithFileReader(mockReader).WithLogger(NewMockAmazonQLogger())
+
+	sessions, err := collector.Collect(context.Background(), &models.CollectionConfig{IncludeFiles: true})
+	if err != nil {
+		t.Fatalf("Collect() error = %v, expected nil", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(sessions))
+	}
+
+	files := sessions[0].Files
+	if len(files) != 2 {
+		t.Fatalf("expected 2 file references, got %d", len(files))
+	}
+	if files[0].Path != "/work/infra/template.yaml" || files[0].Name != "template.yaml" || files[0].Size != 2048 {
+		t.Errorf("unexpected first file reference: %+v", files[0])
+	}
+	if files[1].Path != "/work/infra" || files[1].Name != "infra" {
+		t.Errorf("unexpected workspace reference: %+v", files[1])
+	}
+}
+
 func TestAmazonQCollector_Collect_WithDateFiltering(t *testing.T) {
 	cfg := config.CLIToolConfig{
 		ConfigDir:   "/test/.amazon-q",
@@ -431,6 +476,38 @@ func TestAmazonQCollector_Collect_WithDateFiltering(t *testing.T) {
 	}
 }
 
+func TestAmazonQCollector_convertAmazonQSessionToModel_Settings(t *testing.T) {
+	var amazonQSession AmazonQSessionData
+	raw := `{"id":"q1","title":"Settings","settings":{"service":"codewhisperer","region":"us-west-2","max_tokens":1024,"temperature":0.2}}`
+	if err := json.Unmarshal([]byte(raw), &amazonQSession); err != nil {
+		t.Fatalf("failed to unmarshal session: %v", err)
+	}
+
+	collector := NewAmazonQCollector(config.CLIToolConfig{})
+	session := collector.convertAmazonQSessionToModel(amazonQSession, "/sessions/q1.json")
+
+	expected := map[string]string{
+		"service":     "codewhisperer",
+		"region":      "us-west-2",
+		"temperature": "0.2",
+		"max_tokens":  "1024",
+	}
+	for key, value := range expected {
+		if session.Metadata[key] != value {
+			t.Errorf("expected metadata %s=%q, got %q", key, value, session.Metadata[key])
+		}
+	}
+
+	// 설정이 없으면 temperature/max_tokens를 추가하지 않음
+	session = collector.convertAmazonQSessionToModel(AmazonQSessionData{ID: "q2"}, "/sessions/q2.json")
+	if _, exists := session.Metadata["temperature"]; exists {
+		t.Error("expected no temperature metadata without settings")
+	}
+	if _, exists := session.Metadata["max_tokens"]; exists {
+		t.Error("expected no max_tokens metadata without settings")
+	}
+}
+
 func TestAmazonQCollector_extractTitleFromQuery(t *testing.T) {
 	collector := NewAmazonQCollector(config.CLIToolConfig{})
 
@@ -568,4 +645,26 @@ func TestAmazonQCollector_generateDummyData(t *testing.T) {
 			t.Errorf("Session %d: expected assistant message", i)
 		}
 	}
-}
\ No newline at end of file
+}
+func TestAmazonQCollector_generateDummyData_FixedClock(t *testing.T) {
+	fixed := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	collector := NewAmazonQCollector(config.CLIToolConfig{}).WithClock(func() time.Time { return fixed })
+
+	first := collector.generateDummyData()
+	second := collector.generateDummyData()
+
+	if len(first) != len(second) {
+		t.Fatalf("Expected stable session count, got %d and %d", len(first), len(second))
+	}
+
+	expected := fixed.Add(-24 * time.Hour)
+	if !first[0].Timestamp.Equal(expected) {
+		t.Errorf("Expected first session timestamp %v, got %v", expected, first[0].Timestamp)
+	}
+
+	for i := range first {
+		if !first[i].Timestamp.Equal(second[i].Timestamp) {
+			t.Errorf("Session %d: expected stable timestamp, got %v and %v", i, first[i].Timestamp, second[i].Timestamp)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package collector
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// archiveEntrySeparator는 아카이브 경로와 내부 항목 이름을 구분합니다 (예: sessions.tar!/a.json)
+const archiveEntrySeparator = "!/"
+
+// isTarArchive는 경로가 지원하는 tar 아카이브(.tar, .tar.gz, .tgz)인지 확인합니다.
+func isTarArchive(path string) bool {
+	lower := strings.ToLower(path)
+	return strings.HasSuffix(lower, ".tar") || isGzipTarArchive(lower)
+}
+
+func isGzipTarArchive(path string) bool {
+	lower := strings.ToLower(path)
+	return strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz")
+}
+
+// archiveEntryPath는 아카이브 내부 항목을 가리키는 경로를 만듭니다.
+func archiveEntryPath(archivePath, entryName string) string {
+	return archivePath + archiveEntrySeparator + entryName
+}
+
+// readTarEntries는 tar 아카이브의 JSON 항목을 디스크에 풀지 않고 순서대로 fn에 전달합니다.
+// maxEntrySize를 넘는 항목은 건너뛰고 반환되는 항목 에러 목록에 기록합니다.
+// 아카이브 자체를 읽을 수 없으면 에러를 반환합니다.
+func readTarEntries(r io.Reader, archivePath string, maxEntrySize int64, fn func(entryPath string, data []byte)) ([]error, error) {
+	if isGzipTarArchive(archivePath) {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open gzip archive: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	var entryErrors []error
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return entryErrors, nil
+		}
+		if err != nil {
+			return entryErrors, fmt.Errorf("failed to read tar archive: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg || !strings.HasSuffix(header.Name, ".json") {
+			continue
+		}
+
+		entryPath := archiveEntryPath(archivePath, header.Name)
+		if header.Size > maxEntrySize {
+			entryErrors = append(entryErrors, fmt.Errorf("%s: entry too large: %d bytes", entryPath, header.Size))
+			continue
+		}
+
+		// 헤더 크기를 신뢰하지 않고 한도까지만 읽음
+		data, err := io.ReadAll(io.LimitReader(tr, maxEntrySize+1))
+		if err != nil {
+			return entryErrors, fmt.Errorf("failed to read archive entry %s: %w", entryPath, err)
+		}
+		if int64(len(data)) > maxEntrySize {
+			entryErrors = append(entryErrors, fmt.Errorf("%s: entry too large: exceeds %d bytes", entryPath, maxEntrySize))
+			continue
+		}
+
+		fn(entryPath, data)
+	}
+}
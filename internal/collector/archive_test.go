@@ -0,0 +1,160 @@
+package collector
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ssamai/internal/config"
+	"ssamai/pkg/models"
+)
+
+type tarEntry struct {
+	name string
+	body string
+}
+
+// buildTar는 주어진 항목으로 메모리 내 tar 아카이브를 만듭니다
+func buildTar(t *testing.T, entries []tarEntry, compress bool) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	var gz *gzip.Writer
+	var tw *tar.Writer
+	if compress {
+		gz = gzip.NewWriter(&buf)
+		tw = tar.NewWriter(gz)
+	} else {
+		tw = tar.NewWriter(&buf)
+	}
+
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Mode: 0644, Size: int64(len(entry.body)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("failed to write tar header: %v", err)
+		}
+		if _, err := tw.Write([]byte(entry.body)); err != nil {
+			t.Fatalf("failed to write tar entry: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("failed to close tar writer: %v", err)
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			t.Fatalf("failed to close gzip writer: %v", err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func sessionEntries(count int) []tarEntry {
+	entries := make([]tarEntry, 0, count)
+	for i := 1; i <= count; i++ {
+		entries = append(entries, tarEntry{
+			name: fmt.Sprintf("sessions/session-%d.json", i),
+			body: fmt.Sprintf(`{"id": "archived-%d", "title": "Archived %d", "messages": [{"role": "user", "content": "hello %d"}]}`, i, i, i),
+		})
+	}
+	return entries
+}
+
+func TestIsTarArchive(t *testing.T) {
+	for path, want := range map[string]bool{
+		"sessions.tar":    true,
+		"sessions.tar.gz": true,
+		"SESSIONS.TGZ":    true,
+		"sessions.json":   false,
+		"sessions.gz":     false,
+	} {
+		if got := isTarArchive(path); got != want {
+			t.Errorf("isTarArchive(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestReadTarEntries(t *testing.T) {
+	entries := append(sessionEntries(2),
+		tarEntry{name: "README.md", body: "not a session"},
+		tarEntry{name: "huge.json", body: strings.Repeat("x", 256)},
+	)
+
+	for _, compress := range []bool{false, true} {
+		archivePath := "/archives/sessions.tar"
+		if compress {
+			archivePath += ".gz"
+		}
+
+		var visited []string
+		entryErrors, err := readTarEntries(bytes.NewReader(buildTar(t, entries, compress)), archivePath, 128, func(entryPath string, data []byte) {
+			visited = append(visited, entryPath)
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := []string{
+			archivePath + "!/sessions/session-1.json",
+			archivePath + "!/sessions/session-2.json",
+		}
+		if strings.Join(visited, ",") != strings.Join(want, ",") {
+			t.Errorf("expected entries %v, got %v", want, visited)
+		}
+		if len(entryErrors) != 1 || !strings.Contains(entryErrors[0].Error(), "huge.json") {
+			t.Errorf("expected oversized entry to be reported, got %v", entryErrors)
+		}
+	}
+}
+
+func TestReadTarEntries_InvalidArchive(t *testing.T) {
+	_, err := readTarEntries(strings.NewReader("not gzip"), "broken.tar.gz", maxFileSize, func(string, []byte) {})
+	if err == nil {
+		t.Errorf("expected error for invalid gzip archive")
+	}
+}
+
+func TestCollectFromSessionDirectory_TarArchive(t *testing.T) {
+	mockReader := NewMockFileReader()
+	sessionDir := "/test/sessions"
+	mockReader.AddDir("/test")
+	mockReader.AddDir(sessionDir)
+	mockReader.AddFile(filepath.Join(sessionDir, "a-loose.json"), []byte(`{"id": "loose", "messages": []}`))
+	mockReader.AddFile(filepath.Join(sessionDir, "sessions.tar"), buildTar(t, sessionEntries(3), false))
+
+	collector := NewImprovedGeminiCLICollector(config.CLIToolConfig{
+		ConfigDir:  "/test",
+		SessionDir: sessionDir,
+	}).WithFileReader(mockReader).WithLogger(&MockLogger{})
+
+	sessions, err := collector.Collect(context.Background(), &models.CollectionConfig{
+		Sources: []models.CollectionSource{models.SourceGeminiCLI},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var ids []string
+	for _, session := range sessions {
+		ids = append(ids, session.ID)
+	}
+	want := []string{"loose", "archived-1", "archived-2", "archived-3"}
+	if strings.Join(ids, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected sessions %v, got %v", want, ids)
+	}
+
+	archived := sessions[1]
+	if archived.Metadata["archive_path"] != filepath.Join(sessionDir, "sessions.tar") {
+		t.Errorf("expected archive_path metadata, got %q", archived.Metadata["archive_path"])
+	}
+	if !strings.HasSuffix(archived.Metadata["file_path"], "sessions.tar!/sessions/session-1.json") {
+		t.Errorf("expected file_path to point inside archive, got %q", archived.Metadata["file_path"])
+	}
+	if len(archived.Messages) != 1 || archived.Messages[0].Content != "hello 1" {
+		t.Errorf("expected archived message to be parsed, got %+v", archived.Messages)
+	}
+}
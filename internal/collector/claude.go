@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"ssamai/internal/config"
@@ -24,18 +25,61 @@ func init() {
 	})
 }
 
+const (
+	// claudeWorkspaceFile은 설정 디렉토리 내 프로젝트/작업 공간 정보 파일 이름입니다
+	claudeWorkspaceFile = "workspace.json"
+)
+
+// ClaudeWorkspace는 Claude Code의 프로젝트/작업 공간 정보를 나타냅니다
+type ClaudeWorkspace struct {
+	Project string `json:"project"`
+	Cwd     string `json:"cwd"`
+	Branch  string `json:"branch"`
+}
+
 // ClaudeCodeCollector는 Claude Code 데이터 수집기를 나타냅니다
 type ClaudeCodeCollector struct {
-	config config.CLIToolConfig
+	config     config.CLIToolConfig
+	clock      func() time.Time
+	workerPool WorkerLimiter // 전역 동시성 제한 (nil이면 제한 없음)
+	rewriter   contentRewriter // 소스별 메시지 내용 치환 규칙
+	retry      *RetryPolicy    // 파일 읽기 재시도 정책 (nil이면 재시도 안 함)
 }
 
 // NewClaudeCodeCollector는 새로운 Claude Code 데이터 수집기를 생성합니다
 func NewClaudeCodeCollector(cfg config.CLIToolConfig) *ClaudeCodeCollector {
 	return &ClaudeCodeCollector{
-		config: cfg,
+		config:   cfg,
+		clock:    time.Now,
+		rewriter: newContentRewriter(cfg.ContentRewrites),
 	}
 }
 
+// WithClock은 기본 타임스탬프 생성에 사용할 시계를 주입합니다 (테스트용)
+func (c *ClaudeCodeCollector) WithClock(clock func() time.Time) *ClaudeCodeCollector {
+	c.clock = clock
+	return c
+}
+
+// SetWorkerPool은 collector 간에 공유되는 워커 풀을 설정합니다
+func (c *ClaudeCodeCollector) SetWorkerPool(pool WorkerLimiter) {
+	c.workerPool = pool
+}
+
+// SetRetryPolicy는 일시적인 파일 읽기 오류를 재시도하는 정책을 설정합니다
+func (c *ClaudeCodeCollector) SetRetryPolicy(policy *RetryPolicy) {
+	c.retry = policy
+}
+
+// readFile은 재시도 정책을 적용해 파일을 읽습니다
+func (c *ClaudeCodeCollector) readFile(path string) (data []byte, err error) {
+	err = c.retry.Do(func() error {
+		data, err = os.ReadFile(path)
+		return err
+	})
+	return data, err
+}
+
 // Collect는 Claude Code에서 세션 데이터를 수집합니다 (인터페이스 호환)
 func (c *ClaudeCodeCollector) Collect(ctx context.Context, collectConfig *models.CollectionConfig) ([]models.SessionData, error) {
 	// context 취소 확인
@@ -45,6 +89,19 @@ func (c *ClaudeCodeCollector) Collect(ctx context.Context, collectConfig *models
 	default:
 	}
 
+	if c.rewriter.err != nil {
+		return nil, c.rewriter.err
+	}
+
+	// 파일이 지정되면 디렉토리 탐색 없이 해당 파일만 파싱
+	if hasExplicitFiles(collectConfig) {
+		sessions, err := c.collectFromFiles(ctx, collectConfig)
+		if err != nil {
+			return nil, err
+		}
+		return c.finishSessions(sessions, collectConfig), nil
+	}
+
 	var sessions []models.SessionData
 
 	// 설정 디렉토리 확장
@@ -76,6 +133,16 @@ func (c *ClaudeCodeCollector) Collect(ctx context.Context, collectConfig *models
 	default:
 	}
 
+	// SQLite 히스토리 데이터베이스에서 세션 수집 (설정한 경우에만)
+	if c.config.HistoryDB != nil {
+		dbSessions, err := c.collectFromHistoryDB(ctx)
+		if err != nil {
+			fmt.Printf("경고: 히스토리 데이터베이스 수집 실패: %v\n", err)
+		} else {
+			sessions = append(sessions, dbSessions...)
+		}
+	}
+
 	// 세션 디렉토리에서 개별 세션 파일 수집
 	if c.config.SessionDir != "" {
 		sessionSessions, err := c.collectFromSessionDir(ctx, collectConfig)
@@ -87,12 +154,73 @@ func (c *ClaudeCodeCollector) Collect(ctx context.Context, collectConfig *models
 		}
 	}
 
+	// 작업 공간 메타데이터 추가 (파일이 없으면 건너뜀)
+	if workspace := c.loadWorkspace(configDir); workspace != nil {
+		c.applyWorkspaceMetadata(sessions, workspace)
+	}
+
+	return c.finishSessions(sessions, collectConfig), nil
+}
+
+// finishSessions는 수집한 세션에 치환 규칙, 메타데이터 설정, 날짜 필터를 적용합니다
+func (c *ClaudeCodeCollector) finishSessions(sessions []models.SessionData, collectConfig *models.CollectionConfig) []models.SessionData {
+	// 설정된 내용 치환 규칙 적용
+	c.rewriter.apply(sessions)
+
+	// 설정에서 끈 경우 내부 source_type 메타데이터 제거
+	if !c.config.SourceTypeMetadataEnabled() {
+		omitSourceTypeMetadata(sessions)
+	}
+
 	// 날짜 필터링
 	if collectConfig.DateRange != nil {
 		sessions = c.filterByDateRange(sessions, collectConfig.DateRange)
 	}
 
-	return sessions, nil
+	return sessions
+}
+
+// loadWorkspace는 설정 디렉토리에서 작업 공간 정보를 읽습니다. 파일이 없거나 읽을 수 없으면 nil을 반환합니다
+func (c *ClaudeCodeCollector) loadWorkspace(configDir string) *ClaudeWorkspace {
+	data, err := c.readFile(filepath.Join(configDir, claudeWorkspaceFile))
+	if err != nil {
+		return nil
+	}
+
+	var workspace ClaudeWorkspace
+	if err := json.Unmarshal(data, &workspace); err != nil {
+		fmt.Printf("경고: 작업 공간 파일 파싱 실패: %v\n", err)
+		return nil
+	}
+
+	if workspace.Project == "" && workspace.Cwd != "" {
+		workspace.Project = filepath.Base(workspace.Cwd)
+	}
+
+	return &workspace
+}
+
+// applyWorkspaceMetadata는 세션에 작업 공간 메타데이터를 추가합니다. 세션에 이미 있는 값은 유지합니다
+func (c *ClaudeCodeCollector) applyWorkspaceMetadata(sessions []models.SessionData, workspace *ClaudeWorkspace) {
+	values := map[string]string{
+		"project": workspace.Project,
+		"cwd":     workspace.Cwd,
+		"branch":  workspace.Branch,
+	}
+
+	for i := range sessions {
+		if sessions[i].Metadata == nil {
+			sessions[i].Metadata = make(map[string]string)
+		}
+		for key, value := range values {
+			if value == "" {
+				continue
+			}
+			if _, exists := sessions[i].Metadata[key]; !exists {
+				sessions[i].Metadata[key] = value
+			}
+		}
+	}
 }
 
 // GetSource는 이 수집기가 처리하는 소스 타입을 반환합니다
@@ -102,6 +230,10 @@ func (c *ClaudeCodeCollector) GetSource() models.CollectionSource {
 
 // Validate는 수집기 설정이 유효한지 검증합니다
 func (c *ClaudeCodeCollector) Validate() error {
+	if c.rewriter.err != nil {
+		return c.rewriter.err
+	}
+
 	if c.config.ConfigDir == "" {
 		return fmt.Errorf("설정 디렉토리가 지정되지 않았습니다")
 	}
@@ -145,7 +277,7 @@ func (c *ClaudeCodeCollector) collectFromHistory(ctx context.Context, collectCon
 	}
 
 	// 파일 읽기
-	data, err := os.ReadFile(historyPath)
+	data, err := c.readFile(historyPath)
 	if err != nil {
 		return nil, fmt.Errorf("히스토리 파일 읽기 실패: %w", err)
 	}
@@ -169,6 +301,39 @@ func (c *ClaudeCodeCollector) collectFromHistory(ctx context.Context, collectCon
 	return sessions, nil
 }
 
+// collectFromHistoryDB는 SQLite 데이터베이스의 대화 기록을 세션 파일과 같은 방식으로 변환합니다
+func (c *ClaudeCodeCollector) collectFromHistoryDB(ctx context.Context) ([]models.SessionData, error) {
+	dbSessions, dbPath, err := queryHistoryDB(ctx, *c.config.HistoryDB)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]models.SessionData, 0, len(dbSessions))
+	for _, dbSession := range dbSessions {
+		messages := make([]interface{}, 0, len(dbSession.Messages))
+		for _, message := range dbSession.Messages {
+			messages = append(messages, map[string]interface{}{
+				"role":      message.Role,
+				"content":   message.Content,
+				"timestamp": message.Timestamp,
+			})
+		}
+
+		session := c.parseSessionMap(map[string]interface{}{
+			"id":         dbSession.ID,
+			"created_at": dbSession.CreatedAt(),
+			"messages":   messages,
+		})
+		if session == nil {
+			continue
+		}
+		session.Metadata["file_path"] = dbPath
+		session.Metadata["source_type"] = "claude_code_history_db"
+		sessions = append(sessions, *session)
+	}
+	return sessions, nil
+}
+
 // collectFromSessionDir는 세션 디렉토리에서 개별 세션 파일들을 수집합니다
 func (c *ClaudeCodeCollector) collectFromSessionDir(ctx context.Context, collectConfig *models.CollectionConfig) ([]models.SessionData, error) {
 	sessionDir, err := config.ExpandPath(c.config.SessionDir)
@@ -210,8 +375,18 @@ func (c *ClaudeCodeCollector) collectFromSessionDir(ctx context.Context, collect
 			return nil
 		}
 
+		// 공유 워커 풀에서 슬롯 획득
+		if c.workerPool != nil {
+			if err := c.workerPool.Acquire(ctx); err != nil {
+				return err
+			}
+		}
+
 		// 세션 파일 파싱
 		sessionData, err := c.parseSessionFile(path)
+		if c.workerPool != nil {
+			c.workerPool.Release()
+		}
 		if err != nil {
 			// 개별 파일 파싱 실패는 로그만 남기고 계속 진행
 			fmt.Printf("세션 파일 파싱 실패 (건너뜀): %s - %v\n", path, err)
@@ -232,6 +407,70 @@ func (c *ClaudeCodeCollector) collectFromSessionDir(ctx context.Context, collect
 	return sessions, nil
 }
 
+// collectFromFiles는 collectConfig.Files에 지정된 파일만 파싱합니다 (포함/제외 패턴은 적용하지 않음)
+// .jsonl 파일은 한 줄을 하나의 세션으로, 그 밖의 파일은 세션 파일로 처리합니다
+func (c *ClaudeCodeCollector) collectFromFiles(ctx context.Context, collectConfig *models.CollectionConfig) ([]models.SessionData, error) {
+	var sessions []models.SessionData
+
+	for _, file := range collectConfig.Files {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		path, _, err := resolveExplicitFile(os.Stat, file)
+		if err != nil {
+			return nil, err
+		}
+
+		if isHistoryLinesFile(path) {
+			parsed, err := c.parseSessionLines(path)
+			if err != nil {
+				return nil, err
+			}
+			sessions = append(sessions, parsed...)
+			continue
+		}
+
+		sessionData, err := c.parseSessionFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("세션 파일 파싱 실패 (%s): %w", path, err)
+		}
+		if sessionData != nil {
+			sessions = append(sessions, *sessionData)
+		}
+	}
+
+	return sessions, nil
+}
+
+// parseSessionLines는 한 줄에 세션 하나가 JSON으로 기록된 파일을 파싱합니다 (잘못된 줄은 건너뜀)
+func (c *ClaudeCodeCollector) parseSessionLines(filePath string) ([]models.SessionData, error) {
+	data, err := c.readFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("파일 읽기 실패: %w", err)
+	}
+
+	var sessions []models.SessionData
+	for lineNum, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		var sessionMap map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &sessionMap); err != nil {
+			fmt.Printf("세션 라인 파싱 실패 (건너뜀): %s:%d - %v\n", filePath, lineNum+1, err)
+			continue
+		}
+		if session := c.parseSessionMap(sessionMap); session != nil {
+			sessions = append(sessions, *session)
+		}
+	}
+	return sessions, nil
+}
+
 // parseHistoryData는 히스토리 데이터를 파싱하여 세션 데이터로 변환합니다
 func (c *ClaudeCodeCollector) parseHistoryData(historyData map[string]interface{}) []models.SessionData {
 	var sessions []models.SessionData
@@ -270,7 +509,7 @@ func (c *ClaudeCodeCollector) parseHistoryData(historyData map[string]interface{
 
 // parseSessionFile은 개별 세션 파일을 파싱합니다
 func (c *ClaudeCodeCollector) parseSessionFile(filePath string) (*models.SessionData, error) {
-	data, err := os.ReadFile(filePath)
+	data, err := c.readFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("파일 읽기 실패: %w", err)
 	}
@@ -295,12 +534,9 @@ func (c *ClaudeCodeCollector) parseSessionMap(sessionMap map[string]interface{})
 		Metadata: make(map[string]string),
 	}
 
-	// ID 추출
-	if id, ok := sessionMap["id"].(string); ok {
-		session.ID = id
-	} else {
-		session.ID = fmt.Sprintf("claude-session-%d", time.Now().UnixNano())
-	}
+	// ID 추출 (없으면 메시지를 파싱한 뒤 내용 기반 ID 생성)
+	id, hasID := sessionMap["id"].(string)
+	session.ID = id
 
 	// 타임스탬프 추출
 	if timestamp, ok := sessionMap["timestamp"].(string); ok {
@@ -313,8 +549,9 @@ func (c *ClaudeCodeCollector) parseSessionMap(sessionMap map[string]interface{})
 		}
 	}
 
+	recordedAt := session.Timestamp
 	if session.Timestamp.IsZero() {
-		session.Timestamp = time.Now()
+		session.Timestamp = c.clock()
 	}
 
 	// 제목 추출
@@ -334,6 +571,24 @@ func (c *ClaudeCodeCollector) parseSessionMap(sessionMap map[string]interface{})
 		}
 	}
 
+	if !hasID {
+		session.ID = deterministicSessionID("claude-session", session.Source, firstMessageContent(session.Messages), recordedAt)
+	}
+
+	// 작업 공간 정보 추출 (세션에 기록된 경우)
+	if cwd, ok := sessionMap["cwd"].(string); ok && cwd != "" {
+		session.Metadata["cwd"] = cwd
+		session.Metadata["project"] = filepath.Base(cwd)
+	}
+	if project, ok := sessionMap["project"].(string); ok && project != "" {
+		session.Metadata["project"] = project
+	}
+	if branch, ok := sessionMap["gitBranch"].(string); ok && branch != "" {
+		session.Metadata["branch"] = branch
+	} else if branch, ok := sessionMap["branch"].(string); ok && branch != "" {
+		session.Metadata["branch"] = branch
+	}
+
 	// 메타데이터 추출
 	if metadata, ok := sessionMap["metadata"].(map[string]interface{}); ok {
 		for k, v := range metadata {
@@ -386,27 +641,35 @@ func (c *ClaudeCodeCollector) parseMessage(msgMap map[string]interface{}, index
 	}
 
 	if message.Timestamp.IsZero() {
-		message.Timestamp = time.Now()
+		message.Timestamp = c.clock()
 	}
 
+	// 명시적 순번 추출 (seq, index)
+	message.Sequence = sequenceFromMap(msgMap)
+
+	// 첨부 파일 추출
+	message.Attachments = attachmentsFromMap(msgMap)
+
 	return message
 }
 
 // parseTextSession은 텍스트 파일을 세션으로 파싱합니다
 func (c *ClaudeCodeCollector) parseTextSession(filePath, content string) (*models.SessionData, error) {
 	session := &models.SessionData{
-		ID:        fmt.Sprintf("claude-text-session-%d", time.Now().UnixNano()),
 		Source:    models.SourceClaudeCode,
 		Title:     filepath.Base(filePath),
-		Timestamp: time.Now(),
+		Timestamp: c.clock(),
 		Messages:  make([]models.Message, 0),
 		Metadata:  make(map[string]string),
 	}
 
 	// 파일 수정 시간을 타임스탬프로 사용
+	var modTime time.Time
 	if info, err := os.Stat(filePath); err == nil {
-		session.Timestamp = info.ModTime()
+		modTime = info.ModTime()
+		session.Timestamp = modTime
 	}
+	session.ID = deterministicSessionID("claude-text-session", session.Source, content, modTime)
 
 	// 텍스트 내용을 하나의 메시지로 처리
 	message := models.Message{
@@ -457,20 +720,5 @@ func (c *ClaudeCodeCollector) matchesExcludePattern(filePath string) bool {
 
 // filterByDateRange는 날짜 범위로 세션을 필터링합니다
 func (c *ClaudeCodeCollector) filterByDateRange(sessions []models.SessionData, dateRange *models.DateRange) []models.SessionData {
-	if dateRange == nil {
-		return sessions
-	}
-
-	var filtered []models.SessionData
-	for _, session := range sessions {
-		if !dateRange.Start.IsZero() && session.Timestamp.Before(dateRange.Start) {
-			continue
-		}
-		if !dateRange.End.IsZero() && session.Timestamp.After(dateRange.End) {
-			continue
-		}
-		filtered = append(filtered, session)
-	}
-
-	return filtered
+	return models.FilterSessionsByDateRange(sessions, dateRange)
 }
\ No newline at end of file
@@ -0,0 +1,150 @@
+package collector
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ssamai/internal/config"
+	"ssamai/pkg/models"
+)
+
+// writeClaudeFixture는 테스트용 Claude Code 설정 디렉토리를 생성합니다
+func writeClaudeFixture(t *testing.T, workspace string, sessionFiles map[string]string) config.CLIToolConfig {
+	t.Helper()
+
+	configDir := t.TempDir()
+	sessionDir := filepath.Join(configDir, "sessions")
+	if err := os.MkdirAll(sessionDir, 0755); err != nil {
+		t.Fatalf("failed to create session dir: %v", err)
+	}
+
+	if workspace != "" {
+		if err := os.WriteFile(filepath.Join(configDir, claudeWorkspaceFile), []byte(workspace), 0644); err != nil {
+			t.Fatalf("failed to write workspace file: %v", err)
+		}
+	}
+
+	for name, content := range sessionFiles {
+		if err := os.WriteFile(filepath.Join(sessionDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write session file: %v", err)
+		}
+	}
+
+	return config.CLIToolConfig{
+		ConfigDir:  configDir,
+		SessionDir: sessionDir,
+	}
+}
+
+func TestClaudeCodeCollector_WorkspaceMetadata(t *testing.T) {
+	cfg := writeClaudeFixture(t,
+		`{"cwd": "/home/user/projects/ssamai", "branch": "main"}`,
+		map[string]string{
+			"plain.json":    `{"id": "plain", "title": "Plain", "timestamp": "2024-01-01T10:00:00Z"}`,
+			"override.json": `{"id": "override", "title": "Override", "timestamp": "2024-01-01T11:00:00Z", "cwd": "/work/other", "gitBranch": "feature/x"}`,
+		})
+
+	sessions, err := NewClaudeCodeCollector(cfg).Collect(context.Background(), &models.CollectionConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(sessions))
+	}
+
+	byID := make(map[string]models.SessionData)
+	for _, session := range sessions {
+		byID[session.ID] = session
+	}
+
+	plain := byID["plain"].Metadata
+	if plain["project"] != "ssamai" {
+		t.Errorf("expected project 'ssamai', got %q", plain["project"])
+	}
+	if plain["cwd"] != "/home/user/projects/ssamai" {
+		t.Errorf("expected workspace cwd, got %q", plain["cwd"])
+	}
+	if plain["branch"] != "main" {
+		t.Errorf("expected branch 'main', got %q", plain["branch"])
+	}
+
+	// 세션에 기록된 값이 작업 공간 파일보다 우선
+	override := byID["override"].Metadata
+	if override["cwd"] != "/work/other" || override["project"] != "other" || override["branch"] != "feature/x" {
+		t.Errorf("expected session-level workspace metadata, got %v", override)
+	}
+}
+
+func TestClaudeCodeCollector_WorkspaceMissing(t *testing.T) {
+	cfg := writeClaudeFixture(t, "", map[string]string{
+		"plain.json": `{"id": "plain", "title": "Plain", "timestamp": "2024-01-01T10:00:00Z"}`,
+	})
+
+	sessions, err := NewClaudeCodeCollector(cfg).Collect(context.Background(), &models.CollectionConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sessions) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(sessions))
+	}
+
+	for _, key := range []string{"project", "cwd", "branch"} {
+		if _, exists := sessions[0].Metadata[key]; exists {
+			t.Errorf("expected no %q metadata without workspace file", key)
+		}
+	}
+}
+
+func TestClaudeCodeCollector_WorkspaceInvalid(t *testing.T) {
+	cfg := writeClaudeFixture(t, `{invalid`, nil)
+
+	if workspace := NewClaudeCodeCollector(cfg).loadWorkspace(cfg.ConfigDir); workspace != nil {
+		t.Errorf("expected nil workspace for invalid file, got %+v", workspace)
+	}
+}
+
+func TestClaudeCodeCollector_ParseMessageSequence(t *testing.T) {
+	collector := NewClaudeCodeCollector(config.CLIToolConfig{})
+
+	message := collector.parseMessage(map[string]interface{}{"role": "user", "content": "hi", "seq": float64(3)}, 0)
+	if message.Sequence == nil || *message.Sequence != 3 {
+		t.Errorf("expected sequence 3, got %v", message.Sequence)
+	}
+
+	message = collector.parseMessage(map[string]interface{}{"role": "user", "content": "hi", "index": 1.5}, 0)
+	if message.Sequence != nil {
+		t.Errorf("expected non-integer index to be ignored, got %d", *message.Sequence)
+	}
+}
+
+func TestClaudeCodeCollector_ParseMessageAttachments(t *testing.T) {
+	collector := NewClaudeCodeCollector(config.CLIToolConfig{})
+
+	message := collector.parseMessage(map[string]interface{}{
+		"role":    "user",
+		"content": "첨부한 로그 확인",
+		"attachments": []interface{}{
+			map[string]interface{}{"path": "/tmp/build.log", "size": float64(512)},
+			"/tmp/screenshot.png",
+		},
+	}, 0)
+	if len(message.Attachments) != 2 {
+		t.Fatalf("expected 2 attachments, got %+v", message.Attachments)
+	}
+	if message.Attachments[0].Name != "build.log" || message.Attachments[0].Size != 512 {
+		t.Errorf("unexpected first attachment: %+v", message.Attachments[0])
+	}
+	if message.Attachments[1].Path != "/tmp/screenshot.png" {
+		t.Errorf("unexpected second attachment: %+v", message.Attachments[1])
+	}
+
+	// 형식이 맞지 않는 첨부 파일은 무시
+	message = collector.parseMessage(map[string]interface{}{"role": "user", "content": "hi", "attachments": "not-a-list"}, 0)
+	if message.Attachments != nil {
+		t.Errorf("expected malformed attachments to be ignored, got %+v", message.Attachments)
+	}
+}
@@ -3,6 +3,8 @@ package collector
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"ssamai/pkg/models"
@@ -11,33 +13,85 @@ import (
 // CollectorConstructor는 Collector를 생성하는 함수 타입입니다.
 type CollectorConstructor func(config interface{}) models.Collector
 
-var registry = make(map[models.CollectionSource]CollectorConstructor)
+// collectorCreator는 생성 실패를 에러로 알릴 수 있는 등록 생성자입니다. (플러그인 팩토리 등)
+type collectorCreator func(config interface{}) (models.Collector, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[models.CollectionSource]collectorCreator)
+)
 
 // Register는 새로운 Collector 생성자를 팩토리에 등록합니다.
+// 같은 소스를 다시 등록하면 마지막으로 등록한 생성자가 사용됩니다.
+// 동시에 호출해도 안전합니다.
 func Register(source models.CollectionSource, constructor CollectorConstructor) {
-	registry[source] = constructor
+	register(source, func(config interface{}) (models.Collector, error) {
+		return constructor(config), nil
+	})
+}
+
+// register는 생성자를 소스에 등록합니다. 생성자가 반환한 에러는 GetCollector가 그대로 전달합니다.
+func register(source models.CollectionSource, creator collectorCreator) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[source] = creator
+}
+
+// SnapshotRegistry는 현재 등록 상태를 복사하고, 호출하면 그 상태로 되돌리는 함수를 반환합니다.
+// 테스트에서 Register로 생성자를 바꾼 뒤 다른 테스트에 영향을 주지 않도록 복원할 때 사용합니다.
+func SnapshotRegistry() (restore func()) {
+	registryMu.RLock()
+	saved := make(map[models.CollectionSource]collectorCreator, len(registry))
+	for source, constructor := range registry {
+		saved[source] = constructor
+	}
+	registryMu.RUnlock()
+
+	return func() {
+		registryMu.Lock()
+		registry = saved
+		registryMu.Unlock()
+	}
 }
 
 // GetCollector는 소스에 맞는 Collector 인스턴스를 반환합니다.
 func GetCollector(source models.CollectionSource, config interface{}) (models.Collector, error) {
-	constructor, ok := registry[source]
+	registryMu.RLock()
+	creator, ok := registry[source]
+	registryMu.RUnlock()
+
 	if !ok {
 		return nil, fmt.Errorf("no collector registered for source: %s", source)
 	}
-	return constructor(config), nil
+	collector, err := creator(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create collector for source %s: %w", source, err)
+	}
+	if collector == nil {
+		return nil, fmt.Errorf("collector constructor returned nil for source: %s", source)
+	}
+	return collector, nil
 }
 
-// ListRegisteredSources는 등록된 모든 소스들을 반환합니다.
+// ListRegisteredSources는 등록된 모든 소스들을 이름순으로 반환합니다.
 func ListRegisteredSources() []models.CollectionSource {
+	registryMu.RLock()
 	sources := make([]models.CollectionSource, 0, len(registry))
 	for source := range registry {
 		sources = append(sources, source)
 	}
+	registryMu.RUnlock()
+
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i] < sources[j]
+	})
 	return sources
 }
 
 // IsRegistered는 특정 소스가 등록되어 있는지 확인합니다.
 func IsRegistered(source models.CollectionSource) bool {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
 	_, ok := registry[source]
 	return ok
 }
@@ -54,22 +108,34 @@ func CollectAllSources(ctx context.Context, collectionConfig *models.CollectionC
 	for _, source := range collectionConfig.Sources {
 		config, exists := configs[source]
 		if !exists {
-			errMsg := fmt.Sprintf("소스 '%s'에 대한 설정이 없습니다", source)
-			result.Errors = append(result.Errors, errMsg)
+			result.AddIssue(models.CollectionIssue{
+				Source:   source,
+				Phase:    models.IssuePhaseConfig,
+				Severity: models.IssueSeverityError,
+				Message:  fmt.Sprintf("소스 '%s'에 대한 설정이 없습니다", source),
+			})
 			continue
 		}
 
 		collector, err := GetCollector(source, config)
 		if err != nil {
-			errMsg := fmt.Sprintf("소스 '%s'의 collector 생성 실패: %v", source, err)
-			result.Errors = append(result.Errors, errMsg)
+			result.AddIssue(models.CollectionIssue{
+				Source:   source,
+				Phase:    models.IssuePhaseConfig,
+				Severity: models.IssueSeverityError,
+				Message:  fmt.Sprintf("소스 '%s'의 collector 생성 실패: %v", source, err),
+			})
 			continue
 		}
 
 		sessions, err := collector.Collect(ctx, collectionConfig)
 		if err != nil {
-			errMsg := fmt.Sprintf("소스 '%s'에서 데이터 수집 실패: %v", source, err)
-			result.Errors = append(result.Errors, errMsg)
+			result.AddIssue(models.CollectionIssue{
+				Source:   source,
+				Phase:    models.IssuePhaseCollect,
+				Severity: models.IssueSeverityError,
+				Message:  fmt.Sprintf("소스 '%s'에서 데이터 수집 실패: %v", source, err),
+			})
 			continue
 		}
 
@@ -78,5 +144,6 @@ func CollectAllSources(ctx context.Context, collectionConfig *models.CollectionC
 	}
 
 	result.TotalCount = len(result.Sessions)
+	result.UpdateFallback()
 	return result, nil
 }
\ No newline at end of file
@@ -0,0 +1,115 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"ssamai/pkg/models"
+)
+
+// namedCollector는 생성자 구분을 위한 테스트용 수집기
+type namedCollector struct {
+	source models.CollectionSource
+	name   string
+}
+
+func (c *namedCollector) Collect(ctx context.Context, config *models.CollectionConfig) ([]models.SessionData, error) {
+	return []models.SessionData{{ID: c.name, Source: c.source}}, nil
+}
+
+func (c *namedCollector) GetSource() models.CollectionSource { return c.source }
+
+func (c *namedCollector) Validate() error { return nil }
+
+func (c *namedCollector) GetSupportedFormats() []string { return nil }
+
+// restoreRegistry는 테스트 종료 시 레지스트리를 원래 상태로 되돌립니다
+func restoreRegistry(t *testing.T) {
+	t.Helper()
+	t.Cleanup(SnapshotRegistry())
+}
+
+func TestRegister_Concurrent(t *testing.T) {
+	restoreRegistry(t)
+
+	const goroutines = 32
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			source := models.CollectionSource(fmt.Sprintf("plugin_%02d", i))
+			Register(source, func(interface{}) models.Collector {
+				return &namedCollector{source: source, name: string(source)}
+			})
+
+			// 등록과 조회를 동시에 수행
+			_ = ListRegisteredSources()
+			_ = IsRegistered(models.SourceClaudeCode)
+			if _, err := GetCollector(source, nil); err != nil {
+				t.Errorf("GetCollector(%s) error = %v", source, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < goroutines; i++ {
+		source := models.CollectionSource(fmt.Sprintf("plugin_%02d", i))
+		if !IsRegistered(source) {
+			t.Errorf("expected %s to be registered", source)
+		}
+	}
+}
+
+func TestRegister_LastWins(t *testing.T) {
+	restoreRegistry(t)
+
+	source := models.CollectionSource("duplicate_source")
+	for _, name := range []string{"first", "second"} {
+		name := name
+		Register(source, func(interface{}) models.Collector {
+			return &namedCollector{source: source, name: name}
+		})
+	}
+
+	c, err := GetCollector(source, nil)
+	if err != nil {
+		t.Fatalf("GetCollector() error = %v", err)
+	}
+	sessions, _ := c.Collect(context.Background(), &models.CollectionConfig{})
+	if len(sessions) != 1 || sessions[0].ID != "second" {
+		t.Errorf("expected last registered constructor to win, got %+v", sessions)
+	}
+}
+
+func TestListRegisteredSources_Sorted(t *testing.T) {
+	sources := ListRegisteredSources()
+	for i := 1; i < len(sources); i++ {
+		if sources[i-1] > sources[i] {
+			t.Fatalf("expected sorted sources, got %v", sources)
+		}
+	}
+}
+
+// failingFactory는 Create가 항상 실패하는 테스트용 CollectorFactory
+type failingFactory struct{ err error }
+
+func (f failingFactory) Create(config interface{}) (models.Collector, error) { return nil, f.err }
+
+func (f failingFactory) GetConfigType() interface{} { return nil }
+
+func TestFactoryRegistry_CreateErrorPassedThrough(t *testing.T) {
+	restoreRegistry(t)
+
+	source := models.CollectionSource("broken_plugin")
+	createErr := errors.New("missing api key")
+	factoryRegistry{}.Register(source, failingFactory{err: createErr})
+
+	_, err := GetCollector(source, nil)
+	if !errors.Is(err, createErr) {
+		t.Fatalf("GetCollector() error = %v, want wrapped %v", err, createErr)
+	}
+}
@@ -0,0 +1,45 @@
+package collector
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ssamai/internal/config"
+	"ssamai/pkg/models"
+)
+
+// hasExplicitFiles는 디렉토리 탐색 대신 파싱할 파일 목록이 지정되었는지 확인합니다
+func hasExplicitFiles(collectConfig *models.CollectionConfig) bool {
+	return collectConfig != nil && len(collectConfig.Files) > 0
+}
+
+// isHistoryLinesFile은 한 줄에 한 항목씩 기록된 히스토리 파일(.jsonl)인지 확인합니다
+func isHistoryLinesFile(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".jsonl")
+}
+
+// isSessionLinesFile은 한 줄에 세션 객체 하나씩 기록된 세션 파일(.ndjson)인지 확인합니다
+// 히스토리 항목을 담는 .jsonl과 달리 각 줄이 메시지 목록을 포함한 완전한 세션입니다
+func isSessionLinesFile(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".ndjson")
+}
+
+// resolveExplicitFile은 지정된 파일 경로를 확장하고 일반 파일인지 확인합니다.
+// 사용자가 직접 지정한 파일이므로 없거나 디렉토리이면 건너뛰지 않고 에러를 반환합니다.
+func resolveExplicitFile(stat func(string) (os.FileInfo, error), file string) (string, os.FileInfo, error) {
+	path, err := config.ExpandPath(file)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to expand file path %s: %w", file, err)
+	}
+
+	info, err := stat(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to stat file %s: %w", path, err)
+	}
+	if info.IsDir() {
+		return "", nil, fmt.Errorf("not a file: %s", path)
+	}
+	return path, info, nil
+}
@@ -0,0 +1,167 @@
+package collector
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"ssamai/internal/config"
+	"ssamai/pkg/models"
+)
+
+// sessionIDs는 세션 ID를 정렬해 반환합니다
+func sessionIDs(sessions []models.SessionData) []string {
+	ids := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		ids = append(ids, session.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestGeminiCollect_ExplicitFiles(t *testing.T) {
+	mockReader := NewMockFileReader()
+
+	// 세션 디렉토리와 히스토리 파일에도 세션이 있지만 지정한 파일만 파싱되어야 함
+	mockReader.AddDir("/test")
+	mockReader.AddDir("/test/sessions")
+	mockReader.AddFile("/test/sessions/other.json", []byte(`{"id": "dir-session", "title": "In directory"}`))
+	mockReader.AddFile("/test/history.jsonl", []byte(`{"id":"history-1","prompt":"Hello","response":"Hi","timestamp":"2024-01-01T10:00:00Z"}`))
+
+	mockReader.AddFile("/picked/a.json", []byte(`{"id": "picked-session", "title": "Picked", "created_at": "2024-01-01T10:00:00Z"}`))
+	mockReader.AddFile("/picked/b.jsonl", []byte(`{"id":"picked-1","prompt":"One","response":"1","timestamp":"2024-01-01T10:00:00Z"}
+{"id":"picked-2","prompt":"Two","response":"2","timestamp":"2024-01-01T11:00:00Z"}`))
+
+	collector := NewImprovedGeminiCLICollector(config.CLIToolConfig{
+		ConfigDir:       "/test",
+		SessionDir:      "/test/sessions",
+		HistoryFile:     "/test/history.jsonl",
+		IncludePatterns: []string{"*.log"},
+	}).WithFileReader(mockReader).WithLogger(&MockLogger{})
+
+	sessions, err := collector.Collect(context.Background(), &models.CollectionConfig{
+		Files: []string{"/picked/a.json", "/picked/b.jsonl"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ids := sessionIDs(sessions)
+	if len(ids) != 3 {
+		t.Fatalf("expected 3 sessions from listed files, got %v", ids)
+	}
+	for _, id := range ids {
+		if !strings.HasPrefix(id, "picked") {
+			t.Errorf("unexpected session from outside the listed files: %s", id)
+		}
+	}
+}
+
+func TestGeminiCollect_ExplicitFilesSkipConfigDirCheck(t *testing.T) {
+	mockReader := NewMockFileReader()
+	mockReader.AddFile("/picked/a.json", []byte(`{"id": "picked-session"}`))
+
+	collector := NewImprovedGeminiCLICollector(config.CLIToolConfig{
+		ConfigDir: "/missing",
+	}).WithFileReader(mockReader).WithLogger(&MockLogger{})
+
+	sessions, err := collector.Collect(context.Background(), &models.CollectionConfig{
+		Files: []string{"/picked/a.json"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(sessions))
+	}
+}
+
+func TestGeminiCollect_ExplicitFileMissing(t *testing.T) {
+	collector := NewImprovedGeminiCLICollector(config.CLIToolConfig{
+		ConfigDir: "/test",
+	}).WithFileReader(NewMockFileReader()).WithLogger(&MockLogger{})
+
+	_, err := collector.Collect(context.Background(), &models.CollectionConfig{
+		Files: []string{"/picked/missing.json"},
+	})
+	if err == nil {
+		t.Fatal("expected error for missing listed file")
+	}
+	if !strings.Contains(err.Error(), "/picked/missing.json") {
+		t.Errorf("expected error to name the file, got %v", err)
+	}
+}
+
+func TestAmazonQCollect_ExplicitFiles(t *testing.T) {
+	mockReader := NewMockAmazonQFileReader()
+	mockReader.AddDir("/test/.amazon-q")
+	mockReader.AddFile("/test/.amazon-q/history.json", []byte(`{"id": "history-1", "query": "Ignored", "response": "-"}`))
+	mockReader.AddFile("/picked/a.json", []byte(`{"id": "picked-session", "title": "Picked"}`))
+	mockReader.AddFile("/picked/b.jsonl", []byte(`{"id": "picked-1", "query": "How to create EC2?", "response": "Use AWS console", "timestamp": "2024-01-01T00:00:00Z"}`))
+
+	collector := NewAmazonQCollector(config.CLIToolConfig{
+		ConfigDir:        "/test/.amazon-q",
+		HistoryFile:      "/test/.amazon-q/history.json",
+		WriteGracePeriod: -1, // mock 파일의 수정 시각은 항상 현재
+	}).WithFileReader(mockReader).WithLogger(NewMockAmazonQLogger())
+
+	sessions, err := collector.Collect(context.Background(), &models.CollectionConfig{
+		Files: []string{"/picked/a.json", "/picked/b.jsonl"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ids := sessionIDs(sessions)
+	if len(ids) != 2 {
+		t.Fatalf("expected 2 sessions from listed files (no dummy or AWS config data), got %v", ids)
+	}
+	for _, session := range sessions {
+		if session.Metadata["source_type"] == "amazon_q_dummy" {
+			t.Errorf("unexpected dummy session: %s", session.ID)
+		}
+	}
+}
+
+func TestClaudeCodeCollect_ExplicitFiles(t *testing.T) {
+	cfg := writeClaudeFixture(t, "", map[string]string{
+		"other.json": `{"id": "dir-session", "title": "In directory"}`,
+	})
+
+	picked := t.TempDir()
+	sessionFile := filepath.Join(picked, "a.json")
+	linesFile := filepath.Join(picked, "b.jsonl")
+	if err := os.WriteFile(sessionFile, []byte(`{"id": "picked-session", "title": "Picked"}`), 0644); err != nil {
+		t.Fatalf("failed to write session file: %v", err)
+	}
+	if err := os.WriteFile(linesFile, []byte("{\"id\": \"picked-1\"}\nnot json\n{\"id\": \"picked-2\"}\n"), 0644); err != nil {
+		t.Fatalf("failed to write lines file: %v", err)
+	}
+
+	sessions, err := NewClaudeCodeCollector(cfg).Collect(context.Background(), &models.CollectionConfig{
+		Files: []string{sessionFile, linesFile},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ids := sessionIDs(sessions)
+	want := []string{"picked-1", "picked-2", "picked-session"}
+	if strings.Join(ids, ",") != strings.Join(want, ",") {
+		t.Errorf("expected sessions %v, got %v", want, ids)
+	}
+}
+
+func TestClaudeCodeCollect_ExplicitFileIsDirectory(t *testing.T) {
+	cfg := writeClaudeFixture(t, "", nil)
+
+	_, err := NewClaudeCodeCollector(cfg).Collect(context.Background(), &models.CollectionConfig{
+		Files: []string{cfg.SessionDir},
+	})
+	if err == nil {
+		t.Fatal("expected error for directory in file list")
+	}
+}
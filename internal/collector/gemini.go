@@ -4,7 +4,9 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -33,6 +35,7 @@ const (
 	// 파일 처리 관련 상수
 	maxFileSize        = 100 * 1024 * 1024 // 100MB
 	bufferSize         = 64 * 1024         // 64KB
+	defaultMaxLineSize = 1024 * 1024       // 1MB (히스토리 라인 최대 길이)
 	maxWorkers         = 10                // 최대 워커 수
 	defaultTimeout     = 30 * time.Second  // 기본 타임아웃
 	maxJSONDepth       = 100               // JSON 파싱 최대 깊이
@@ -52,6 +55,7 @@ type FileReader interface {
 	ReadFile(filename string) ([]byte, error)
 	Stat(filename string) (os.FileInfo, error)
 	WalkDir(root string, fn fs.WalkDirFunc) error
+	Open(filename string) (io.ReadCloser, error)
 }
 
 // DefaultFileReader는 FileReader의 기본 구현
@@ -69,11 +73,24 @@ func (r *DefaultFileReader) WalkDir(root string, fn fs.WalkDirFunc) error {
 	return filepath.WalkDir(root, fn)
 }
 
+func (r *DefaultFileReader) Open(filename string) (io.ReadCloser, error) {
+	return os.Open(filename)
+}
+
 // ImprovedGeminiCLICollector는 개선된 Gemini CLI 수집기
 type ImprovedGeminiCLICollector struct {
 	config     config.CLIToolConfig
 	fileReader FileReader
 	logger     Logger // 추가된 로거 인터페이스
+	clock      func() time.Time
+	workerPool WorkerLimiter // 전역 동시성 제한 (nil이면 제한 없음)
+	rewriter   contentRewriter // 소스별 메시지 내용 치환 규칙
+
+	rejectedMu sync.Mutex
+	rejected   []RejectedLines // 마지막 수집에서 거부된 히스토리 라인 (ReportRejected 설정 시)
+
+	scanMu    sync.Mutex
+	scanStats []models.DirScanStats // 마지막 수집의 세션 디렉토리 스캔 결과
 }
 
 // Logger는 로깅을 위한 인터페이스
@@ -104,6 +121,8 @@ func NewImprovedGeminiCLICollector(config config.CLIToolConfig) *ImprovedGeminiC
 		config:     config,
 		fileReader: &DefaultFileReader{},
 		logger:     &DefaultLogger{},
+		clock:      time.Now,
+		rewriter:   newContentRewriter(config.ContentRewrites),
 	}
 }
 
@@ -119,22 +138,96 @@ func (g *ImprovedGeminiCLICollector) WithLogger(logger Logger) *ImprovedGeminiCL
 	return g
 }
 
+// WithClock은 기본 타임스탬프 생성에 사용할 시계 의존성 주입 (테스트용)
+func (g *ImprovedGeminiCLICollector) WithClock(clock func() time.Time) *ImprovedGeminiCLICollector {
+	g.clock = clock
+	return g
+}
+
+// SetWorkerPool은 collector 간에 공유되는 워커 풀을 설정합니다
+func (g *ImprovedGeminiCLICollector) SetWorkerPool(pool WorkerLimiter) {
+	g.workerPool = pool
+}
+
+// SetRetryPolicy는 파일 읽기, 상태 확인, 디렉토리 탐색에 재시도 정책을 적용합니다
+func (g *ImprovedGeminiCLICollector) SetRetryPolicy(policy *RetryPolicy) {
+	g.fileReader = newRetryingFileReader(g.fileReader, policy)
+}
+
+// RejectedLines는 마지막 수집에서 파싱에 실패해 건너뛴 히스토리 라인을 반환합니다
+func (g *ImprovedGeminiCLICollector) RejectedLines() []RejectedLines {
+	g.rejectedMu.Lock()
+	defer g.rejectedMu.Unlock()
+	return append([]RejectedLines(nil), g.rejected...)
+}
+
+// recordRejected는 파일별 거부 라인 정보를 기록합니다
+func (g *ImprovedGeminiCLICollector) recordRejected(report RejectedLines) {
+	g.rejectedMu.Lock()
+	g.rejected = append(g.rejected, report)
+	g.rejectedMu.Unlock()
+}
+
+// ScanStats는 마지막 수집에서 세션 디렉토리를 스캔한 결과를 반환합니다
+func (g *ImprovedGeminiCLICollector) ScanStats() []models.DirScanStats {
+	g.scanMu.Lock()
+	defer g.scanMu.Unlock()
+	return append([]models.DirScanStats(nil), g.scanStats...)
+}
+
+// recordScanStats는 세션 디렉토리 스캔 결과를 기록합니다
+func (g *ImprovedGeminiCLICollector) recordScanStats(stats models.DirScanStats) {
+	g.scanMu.Lock()
+	g.scanStats = append(g.scanStats, stats)
+	g.scanMu.Unlock()
+}
+
 // Collect는 컨텍스트 관리와 에러 처리가 개선된 수집 메서드
 func (g *ImprovedGeminiCLICollector) Collect(ctx context.Context, collectConfig *models.CollectionConfig) ([]models.SessionData, error) {
 	if collectConfig == nil {
 		return nil, fmt.Errorf("collection config is nil")
 	}
 
+	// context 취소 확인
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if g.rewriter.err != nil {
+		return nil, g.rewriter.err
+	}
+
+	// 이전 수집의 거부 라인 기록 초기화
+	g.rejectedMu.Lock()
+	g.rejected = nil
+	g.rejectedMu.Unlock()
+	g.scanMu.Lock()
+	g.scanStats = nil
+	g.scanMu.Unlock()
+
 	// 타임아웃이 설정된 컨텍스트 생성
 	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
 	defer cancel()
 
+	// 파일이 지정되면 디렉토리 탐색 없이 해당 파일만 파싱
+	explicitFiles := hasExplicitFiles(collectConfig)
+
 	// 설정 디렉토리 검증
-	if err := g.validateConfigDirectory(); err != nil {
-		return nil, fmt.Errorf("config directory validation failed: %w", err)
+	if !explicitFiles {
+		if err := g.validateConfigDirectory(); err != nil {
+			return nil, fmt.Errorf("config directory validation failed: %w", err)
+		}
 	}
 
 	var allSessions []models.SessionData
+	if explicitFiles {
+		sessions, err := g.collectFromFiles(ctx, collectConfig)
+		if err != nil {
+			return nil, err
+		}
+		allSessions = sessions
+	}
+
 	var mu sync.Mutex
 	var wg sync.WaitGroup
 	errs := make([]error, 0)
@@ -148,7 +241,7 @@ func (g *ImprovedGeminiCLICollector) Collect(ctx context.Context, collectConfig
 	}
 
 	// 히스토리 파일 처리
-	if g.config.HistoryFile != "" {
+	if g.config.HistoryFile != "" && !explicitFiles {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
@@ -163,8 +256,24 @@ func (g *ImprovedGeminiCLICollector) Collect(ctx context.Context, collectConfig
 		}()
 	}
 
+	// SQLite 히스토리 데이터베이스 처리 (설정한 경우에만)
+	if g.config.HistoryDB != nil && !explicitFiles {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sessions, err := g.collectFromHistoryDB(ctx)
+			if err != nil {
+				addError(fmt.Errorf("history database collection failed: %w", err))
+				return
+			}
+			mu.Lock()
+			allSessions = append(allSessions, sessions...)
+			mu.Unlock()
+		}()
+	}
+
 	// 세션 디렉토리 처리
-	if g.config.SessionDir != "" {
+	if g.config.SessionDir != "" && !explicitFiles {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
@@ -186,6 +295,14 @@ func (g *ImprovedGeminiCLICollector) Collect(ctx context.Context, collectConfig
 		g.logger.Warnf("Collection warning: %v\n", err)
 	}
 
+	// 설정된 내용 치환 규칙 적용
+	g.rewriter.apply(allSessions)
+
+	// 설정에서 끈 경우 내부 source_type 메타데이터 제거
+	if !g.config.SourceTypeMetadataEnabled() {
+		omitSourceTypeMetadata(allSessions)
+	}
+
 	// 날짜 필터링
 	if collectConfig.DateRange != nil {
 		allSessions = g.filterByDateRange(allSessions, collectConfig.DateRange)
@@ -208,6 +325,100 @@ func (g *ImprovedGeminiCLICollector) validateConfigDirectory() error {
 	return nil
 }
 
+// collectFromFiles는 collectConfig.Files에 지정된 파일만 파싱합니다 (포함/제외 패턴은 적용하지 않음)
+// .jsonl 파일은 히스토리 라인으로, tar 아카이브는 항목별로, 그 밖의 파일은 세션 파일로 처리합니다
+func (g *ImprovedGeminiCLICollector) collectFromFiles(ctx context.Context, collectConfig *models.CollectionConfig) ([]models.SessionData, error) {
+	var sessions []models.SessionData
+
+	for _, file := range collectConfig.Files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		path, info, err := resolveExplicitFile(g.fileReader.Stat, file)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case isHistoryLinesFile(path):
+			if info.Size() > maxFileSize {
+				return nil, fmt.Errorf("history file too large: %d bytes (max: %d)", info.Size(), maxFileSize)
+			}
+			parsed, err := g.parseHistoryFileStreaming(ctx, path, collectConfig)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse history file %s: %w", path, err)
+			}
+			sessions = append(sessions, parsed...)
+
+		case isSessionLinesFile(path):
+			parsed, lineErrors, err := g.parseSessionLinesFile(path, collectConfig)
+			for _, lineErr := range lineErrors {
+				g.logger.Warnf("Failed to parse session line in %s: %v\n", path, lineErr)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse session file %s: %w", path, err)
+			}
+			for _, session := range parsed {
+				sessions = append(sessions, *session)
+			}
+
+		case isTarArchive(path):
+			parsed, entryErrors, err := g.parseSessionArchive(path, collectConfig)
+			for _, entryErr := range entryErrors {
+				g.logger.Warnf("Failed to parse session file in archive: %v\n", entryErr)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read session archive %s: %w", path, err)
+			}
+			for _, session := range parsed {
+				sessions = append(sessions, *session)
+			}
+
+		default:
+			session, err := g.parseSessionFileSafe(path, collectConfig)
+			if errors.Is(err, errFileInProgress) {
+				g.logger.Printf("Skipping session file being written, will retry on next run: %s (%v)\n", path, err)
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse session file %s: %w", path, err)
+			}
+			sessions = append(sessions, *session)
+		}
+	}
+
+	return sessions, nil
+}
+
+// collectFromHistoryDB는 SQLite 데이터베이스의 대화 기록을 세션 파일과 같은 방식으로 변환합니다
+func (g *ImprovedGeminiCLICollector) collectFromHistoryDB(ctx context.Context) ([]models.SessionData, error) {
+	dbSessions, dbPath, err := queryHistoryDB(ctx, *g.config.HistoryDB)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]models.SessionData, 0, len(dbSessions))
+	for _, dbSession := range dbSessions {
+		geminiSession := GeminiSessionData{ID: dbSession.ID, CreatedAt: dbSession.CreatedAt()}
+		for _, message := range dbSession.Messages {
+			geminiSession.Messages = append(geminiSession.Messages, GeminiMessage{
+				Role:      message.Role,
+				Content:   message.Content,
+				Timestamp: message.Timestamp,
+			})
+			if geminiSession.Title == "" && message.Role == "user" {
+				geminiSession.Title = g.extractTitleFromPrompt(message.Content)
+			}
+		}
+
+		session := g.convertGeminiSessionToModel(geminiSession, dbPath)
+		session.Metadata["source_type"] = "gemini_cli_history_db"
+		sessions = append(sessions, *session)
+	}
+	return sessions, nil
+}
+
 // collectFromHistoryWithRetry는 재시도 로직이 있는 히스토리 수집
 func (g *ImprovedGeminiCLICollector) collectFromHistoryWithRetry(ctx context.Context, collectConfig *models.CollectionConfig) ([]models.SessionData, error) {
 	historyPath, err := config.ExpandPath(g.config.HistoryFile)
@@ -230,39 +441,56 @@ func (g *ImprovedGeminiCLICollector) collectFromHistoryWithRetry(ctx context.Con
 }
 
 // parseHistoryFileStreaming은 메모리 효율적인 히스토리 파일 파싱
+// 최대 길이를 넘는 라인은 파일 전체를 실패시키지 않고 경고와 함께 건너뜁니다
 func (g *ImprovedGeminiCLICollector) parseHistoryFileStreaming(ctx context.Context, filePath string, collectConfig *models.CollectionConfig) ([]models.SessionData, error) {
-	file, err := os.Open(filePath)
+	file, err := g.fileReader.Open(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open history file: %w", err)
 	}
 	defer file.Close()
 
 	var sessions []models.SessionData
-	scanner := bufio.NewScanner(file)
-	scanner.Buffer(make([]byte, bufferSize), bufferSize)
-	
+	reader := bufio.NewReaderSize(file, bufferSize)
+	maxLineSize := g.maxLineSize()
+
+	// 거부된 라인 보고 (--report-rejected)
+	rejected := RejectedLines{File: filePath}
+	if collectConfig != nil && collectConfig.ReportRejected {
+		defer func() {
+			if rejected.Count > 0 {
+				g.recordRejected(rejected)
+			}
+		}()
+	}
+
 	lineNum := 0
-	for scanner.Scan() {
+	for {
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		default:
 		}
 
-		lineNum++
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
+		raw, tooLong, readErr := readHistoryLine(reader, maxLineSize)
+		if readErr != nil && readErr != io.EOF {
+			return nil, fmt.Errorf("error reading history file: %w", readErr)
 		}
-
-		session, err := g.parseHistoryLine(line, lineNum)
-		if err != nil {
-			g.logger.Warnf("Failed to parse history line %d: %v", lineNum, err)
-			continue
+		if readErr == io.EOF && len(raw) == 0 && !tooLong {
+			break
 		}
 
-		if session != nil {
-			sessions = append(sessions, *session)
+		lineNum++
+		if tooLong {
+			g.logger.Warnf("Skipping history line %d: exceeds max line size (%d bytes)\n", lineNum, maxLineSize)
+			rejected.add(lineNum)
+		} else if line := strings.TrimSpace(string(raw)); line != "" {
+			session, err := g.parseHistoryLine(filePath, line, lineNum)
+			if err != nil {
+				g.logger.Warnf("Failed to parse history line %d: %v", lineNum, err)
+				rejected.add(lineNum)
+			} else if session != nil {
+				sessions = append(sessions, *session)
+			}
 		}
 
 		// 메모리 사용량 제한
@@ -270,29 +498,64 @@ func (g *ImprovedGeminiCLICollector) parseHistoryFileStreaming(ctx context.Conte
 			g.logger.Warnf("Reached maximum messages per file limit: %d", maxMessagesPerFile)
 			break
 		}
-	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading history file: %w", err)
+		if readErr == io.EOF {
+			break
+		}
 	}
 
 	return sessions, nil
 }
 
+// maxLineSize는 설정된 히스토리 라인 최대 길이를 반환합니다
+func (g *ImprovedGeminiCLICollector) maxLineSize() int {
+	if g.config.MaxLineSize > 0 {
+		return g.config.MaxLineSize
+	}
+	return defaultMaxLineSize
+}
+
+// readHistoryLine은 한 라인을 읽습니다. 최대 길이를 넘는 라인은 끝까지 버리고 tooLong을 반환합니다
+func readHistoryLine(reader *bufio.Reader, maxLineSize int) ([]byte, bool, error) {
+	var line []byte
+	tooLong := false
+
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		if !tooLong {
+			if len(line)+len(chunk) > maxLineSize {
+				tooLong = true
+				line = nil
+			} else {
+				line = append(line, chunk...)
+			}
+		}
+
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		return line, tooLong, err
+	}
+}
+
 // parseHistoryLine은 안전한 히스토리 라인 파싱
-func (g *ImprovedGeminiCLICollector) parseHistoryLine(line string, lineNum int) (*models.SessionData, error) {
+// filePath와 lineNum은 ID가 없는 항목의 ID를 만들 때 위치로 사용합니다
+func (g *ImprovedGeminiCLICollector) parseHistoryLine(filePath, line string, lineNum int) (*models.SessionData, error) {
 	// JSON 파싱 시도
 	if strings.HasPrefix(line, "{") {
-		return g.parseJSONHistoryEntry(line, lineNum)
+		return g.parseJSONHistoryEntry(filePath, line, lineNum)
 	}
 
 	// 텍스트로 처리
-	return g.parseTextHistoryEntry(line, lineNum), nil
+	return g.parseTextHistoryEntry(filePath, line, lineNum), nil
 }
 
 // parseJSONHistoryEntry는 안전한 JSON 히스토리 엔트리 파싱
-func (g *ImprovedGeminiCLICollector) parseJSONHistoryEntry(line string, lineNum int) (*models.SessionData, error) {
+func (g *ImprovedGeminiCLICollector) parseJSONHistoryEntry(filePath, line string, lineNum int) (*models.SessionData, error) {
 	var entry GeminiHistoryEntry
+	if err := checkJSONDepth([]byte(line), maxJSONDepth); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
 	decoder := json.NewDecoder(strings.NewReader(line))
 	decoder.DisallowUnknownFields() // 알 수 없는 필드 거부
 
@@ -300,7 +563,7 @@ func (g *ImprovedGeminiCLICollector) parseJSONHistoryEntry(line string, lineNum
 		return nil, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	return g.convertHistoryEntryToSession(entry, lineNum), nil
+	return g.convertHistoryEntryToSession(entry, filePath, lineNum), nil
 }
 
 // GeminiHistoryEntry는 Gemini CLI 히스토리 엔트리 구조체
@@ -324,16 +587,20 @@ type GeminiSessionData struct {
 	Messages     []GeminiMessage          `json:"messages"`
 	Metadata     map[string]interface{}   `json:"metadata"`
 	Settings     *GeminiSessionSettings   `json:"settings"`
+	Files        []SessionFileEntry       `json:"files"`
 }
 
 // GeminiMessage는 Gemini CLI 메시지 구조체
 type GeminiMessage struct {
-	ID        string                 `json:"id"`
-	Role      string                 `json:"role"`
-	Content   string                 `json:"content"`
-	Parts     []GeminiMessagePart    `json:"parts"`
-	Timestamp string                 `json:"timestamp"`
-	Metadata  map[string]interface{} `json:"metadata"`
+	ID          string                 `json:"id"`
+	Role        string                 `json:"role"`
+	Content     string                 `json:"content"`
+	Parts       []GeminiMessagePart    `json:"parts"`
+	Timestamp   string                 `json:"timestamp"`
+	Seq         *int                   `json:"seq"`
+	Index       *int                   `json:"index"`
+	Metadata    map[string]interface{} `json:"metadata"`
+	Attachments []SessionFileEntry     `json:"attachments"`
 }
 
 // GeminiMessagePart는 Gemini 메시지 파트 구조체
@@ -350,26 +617,26 @@ type GeminiSessionSettings struct {
 }
 
 // convertHistoryEntryToSession은 히스토리 엔트리를 세션으로 변환
-func (g *ImprovedGeminiCLICollector) convertHistoryEntryToSession(entry GeminiHistoryEntry, index int) *models.SessionData {
+func (g *ImprovedGeminiCLICollector) convertHistoryEntryToSession(entry GeminiHistoryEntry, filePath string, index int) *models.SessionData {
+	// 타임스탬프 파싱
+	recordedAt := parseRecordedTime(entry.Timestamp)
+
+	// ID가 없으면 파일 위치와 내용 기반 ID 사용 (같은 내용이 반복되어도 줄마다 구분)
 	sessionID := entry.ID
 	if sessionID == "" {
-		sessionID = fmt.Sprintf("gemini-cli-history-%d", index)
+		sessionID = positionalSessionID("gemini-cli-history", models.SourceGeminiCLI, filePath, index, entry.Prompt, recordedAt)
 	}
 
 	session := &models.SessionData{
 		ID:        sessionID,
 		Source:    models.SourceGeminiCLI,
-		Timestamp: time.Now(),
+		Timestamp: g.clock(),
 		Title:     g.extractTitleFromPrompt(entry.Prompt),
 		Messages:  make([]models.Message, 0, 2),
 		Metadata:  make(map[string]string),
 	}
-
-	// 타임스탬프 파싱
-	if entry.Timestamp != "" {
-		if timestamp, err := time.Parse(time.RFC3339, entry.Timestamp); err == nil {
-			session.Timestamp = timestamp
-		}
+	if !recordedAt.IsZero() {
+		session.Timestamp = recordedAt
 	}
 
 	// 메타데이터 설정
@@ -405,23 +672,24 @@ func (g *ImprovedGeminiCLICollector) convertHistoryEntryToSession(entry GeminiHi
 }
 
 // parseTextHistoryEntry는 텍스트 히스토리 엔트리 파싱
-func (g *ImprovedGeminiCLICollector) parseTextHistoryEntry(line string, lineNum int) *models.SessionData {
+func (g *ImprovedGeminiCLICollector) parseTextHistoryEntry(filePath, line string, lineNum int) *models.SessionData {
 	if len(strings.TrimSpace(line)) == 0 {
 		return nil
 	}
 
-	sessionID := fmt.Sprintf("gemini-cli-text-%d", lineNum)
+	// 같은 텍스트 줄이 반복될 수 있으므로 파일 위치도 ID에 포함
+	sessionID := positionalSessionID("gemini-cli-text", models.SourceGeminiCLI, filePath, lineNum, line, time.Time{})
 	return &models.SessionData{
 		ID:        sessionID,
 		Source:    models.SourceGeminiCLI,
-		Timestamp: time.Now(),
+		Timestamp: g.clock(),
 		Title:     "Gemini CLI History Entry",
 		Messages: []models.Message{
 			{
 				ID:        fmt.Sprintf("%s-user", sessionID),
 				Role:      "user",
 				Content:   line,
-				Timestamp: time.Now(),
+				Timestamp: g.clock(),
 				Metadata:  map[string]string{"source_type": "gemini_cli_text"},
 			},
 		},
@@ -439,16 +707,27 @@ func (g *ImprovedGeminiCLICollector) collectFromSessionDirConcurrent(ctx context
 		return nil, fmt.Errorf("failed to expand session directory path: %w", err)
 	}
 
-	// 파일 목록 수집
+	// 파일 목록 수집 (세션이 없을 때 원인을 구분할 수 있도록 스캔 결과를 기록)
+	stats := models.DirScanStats{Source: models.SourceGeminiCLI, Dir: sessionDirPath}
 	var filePaths []string
 	err = g.fileReader.WalkDir(sessionDirPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
+			if path == sessionDirPath && errors.Is(err, fs.ErrNotExist) {
+				stats.Missing = true
+				return fs.SkipAll
+			}
 			return err
 		}
 
-		if d.IsDir() || !strings.HasSuffix(path, ".json") {
+		if d.IsDir() {
+			return nil
+		}
+		stats.FilesScanned++
+
+		if !strings.HasSuffix(path, ".json") && !isSessionLinesFile(path) && !isTarArchive(path) {
 			return nil
 		}
+		stats.FilesMatched++
 
 		filePaths = append(filePaths, path)
 		return nil
@@ -461,6 +740,7 @@ func (g *ImprovedGeminiCLICollector) collectFromSessionDirConcurrent(ctx context
 	// 워커 수 결정
 	numWorkers := min(maxWorkers, len(filePaths), runtime.NumCPU())
 	if numWorkers == 0 {
+		g.recordScanStats(stats)
 		return []models.SessionData{}, nil
 	}
 
@@ -522,6 +802,13 @@ func (g *ImprovedGeminiCLICollector) collectFromSessionDirConcurrent(ctx context
 		}
 	}
 
+	// 워커 처리 순서와 무관하게 파일 순서대로 정렬
+	sortSessionsByFilePath(sessions, filePaths)
+
+	stats.FilesParsed = countParsedFiles(sessions, filePaths)
+	stats.FilesErrored = countErroredFiles(errors, sessions)
+	g.recordScanStats(stats)
+
 	// 에러 로깅
 	for _, err := range errors {
 		g.logger.Warnf("Session file processing error: %v", err)
@@ -541,9 +828,59 @@ func (g *ImprovedGeminiCLICollector) sessionFileWorker(ctx context.Context, wg *
 				return
 			}
 
+			// 공유 워커 풀에서 슬롯 획득
+			if g.workerPool != nil {
+				if err := g.workerPool.Acquire(ctx); err != nil {
+					return
+				}
+			}
+			if isTarArchive(filePath) {
+				sessions, entryErrors, err := g.parseSessionArchive(filePath, collectConfig)
+				if g.workerPool != nil {
+					g.workerPool.Release()
+				}
+				for _, entryErr := range entryErrors {
+					errorChan <- newFileParseError(filePath, fmt.Errorf("failed to parse session file in archive: %w", entryErr))
+				}
+				if err != nil {
+					errorChan <- newFileParseError(filePath, fmt.Errorf("failed to read session archive %s: %w", filePath, err))
+				}
+				for _, session := range sessions {
+					resultChan <- session
+				}
+				continue
+			}
+			if isSessionLinesFile(filePath) {
+				sessions, lineErrors, err := g.parseSessionLinesFile(filePath, collectConfig)
+				if g.workerPool != nil {
+					g.workerPool.Release()
+				}
+				if errors.Is(err, errFileInProgress) {
+					g.logger.Printf("Skipping session file being written, will retry on next run: %s (%v)\n", filePath, err)
+					continue
+				}
+				for _, lineErr := range lineErrors {
+					errorChan <- newFileParseError(filePath, fmt.Errorf("failed to parse session line in %s: %w", filePath, lineErr))
+				}
+				if err != nil {
+					errorChan <- newFileParseError(filePath, fmt.Errorf("failed to parse session file %s: %w", filePath, err))
+				}
+				for _, session := range sessions {
+					resultChan <- session
+				}
+				continue
+			}
+
 			session, err := g.parseSessionFileSafe(filePath, collectConfig)
+			if g.workerPool != nil {
+				g.workerPool.Release()
+			}
+			if errors.Is(err, errFileInProgress) {
+				g.logger.Printf("Skipping session file being written, will retry on next run: %s (%v)\n", filePath, err)
+				continue
+			}
 			if err != nil {
-				errorChan <- fmt.Errorf("failed to parse session file %s: %w", filePath, err)
+				errorChan <- newFileParseError(filePath, fmt.Errorf("failed to parse session file %s: %w", filePath, err))
 				continue
 			}
 
@@ -567,20 +904,106 @@ func (g *ImprovedGeminiCLICollector) parseSessionFileSafe(path string, collectCo
 		return nil, fmt.Errorf("file too large: %d bytes", info.Size())
 	}
 
+	// 도구가 아직 쓰고 있는 파일은 잘린 JSON일 수 있으므로 다음 수집으로 미룸
+	if err := checkWriteInProgress(info, g.clock(), writeGracePeriod(g.config)); err != nil {
+		return nil, err
+	}
+
 	// 파일 읽기
 	data, err := g.fileReader.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
+	return g.parseSessionBytes(data, path, collectConfig)
+}
+
+// parseSessionLinesFile은 한 줄에 GeminiSessionData 하나씩 기록된 .ndjson 파일을 여러 세션으로 파싱합니다.
+// 파싱할 수 없는 줄은 lineErrors로 반환하고 나머지 줄은 계속 처리합니다.
+func (g *ImprovedGeminiCLICollector) parseSessionLinesFile(path string, collectConfig *models.CollectionConfig) (sessions []*models.SessionData, lineErrors []error, err error) {
+	info, err := g.fileReader.Stat(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+	if info.Size() > maxFileSize {
+		return nil, nil, fmt.Errorf("file too large: %d bytes", info.Size())
+	}
+	if err := checkWriteInProgress(info, g.clock(), writeGracePeriod(g.config)); err != nil {
+		return nil, nil, err
+	}
+
+	data, err := g.fileReader.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		lineNum := i + 1
+
+		var sessionData GeminiSessionData
+		if err := unmarshalJSONLimited([]byte(line), &sessionData); err != nil {
+			lineErrors = append(lineErrors, fmt.Errorf("line %d: %w", lineNum, err))
+			continue
+		}
+
+		session := g.convertGeminiSessionToModel(sessionData, path)
+
+		// ID가 없는 줄은 파일 이름 대신 파일 위치와 내용 기반 ID 사용 (줄마다 구분되도록)
+		if sessionData.ID == "" {
+			session.ID = positionalSessionID("gemini-cli", session.Source, path, lineNum, firstMessageContent(session.Messages), parseRecordedTime(sessionData.CreatedAt))
+		}
+		if collectConfig.IncludeFiles {
+			session.Files = convertFileEntries(sessionData.Files)
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, lineErrors, nil
+}
+
+// parseSessionArchive는 tar 아카이브 안의 세션 파일들을 압축 해제 없이 파싱합니다.
+// 항목별 크기 제한을 넘거나 읽을 수 없는 항목은 entryErrors로 반환합니다.
+func (g *ImprovedGeminiCLICollector) parseSessionArchive(path string, collectConfig *models.CollectionConfig) (sessions []*models.SessionData, entryErrors []error, err error) {
+	file, err := g.fileReader.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+
+	var parseErrors []error
+	entryErrors, err = readTarEntries(file, path, maxFileSize, func(entryPath string, data []byte) {
+		session, parseErr := g.parseSessionBytes(data, entryPath, collectConfig)
+		if parseErr != nil {
+			parseErrors = append(parseErrors, fmt.Errorf("%s: %w", entryPath, parseErr))
+			return
+		}
+		session.Metadata["archive_path"] = path
+		sessions = append(sessions, session)
+	})
+	return sessions, append(entryErrors, parseErrors...), err
+}
+
+// parseSessionBytes는 세션 파일 내용을 세션 모델로 변환합니다.
+// 중첩 깊이가 maxJSONDepth를 넘는 JSON은 텍스트로 처리하지 않고 에러를 반환합니다.
+func (g *ImprovedGeminiCLICollector) parseSessionBytes(data []byte, path string, collectConfig *models.CollectionConfig) (*models.SessionData, error) {
 	// JSON 파싱
 	var sessionData GeminiSessionData
-	if err := json.Unmarshal(data, &sessionData); err != nil {
+	if err := unmarshalJSONLimited(data, &sessionData); err != nil {
+		if errors.Is(err, errJSONTooDeep) {
+			return nil, fmt.Errorf("failed to parse session: %w", err)
+		}
 		// JSON 파싱 실패 시 텍스트로 처리
 		return g.parseTextSession(string(data), path), nil
 	}
 
-	return g.convertGeminiSessionToModel(sessionData, path), nil
+	session := g.convertGeminiSessionToModel(sessionData, path)
+	if collectConfig.IncludeFiles {
+		session.Files = convertFileEntries(sessionData.Files)
+	}
+	return session, nil
 }
 
 // convertGeminiSessionToModel은 Gemini 세션 데이터를 모델로 변환
@@ -588,7 +1011,7 @@ func (g *ImprovedGeminiCLICollector) convertGeminiSessionToModel(geminiSession G
 	session := &models.SessionData{
 		ID:        geminiSession.ID,
 		Source:    models.SourceGeminiCLI,
-		Timestamp: time.Now(),
+		Timestamp: g.clock(),
 		Title:     geminiSession.Title,
 		Messages:  make([]models.Message, 0, len(geminiSession.Messages)),
 		Metadata:  make(map[string]string),
@@ -611,14 +1034,21 @@ func (g *ImprovedGeminiCLICollector) convertGeminiSessionToModel(geminiSession G
 	session.Metadata["model"] = geminiSession.Model
 	session.Metadata["source_type"] = "gemini_cli_session"
 
+	// 세션 설정 (모델, temperature, max_tokens)
+	if settings := geminiSession.Settings; settings != nil {
+		applyModelSettings(session.Metadata, settings.Model, settings.Temperature, settings.MaxTokens)
+	}
+
 	// 메시지 변환
 	for _, geminiMsg := range geminiSession.Messages {
 		msg := models.Message{
-			ID:        geminiMsg.ID,
-			Role:      geminiMsg.Role,
-			Content:   g.extractContentFromGeminiMessage(geminiMsg),
-			Timestamp: session.Timestamp,
-			Metadata:  make(map[string]string),
+			ID:          geminiMsg.ID,
+			Role:        geminiMsg.Role,
+			Content:     g.extractContentFromGeminiMessage(geminiMsg),
+			Timestamp:   session.Timestamp,
+			Sequence:    messageSequence(geminiMsg.Seq, geminiMsg.Index),
+			Metadata:    make(map[string]string),
+			Attachments: convertFileEntries(geminiMsg.Attachments),
 		}
 
 		// 메시지 타임스탬프 파싱
@@ -659,14 +1089,14 @@ func (g *ImprovedGeminiCLICollector) parseTextSession(content string, path strin
 	return &models.SessionData{
 		ID:        sessionID,
 		Source:    models.SourceGeminiCLI,
-		Timestamp: time.Now(),
+		Timestamp: g.clock(),
 		Title:     fmt.Sprintf("Gemini CLI Session: %s", fileName),
 		Messages: []models.Message{
 			{
 				ID:        fmt.Sprintf("%s-content", sessionID),
 				Role:      "user",
 				Content:   content,
-				Timestamp: time.Now(),
+				Timestamp: g.clock(),
 				Metadata:  map[string]string{"source_type": "gemini_cli_text"},
 			},
 		},
@@ -701,29 +1131,12 @@ func (g *ImprovedGeminiCLICollector) extractTitleFromPrompt(prompt string) strin
 
 // filterByDateRange는 날짜 범위 필터링
 func (g *ImprovedGeminiCLICollector) filterByDateRange(sessions []models.SessionData, dateRange *models.DateRange) []models.SessionData {
-	if dateRange == nil {
-		return sessions
-	}
-
-	filtered := make([]models.SessionData, 0, len(sessions))
-	for _, session := range sessions {
-		if g.isWithinDateRange(session.Timestamp, dateRange) {
-			filtered = append(filtered, session)
-		}
-	}
-
-	return filtered
+	return models.FilterSessionsByDateRange(sessions, dateRange)
 }
 
 // isWithinDateRange는 날짜가 범위 내에 있는지 확인
 func (g *ImprovedGeminiCLICollector) isWithinDateRange(timestamp time.Time, dateRange *models.DateRange) bool {
-	if !dateRange.Start.IsZero() && timestamp.Before(dateRange.Start) {
-		return false
-	}
-	if !dateRange.End.IsZero() && timestamp.After(dateRange.End) {
-		return false
-	}
-	return true
+	return dateRange.Contains(timestamp)
 }
 
 // GetSource는 소스 타입 반환
@@ -733,12 +1146,15 @@ func (g *ImprovedGeminiCLICollector) GetSource() models.CollectionSource {
 
 // Validate는 설정 검증
 func (g *ImprovedGeminiCLICollector) Validate() error {
+	if g.rewriter.err != nil {
+		return g.rewriter.err
+	}
 	return g.validateConfigDirectory()
 }
 
 // GetSupportedFormats는 지원 형식 반환
 func (g *ImprovedGeminiCLICollector) GetSupportedFormats() []string {
-	return []string{"json", "text", "jsonl"}
+	return []string{"json", "text", "jsonl", "ndjson"}
 }
 
 // min은 정수의 최솟값 반환 (Go 1.21 이전 버전 호환)
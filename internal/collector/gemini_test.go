@@ -1,8 +1,11 @@
 package collector
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -68,16 +71,30 @@ func (m *MockFileReader) WalkDir(root string, fn fs.WalkDirFunc) error {
 	return nil
 }
 
+func (m *MockFileReader) Open(filename string) (io.ReadCloser, error) {
+	if data, exists := m.files[filename]; exists {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	return nil, os.ErrNotExist
+}
+
 func (m *MockFileReader) AddFile(path string, content []byte) {
 	m.files[path] = content
 	m.stats[path] = MockFileInfo{
 		name:    filepath.Base(path),
 		size:    int64(len(content)),
-		modTime: time.Now(),
+		modTime: time.Now().Add(-time.Hour), // 쓰기 유예 시간에 걸리지 않는 다 쓴 파일
 		isDir:   false,
 	}
 }
 
+// SetModTime은 파일의 수정 시각을 바꿉니다
+func (m *MockFileReader) SetModTime(path string, modTime time.Time) {
+	info := m.stats[path].(MockFileInfo)
+	info.modTime = modTime
+	m.stats[path] = info
+}
+
 func (m *MockFileReader) AddDir(path string) {
 	m.stats[path] = MockFileInfo{
 		name:    filepath.Base(path),
@@ -146,6 +163,158 @@ func TestWithLogger(t *testing.T) {
 	}
 }
 
+func TestWithClock(t *testing.T) {
+	fixed := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	collector := NewImprovedGeminiCLICollector(config.CLIToolConfig{}).WithClock(func() time.Time { return fixed })
+
+	// 타임스탬프가 없는 엔트리는 주입된 시계 기준으로 생성되어야 함
+	session := collector.convertHistoryEntryToSession(GeminiHistoryEntry{
+		Prompt:   "Hello",
+		Response: "Hi",
+	}, "history.jsonl", 0)
+
+	if !session.Timestamp.Equal(fixed) {
+		t.Errorf("expected session timestamp %v, got %v", fixed, session.Timestamp)
+	}
+
+	if len(session.Messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(session.Messages))
+	}
+
+	if !session.Messages[0].Timestamp.Equal(fixed) {
+		t.Errorf("expected user message timestamp %v, got %v", fixed, session.Messages[0].Timestamp)
+	}
+
+	if !session.Messages[1].Timestamp.Equal(fixed.Add(time.Second)) {
+		t.Errorf("expected assistant message timestamp %v, got %v", fixed.Add(time.Second), session.Messages[1].Timestamp)
+	}
+}
+
+func TestConvertGeminiSessionToModel_Settings(t *testing.T) {
+	var geminiSession GeminiSessionData
+	raw := `{"id":"s1","title":"Settings","settings":{"model":"gemini-1.5-pro","temperature":0.7,"max_tokens":2048}}`
+	if err := json.Unmarshal([]byte(raw), &geminiSession); err != nil {
+		t.Fatalf("failed to unmarshal session: %v", err)
+	}
+
+	collector := NewImprovedGeminiCLICollector(config.CLIToolConfig{})
+	session := collector.convertGeminiSessionToModel(geminiSession, "/sessions/s1.json")
+
+	expected := map[string]string{
+		"model":       "gemini-1.5-pro",
+		"temperature": "0.7",
+		"max_tokens":  "2048",
+	}
+	for key, value := range expected {
+		if session.Metadata[key] != value {
+			t.Errorf("expected metadata %s=%q, got %q", key, value, session.Metadata[key])
+		}
+	}
+
+	// 세션 최상위 모델이 있으면 우선 사용
+	geminiSession.Model = "gemini-pro"
+	session = collector.convertGeminiSessionToModel(geminiSession, "/sessions/s1.json")
+	if session.Metadata["model"] != "gemini-pro" {
+		t.Errorf("expected top-level model to win, got %q", session.Metadata["model"])
+	}
+}
+
+func TestConvertGeminiSessionToModel_Sequence(t *testing.T) {
+	var geminiSession GeminiSessionData
+	raw := `{"id":"s1","messages":[
+		{"role":"assistant","content":"b","timestamp":"2024-01-01T10:00:00Z","seq":2},
+		{"role":"user","content":"a","timestamp":"2024-01-01T10:00:00Z","index":1},
+		{"role":"user","content":"c","timestamp":"2024-01-01T10:00:00Z"}
+	]}`
+	if err := json.Unmarshal([]byte(raw), &geminiSession); err != nil {
+		t.Fatalf("failed to unmarshal session: %v", err)
+	}
+
+	session := NewImprovedGeminiCLICollector(config.CLIToolConfig{}).convertGeminiSessionToModel(geminiSession, "/sessions/s1.json")
+
+	if got := session.Messages[0].Sequence; got == nil || *got != 2 {
+		t.Errorf("expected seq 2, got %v", got)
+	}
+	if got := session.Messages[1].Sequence; got == nil || *got != 1 {
+		t.Errorf("expected index 1 to be used as sequence, got %v", got)
+	}
+	if session.Messages[2].Sequence != nil {
+		t.Errorf("expected no sequence, got %d", *session.Messages[2].Sequence)
+	}
+}
+
+func TestConvertGeminiSessionToModel_Attachments(t *testing.T) {
+	var geminiSession GeminiSessionData
+	raw := `{"id":"s1","messages":[
+		{"role":"user","content":"이 화면 봐줘","attachments":[{"path":"/tmp/screen.png","size":2048,"content_type":"image/png"},"/docs/spec.pdf"]},
+		{"role":"assistant","content":"확인했습니다"}
+	]}`
+	if err := json.Unmarshal([]byte(raw), &geminiSession); err != nil {
+		t.Fatalf("failed to unmarshal session: %v", err)
+	}
+
+	session := NewImprovedGeminiCLICollector(config.CLIToolConfig{}).convertGeminiSessionToModel(geminiSession, "/sessions/s1.json")
+
+	attachments := session.Messages[0].Attachments
+	if len(attachments) != 2 {
+		t.Fatalf("expected 2 attachments, got %+v", attachments)
+	}
+	if attachments[0].Name != "screen.png" || attachments[0].Size != 2048 || attachments[0].ContentType != "image/png" {
+		t.Errorf("unexpected first attachment: %+v", attachments[0])
+	}
+	if attachments[1].Path != "/docs/spec.pdf" || attachments[1].Name != "spec.pdf" {
+		t.Errorf("unexpected second attachment: %+v", attachments[1])
+	}
+	if session.Messages[1].Attachments != nil {
+		t.Errorf("expected no attachments on second message, got %+v", session.Messages[1].Attachments)
+	}
+}
+
+func TestParseSessionFileSafe_FileReferences(t *testing.T) {
+	mockReader := NewMockFileReader()
+	sessionJSON := `{
+		"id": "session-files",
+		"title": "Files",
+		"files": [
+			{"path": "/work/app/main.go", "size": 120, "mod_time": "2024-01-01T10:00:00Z", "content_type": "text/x-go"},
+			"/work/app/README.md",
+			{"name": "missing-path.txt"}
+		]
+	}`
+	mockReader.AddFile("/test/sessions/files.json", []byte(sessionJSON))
+
+	collector := NewImprovedGeminiCLICollector(config.CLIToolConfig{}).WithFileReader(mockReader)
+
+	session, err := collector.parseSessionFileSafe("/test/sessions/files.json", &models.CollectionConfig{IncludeFiles: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(session.Files) != 2 {
+		t.Fatalf("expected 2 file references, got %d", len(session.Files))
+	}
+
+	first := session.Files[0]
+	if first.Path != "/work/app/main.go" || first.Name != "main.go" || first.Size != 120 || first.ContentType != "text/x-go" {
+		t.Errorf("unexpected first file reference: %+v", first)
+	}
+	if !first.ModTime.Equal(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected mod time: %v", first.ModTime)
+	}
+	if session.Files[1].Path != "/work/app/README.md" || session.Files[1].Name != "README.md" {
+		t.Errorf("unexpected second file reference: %+v", session.Files[1])
+	}
+
+	// IncludeFiles가 꺼져 있으면 파일 참조를 수집하지 않음
+	session, err = collector.parseSessionFileSafe("/test/sessions/files.json", &models.CollectionConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(session.Files) != 0 {
+		t.Errorf("expected no file references without IncludeFiles, got %d", len(session.Files))
+	}
+}
+
 func TestCollectWithNilConfig(t *testing.T) {
 	collector := NewImprovedGeminiCLICollector(config.CLIToolConfig{})
 	
@@ -455,7 +624,7 @@ func TestGetSupportedFormats(t *testing.T) {
 	collector := NewImprovedGeminiCLICollector(config.CLIToolConfig{})
 	
 	formats := collector.GetSupportedFormats()
-	expectedFormats := []string{"json", "text", "jsonl"}
+	expectedFormats := []string{"json", "text", "jsonl", "ndjson"}
 	
 	if len(formats) != len(expectedFormats) {
 		t.Fatalf("expected %d formats, got %d", len(expectedFormats), len(formats))
@@ -490,6 +659,65 @@ func TestExtractTitleFromPrompt(t *testing.T) {
 	}
 }
 
+func TestCollectFromHistoryWithOversizedLine(t *testing.T) {
+	mockReader := NewMockFileReader()
+	mockLogger := &MockLogger{}
+
+	// 64KB를 넘는 라인 앞뒤로 정상 라인 배치
+	oversized := fmt.Sprintf(`{"id":"huge","prompt":"%s","timestamp":"2024-01-01T10:00:00Z"}`, strings.Repeat("a", 70*1024))
+	historyContent := strings.Join([]string{
+		`{"id":"before","prompt":"Before","response":"ok","timestamp":"2024-01-01T09:00:00Z"}`,
+		oversized,
+		`{"id":"after","prompt":"After","response":"ok","timestamp":"2024-01-01T11:00:00Z"}`,
+	}, "\n")
+
+	historyPath := "/test/history.jsonl"
+	mockReader.AddFile(historyPath, []byte(historyContent))
+	mockReader.AddDir("/test")
+
+	collector := NewImprovedGeminiCLICollector(config.CLIToolConfig{
+		ConfigDir:   "/test",
+		HistoryFile: historyPath,
+		MaxLineSize: 64 * 1024,
+	}).WithFileReader(mockReader).WithLogger(mockLogger)
+
+	sessions, err := collector.parseHistoryFileStreaming(context.Background(), historyPath, &models.CollectionConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(sessions))
+	}
+	if sessions[0].ID != "before" || sessions[1].ID != "after" {
+		t.Errorf("unexpected session IDs: %s, %s", sessions[0].ID, sessions[1].ID)
+	}
+
+	warned := false
+	for _, log := range mockLogger.logs {
+		if strings.Contains(log, "Skipping history line 2") {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Errorf("expected warning for oversized line, got logs: %v", mockLogger.logs)
+	}
+
+	// 기본 최대 길이에서는 같은 라인이 정상 파싱되어야 함
+	collector = NewImprovedGeminiCLICollector(config.CLIToolConfig{
+		ConfigDir:   "/test",
+		HistoryFile: historyPath,
+	}).WithFileReader(mockReader).WithLogger(&MockLogger{})
+
+	sessions, err = collector.parseHistoryFileStreaming(context.Background(), historyPath, &models.CollectionConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sessions) != 3 {
+		t.Errorf("expected 3 sessions with default max line size, got %d", len(sessions))
+	}
+}
+
 // 벤치마크 테스트
 func BenchmarkCollectFromHistory(b *testing.B) {
 	mockReader := NewMockFileReader()
@@ -524,4 +752,122 @@ func BenchmarkCollectFromHistory(b *testing.B) {
 			b.Fatalf("unexpected error: %v", err)
 		}
 	}
-}
\ No newline at end of file
+}
+func TestCollectFromHistoryReportsRejectedLines(t *testing.T) {
+	lines := []string{
+		`{"id":"ok-1","prompt":"First","response":"ok","timestamp":"2024-01-01T09:00:00Z"}`,
+	}
+	// 알 수 없는 필드가 있는 라인 6개와 깨진 JSON 1개
+	for i := 0; i < 6; i++ {
+		lines = append(lines, fmt.Sprintf(`{"id":"extra-%d","prompt":"p","unexpected":true}`, i))
+	}
+	lines = append(lines, `{"id":"broken"`)
+	lines = append(lines, `{"id":"ok-2","prompt":"Last","response":"ok","timestamp":"2024-01-01T10:00:00Z"}`)
+
+	historyPath := "/test/history.jsonl"
+	mockReader := NewMockFileReader()
+	mockReader.AddFile(historyPath, []byte(strings.Join(lines, "\n")))
+	mockReader.AddDir("/test")
+
+	newCollector := func() *ImprovedGeminiCLICollector {
+		return NewImprovedGeminiCLICollector(config.CLIToolConfig{
+			ConfigDir:   "/test",
+			HistoryFile: historyPath,
+		}).WithFileReader(mockReader).WithLogger(&MockLogger{})
+	}
+
+	collector := newCollector()
+	sessions, err := collector.Collect(context.Background(), &models.CollectionConfig{ReportRejected: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 valid sessions, got %d", len(sessions))
+	}
+
+	reports := collector.RejectedLines()
+	if len(reports) != 1 {
+		t.Fatalf("expected 1 rejection report, got %d", len(reports))
+	}
+	if reports[0].File != historyPath {
+		t.Errorf("expected report for %s, got %s", historyPath, reports[0].File)
+	}
+	if reports[0].Count != 7 {
+		t.Errorf("expected 7 rejected lines, got %d", reports[0].Count)
+	}
+	wantSamples := []int{2, 3, 4, 5, 6}
+	if fmt.Sprint(reports[0].SampleLines) != fmt.Sprint(wantSamples) {
+		t.Errorf("expected sample lines %v, got %v", wantSamples, reports[0].SampleLines)
+	}
+
+	// 옵션이 꺼져 있으면 기록하지 않음
+	collector = newCollector()
+	if _, err := collector.Collect(context.Background(), &models.CollectionConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reports := collector.RejectedLines(); len(reports) != 0 {
+		t.Errorf("expected no reports without ReportRejected, got %v", reports)
+	}
+}
+
+func TestCollect_EmitSourceTypeMetadata(t *testing.T) {
+	newCollector := func(emit *bool) *ImprovedGeminiCLICollector {
+		mockReader := NewMockFileReader()
+		mockReader.AddDir("/test")
+		mockReader.AddDir("/test/sessions")
+		mockReader.AddFile("/test/sessions/session.json",
+			[]byte(`{"id": "s1", "messages": [{"role": "user", "content": "hello"}]}`))
+
+		return NewImprovedGeminiCLICollector(config.CLIToolConfig{
+			ConfigDir:              "/test",
+			SessionDir:             "/test/sessions",
+			EmitSourceTypeMetadata: emit,
+		}).WithFileReader(mockReader).WithLogger(&MockLogger{})
+	}
+	collectConfig := &models.CollectionConfig{Sources: []models.CollectionSource{models.SourceGeminiCLI}}
+
+	sessions, err := newCollector(nil).Collect(context.Background(), collectConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].Metadata["source_type"] != "gemini_cli_session" {
+		t.Fatalf("expected source_type metadata by default, got %+v", sessions)
+	}
+
+	disabled := false
+	sessions, err = newCollector(&disabled).Collect(context.Background(), collectConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(sessions))
+	}
+	if _, ok := sessions[0].Metadata["source_type"]; ok {
+		t.Errorf("expected source_type metadata to be omitted, got %q", sessions[0].Metadata["source_type"])
+	}
+	if sessions[0].Metadata["file_path"] == "" {
+		t.Errorf("expected other metadata to be kept, got %v", sessions[0].Metadata)
+	}
+}
+
+func TestOmitSourceTypeMetadata(t *testing.T) {
+	sessions := []models.SessionData{{
+		Metadata: map[string]string{"source_type": "amazon_q_history", "model": "q"},
+		Messages: []models.Message{
+			{Metadata: map[string]string{"source_type": "amazon_q_text"}},
+			{},
+		},
+	}}
+
+	omitSourceTypeMetadata(sessions)
+
+	if _, ok := sessions[0].Metadata["source_type"]; ok {
+		t.Errorf("expected session source_type to be removed")
+	}
+	if sessions[0].Metadata["model"] != "q" {
+		t.Errorf("expected other session metadata to be kept")
+	}
+	if _, ok := sessions[0].Messages[0].Metadata["source_type"]; ok {
+		t.Errorf("expected message source_type to be removed")
+	}
+}
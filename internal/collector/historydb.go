@@ -0,0 +1,163 @@
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"ssamai/internal/config"
+
+	_ "modernc.org/sqlite"
+)
+
+// historyDBDriver는 히스토리 데이터베이스를 여는 database/sql 드라이버 이름입니다
+const historyDBDriver = "sqlite"
+
+// historyDBTimeLayouts는 텍스트로 저장된 타임스탬프에 시도하는 형식입니다 (SQLite CURRENT_TIMESTAMP 포함)
+var historyDBTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05",
+}
+
+// historyDBMessage는 히스토리 데이터베이스의 한 행입니다 (Timestamp는 RFC3339 문자열, 알 수 없으면 빈 문자열)
+type historyDBMessage struct {
+	Role      string
+	Content   string
+	Timestamp string
+}
+
+// historyDBSession은 같은 세션 ID의 행들을 시간순으로 묶은 것입니다
+type historyDBSession struct {
+	ID       string
+	Messages []historyDBMessage
+}
+
+// CreatedAt은 세션 첫 메시지의 타임스탬프를 반환합니다
+func (s historyDBSession) CreatedAt() string {
+	for _, message := range s.Messages {
+		if message.Timestamp != "" {
+			return message.Timestamp
+		}
+	}
+	return ""
+}
+
+// historyDBQuery는 매핑 설정으로 메시지 행을 조회하는 쿼리를 만듭니다
+// 이름은 config.HistoryDBConfig.Validate에서 식별자 형식으로 검증되며, 여기서는 따옴표로 감쌉니다
+func historyDBQuery(cfg config.HistoryDBConfig) string {
+	return fmt.Sprintf(`SELECT "%s", "%s", "%s", "%s" FROM "%s" ORDER BY "%s", "%s"`,
+		cfg.SessionColumn, cfg.RoleColumn, cfg.ContentColumn, cfg.TimestampColumn,
+		cfg.Table, cfg.SessionColumn, cfg.TimestampColumn)
+}
+
+// queryHistoryDB는 SQLite 히스토리 데이터베이스에서 메시지를 읽어 세션별로 묶습니다
+// 세션은 데이터베이스에서 처음 나온 순서(세션 ID 순)로 반환합니다
+func queryHistoryDB(ctx context.Context, dbConfig config.HistoryDBConfig) ([]historyDBSession, string, error) {
+	if err := dbConfig.Validate(); err != nil {
+		return nil, "", err
+	}
+	dbConfig = dbConfig.WithDefaults()
+
+	path, err := config.ExpandPath(dbConfig.Path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to expand history database path: %w", err)
+	}
+
+	// 없는 경로를 열면 SQLite가 빈 데이터베이스 파일을 만들므로 먼저 확인하고 읽기 전용으로 엶
+	if _, err := os.Stat(path); err != nil {
+		return nil, "", fmt.Errorf("history database not found: %w", err)
+	}
+
+	db, err := sql.Open(historyDBDriver, readOnlyDBURI(path))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open history database: %w", err)
+	}
+	defer db.Close()
+
+	sessions, err := readHistoryDB(ctx, db, dbConfig)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read history database %s: %w", path, err)
+	}
+	return sessions, path, nil
+}
+
+// readOnlyDBURI는 SQLite 데이터베이스를 읽기 전용으로 여는 file: URI를 반환합니다
+func readOnlyDBURI(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path // Windows 드라이브 경로 (file:///C:/...)
+	}
+	return (&url.URL{Scheme: "file", Path: path, RawQuery: "mode=ro"}).String()
+}
+
+// readHistoryDB는 열린 데이터베이스에서 매핑 설정대로 메시지 행을 읽습니다
+func readHistoryDB(ctx context.Context, db *sql.DB, dbConfig config.HistoryDBConfig) ([]historyDBSession, error) {
+	rows, err := db.QueryContext(ctx, historyDBQuery(dbConfig))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []historyDBSession
+	index := make(map[string]int)
+	for rows.Next() {
+		var sessionID, role, content sql.NullString
+		var timestamp interface{}
+		if err := rows.Scan(&sessionID, &role, &content, &timestamp); err != nil {
+			return nil, err
+		}
+		if !sessionID.Valid || sessionID.String == "" {
+			continue
+		}
+
+		i, ok := index[sessionID.String]
+		if !ok {
+			i = len(sessions)
+			index[sessionID.String] = i
+			sessions = append(sessions, historyDBSession{ID: sessionID.String})
+		}
+		sessions[i].Messages = append(sessions[i].Messages, historyDBMessage{
+			Role:      role.String,
+			Content:   content.String,
+			Timestamp: historyDBTimestamp(timestamp),
+		})
+	}
+	return sessions, rows.Err()
+}
+
+// historyDBTimestamp는 타임스탬프 컬럼 값을 RFC3339 문자열로 변환합니다
+// 정수/실수는 Unix 초로 보고, 해석할 수 없는 값은 빈 문자열을 반환합니다
+func historyDBTimestamp(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case int64:
+		return time.Unix(v, 0).UTC().Format(time.RFC3339)
+	case float64:
+		return time.Unix(int64(v), 0).UTC().Format(time.RFC3339)
+	case []byte:
+		return historyDBTimestamp(string(v))
+	case string:
+		text := strings.TrimSpace(v)
+		if seconds, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return historyDBTimestamp(seconds)
+		}
+		for _, layout := range historyDBTimeLayouts {
+			if parsed, err := time.Parse(layout, text); err == nil {
+				return parsed.Format(time.RFC3339)
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,212 @@
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ssamai/internal/config"
+	"ssamai/pkg/models"
+)
+
+// createHistoryDB는 테스트용 SQLite 데이터베이스를 만들고 경로를 반환합니다
+func createHistoryDB(t *testing.T, statements ...string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "history.db")
+	db, err := sql.Open(historyDBDriver, path)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("failed to execute %q: %v", statement, err)
+		}
+	}
+	return path
+}
+
+func TestQueryHistoryDB_GroupsRowsBySession(t *testing.T) {
+	path := createHistoryDB(t,
+		`CREATE TABLE messages (session_id TEXT, role TEXT, content TEXT, timestamp TEXT)`,
+		`INSERT INTO messages VALUES
+			('s1', 'assistant', '안녕하세요', '2024-01-01 10:00:05'),
+			('s1', 'user', '인사해줘', '2024-01-01 10:00:00'),
+			('s2', 'user', 'Hello', '2024-01-02T09:00:00Z')`,
+	)
+
+	sessions, dbPath, err := queryHistoryDB(context.Background(), config.HistoryDBConfig{Path: path})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dbPath != path {
+		t.Errorf("expected path %s, got %s", path, dbPath)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(sessions))
+	}
+
+	first := sessions[0]
+	if first.ID != "s1" || len(first.Messages) != 2 {
+		t.Fatalf("unexpected first session: %+v", first)
+	}
+	if first.Messages[0].Role != "user" || first.Messages[0].Content != "인사해줘" {
+		t.Errorf("expected messages ordered by timestamp, got %+v", first.Messages)
+	}
+	if first.CreatedAt() != "2024-01-01T10:00:00Z" {
+		t.Errorf("unexpected created_at: %s", first.CreatedAt())
+	}
+	if sessions[1].CreatedAt() != "2024-01-02T09:00:00Z" {
+		t.Errorf("unexpected created_at: %s", sessions[1].CreatedAt())
+	}
+}
+
+func TestQueryHistoryDB_CustomMapping(t *testing.T) {
+	path := createHistoryDB(t,
+		`CREATE TABLE chat_log (conversation TEXT, speaker TEXT, body TEXT, sent_at INTEGER)`,
+		`INSERT INTO chat_log VALUES ('c1', 'user', 'Explain goroutines', 1704103200)`,
+	)
+
+	sessions, _, err := queryHistoryDB(context.Background(), config.HistoryDBConfig{
+		Path:            path,
+		Table:           "chat_log",
+		SessionColumn:   "conversation",
+		RoleColumn:      "speaker",
+		ContentColumn:   "body",
+		TimestampColumn: "sent_at",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sessions) != 1 || len(sessions[0].Messages) != 1 {
+		t.Fatalf("expected 1 session with 1 message, got %+v", sessions)
+	}
+	if got := sessions[0].Messages[0].Timestamp; got != "2024-01-01T10:00:00Z" {
+		t.Errorf("expected unix timestamp to be converted, got %s", got)
+	}
+}
+
+func TestQueryHistoryDB_MissingTable(t *testing.T) {
+	path := createHistoryDB(t, `CREATE TABLE other (id INTEGER)`)
+
+	if _, _, err := queryHistoryDB(context.Background(), config.HistoryDBConfig{Path: path}); err == nil {
+		t.Error("expected error for missing table")
+	}
+}
+
+func TestQueryHistoryDB_MissingFileNotCreated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "typo", "histroy.db")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err := queryHistoryDB(context.Background(), config.HistoryDBConfig{Path: path})
+	if err == nil || !strings.Contains(err.Error(), "history database not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if _, statErr := os.Stat(path); !os.IsNotExist(statErr) {
+		t.Errorf("expected no database file to be created, stat err: %v", statErr)
+	}
+}
+
+func TestQueryHistoryDB_OpensReadOnly(t *testing.T) {
+	path := createHistoryDB(t, `CREATE TABLE messages (session_id TEXT, role TEXT, content TEXT, timestamp TEXT)`)
+
+	db, err := sql.Open(historyDBDriver, readOnlyDBURI(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(`INSERT INTO messages VALUES ('s1', 'user', 'hi', '')`); err == nil {
+		t.Error("expected write to read-only database to fail")
+	}
+}
+
+func TestHistoryDBTimestamp(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected string
+	}{
+		{"2024-01-01T10:00:00Z", "2024-01-01T10:00:00Z"},
+		{"2024-01-01 10:00:00", "2024-01-01T10:00:00Z"},
+		{[]byte("2024-01-01 10:00:00.5"), "2024-01-01T10:00:00Z"},
+		{int64(1704103200), "2024-01-01T10:00:00Z"},
+		{"1704103200", "2024-01-01T10:00:00Z"},
+		{"not a time", ""},
+		{nil, ""},
+	}
+
+	for _, tt := range tests {
+		if got := historyDBTimestamp(tt.value); got != tt.expected {
+			t.Errorf("historyDBTimestamp(%v) = %q, expected %q", tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestGeminiCollect_HistoryDB(t *testing.T) {
+	path := createHistoryDB(t,
+		`CREATE TABLE messages (session_id TEXT, role TEXT, content TEXT, timestamp TEXT)`,
+		`INSERT INTO messages VALUES
+			('db-1', 'user', 'SQLite 사용법 알려줘', '2024-01-01T10:00:00Z'),
+			('db-1', 'assistant', '다음과 같이 사용합니다', '2024-01-01T10:00:03Z')`,
+	)
+
+	mockReader := NewMockFileReader()
+	mockReader.AddDir("/test")
+
+	collector := NewImprovedGeminiCLICollector(config.CLIToolConfig{
+		ConfigDir: "/test",
+		HistoryDB: &config.HistoryDBConfig{Path: path},
+	}).WithFileReader(mockReader).WithLogger(&MockLogger{})
+
+	sessions, err := collector.Collect(context.Background(), &models.CollectionConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var found *models.SessionData
+	for i := range sessions {
+		if sessions[i].ID == "db-1" {
+			found = &sessions[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("expected session from history database, got %v", sessionIDs(sessions))
+	}
+	if len(found.Messages) != 2 {
+		t.Errorf("expected 2 messages, got %d", len(found.Messages))
+	}
+	if found.Title != "SQLite 사용법 알려줘" {
+		t.Errorf("unexpected title: %s", found.Title)
+	}
+	if found.Metadata["source_type"] != "gemini_cli_history_db" {
+		t.Errorf("unexpected source_type: %s", found.Metadata["source_type"])
+	}
+}
+
+func TestClaudeCollectFromHistoryDB(t *testing.T) {
+	path := createHistoryDB(t,
+		`CREATE TABLE messages (session_id TEXT, role TEXT, content TEXT, timestamp TEXT)`,
+		`INSERT INTO messages VALUES ('claude-1', 'user', 'Refactor this', '2024-01-01T10:00:00Z')`,
+	)
+
+	collector := NewClaudeCodeCollector(config.CLIToolConfig{
+		HistoryDB: &config.HistoryDBConfig{Path: path},
+	})
+
+	sessions, err := collector.collectFromHistoryDB(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != "claude-1" {
+		t.Fatalf("unexpected sessions: %v", sessionIDs(sessions))
+	}
+	if sessions[0].Metadata["file_path"] != path {
+		t.Errorf("expected file_path metadata %s, got %s", path, sessions[0].Metadata["file_path"])
+	}
+}
@@ -0,0 +1,37 @@
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"ssamai/internal/config"
+)
+
+// defaultWriteGracePeriod는 수정된 지 이 시간이 지나지 않은 세션 파일을 쓰는 중으로 보는 기본값입니다
+const defaultWriteGracePeriod = time.Second
+
+// errFileInProgress는 도구가 아직 쓰고 있는 것으로 보이는 세션 파일을 건너뛸 때 반환됩니다
+var errFileInProgress = errors.New("file is still being written")
+
+// writeGracePeriod는 설정된 쓰기 유예 시간을 반환합니다 (0이면 기본값, 음수면 검사하지 않음)
+func writeGracePeriod(cfg config.CLIToolConfig) time.Duration {
+	if cfg.WriteGracePeriod == 0 {
+		return defaultWriteGracePeriod
+	}
+	return cfg.WriteGracePeriod
+}
+
+// checkWriteInProgress는 파일이 유예 시간 안에 수정되었으면 errFileInProgress를 반환합니다
+// 수정 시각이 now보다 미래인 경우(시계 차이)는 쓰는 중으로 보지 않습니다
+func checkWriteInProgress(info os.FileInfo, now time.Time, grace time.Duration) error {
+	if grace <= 0 {
+		return nil
+	}
+	age := now.Sub(info.ModTime())
+	if age >= 0 && age < grace {
+		return fmt.Errorf("%w: modified %s ago", errFileInProgress, age.Round(time.Millisecond))
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ssamai/internal/config"
+	"ssamai/pkg/models"
+)
+
+func TestCheckWriteInProgress(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		modTime time.Time
+		grace   time.Duration
+		want    bool
+	}{
+		{name: "just written", modTime: now, grace: time.Second, want: true},
+		{name: "within grace", modTime: now.Add(-500 * time.Millisecond), grace: time.Second, want: true},
+		{name: "settled", modTime: now.Add(-time.Second), grace: time.Second, want: false},
+		{name: "future modtime", modTime: now.Add(time.Minute), grace: time.Second, want: false},
+		{name: "check disabled", modTime: now, grace: -1, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkWriteInProgress(MockFileInfo{modTime: tt.modTime}, now, tt.grace)
+			if got := errors.Is(err, errFileInProgress); got != tt.want {
+				t.Errorf("in progress = %v (err=%v), want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteGracePeriod(t *testing.T) {
+	if got := writeGracePeriod(config.CLIToolConfig{}); got != defaultWriteGracePeriod {
+		t.Errorf("default grace period = %v, want %v", got, defaultWriteGracePeriod)
+	}
+	if got := writeGracePeriod(config.CLIToolConfig{WriteGracePeriod: 5 * time.Second}); got != 5*time.Second {
+		t.Errorf("configured grace period = %v, want 5s", got)
+	}
+}
+
+func TestCollect_SkipsFilesBeingWritten(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	sessionDir := "/test/sessions"
+
+	mockReader := NewMockFileReader()
+	mockReader.AddDir("/test")
+	mockReader.AddDir(sessionDir)
+	mockReader.AddFile(filepath.Join(sessionDir, "done.json"), []byte(`{"id": "done", "messages": []}`))
+	mockReader.AddFile(filepath.Join(sessionDir, "writing.json"), []byte(`{"id": "writing", "messa`))
+	mockReader.SetModTime(filepath.Join(sessionDir, "done.json"), now.Add(-time.Minute))
+	mockReader.SetModTime(filepath.Join(sessionDir, "writing.json"), now)
+
+	mockLogger := &MockLogger{}
+	collector := NewImprovedGeminiCLICollector(config.CLIToolConfig{ConfigDir: "/test", SessionDir: sessionDir}).
+		WithFileReader(mockReader).
+		WithLogger(mockLogger).
+		WithClock(func() time.Time { return now })
+
+	sessions, err := collector.Collect(context.Background(), &models.CollectionConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != "done" {
+		t.Fatalf("expected only the settled session, got %+v", sessions)
+	}
+
+	logged := strings.Join(mockLogger.logs, "\n")
+	if !strings.Contains(logged, "INFO: Skipping session file being written") || !strings.Contains(logged, "writing.json") {
+		t.Errorf("expected skipped file to be logged for a later run, got logs:\n%s", logged)
+	}
+	if strings.Contains(logged, "WARN") {
+		t.Errorf("skipped file should not be reported as a warning, got logs:\n%s", logged)
+	}
+}
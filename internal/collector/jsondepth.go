@@ -0,0 +1,54 @@
+package collector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// errJSONTooDeep는 JSON 중첩 깊이가 maxJSONDepth를 넘을 때 반환됩니다
+var errJSONTooDeep = errors.New("JSON nesting too deep")
+
+// checkJSONDepth는 전체를 디코딩하기 전에 JSON 문서의 중첩 깊이가 max를 넘지 않는지 확인합니다.
+// 문자열 안의 괄호는 세지 않으며, 문법 오류는 이후 디코더가 보고하도록 그대로 둡니다.
+func checkJSONDepth(data []byte, max int) error {
+	depth := 0
+	inString, escaped := false, false
+
+	for _, b := range data {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case b == '\\':
+				escaped = true
+			case b == '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch b {
+		case '"':
+			inString = true
+		case '{', '[':
+			depth++
+			if depth > max {
+				return fmt.Errorf("%w: more than %d levels", errJSONTooDeep, max)
+			}
+		case '}', ']':
+			if depth > 0 {
+				depth--
+			}
+		}
+	}
+	return nil
+}
+
+// unmarshalJSONLimited는 중첩 깊이를 maxJSONDepth로 제한한 json.Unmarshal입니다
+func unmarshalJSONLimited(data []byte, v interface{}) error {
+	if err := checkJSONDepth(data, maxJSONDepth); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,141 @@
+package collector
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"ssamai/pkg/models"
+)
+
+// applyModelSettings는 세션 설정(모델, temperature, max_tokens)을 세션 메타데이터에 추가합니다
+// 값이 비어 있거나 0인 항목과 이미 설정된 키는 건너뜁니다
+func applyModelSettings(metadata map[string]string, model string, temperature float64, maxTokens int) {
+	setIfEmpty := func(key, value string) {
+		if value == "" || metadata[key] != "" {
+			return
+		}
+		metadata[key] = value
+	}
+
+	setIfEmpty("model", model)
+	if temperature != 0 {
+		setIfEmpty("temperature", strconv.FormatFloat(temperature, 'f', -1, 64))
+	}
+	if maxTokens > 0 {
+		setIfEmpty("max_tokens", strconv.Itoa(maxTokens))
+	}
+}
+
+// sourceTypeMetadataKey는 수집기가 데이터 출처 종류를 기록하는 내부 메타데이터 키입니다
+const sourceTypeMetadataKey = "source_type"
+
+// omitSourceTypeMetadata는 세션과 메시지 메타데이터에서 source_type 키를 제거합니다
+func omitSourceTypeMetadata(sessions []models.SessionData) {
+	for i := range sessions {
+		delete(sessions[i].Metadata, sourceTypeMetadataKey)
+		for j := range sessions[i].Messages {
+			delete(sessions[i].Messages[j].Metadata, sourceTypeMetadataKey)
+		}
+	}
+}
+
+// messageSequence는 메시지의 명시적 순번을 반환합니다. seq를 우선하고 없으면 index를 사용합니다
+func messageSequence(seq, index *int) *int {
+	if seq != nil {
+		return seq
+	}
+	return index
+}
+
+// sequenceFromMap은 맵 형태 메시지의 seq 또는 index 숫자 필드를 순번으로 읽습니다
+// 정수가 아닌 값은 무시합니다
+func sequenceFromMap(msgMap map[string]interface{}) *int {
+	for _, key := range []string{"seq", "index"} {
+		if value, ok := msgMap[key].(float64); ok && value == float64(int(value)) {
+			sequence := int(value)
+			return &sequence
+		}
+	}
+	return nil
+}
+
+// SessionFileEntry는 세션 JSON에 포함된 첨부 파일 또는 작업 공간 경로 항목입니다
+// 객체 형식({"path": ..., "size": ...})과 경로 문자열 형식을 모두 지원합니다
+type SessionFileEntry struct {
+	Path        string `json:"path"`
+	Name        string `json:"name"`
+	Size        int64  `json:"size"`
+	ModTime     string `json:"mod_time"`
+	ContentType string `json:"content_type"`
+}
+
+// UnmarshalJSON은 경로 문자열만 있는 항목도 허용합니다
+func (f *SessionFileEntry) UnmarshalJSON(data []byte) error {
+	var path string
+	if err := json.Unmarshal(data, &path); err == nil {
+		*f = SessionFileEntry{Path: path}
+		return nil
+	}
+
+	type rawEntry SessionFileEntry
+	var entry rawEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return err
+	}
+	*f = SessionFileEntry(entry)
+	return nil
+}
+
+// attachmentsFromMap은 메시지 맵의 attachments 항목을 파일 참조로 변환합니다
+// 형식이 맞지 않으면 첨부 파일 없이 nil을 반환합니다
+func attachmentsFromMap(msgMap map[string]interface{}) []models.FileReference {
+	raw, ok := msgMap["attachments"]
+	if !ok {
+		return nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+	var entries []SessionFileEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil
+	}
+	return convertFileEntries(entries)
+}
+
+// convertFileEntries는 세션 파일 항목을 모델의 파일 참조로 변환합니다
+// 경로가 없는 항목은 건너뜁니다
+func convertFileEntries(entries []SessionFileEntry) []models.FileReference {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	files := make([]models.FileReference, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Path == "" {
+			continue
+		}
+
+		file := models.FileReference{
+			Path:        entry.Path,
+			Name:        entry.Name,
+			Size:        entry.Size,
+			ContentType: entry.ContentType,
+		}
+		if file.Name == "" {
+			file.Name = filepath.Base(entry.Path)
+		}
+		if entry.ModTime != "" {
+			if modTime, err := time.Parse(time.RFC3339, entry.ModTime); err == nil {
+				file.ModTime = modTime
+			}
+		}
+		files = append(files, file)
+	}
+
+	return files
+}
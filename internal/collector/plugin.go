@@ -0,0 +1,49 @@
+package collector
+
+import (
+	"ssamai/pkg/models"
+)
+
+// factoryRegistry는 패키지 팩토리 레지스트리를 models.CollectorRegistry로 노출합니다
+// 플러그인 관리자가 로드한 수집기를 GetCollector로 바로 사용할 수 있게 합니다
+type factoryRegistry struct{}
+
+var _ models.CollectorRegistry = factoryRegistry{}
+
+// NewPluginManager는 로드한 수집기 플러그인을 팩토리에 등록하는 플러그인 관리자를 생성합니다
+func NewPluginManager() models.PluginManager {
+	return models.NewPluginManagerWithRegistry(factoryRegistry{})
+}
+
+// LoadPlugin은 .so 수집기 플러그인을 로드하고 지원 소스들을 팩토리에 등록합니다
+// 라이브러리용 API로, CLI(collect --sources)는 아직 플러그인을 로드하지 않고 기본 소스만 받습니다
+// 로드한 소스의 수집기는 GetCollector로 직접 생성해 사용합니다
+func LoadPlugin(path string) (models.Plugin, error) {
+	return NewPluginManager().LoadPlugin(path)
+}
+
+// Register는 CollectorFactory를 팩토리에 등록합니다 (Create 에러는 GetCollector가 그대로 반환)
+func (factoryRegistry) Register(source models.CollectionSource, factory models.CollectorFactory) {
+	register(source, factory.Create)
+}
+
+// Get은 기본 설정(nil)으로 소스의 Collector를 생성합니다
+func (factoryRegistry) Get(source models.CollectionSource) (models.Collector, error) {
+	return GetCollector(source, nil)
+}
+
+// GetAll은 등록된 모든 소스의 Collector를 기본 설정(nil)으로 생성해 반환합니다
+func (factoryRegistry) GetAll() map[models.CollectionSource]models.Collector {
+	collectors := make(map[models.CollectionSource]models.Collector)
+	for _, source := range ListRegisteredSources() {
+		if collector, err := GetCollector(source, nil); err == nil {
+			collectors[source] = collector
+		}
+	}
+	return collectors
+}
+
+// ListSources는 등록된 모든 소스들을 반환합니다
+func (factoryRegistry) ListSources() []models.CollectionSource {
+	return ListRegisteredSources()
+}
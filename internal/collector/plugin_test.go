@@ -0,0 +1,77 @@
+//go:build (linux || darwin) && cgo
+
+package collector
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"ssamai/pkg/models"
+)
+
+// buildFixturePlugin은 testdata/plugin을 공유 라이브러리로 빌드합니다
+// 테스트 바이너리가 -race로 빌드되었으면 플러그인도 -race로 빌드합니다
+// go 도구가 없거나 빌드에 실패하면 테스트를 건너뜁니다
+func buildFixturePlugin(t *testing.T) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping plugin build in short mode")
+	}
+
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found")
+	}
+
+	output := filepath.Join(t.TempDir(), "fixture.so")
+	args := []string{"build", "-buildmode=plugin"}
+	if raceEnabled {
+		args = append(args, "-race")
+	}
+	args = append(args, "-o", output, "./testdata/plugin")
+	cmd := exec.Command(goTool, args...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Skipf("failed to build fixture plugin: %v\n%s", err, out)
+	}
+	return output
+}
+
+func TestLoadPlugin(t *testing.T) {
+	restoreRegistry(t)
+	path := buildFixturePlugin(t)
+
+	loaded, err := LoadPlugin(path)
+	if err != nil {
+		t.Fatalf("LoadPlugin() error = %v", err)
+	}
+	if loaded.GetName() != "fixture" {
+		t.Errorf("plugin name = %q, want fixture", loaded.GetName())
+	}
+
+	source := models.CollectionSource("fixture_cli")
+	if !IsRegistered(source) {
+		t.Fatalf("expected %s to be registered", source)
+	}
+
+	collector, err := GetCollector(source, nil)
+	if err != nil {
+		t.Fatalf("GetCollector() error = %v", err)
+	}
+	sessions, err := collector.Collect(context.Background(), &models.CollectionConfig{})
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != "fixture-session" {
+		t.Errorf("sessions = %+v, want single fixture-session", sessions)
+	}
+}
+
+func TestLoadPlugin_InvalidFile(t *testing.T) {
+	restoreRegistry(t)
+
+	if _, err := LoadPlugin(filepath.Join(t.TempDir(), "missing.so")); err == nil {
+		t.Error("expected error for missing plugin file")
+	}
+}
@@ -0,0 +1,75 @@
+package collector
+
+import (
+	"context"
+	"runtime"
+	"sort"
+
+	"ssamai/pkg/models"
+)
+
+// WorkerLimiter는 collector 간에 공유되는 동시 작업 수 제한 인터페이스입니다.
+type WorkerLimiter interface {
+	// Acquire는 작업 슬롯을 얻을 때까지 대기합니다. 컨텍스트가 취소되면 에러를 반환합니다.
+	Acquire(ctx context.Context) error
+
+	// Release는 Acquire로 얻은 작업 슬롯을 반환합니다.
+	Release()
+}
+
+// WorkerPoolAware는 공유 워커 풀을 주입받을 수 있는 collector를 나타냅니다.
+type WorkerPoolAware interface {
+	SetWorkerPool(pool WorkerLimiter)
+}
+
+// WorkerPool은 세마포어 기반의 WorkerLimiter 기본 구현입니다.
+type WorkerPool struct {
+	sem chan struct{}
+}
+
+// NewWorkerPool은 최대 size개의 작업을 동시에 허용하는 워커 풀을 생성합니다.
+// size가 0 이하이면 CPU 수를 사용합니다.
+func NewWorkerPool(size int) *WorkerPool {
+	if size <= 0 {
+		size = runtime.NumCPU()
+	}
+	return &WorkerPool{
+		sem: make(chan struct{}, size),
+	}
+}
+
+// Acquire는 작업 슬롯을 얻습니다.
+func (p *WorkerPool) Acquire(ctx context.Context) error {
+	select {
+	case p.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release는 작업 슬롯을 반환합니다.
+func (p *WorkerPool) Release() {
+	<-p.sem
+}
+
+// Size는 워커 풀의 최대 동시 작업 수를 반환합니다.
+func (p *WorkerPool) Size() int {
+	return cap(p.sem)
+}
+
+// sortSessionsByFilePath는 세션을 원본 파일 경로 순서(사전순)로 정렬합니다.
+func sortSessionsByFilePath(sessions []models.SessionData, filePaths []string) {
+	sorted := append([]string(nil), filePaths...)
+	sort.Strings(sorted)
+
+	order := make(map[string]int, len(sorted))
+	for i, path := range sorted {
+		order[path] = i
+	}
+
+	// 아카이브에서 읽은 세션은 아카이브 경로 기준으로 정렬하고, 아카이브 내부 순서는 유지
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return order[sessionSourceFile(sessions[i])] < order[sessionSourceFile(sessions[j])]
+	})
+}
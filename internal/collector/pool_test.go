@@ -0,0 +1,136 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"ssamai/internal/config"
+	"ssamai/pkg/models"
+)
+
+// spyWorkerLimiter는 동시 작업 수를 기록하는 테스트용 WorkerLimiter
+type spyWorkerLimiter struct {
+	pool *WorkerPool
+
+	mu       sync.Mutex
+	current  int
+	max      int
+	acquired int
+}
+
+func newSpyWorkerLimiter(size int) *spyWorkerLimiter {
+	return &spyWorkerLimiter{pool: NewWorkerPool(size)}
+}
+
+func (s *spyWorkerLimiter) Acquire(ctx context.Context) error {
+	if err := s.pool.Acquire(ctx); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.current++
+	s.acquired++
+	if s.current > s.max {
+		s.max = s.current
+	}
+	s.mu.Unlock()
+
+	// 작업이 겹치도록 잠시 대기
+	time.Sleep(2 * time.Millisecond)
+	return nil
+}
+
+func (s *spyWorkerLimiter) Release() {
+	s.mu.Lock()
+	s.current--
+	s.mu.Unlock()
+	s.pool.Release()
+}
+
+func TestNewWorkerPool(t *testing.T) {
+	if size := NewWorkerPool(3).Size(); size != 3 {
+		t.Errorf("expected size 3, got %d", size)
+	}
+	if size := NewWorkerPool(0).Size(); size <= 0 {
+		t.Errorf("expected positive default size, got %d", size)
+	}
+}
+
+func TestWorkerPool_AcquireCancelled(t *testing.T) {
+	pool := NewWorkerPool(1)
+	if err := pool.Acquire(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer pool.Release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := pool.Acquire(ctx); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestWorkerPool_SharedAcrossCollectors(t *testing.T) {
+	const limit = 2
+	const filesPerCollector = 8
+	spy := newSpyWorkerLimiter(limit)
+
+	// Gemini 세션 파일
+	geminiReader := NewMockFileReader()
+	geminiReader.AddDir("/gemini/sessions")
+	for i := 0; i < filesPerCollector; i++ {
+		geminiReader.AddFile(fmt.Sprintf("/gemini/sessions/s%d.json", i),
+			[]byte(fmt.Sprintf(`{"id":"gemini-%d","title":"Gemini %d","messages":[]}`, i, i)))
+	}
+	gemini := NewImprovedGeminiCLICollector(config.CLIToolConfig{SessionDir: "/gemini/sessions"}).
+		WithFileReader(geminiReader).WithLogger(&MockLogger{})
+	gemini.SetWorkerPool(spy)
+
+	// Amazon Q 세션 파일
+	amazonQReader := NewMockAmazonQFileReader()
+	amazonQReader.AddDir("/amazonq/sessions")
+	for i := 0; i < filesPerCollector; i++ {
+		amazonQReader.AddFile(fmt.Sprintf("/amazonq/sessions/s%d.json", i),
+			[]byte(fmt.Sprintf(`{"id":"amazonq-%d","title":"Amazon Q %d","messages":[]}`, i, i)))
+	}
+	amazonQ := NewAmazonQCollector(config.CLIToolConfig{SessionDir: "/amazonq/sessions"}).
+		WithFileReader(amazonQReader).WithLogger(NewMockAmazonQLogger())
+	amazonQ.SetWorkerPool(spy)
+
+	collectConfig := &models.CollectionConfig{}
+	var wg sync.WaitGroup
+	counts := make([]int, 2)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		sessions, err := gemini.collectFromSessionDirConcurrent(context.Background(), collectConfig)
+		if err != nil {
+			t.Errorf("gemini collection failed: %v", err)
+		}
+		counts[0] = len(sessions)
+	}()
+	go func() {
+		defer wg.Done()
+		sessions, err := amazonQ.collectFromSessionDirConcurrent(context.Background(), collectConfig)
+		if err != nil {
+			t.Errorf("amazon q collection failed: %v", err)
+		}
+		counts[1] = len(sessions)
+	}()
+	wg.Wait()
+
+	if counts[0] != filesPerCollector || counts[1] != filesPerCollector {
+		t.Errorf("expected %d sessions per collector, got %v", filesPerCollector, counts)
+	}
+	if spy.acquired != 2*filesPerCollector {
+		t.Errorf("expected %d acquisitions, got %d", 2*filesPerCollector, spy.acquired)
+	}
+	if spy.max > limit {
+		t.Errorf("expected global concurrency <= %d, got %d", limit, spy.max)
+	}
+}
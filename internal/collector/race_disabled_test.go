@@ -0,0 +1,6 @@
+//go:build !race
+
+package collector
+
+// raceEnabled는 테스트 바이너리가 -race로 빌드되었는지 나타냅니다
+const raceEnabled = false
@@ -0,0 +1,7 @@
+//go:build race
+
+package collector
+
+// raceEnabled는 테스트 바이너리가 -race로 빌드되었는지 나타냅니다
+// 플러그인은 호스트 바이너리와 같은 빌드 옵션이어야 로드할 수 있습니다
+const raceEnabled = true
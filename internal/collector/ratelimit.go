@@ -0,0 +1,65 @@
+package collector
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter는 초당 파일 읽기 수를 제한하는 토큰 버킷 기반 WorkerLimiter입니다.
+// 버킷 크기는 1이므로 읽기 사이에 항상 1/rate 초 이상의 간격이 생깁니다.
+// inner가 설정되어 있으면 속도 제한 후 동시 작업 수 제한도 함께 적용합니다.
+type RateLimiter struct {
+	inner    WorkerLimiter
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time // 다음 토큰을 사용할 수 있는 시각
+
+	// 테스트에서 교체할 수 있는 시계
+	now   func() time.Time
+	after func(d time.Duration) <-chan time.Time
+}
+
+// NewRateLimiter는 초당 perSecond개의 읽기를 허용하는 속도 제한기를 생성합니다.
+// inner가 nil이면 동시 작업 수는 제한하지 않습니다.
+func NewRateLimiter(inner WorkerLimiter, perSecond float64) *RateLimiter {
+	return &RateLimiter{
+		inner:    inner,
+		interval: time.Duration(float64(time.Second) / perSecond),
+		now:      time.Now,
+		after:    time.After,
+	}
+}
+
+// Acquire는 토큰을 얻을 때까지 대기한 뒤 내부 제한기의 작업 슬롯을 얻습니다.
+func (r *RateLimiter) Acquire(ctx context.Context) error {
+	r.mu.Lock()
+	now := r.now()
+	if r.next.Before(now) {
+		r.next = now
+	}
+	wait := r.next.Sub(now)
+	r.next = r.next.Add(r.interval)
+	r.mu.Unlock()
+
+	if wait > 0 {
+		select {
+		case <-r.after(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if r.inner != nil {
+		return r.inner.Acquire(ctx)
+	}
+	return nil
+}
+
+// Release는 내부 제한기의 작업 슬롯을 반환합니다.
+func (r *RateLimiter) Release() {
+	if r.inner != nil {
+		r.inner.Release()
+	}
+}
@@ -0,0 +1,112 @@
+package collector
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock은 대기 시 즉시 시간을 앞당기는 테스트용 시계
+type fakeClock struct {
+	mu      sync.Mutex
+	current time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.current
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	c.current = c.current.Add(d)
+	now := c.current
+	c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	ch <- now
+	return ch
+}
+
+func newFakeClockRateLimiter(inner WorkerLimiter, perSecond float64) (*RateLimiter, *fakeClock) {
+	clock := &fakeClock{current: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := NewRateLimiter(inner, perSecond)
+	limiter.now = clock.Now
+	limiter.after = clock.After
+	return limiter, clock
+}
+
+func TestRateLimiter_CapsReadRate(t *testing.T) {
+	limiter, clock := newFakeClockRateLimiter(nil, 5)
+	start := clock.Now()
+
+	const reads = 11
+	for i := 0; i < reads; i++ {
+		if err := limiter.Acquire(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		limiter.Release()
+	}
+
+	// 첫 읽기는 즉시, 이후 10번은 200ms 간격
+	elapsed := clock.Now().Sub(start)
+	if elapsed != 2*time.Second {
+		t.Errorf("expected 2s elapsed for %d reads at 5/s, got %s", reads, elapsed)
+	}
+	if rate := float64(reads-1) / elapsed.Seconds(); rate > 5 {
+		t.Errorf("expected effective rate <= 5/s, got %.2f", rate)
+	}
+}
+
+func TestRateLimiter_NoWaitAfterIdle(t *testing.T) {
+	limiter, clock := newFakeClockRateLimiter(nil, 2)
+
+	if err := limiter.Acquire(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 간격 이상 쉬었다면 다음 읽기는 대기하지 않음
+	clock.After(time.Second)
+	before := clock.Now()
+	if err := limiter.Acquire(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if waited := clock.Now().Sub(before); waited != 0 {
+		t.Errorf("expected no wait after idle period, waited %s", waited)
+	}
+}
+
+func TestRateLimiter_AcquireCancelled(t *testing.T) {
+	limiter := NewRateLimiter(nil, 0.001)
+
+	if err := limiter.Acquire(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := limiter.Acquire(ctx); err == nil {
+		t.Errorf("expected error when context is cancelled while waiting")
+	}
+}
+
+func TestRateLimiter_WrapsWorkerPool(t *testing.T) {
+	spy := newSpyWorkerLimiter(1)
+	limiter, _ := newFakeClockRateLimiter(spy, 100)
+
+	for i := 0; i < 3; i++ {
+		if err := limiter.Acquire(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		limiter.Release()
+	}
+
+	if spy.acquired != 3 {
+		t.Errorf("expected inner limiter to be acquired 3 times, got %d", spy.acquired)
+	}
+	if spy.current != 0 {
+		t.Errorf("expected all inner slots to be released, got %d in use", spy.current)
+	}
+}
@@ -0,0 +1,25 @@
+package collector
+
+// maxRejectedSamples는 보고할 거부된 라인 번호 샘플의 최대 개수입니다.
+const maxRejectedSamples = 5
+
+// RejectedLines는 한 파일에서 파싱에 실패해 건너뛴 라인 정보입니다.
+type RejectedLines struct {
+	File        string
+	Count       int
+	SampleLines []int // 앞에서부터 최대 maxRejectedSamples개
+}
+
+// add는 거부된 라인을 기록합니다.
+func (r *RejectedLines) add(lineNum int) {
+	r.Count++
+	if len(r.SampleLines) < maxRejectedSamples {
+		r.SampleLines = append(r.SampleLines, lineNum)
+	}
+}
+
+// RejectionReporter는 마지막 수집에서 건너뛴 라인을 보고할 수 있는 collector를 나타냅니다.
+// CollectionConfig.ReportRejected가 설정된 경우에만 기록됩니다.
+type RejectionReporter interface {
+	RejectedLines() []RejectedLines
+}
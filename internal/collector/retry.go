@@ -0,0 +1,187 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"math/rand"
+	"os"
+	"time"
+)
+
+// 파일 시스템 재시도 기본값입니다 (--fs-retries로 재시도 횟수만 지정한 경우)
+const (
+	DefaultFSRetryBaseDelay = 50 * time.Millisecond
+	DefaultFSRetryJitter    = 0.5
+)
+
+// RetryPolicy는 일시적인 파일 시스템 오류(네트워크 마운트 등)를 재시도하는 방법입니다.
+// 재시도 전 대기 시간은 BaseDelay에서 시작해 두 배씩 늘어나며, Jitter 비율만큼 무작위로 흔들립니다.
+type RetryPolicy struct {
+	Retries   int           // 첫 시도 이후 최대 재시도 횟수
+	BaseDelay time.Duration // 첫 재시도 전 대기 시간
+	Jitter    float64       // 대기 시간에 더하거나 빼는 최대 비율 (0~1)
+
+	// 테스트에서 교체할 수 있는 대기/난수 함수
+	sleep  func(time.Duration)
+	random func() float64
+}
+
+// RetryPolicyAware는 파일 시스템 재시도 정책을 주입받을 수 있는 collector를 나타냅니다.
+type RetryPolicyAware interface {
+	SetRetryPolicy(policy *RetryPolicy)
+}
+
+// NewRetryPolicy는 기본 대기 시간과 지터로 최대 retries번 재시도하는 정책을 생성합니다.
+func NewRetryPolicy(retries int) *RetryPolicy {
+	return &RetryPolicy{
+		Retries:   retries,
+		BaseDelay: DefaultFSRetryBaseDelay,
+		Jitter:    DefaultFSRetryJitter,
+		sleep:     time.Sleep,
+		random:    rand.Float64,
+	}
+}
+
+// Backoff는 retry번째(0부터) 재시도 전에 기다릴 시간을 반환합니다.
+// 결과는 BaseDelay*2^retry의 (1-Jitter)배에서 (1+Jitter)배 사이입니다.
+func (p *RetryPolicy) Backoff(retry int) time.Duration {
+	delay := float64(p.BaseDelay) * float64(int64(1)<<min(retry, 30))
+	jitter := p.Jitter
+	if jitter < 0 {
+		jitter = 0
+	} else if jitter > 1 {
+		jitter = 1
+	}
+	return time.Duration(delay * (1 + jitter*(2*p.random()-1)))
+}
+
+// Do는 op가 재시도할 수 있는 에러를 반환하는 동안 최대 Retries번 다시 실행합니다.
+// 파일이 없다는 에러와 컨텍스트 에러는 재시도해도 달라지지 않으므로 바로 반환합니다.
+func (p *RetryPolicy) Do(op func() error) error {
+	err := op()
+	for retry := 0; p != nil && retry < p.Retries && isRetryableFSError(err); retry++ {
+		p.sleep(p.Backoff(retry))
+		err = op()
+	}
+	return err
+}
+
+// isRetryableFSError는 다시 시도하면 성공할 수 있는 파일 시스템 에러인지 확인합니다.
+func isRetryableFSError(err error) bool {
+	return err != nil &&
+		!errors.Is(err, fs.ErrNotExist) &&
+		!errors.Is(err, context.Canceled) &&
+		!errors.Is(err, context.DeadlineExceeded)
+}
+
+// retryingFileReader는 FileReader 호출을 재시도 정책으로 감쌉니다.
+type retryingFileReader struct {
+	inner  FileReader
+	policy *RetryPolicy
+}
+
+// newRetryingFileReader는 reader를 재시도 정책으로 감쌉니다 (이미 감싼 reader는 정책만 바꿈).
+func newRetryingFileReader(reader FileReader, policy *RetryPolicy) FileReader {
+	if retrying, ok := reader.(*retryingFileReader); ok {
+		reader = retrying.inner
+	}
+	return &retryingFileReader{inner: reader, policy: policy}
+}
+
+func (r *retryingFileReader) ReadFile(filename string) (data []byte, err error) {
+	err = r.policy.Do(func() error {
+		data, err = r.inner.ReadFile(filename)
+		return err
+	})
+	return data, err
+}
+
+func (r *retryingFileReader) Stat(filename string) (info os.FileInfo, err error) {
+	err = r.policy.Do(func() error {
+		info, err = r.inner.Stat(filename)
+		return err
+	})
+	return info, err
+}
+
+func (r *retryingFileReader) Open(filename string) (file io.ReadCloser, err error) {
+	err = r.policy.Do(func() error {
+		file, err = r.inner.Open(filename)
+		return err
+	})
+	return file, err
+}
+
+func (r *retryingFileReader) WalkDir(root string, fn fs.WalkDirFunc) error {
+	return retryWalkDir(r.policy, r.inner.WalkDir, root, fn)
+}
+
+// retryWalkDir는 루트 디렉토리를 읽지 못한 경우에만 탐색 전체를 재시도합니다.
+// 이미 항목을 fn에 전달한 뒤의 에러는 같은 항목을 두 번 처리하지 않도록 재시도하지 않고,
+// 마지막 시도에서는 루트 에러도 fn에 전달해 기존과 같이 처리되게 합니다.
+func retryWalkDir(policy *RetryPolicy, walk func(string, fs.WalkDirFunc) error, root string, fn fs.WalkDirFunc) error {
+	attempt := 0
+	var walkErr error
+	policy.Do(func() error {
+		last := policy == nil || attempt >= policy.Retries
+		attempt++
+
+		started := false
+		walkErr = walk(root, func(path string, d fs.DirEntry, err error) error {
+			if !started && !last && path == root && isRetryableFSError(err) {
+				return err
+			}
+			started = true
+			return fn(path, d, err)
+		})
+		if started {
+			return nil
+		}
+		return walkErr
+	})
+	return walkErr
+}
+
+// retryingAmazonQFileReader는 AmazonQFileReader 호출을 재시도 정책으로 감쌉니다.
+type retryingAmazonQFileReader struct {
+	inner  AmazonQFileReader
+	policy *RetryPolicy
+}
+
+// newRetryingAmazonQFileReader는 reader를 재시도 정책으로 감쌉니다 (이미 감싼 reader는 정책만 바꿈).
+func newRetryingAmazonQFileReader(reader AmazonQFileReader, policy *RetryPolicy) AmazonQFileReader {
+	if retrying, ok := reader.(*retryingAmazonQFileReader); ok {
+		reader = retrying.inner
+	}
+	return &retryingAmazonQFileReader{inner: reader, policy: policy}
+}
+
+func (r *retryingAmazonQFileReader) ReadFile(filename string) (data []byte, err error) {
+	err = r.policy.Do(func() error {
+		data, err = r.inner.ReadFile(filename)
+		return err
+	})
+	return data, err
+}
+
+func (r *retryingAmazonQFileReader) Stat(filename string) (info os.FileInfo, err error) {
+	err = r.policy.Do(func() error {
+		info, err = r.inner.Stat(filename)
+		return err
+	})
+	return info, err
+}
+
+func (r *retryingAmazonQFileReader) OpenFile(name string) (file *os.File, err error) {
+	err = r.policy.Do(func() error {
+		file, err = r.inner.OpenFile(name)
+		return err
+	})
+	return file, err
+}
+
+func (r *retryingAmazonQFileReader) WalkDir(root string, fn fs.WalkDirFunc) error {
+	return retryWalkDir(r.policy, r.inner.WalkDir, root, fn)
+}
@@ -0,0 +1,207 @@
+package collector
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// flakyFileReader는 처음 failures번은 일시적인 에러를 반환하는 테스트용 파일 리더
+type flakyFileReader struct {
+	*MockFileReader
+	failures int
+	err      error
+	calls    int
+}
+
+func (r *flakyFileReader) ReadFile(filename string) ([]byte, error) {
+	r.calls++
+	if r.calls <= r.failures {
+		return nil, r.err
+	}
+	return r.MockFileReader.ReadFile(filename)
+}
+
+func (r *flakyFileReader) WalkDir(root string, fn fs.WalkDirFunc) error {
+	r.calls++
+	if r.calls <= r.failures {
+		return fn(root, nil, r.err)
+	}
+	return r.MockFileReader.WalkDir(root, fn)
+}
+
+// newTestRetryPolicy는 실제로 기다리지 않고 대기 시간을 기록하는 정책을 만듭니다
+func newTestRetryPolicy(retries int, random func() float64) (*RetryPolicy, *[]time.Duration) {
+	var delays []time.Duration
+	policy := NewRetryPolicy(retries)
+	policy.sleep = func(d time.Duration) { delays = append(delays, d) }
+	if random != nil {
+		policy.random = random
+	}
+	return policy, &delays
+}
+
+func TestRetryingFileReader_RetriesTransientErrors(t *testing.T) {
+	mock := NewMockFileReader()
+	mock.AddFile("/data/session.json", []byte("{}"))
+	flaky := &flakyFileReader{MockFileReader: mock, failures: 2, err: syscall.EIO}
+
+	policy, delays := newTestRetryPolicy(3, nil)
+	data, err := newRetryingFileReader(flaky, policy).ReadFile("/data/session.json")
+	if err != nil {
+		t.Fatalf("expected read to succeed after retries, got %v", err)
+	}
+	if string(data) != "{}" {
+		t.Errorf("unexpected data: %q", data)
+	}
+	if flaky.calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", flaky.calls)
+	}
+	if len(*delays) != 2 {
+		t.Errorf("expected 2 backoff sleeps, got %d", len(*delays))
+	}
+}
+
+func TestRetryingFileReader_GivesUpAfterBudget(t *testing.T) {
+	flaky := &flakyFileReader{MockFileReader: NewMockFileReader(), failures: 10, err: syscall.EIO}
+
+	policy, _ := newTestRetryPolicy(2, nil)
+	_, err := newRetryingFileReader(flaky, policy).ReadFile("/data/session.json")
+	if !errors.Is(err, syscall.EIO) {
+		t.Errorf("expected last transient error, got %v", err)
+	}
+	if flaky.calls != 3 {
+		t.Errorf("expected 1 attempt plus 2 retries, got %d", flaky.calls)
+	}
+}
+
+func TestRetryingFileReader_DoesNotRetryNotExist(t *testing.T) {
+	flaky := &flakyFileReader{MockFileReader: NewMockFileReader(), failures: 1, err: os.ErrNotExist}
+
+	policy, delays := newTestRetryPolicy(3, nil)
+	_, err := newRetryingFileReader(flaky, policy).ReadFile("/missing.json")
+	if !os.IsNotExist(err) {
+		t.Errorf("expected not-exist error, got %v", err)
+	}
+	if flaky.calls != 1 || len(*delays) != 0 {
+		t.Errorf("expected no retries for missing file, got %d calls and %d sleeps", flaky.calls, len(*delays))
+	}
+}
+
+func TestRetryingFileReader_WrapsOnce(t *testing.T) {
+	mock := NewMockFileReader()
+	first := newRetryingFileReader(mock, NewRetryPolicy(1))
+	second := newRetryingFileReader(first, NewRetryPolicy(4))
+
+	retrying, ok := second.(*retryingFileReader)
+	if !ok || retrying.inner != mock {
+		t.Fatalf("expected re-wrapping to replace the policy, got %#v", second)
+	}
+	if retrying.policy.Retries != 4 {
+		t.Errorf("expected new policy to be used, got %d retries", retrying.policy.Retries)
+	}
+}
+
+func TestRetryPolicy_BackoffJitterBounds(t *testing.T) {
+	policy := NewRetryPolicy(5)
+	policy.BaseDelay = 100 * time.Millisecond
+	policy.Jitter = 0.25
+
+	for retry := 0; retry < 4; retry++ {
+		base := policy.BaseDelay * time.Duration(1<<retry)
+		low := time.Duration(float64(base) * 0.75)
+		high := time.Duration(float64(base) * 1.25)
+
+		// 난수 양 끝값에서 정확히 경계
+		policy.random = func() float64 { return 0 }
+		if got := policy.Backoff(retry); got != low {
+			t.Errorf("retry %d: expected lower bound %v, got %v", retry, low, got)
+		}
+		policy.random = func() float64 { return 1 }
+		if got := policy.Backoff(retry); got != high {
+			t.Errorf("retry %d: expected upper bound %v, got %v", retry, high, got)
+		}
+
+		// 실제 난수로도 범위를 벗어나지 않음
+		policy.random = NewRetryPolicy(0).random
+		for i := 0; i < 100; i++ {
+			if got := policy.Backoff(retry); got < low || got > high {
+				t.Fatalf("retry %d: backoff %v outside [%v, %v]", retry, got, low, high)
+			}
+		}
+	}
+
+	// 지터가 없으면 정확히 두 배씩 증가
+	policy.Jitter = 0
+	if got := policy.Backoff(2); got != 400*time.Millisecond {
+		t.Errorf("expected 400ms without jitter, got %v", got)
+	}
+}
+
+func TestRetryPolicy_DoSleepsWithBackoff(t *testing.T) {
+	policy, delays := newTestRetryPolicy(3, func() float64 { return 0.5 })
+	policy.BaseDelay = 10 * time.Millisecond
+
+	attempts := 0
+	err := policy.Do(func() error {
+		attempts++
+		return syscall.EIO
+	})
+	if !errors.Is(err, syscall.EIO) || attempts != 4 {
+		t.Fatalf("expected 4 failing attempts, got %d (%v)", attempts, err)
+	}
+	want := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond}
+	if len(*delays) != len(want) {
+		t.Fatalf("expected %d sleeps, got %v", len(want), *delays)
+	}
+	for i, d := range want {
+		if (*delays)[i] != d {
+			t.Errorf("sleep %d: expected %v, got %v", i, d, (*delays)[i])
+		}
+	}
+
+	// nil 정책은 한 번만 실행
+	var nilPolicy *RetryPolicy
+	attempts = 0
+	nilPolicy.Do(func() error { attempts++; return syscall.EIO })
+	if attempts != 1 {
+		t.Errorf("expected nil policy to run once, got %d", attempts)
+	}
+}
+
+func TestRetryingFileReader_WalkDirRetriesRootFailure(t *testing.T) {
+	mock := NewMockFileReader()
+	mock.AddFile("/data/a.json", []byte("{}"))
+	flaky := &flakyFileReader{MockFileReader: mock, failures: 1, err: syscall.EIO}
+
+	policy, _ := newTestRetryPolicy(2, nil)
+	var visited []string
+	err := newRetryingFileReader(flaky, policy).WalkDir("/data", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			t.Errorf("unexpected error passed to walk func: %v", err)
+			return err
+		}
+		visited = append(visited, path)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(visited) != 1 || visited[0] != "/data/a.json" {
+		t.Errorf("expected walk to visit file after retry, got %v", visited)
+	}
+
+	// 재시도를 다 쓰면 루트 에러가 walk 함수에 전달됨
+	flaky = &flakyFileReader{MockFileReader: mock, failures: 10, err: syscall.EIO}
+	var rootErr error
+	newRetryingFileReader(flaky, policy).WalkDir("/data", func(path string, d fs.DirEntry, err error) error {
+		rootErr = err
+		return nil
+	})
+	if !errors.Is(rootErr, syscall.EIO) || flaky.calls != 3 {
+		t.Errorf("expected root error after 3 attempts, got %v after %d", rootErr, flaky.calls)
+	}
+}
@@ -0,0 +1,41 @@
+package collector
+
+import (
+	"fmt"
+
+	"ssamai/internal/config"
+	"ssamai/pkg/models"
+)
+
+// contentRewriter는 소스 설정의 content_rewrites 규칙을 파싱 직후 메시지 내용에 적용합니다.
+// 규칙은 collector 생성 시 한 번만 컴파일하며, 컴파일 에러는 Collect/Validate에서 반환합니다.
+type contentRewriter struct {
+	rules []config.CompiledRewrite
+	err   error
+}
+
+// newContentRewriter는 치환 규칙을 컴파일합니다.
+func newContentRewriter(rules []config.ContentRewrite) contentRewriter {
+	compiled, err := config.CompileContentRewrites(rules)
+	if err != nil {
+		return contentRewriter{err: fmt.Errorf("invalid content rewrite rule: %w", err)}
+	}
+	return contentRewriter{rules: compiled}
+}
+
+// apply는 모든 세션의 메시지 내용에 규칙을 순서대로 적용합니다.
+func (r contentRewriter) apply(sessions []models.SessionData) {
+	if len(r.rules) == 0 {
+		return
+	}
+
+	for i := range sessions {
+		for j := range sessions[i].Messages {
+			content := sessions[i].Messages[j].Content
+			for _, rule := range r.rules {
+				content = rule.Apply(content)
+			}
+			sessions[i].Messages[j].Content = content
+		}
+	}
+}
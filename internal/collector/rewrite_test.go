@@ -0,0 +1,92 @@
+package collector
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"ssamai/internal/config"
+	"ssamai/pkg/models"
+)
+
+const injectedPrefix = "[gemini-system] "
+
+func TestContentRewrites_AppliedOnlyToConfiguredSource(t *testing.T) {
+	sessionJSON := `{"id": "s1", "messages": [{"role": "user", "content": "[gemini-system] hello"}]}`
+
+	geminiReader := NewMockFileReader()
+	geminiReader.AddDir("/gemini")
+	geminiReader.AddDir("/gemini/sessions")
+	geminiReader.AddFile("/gemini/sessions/s1.json", []byte(sessionJSON))
+	gemini := NewImprovedGeminiCLICollector(config.CLIToolConfig{
+		ConfigDir:  "/gemini",
+		SessionDir: "/gemini/sessions",
+		ContentRewrites: []config.ContentRewrite{
+			{Pattern: `^\[gemini-system\]\s*`, Replacement: ""},
+		},
+	}).WithFileReader(geminiReader).WithLogger(&MockLogger{})
+
+	amazonQReader := NewMockAmazonQFileReader()
+	amazonQReader.AddDir("/amazonq")
+	amazonQReader.AddDir("/amazonq/sessions")
+	amazonQReader.AddFile("/amazonq/sessions/s1.json", []byte(sessionJSON))
+	amazonQ := NewAmazonQCollector(config.CLIToolConfig{
+		ConfigDir:  "/amazonq",
+		SessionDir: "/amazonq/sessions",
+	}).WithFileReader(amazonQReader).WithLogger(NewMockAmazonQLogger())
+
+	collectConfig := &models.CollectionConfig{}
+
+	geminiSessions, err := gemini.Collect(context.Background(), collectConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(geminiSessions) != 1 || len(geminiSessions[0].Messages) != 1 {
+		t.Fatalf("expected 1 gemini session with 1 message, got %+v", geminiSessions)
+	}
+	if got := geminiSessions[0].Messages[0].Content; got != "hello" {
+		t.Errorf("expected rewritten content %q, got %q", "hello", got)
+	}
+
+	amazonQSessions, err := amazonQ.Collect(context.Background(), collectConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(amazonQSessions) != 1 || len(amazonQSessions[0].Messages) != 1 {
+		t.Fatalf("expected 1 amazon q session with 1 message, got %+v", amazonQSessions)
+	}
+	if got := amazonQSessions[0].Messages[0].Content; !strings.HasPrefix(got, injectedPrefix) {
+		t.Errorf("expected other sources to be left untouched, got %q", got)
+	}
+}
+
+func TestContentRewrites_InvalidPattern(t *testing.T) {
+	collector := NewImprovedGeminiCLICollector(config.CLIToolConfig{
+		ConfigDir:       "/gemini",
+		ContentRewrites: []config.ContentRewrite{{Pattern: "([unclosed"}},
+	}).WithFileReader(NewMockFileReader()).WithLogger(&MockLogger{})
+
+	if err := collector.Validate(); err == nil || !strings.Contains(err.Error(), "content_rewrites[0]") {
+		t.Errorf("expected Validate to report invalid rewrite, got %v", err)
+	}
+	if _, err := collector.Collect(context.Background(), &models.CollectionConfig{}); err == nil {
+		t.Errorf("expected Collect to fail with invalid rewrite")
+	}
+}
+
+func TestContentRewriter_AppliesRulesInOrder(t *testing.T) {
+	rewriter := newContentRewriter([]config.ContentRewrite{
+		{Pattern: "foo", Replacement: "bar"},
+		{Pattern: "bar", Replacement: "baz"},
+	})
+	if rewriter.err != nil {
+		t.Fatalf("unexpected error: %v", rewriter.err)
+	}
+
+	sessions := []models.SessionData{{Messages: []models.Message{{Content: "foo"}, {Content: "other"}}}}
+	rewriter.apply(sessions)
+
+	if sessions[0].Messages[0].Content != "baz" || sessions[0].Messages[1].Content != "other" {
+		t.Errorf("unexpected rewrite result: %+v", sessions[0].Messages)
+	}
+}
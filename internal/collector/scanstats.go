@@ -0,0 +1,68 @@
+package collector
+
+import (
+	"errors"
+
+	"ssamai/pkg/models"
+)
+
+// ScanReporter는 마지막 수집에서 세션 디렉토리를 스캔한 결과를 보고할 수 있는 collector를 나타냅니다.
+type ScanReporter interface {
+	ScanStats() []models.DirScanStats
+}
+
+// sessionSourceFile은 세션을 읽어 온 파일 경로를 반환합니다 (아카이브에서 읽은 세션은 아카이브 경로).
+func sessionSourceFile(session models.SessionData) string {
+	if archivePath := session.Metadata["archive_path"]; archivePath != "" {
+		return archivePath
+	}
+	return session.Metadata["file_path"]
+}
+
+// countParsedFiles는 filePaths 중 하나 이상의 세션을 만들어 낸 파일 수를 셉니다.
+func countParsedFiles(sessions []models.SessionData, filePaths []string) int {
+	matched := make(map[string]bool, len(filePaths))
+	for _, path := range filePaths {
+		matched[path] = true
+	}
+
+	parsed := make(map[string]bool)
+	for _, session := range sessions {
+		if file := sessionSourceFile(session); matched[file] {
+			parsed[file] = true
+		}
+	}
+	return len(parsed)
+}
+
+// fileParseError는 파싱 에러가 어느 파일에서 발생했는지 함께 기록합니다 (메시지는 원래 에러와 같음)
+type fileParseError struct {
+	path string
+	err  error
+}
+
+func newFileParseError(path string, err error) error {
+	return &fileParseError{path: path, err: err}
+}
+
+func (e *fileParseError) Error() string { return e.err.Error() }
+
+func (e *fileParseError) Unwrap() error { return e.err }
+
+// countErroredFiles는 파싱 에러가 발생해 세션을 하나도 만들지 못한 파일 수를 셉니다.
+// 일부 줄만 실패한 파일은 파싱된 파일로 세므로 스캔, 파싱, 에러 수가 서로 겹치지 않습니다.
+func countErroredFiles(errs []error, sessions []models.SessionData) int {
+	parsed := make(map[string]bool)
+	for _, session := range sessions {
+		parsed[sessionSourceFile(session)] = true
+	}
+
+	errored := make(map[string]bool)
+	for _, err := range errs {
+		var fileErr *fileParseError
+		if errors.As(err, &fileErr) && !parsed[fileErr.path] {
+			errored[fileErr.path] = true
+		}
+	}
+	return len(errored)
+}
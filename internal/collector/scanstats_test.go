@@ -0,0 +1,153 @@
+package collector
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ssamai/internal/config"
+	"ssamai/pkg/models"
+)
+
+func collectGeminiScanStats(t *testing.T, sessionDir string) models.DirScanStats {
+	t.Helper()
+
+	gemini := NewImprovedGeminiCLICollector(config.CLIToolConfig{SessionDir: sessionDir}).WithLogger(&MockLogger{})
+	if _, err := gemini.collectFromSessionDirConcurrent(context.Background(), &models.CollectionConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stats := gemini.ScanStats()
+	if len(stats) != 1 {
+		t.Fatalf("expected 1 scan report, got %+v", stats)
+	}
+	if stats[0].Source != models.SourceGeminiCLI || stats[0].Dir != sessionDir {
+		t.Errorf("unexpected scan report identity: %+v", stats[0])
+	}
+	return stats[0]
+}
+
+// tooDeepJSON은 텍스트 대체 파싱 없이 거부되는 세션 파일 내용입니다
+var tooDeepJSON = strings.Repeat("[", maxJSONDepth+1) + strings.Repeat("]", maxJSONDepth+1)
+
+func writeScanFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// 쓰기 유예 시간에 걸리지 않도록 다 쓴 파일로 만듦
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, past, past); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGeminiScanStats(t *testing.T) {
+	t.Run("missing directory", func(t *testing.T) {
+		stats := collectGeminiScanStats(t, filepath.Join(t.TempDir(), "missing"))
+		if !stats.Missing || stats.FilesScanned != 0 {
+			t.Errorf("expected missing directory, got %+v", stats)
+		}
+	})
+
+	t.Run("empty directory", func(t *testing.T) {
+		stats := collectGeminiScanStats(t, t.TempDir())
+		if stats.Missing || stats.FilesScanned != 0 || stats.FilesMatched != 0 {
+			t.Errorf("expected empty directory, got %+v", stats)
+		}
+	})
+
+	t.Run("no matching files", func(t *testing.T) {
+		dir := t.TempDir()
+		writeScanFile(t, dir, "notes.txt", "hello")
+		writeScanFile(t, dir, "debug.log", "hello")
+
+		stats := collectGeminiScanStats(t, dir)
+		if stats.FilesScanned != 2 || stats.FilesMatched != 0 || stats.FilesParsed != 0 {
+			t.Errorf("expected 2 scanned, 0 matched, got %+v", stats)
+		}
+	})
+
+	t.Run("matched but unparseable", func(t *testing.T) {
+		dir := t.TempDir()
+		writeScanFile(t, dir, "broken.json", tooDeepJSON)
+		writeScanFile(t, dir, "notes.txt", "hello")
+
+		stats := collectGeminiScanStats(t, dir)
+		if stats.FilesScanned != 2 || stats.FilesMatched != 1 || stats.FilesParsed != 0 {
+			t.Errorf("expected 2 scanned, 1 matched, 0 parsed, got %+v", stats)
+		}
+	})
+
+	t.Run("parsed files", func(t *testing.T) {
+		dir := t.TempDir()
+		writeScanFile(t, dir, "a.json", `{"id":"a","title":"A","messages":[{"role":"user","content":"hi"}]}`)
+		writeScanFile(t, dir, "b.json", `{"id":"b","title":"B","messages":[{"role":"user","content":"hi"}]}`)
+		writeScanFile(t, dir, "broken.json", tooDeepJSON)
+
+		stats := collectGeminiScanStats(t, dir)
+		if stats.FilesScanned != 3 || stats.FilesMatched != 3 || stats.FilesParsed != 2 {
+			t.Errorf("expected 3 scanned, 3 matched, 2 parsed, got %+v", stats)
+		}
+	})
+
+	t.Run("mixed directory", func(t *testing.T) {
+		dir := t.TempDir()
+		writeScanFile(t, dir, "a.json", `{"id":"a","title":"A","messages":[{"role":"user","content":"hi"}]}`)
+		writeScanFile(t, dir, "broken-1.json", tooDeepJSON)
+		writeScanFile(t, dir, "broken-2.json", tooDeepJSON)
+		// 일부 줄만 실패한 파일은 파싱된 파일로 셈
+		writeScanFile(t, dir, "export.ndjson", `{"id":"l1","messages":[{"role":"user","content":"hi"}]}`+"\n"+tooDeepJSON+"\n")
+		writeScanFile(t, dir, "notes.txt", "hello")
+		writeScanFile(t, dir, "debug.log", "hello")
+		writeScanFile(t, dir, "readme.md", "# notes")
+
+		stats := collectGeminiScanStats(t, dir)
+		if stats.FilesScanned != 7 || stats.FilesParsed != 2 || stats.FilesSkipped() != 3 || stats.FilesErrored != 2 {
+			t.Errorf("expected 7 scanned, 2 parsed, 3 skipped, 2 errors, got %+v (skipped %d)", stats, stats.FilesSkipped())
+		}
+	})
+}
+
+func TestAmazonQScanStats(t *testing.T) {
+	reader := NewMockAmazonQFileReader()
+	reader.AddDir("/amazonq/sessions")
+	reader.AddFile("/amazonq/sessions/s1.json", []byte(`{"id":"s1","title":"S1","messages":[]}`))
+	reader.AddFile("/amazonq/sessions/readme.md", []byte("# notes"))
+
+	amazonQ := NewAmazonQCollector(config.CLIToolConfig{SessionDir: "/amazonq/sessions"}).
+		WithFileReader(reader).WithLogger(NewMockAmazonQLogger())
+	if _, err := amazonQ.collectFromSessionDirConcurrent(context.Background(), &models.CollectionConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stats := amazonQ.ScanStats()
+	want := models.DirScanStats{Source: models.SourceAmazonQ, Dir: "/amazonq/sessions", FilesScanned: 2, FilesMatched: 1, FilesParsed: 1}
+	if len(stats) != 1 || stats[0] != want {
+		t.Errorf("expected %+v, got %+v", want, stats)
+	}
+
+	// 파싱하지 못한 파일은 에러로 셈
+	reader.AddFile("/amazonq/sessions/broken.json", []byte(tooDeepJSON))
+	if _, err := amazonQ.collectFromSessionDirConcurrent(context.Background(), &models.CollectionConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = models.DirScanStats{Source: models.SourceAmazonQ, Dir: "/amazonq/sessions", FilesScanned: 3, FilesMatched: 2, FilesParsed: 1, FilesErrored: 1}
+	if stats := amazonQ.ScanStats(); len(stats) != 2 || stats[1] != want {
+		t.Errorf("expected %+v, got %+v", want, stats)
+	}
+
+	// 디렉토리가 없으면 Missing으로 보고
+	amazonQ = NewAmazonQCollector(config.CLIToolConfig{SessionDir: "/amazonq/missing"}).
+		WithFileReader(reader).WithLogger(NewMockAmazonQLogger())
+	if _, err := amazonQ.collectFromSessionDirConcurrent(context.Background(), &models.CollectionConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats := amazonQ.ScanStats(); len(stats) != 1 || !stats[0].Missing {
+		t.Errorf("expected missing directory report, got %+v", stats)
+	}
+}
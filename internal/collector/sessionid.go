@@ -0,0 +1,64 @@
+package collector
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"ssamai/pkg/models"
+)
+
+// sessionIDHashLength는 결정적 세션 ID에 사용하는 해시 16진수 문자 수입니다
+const sessionIDHashLength = 16
+
+// deterministicSessionID는 원본에 안정적인 ID가 없는 세션의 ID를 내용으로부터 만듭니다
+// 소스, 첫 메시지 내용, 기록된 타임스탬프(없으면 zero)의 해시를 사용하므로
+// 같은 입력은 실행 시각이나 파일 안 위치와 관계없이 항상 같은 ID를 얻습니다
+func deterministicSessionID(prefix string, source models.CollectionSource, firstMessage string, timestamp time.Time) string {
+	return sessionIDHash(prefix, source, "", firstMessage, timestamp)
+}
+
+// positionalSessionID는 deterministicSessionID에 파일 경로와 줄 번호를 더해 해시합니다
+// 히스토리처럼 같은 내용의 줄이 반복될 수 있는 파일에서 줄마다 다른 ID를 얻기 위해 사용합니다
+// 같은 파일의 같은 줄은 실행할 때마다 같은 ID를 얻습니다
+func positionalSessionID(prefix string, source models.CollectionSource, path string, lineNum int, firstMessage string, timestamp time.Time) string {
+	return sessionIDHash(prefix, source, path+":"+strconv.Itoa(lineNum), firstMessage, timestamp)
+}
+
+// sessionIDHash는 소스, 위치(없으면 생략), 첫 메시지, 타임스탬프의 해시로 ID를 만듭니다
+func sessionIDHash(prefix string, source models.CollectionSource, position, firstMessage string, timestamp time.Time) string {
+	hash := sha256.New()
+	hash.Write([]byte(source))
+	hash.Write([]byte{0})
+	if position != "" {
+		hash.Write([]byte(position))
+		hash.Write([]byte{0})
+	}
+	hash.Write([]byte(firstMessage))
+	hash.Write([]byte{0})
+	if !timestamp.IsZero() {
+		hash.Write([]byte(timestamp.UTC().Format(time.RFC3339Nano)))
+	}
+	return prefix + "-" + hex.EncodeToString(hash.Sum(nil))[:sessionIDHashLength]
+}
+
+// firstMessageContent는 첫 메시지의 내용을 반환합니다 (메시지가 없으면 빈 문자열)
+func firstMessageContent(messages []models.Message) string {
+	if len(messages) == 0 {
+		return ""
+	}
+	return messages[0].Content
+}
+
+// parseRecordedTime은 원본에 기록된 RFC3339 시각을 파싱합니다 (없거나 형식이 다르면 zero)
+func parseRecordedTime(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return timestamp
+}
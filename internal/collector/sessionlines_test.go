@@ -0,0 +1,97 @@
+package collector
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ssamai/internal/config"
+	"ssamai/pkg/models"
+)
+
+// sessionLinesFixture는 한 줄에 세션 하나씩 기록된 NDJSON 내용입니다 (빈 줄과 깨진 줄 포함)
+const sessionLinesFixture = `{"id": "line-1", "title": "First", "created_at": "2024-01-01T10:00:00Z", "messages": [{"role": "user", "content": "hello"}, {"role": "assistant", "content": "hi"}]}
+{"id": "line-2", "title": "Second", "created_at": "2024-01-02T10:00:00Z", "messages": [{"role": "user", "content": "안녕"}]}
+
+{not json}
+{"title": "No ID", "messages": []}
+`
+
+func TestIsSessionLinesFile(t *testing.T) {
+	tests := map[string]bool{
+		"sessions.ndjson": true,
+		"SESSIONS.NDJSON": true,
+		"history.jsonl":   false,
+		"session.json":    false,
+	}
+	for path, expected := range tests {
+		if got := isSessionLinesFile(path); got != expected {
+			t.Errorf("isSessionLinesFile(%q) = %v, expected %v", path, got, expected)
+		}
+	}
+}
+
+func TestCollectFromSessionDirectory_SessionLines(t *testing.T) {
+	mockReader := NewMockFileReader()
+	sessionDir := "/test/sessions"
+	mockReader.AddDir("/test")
+	mockReader.AddDir(sessionDir)
+	mockReader.AddFile(filepath.Join(sessionDir, "a-single.json"), []byte(`{"id": "single", "messages": []}`))
+	mockReader.AddFile(filepath.Join(sessionDir, "export.ndjson"), []byte(sessionLinesFixture))
+
+	collector := NewImprovedGeminiCLICollector(config.CLIToolConfig{
+		ConfigDir:  "/test",
+		SessionDir: sessionDir,
+	}).WithFileReader(mockReader).WithLogger(&MockLogger{})
+
+	sessions, err := collector.Collect(context.Background(), &models.CollectionConfig{
+		Sources: []models.CollectionSource{models.SourceGeminiCLI},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var ids []string
+	for _, session := range sessions {
+		ids = append(ids, session.ID)
+	}
+	noID := positionalSessionID("gemini-cli", models.SourceGeminiCLI, filepath.Join(sessionDir, "export.ndjson"), 5, "", time.Time{})
+	want := []string{"single", "line-1", "line-2", noID}
+	if strings.Join(ids, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected sessions %v, got %v", want, ids)
+	}
+
+	first := sessions[1]
+	if len(first.Messages) != 2 || first.Messages[1].Content != "hi" {
+		t.Errorf("expected messages of the first line, got %+v", first.Messages)
+	}
+	if first.Metadata["file_path"] != filepath.Join(sessionDir, "export.ndjson") {
+		t.Errorf("unexpected file_path: %s", first.Metadata["file_path"])
+	}
+
+	stats := collector.ScanStats()
+	if len(stats) != 1 || stats[0].FilesMatched != 2 || stats[0].FilesParsed != 2 {
+		t.Errorf("expected both files matched and parsed, got %+v", stats)
+	}
+}
+
+func TestGeminiCollect_ExplicitSessionLinesFile(t *testing.T) {
+	mockReader := NewMockFileReader()
+	mockReader.AddFile("/picked/export.ndjson", []byte(sessionLinesFixture))
+
+	collector := NewImprovedGeminiCLICollector(config.CLIToolConfig{
+		ConfigDir: "/missing",
+	}).WithFileReader(mockReader).WithLogger(&MockLogger{})
+
+	sessions, err := collector.Collect(context.Background(), &models.CollectionConfig{
+		Files: []string{"/picked/export.ndjson"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sessions) != 3 {
+		t.Fatalf("expected 3 sessions from NDJSON file, got %v", sessionIDs(sessions))
+	}
+}
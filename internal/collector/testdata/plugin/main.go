@@ -0,0 +1,46 @@
+// 이 패키지는 플러그인 로딩 테스트용 수집기 플러그인입니다
+// go build -buildmode=plugin 으로 빌드합니다
+package main
+
+import (
+	"context"
+
+	"ssamai/pkg/models"
+)
+
+type fixturePlugin struct {
+	initialized bool
+}
+
+// New는 플러그인 관리자가 찾는 생성자 심볼입니다
+func New() models.CollectorPlugin {
+	return &fixturePlugin{}
+}
+
+func (p *fixturePlugin) GetName() string    { return "fixture" }
+func (p *fixturePlugin) GetVersion() string { return "0.1.0" }
+
+func (p *fixturePlugin) Initialize(config interface{}) error {
+	p.initialized = true
+	return nil
+}
+
+func (p *fixturePlugin) Cleanup() error { return nil }
+
+func (p *fixturePlugin) CreateCollector(config interface{}) (models.Collector, error) {
+	return &fixtureCollector{}, nil
+}
+
+func (p *fixturePlugin) GetSupportedSources() []models.CollectionSource {
+	return []models.CollectionSource{"fixture_cli"}
+}
+
+type fixtureCollector struct{}
+
+func (c *fixtureCollector) Collect(ctx context.Context, config *models.CollectionConfig) ([]models.SessionData, error) {
+	return []models.SessionData{{ID: "fixture-session", Source: c.GetSource()}}, nil
+}
+
+func (c *fixtureCollector) GetSource() models.CollectionSource { return "fixture_cli" }
+func (c *fixtureCollector) Validate() error                    { return nil }
+func (c *fixtureCollector) GetSupportedFormats() []string      { return []string{"fixture"} }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -12,6 +13,8 @@ import (
 type Config struct {
 	CollectionSettings CollectionSettings `yaml:"collection_settings"`
 	OutputSettings     OutputSettings     `yaml:"output_settings"`
+
+	sources map[string]SettingSource // 설정 파일/환경 변수가 정한 값의 출처 (SourceOf로 조회)
 }
 
 // CollectionSettings는 데이터 수집 설정을 나타냅니다
@@ -30,6 +33,17 @@ type CLIToolConfig struct {
 	CacheDir        string   `yaml:"cache_dir,omitempty"`
 	IncludePatterns []string `yaml:"include_patterns"`
 	ExcludePatterns []string `yaml:"exclude_patterns"`
+	MaxLineSize     int      `yaml:"max_line_size,omitempty"` // 히스토리 라인 최대 길이 (bytes, 0이면 기본값)
+	ContentRewrites []ContentRewrite `yaml:"content_rewrites,omitempty"` // 파싱 직후 메시지 내용에 적용할 치환 규칙
+	EmitSourceTypeMetadata *bool     `yaml:"emit_source_type_metadata,omitempty"` // source_type 내부 메타데이터 기록 여부 (기본값: true)
+	WriteGracePeriod time.Duration   `yaml:"write_grace_period,omitempty"` // 이 시간 안에 수정된 세션 파일은 쓰는 중으로 보고 건너뜀 (0이면 1초, 음수면 검사 안 함)
+	HistoryDB       *HistoryDBConfig `yaml:"history_db,omitempty"` // 설정하면 SQLite 데이터베이스의 대화 기록도 수집
+}
+
+// SourceTypeMetadataEnabled는 수집된 세션에 source_type 메타데이터를 남길지 반환합니다
+// 설정하지 않으면 기존 동작대로 기록합니다
+func (c CLIToolConfig) SourceTypeMetadataEnabled() bool {
+	return c.EmitSourceTypeMetadata == nil || *c.EmitSourceTypeMetadata
 }
 
 // OutputSettings는 출력 설정을 나타냅니다
@@ -43,12 +57,57 @@ type OutputSettings struct {
 }
 
 // LoadConfig는 설정 파일을 로드합니다
+// 우선순위는 기본값 < 전역 설정 파일 < 환경 변수(SSAMAI_*)이며, 명령줄 플래그는 그 위에 적용됩니다
+// 프로젝트 설정(.ssamairc)은 읽지 않습니다 (LoadProjectConfig 참고)
 func LoadConfig(configPath string) (*Config, error) {
+	return loadLayeredConfig(configPath, "")
+}
+
+// LoadProjectConfig는 전역 설정 위에 startDir부터 상위로 찾은 프로젝트 설정(.ssamairc)을 덮어써 로드합니다
+// 우선순위는 기본값 < 전역 설정 파일 < 프로젝트 설정 < 환경 변수(SSAMAI_*)이며, 명령줄 플래그는 그 위에 적용됩니다
+func LoadProjectConfig(configPath, startDir string) (*Config, error) {
+	return loadLayeredConfig(configPath, startDir)
+}
+
+// loadLayeredConfig는 설정 계층을 차례로 덮어쓴 뒤 검증하고 기본값을 채웁니다
+// startDir이 비어 있으면 프로젝트 설정 단계를 건너뜁니다
+func loadLayeredConfig(configPath, startDir string) (*Config, error) {
+	config, err := loadGlobalConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// startDir부터 찾은 프로젝트 설정으로 덮어쓰기
+	if startDir != "" {
+		if err := mergeProjectConfig(config, startDir); err != nil {
+			return nil, err
+		}
+	}
+
+	// 환경 변수로 덮어쓰기
+	if err := applyEnvOverrides(config, os.LookupEnv); err != nil {
+		return nil, err
+	}
+
+	// 설정 검증
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("설정 검증 실패: %w", err)
+	}
+
+	// 경로가 비어 있는 도구는 기본값보다 먼저 레지스트리에 기록된 데이터 디렉토리 사용 (Windows 전용)
+	applyRegistryPaths(&config.CollectionSettings)
+
+	// 기본값 설정
+	config.SetDefaults()
+
+	return config, nil
+}
+
+// loadGlobalConfig는 전역 설정 파일을 읽습니다 (경로가 비었거나 파일이 없으면 기본 설정)
+func loadGlobalConfig(configPath string) (*Config, error) {
 	// 빈 경로일 경우 기본 설정 반환
 	if configPath == "" {
-		config := createDefaultConfig()
-		config.SetDefaults()
-		return config, nil
+		return createDefaultConfig(), nil
 	}
 	
 	// 경로 확장 (~ 처리)
@@ -65,9 +124,7 @@ func LoadConfig(configPath string) (*Config, error) {
 	if err != nil {
 		// 파일이 없으면 기본 설정 반환
 		if os.IsNotExist(err) {
-			config := createDefaultConfig()
-			config.SetDefaults()
-			return config, nil
+			return createDefaultConfig(), nil
 		}
 		return nil, fmt.Errorf("설정 파일을 읽을 수 없습니다 (%s): %w", configPath, err)
 	}
@@ -77,58 +134,56 @@ func LoadConfig(configPath string) (*Config, error) {
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("설정 파일 파싱 오류: %w", err)
 	}
-
-	// 설정 검증
-	if err := config.Validate(); err != nil {
-		return nil, fmt.Errorf("설정 검증 실패: %w", err)
+	if err := config.recordFileSources(data, LayerGlobal, configPath); err != nil {
+		return nil, err
 	}
 
-	// 기본값 설정
-	config.SetDefaults()
-
 	return &config, nil
 }
 
 // Validate는 설정의 유효성을 검증합니다
 func (c *Config) Validate() error {
-	// 기본 검증 로직 추가 가능
+	// 소스별 내용 치환 규칙의 정규식 검증
+	tools := []struct {
+		name string
+		cfg  CLIToolConfig
+	}{
+		{"claude_code", c.CollectionSettings.ClaudeCode},
+		{"gemini_cli", c.CollectionSettings.GeminiCLI},
+		{"amazon_q", c.CollectionSettings.AmazonQ},
+	}
+	for _, tool := range tools {
+		if _, err := CompileContentRewrites(tool.cfg.ContentRewrites); err != nil {
+			return fmt.Errorf("collection_settings.%s.%w", tool.name, err)
+		}
+		if tool.cfg.HistoryDB != nil {
+			if err := tool.cfg.HistoryDB.Validate(); err != nil {
+				return fmt.Errorf("collection_settings.%s.%w", tool.name, err)
+			}
+		}
+	}
+
 	return nil
 }
 
 // createDefaultConfig는 기본 설정을 생성합니다
 func createDefaultConfig() *Config {
 	return &Config{
-		CollectionSettings: CollectionSettings{
-			ClaudeCode: CLIToolConfig{
-				ConfigDir:       "~/.claude",
-				SessionDir:      "~/.claude/sessions",
-				HistoryFile:     "~/.claude/history.json",
-				IncludePatterns: []string{"*.json", "*.md", "*.log"},
-				ExcludePatterns: []string{"*.tmp", "*.cache"},
-			},
-			GeminiCLI: CLIToolConfig{
-				ConfigDir:       "~/.config/gemini",
-				HistoryFile:     "~/.config/gemini/history.json",
-				LogsDir:         "~/.config/gemini/logs",
-				IncludePatterns: []string{"*.json", "*.log", "*.yaml"},
-				ExcludePatterns: []string{"*.tmp"},
-			},
-			AmazonQ: CLIToolConfig{
-				ConfigDir:       "~/.aws/amazonq",
-				HistoryFile:     "~/.aws/amazonq/history.json",
-				CacheDir:        "~/.aws/amazonq/cache",
-				IncludePatterns: []string{"*.json", "*.log"},
-				ExcludePatterns: []string{"*.tmp"},
-			},
-		},
-		OutputSettings: OutputSettings{
-			TemplateDir:       "./templates",
-			DefaultTemplate:   "comprehensive",
-			IncludeMetadata:   true,
-			IncludeTimestamps: true,
-			FormatCodeBlocks:  true,
-			GenerateTOC:       true,
-		},
+		// OS별 기본 경로 (Windows는 %APPDATA%, macOS는 Application Support 등)
+		CollectionSettings: DefaultCollectionSettings(),
+		OutputSettings:     DefaultOutputSettings(),
+	}
+}
+
+// DefaultOutputSettings는 설정 파일이 없을 때 사용하는 출력 설정 기본값을 반환합니다
+func DefaultOutputSettings() OutputSettings {
+	return OutputSettings{
+		TemplateDir:       "./templates",
+		DefaultTemplate:   "comprehensive",
+		IncludeMetadata:   true,
+		IncludeTimestamps: true,
+		FormatCodeBlocks:  true,
+		GenerateTOC:       true,
 	}
 }
 
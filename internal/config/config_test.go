@@ -276,6 +276,22 @@ func TestCLIToolConfig_AllFields(t *testing.T) {
 	assert.Contains(t, config.ExcludePatterns, "*.tmp")
 }
 
+func TestLoadConfig_EmitSourceTypeMetadata(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+collection_settings:
+  gemini_cli:
+    config_dir: "~/.gemini"
+    emit_source_type_metadata: false
+`), 0644))
+
+	cfg, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.False(t, cfg.CollectionSettings.GeminiCLI.SourceTypeMetadataEnabled())
+	// 설정하지 않은 소스는 기본값(true)
+	assert.True(t, cfg.CollectionSettings.ClaudeCode.SourceTypeMetadataEnabled())
+}
+
 func TestOutputSettings_BasicFields(t *testing.T) {
 	settings := OutputSettings{
 		TemplateDir:       "./templates",
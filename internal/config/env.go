@@ -0,0 +1,62 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// 설정을 덮어쓰는 환경 변수 이름 (전역/프로젝트 설정보다 우선, 명령줄 플래그보다는 낮음)
+const (
+	EnvTemplateDir       = "SSAMAI_TEMPLATE_DIR"
+	EnvDefaultTemplate   = "SSAMAI_DEFAULT_TEMPLATE"
+	EnvIncludeMetadata   = "SSAMAI_INCLUDE_METADATA"
+	EnvIncludeTimestamps = "SSAMAI_INCLUDE_TIMESTAMPS"
+	EnvFormatCodeBlocks  = "SSAMAI_FORMAT_CODE_BLOCKS"
+	EnvGenerateTOC       = "SSAMAI_GENERATE_TOC"
+)
+
+// applyEnvOverrides는 설정된 SSAMAI_* 환경 변수로 출력 설정을 덮어씁니다
+// 불리언 값은 strconv.ParseBool 형식(true/false/1/0 등)이어야 합니다
+func applyEnvOverrides(config *Config, lookupEnv func(string) (string, bool)) error {
+	output := &config.OutputSettings
+
+	textVars := []struct {
+		name  string
+		key   string
+		value *string
+	}{
+		{EnvTemplateDir, "output_settings.template_dir", &output.TemplateDir},
+		{EnvDefaultTemplate, "output_settings.default_template", &output.DefaultTemplate},
+	}
+	for _, env := range textVars {
+		if value, ok := lookupEnv(env.name); ok && value != "" {
+			*env.value = value
+			config.setSource(env.key, SettingSource{Layer: LayerEnv, Origin: env.name})
+		}
+	}
+
+	boolVars := []struct {
+		name  string
+		key   string
+		value *bool
+	}{
+		{EnvIncludeMetadata, "output_settings.include_metadata", &output.IncludeMetadata},
+		{EnvIncludeTimestamps, "output_settings.include_timestamps", &output.IncludeTimestamps},
+		{EnvFormatCodeBlocks, "output_settings.format_code_blocks", &output.FormatCodeBlocks},
+		{EnvGenerateTOC, "output_settings.generate_toc", &output.GenerateTOC},
+	}
+	for _, env := range boolVars {
+		value, ok := lookupEnv(env.name)
+		if !ok || value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("환경 변수 %s 값이 올바르지 않습니다 (%q): %w", env.name, value, err)
+		}
+		*env.value = parsed
+		config.setSource(env.key, SettingSource{Layer: LayerEnv, Origin: env.name})
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyEnvOverrides(t *testing.T) {
+	env := map[string]string{
+		EnvDefaultTemplate: "minimal",
+		EnvGenerateTOC:     "false",
+		EnvIncludeMetadata: "", // 빈 값은 무시
+	}
+	lookup := func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+
+	cfg := createDefaultConfig()
+	require.NoError(t, applyEnvOverrides(cfg, lookup))
+	assert.Equal(t, "minimal", cfg.OutputSettings.DefaultTemplate)
+	assert.False(t, cfg.OutputSettings.GenerateTOC)
+	assert.True(t, cfg.OutputSettings.IncludeMetadata)
+	assert.Equal(t, "./templates", cfg.OutputSettings.TemplateDir)
+
+	env[EnvFormatCodeBlocks] = "maybe"
+	err := applyEnvOverrides(cfg, lookup)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), EnvFormatCodeBlocks)
+}
+
+func TestLoadProjectConfig_EnvOverridesProject(t *testing.T) {
+	repo := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ProjectConfigFileName), []byte(`
+output_settings:
+  default_template: "technical"
+  generate_toc: false
+`), 0644))
+	t.Setenv(EnvDefaultTemplate, "minimal")
+
+	// 우선순위: 전역 < 프로젝트 < 환경 변수
+	cfg, err := LoadProjectConfig("", repo)
+	require.NoError(t, err)
+	assert.Equal(t, "minimal", cfg.OutputSettings.DefaultTemplate)
+	assert.False(t, cfg.OutputSettings.GenerateTOC)
+
+	// 프로젝트 설정 없이도 환경 변수는 적용
+	cfg, err = LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, "minimal", cfg.OutputSettings.DefaultTemplate)
+	assert.True(t, cfg.OutputSettings.GenerateTOC)
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// 히스토리 데이터베이스 매핑의 기본 테이블/컬럼 이름입니다
+const (
+	DefaultHistoryDBTable           = "messages"
+	DefaultHistoryDBSessionColumn   = "session_id"
+	DefaultHistoryDBRoleColumn      = "role"
+	DefaultHistoryDBContentColumn   = "content"
+	DefaultHistoryDBTimestampColumn = "timestamp"
+)
+
+// sqlIdentifierPattern은 쿼리에 넣을 수 있는 테이블/컬럼 이름 형식입니다
+var sqlIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// HistoryDBConfig는 SQLite 데이터베이스에 저장된 대화 기록의 위치와 테이블/컬럼 매핑입니다
+// 한 행이 메시지 하나이며, 세션 컬럼 값이 같은 행들을 하나의 세션으로 묶습니다
+type HistoryDBConfig struct {
+	Path            string `yaml:"path"`
+	Table           string `yaml:"table,omitempty"`            // 기본값: messages
+	SessionColumn   string `yaml:"session_column,omitempty"`   // 기본값: session_id
+	RoleColumn      string `yaml:"role_column,omitempty"`      // 기본값: role
+	ContentColumn   string `yaml:"content_column,omitempty"`   // 기본값: content
+	TimestampColumn string `yaml:"timestamp_column,omitempty"` // 기본값: timestamp (RFC3339, "YYYY-MM-DD HH:MM:SS" 또는 Unix 초)
+}
+
+// WithDefaults는 비어 있는 테이블/컬럼 이름을 기본값으로 채운 사본을 반환합니다
+func (c HistoryDBConfig) WithDefaults() HistoryDBConfig {
+	defaults := []struct {
+		field *string
+		value string
+	}{
+		{&c.Table, DefaultHistoryDBTable},
+		{&c.SessionColumn, DefaultHistoryDBSessionColumn},
+		{&c.RoleColumn, DefaultHistoryDBRoleColumn},
+		{&c.ContentColumn, DefaultHistoryDBContentColumn},
+		{&c.TimestampColumn, DefaultHistoryDBTimestampColumn},
+	}
+	for _, d := range defaults {
+		if *d.field == "" {
+			*d.field = d.value
+		}
+	}
+	return c
+}
+
+// Validate는 데이터베이스 경로와 테이블/컬럼 이름을 검증합니다
+// 이름은 쿼리에 그대로 들어가므로 영문자, 숫자, 밑줄만 허용합니다
+func (c HistoryDBConfig) Validate() error {
+	if c.Path == "" {
+		return fmt.Errorf("history_db.path가 비어 있습니다")
+	}
+
+	resolved := c.WithDefaults()
+	names := []struct {
+		key, value string
+	}{
+		{"table", resolved.Table},
+		{"session_column", resolved.SessionColumn},
+		{"role_column", resolved.RoleColumn},
+		{"content_column", resolved.ContentColumn},
+		{"timestamp_column", resolved.TimestampColumn},
+	}
+	for _, name := range names {
+		if !sqlIdentifierPattern.MatchString(name.value) {
+			return fmt.Errorf("history_db.%s: 잘못된 이름 %q (영문자, 숫자, 밑줄만 사용 가능)", name.key, name.value)
+		}
+	}
+	return nil
+}
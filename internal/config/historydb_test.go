@@ -0,0 +1,51 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestHistoryDBConfig_WithDefaults(t *testing.T) {
+	resolved := HistoryDBConfig{Path: "chat.db", Table: "chat_log"}.WithDefaults()
+
+	assert.Equal(t, "chat_log", resolved.Table)
+	assert.Equal(t, DefaultHistoryDBSessionColumn, resolved.SessionColumn)
+	assert.Equal(t, DefaultHistoryDBRoleColumn, resolved.RoleColumn)
+	assert.Equal(t, DefaultHistoryDBContentColumn, resolved.ContentColumn)
+	assert.Equal(t, DefaultHistoryDBTimestampColumn, resolved.TimestampColumn)
+}
+
+func TestHistoryDBConfig_Validate(t *testing.T) {
+	assert.NoError(t, HistoryDBConfig{Path: "chat.db"}.Validate())
+	assert.NoError(t, HistoryDBConfig{Path: "chat.db", Table: "chat_log_v2"}.Validate())
+
+	err := HistoryDBConfig{}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "history_db.path")
+
+	err = HistoryDBConfig{Path: "chat.db", ContentColumn: "body; DROP TABLE x"}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "history_db.content_column")
+}
+
+func TestConfig_ValidateHistoryDB(t *testing.T) {
+	var cfg Config
+	require.NoError(t, yaml.Unmarshal([]byte(`
+collection_settings:
+  gemini_cli:
+    enabled: true
+    history_db:
+      path: ~/.gemini/history.db
+      table: "bad-name"
+`), &cfg))
+
+	require.NotNil(t, cfg.CollectionSettings.GeminiCLI.HistoryDB)
+	assert.Equal(t, "~/.gemini/history.db", cfg.CollectionSettings.GeminiCLI.HistoryDB.Path)
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "history_db.table")
+}
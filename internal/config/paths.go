@@ -0,0 +1,108 @@
+package config
+
+import (
+	"os"
+	"runtime"
+	"strings"
+)
+
+// DefaultCollectionSettings는 현재 OS에 맞는 기본 수집 설정을 반환합니다
+// 반환된 경로는 설정 파일로 덮어쓸 수 있습니다
+func DefaultCollectionSettings() CollectionSettings {
+	// 레지스트리에 기록된 데이터 디렉토리를 먼저 반영하고 (Windows 전용), 남은 항목만 OS 기본값으로 채움
+	var settings CollectionSettings
+	applyRegistryPaths(&settings)
+	applyCollectionDefaults(&settings, defaultCollectionSettings(runtime.GOOS, os.Getenv))
+	return settings
+}
+
+// applyCollectionDefaults는 settings에서 비어 있는 경로와 패턴을 defaults 값으로 채웁니다
+func applyCollectionDefaults(settings *CollectionSettings, defaults CollectionSettings) {
+	fillToolDefaults(&settings.ClaudeCode, defaults.ClaudeCode)
+	fillToolDefaults(&settings.GeminiCLI, defaults.GeminiCLI)
+	fillToolDefaults(&settings.AmazonQ, defaults.AmazonQ)
+}
+
+// fillToolDefaults는 도구 설정에서 비어 있는 항목만 defaults 값으로 채웁니다
+func fillToolDefaults(cfg *CLIToolConfig, defaults CLIToolConfig) {
+	fields := []struct {
+		value    *string
+		fallback string
+	}{
+		{&cfg.ConfigDir, defaults.ConfigDir},
+		{&cfg.SessionDir, defaults.SessionDir},
+		{&cfg.HistoryFile, defaults.HistoryFile},
+		{&cfg.LogsDir, defaults.LogsDir},
+		{&cfg.CacheDir, defaults.CacheDir},
+	}
+	for _, field := range fields {
+		if *field.value == "" {
+			*field.value = field.fallback
+		}
+	}
+	if cfg.IncludePatterns == nil {
+		cfg.IncludePatterns = defaults.IncludePatterns
+	}
+	if cfg.ExcludePatterns == nil {
+		cfg.ExcludePatterns = defaults.ExcludePatterns
+	}
+}
+
+// defaultCollectionSettings는 주어진 OS와 환경 변수로 기본 수집 설정을 생성합니다 (테스트용)
+func defaultCollectionSettings(goos string, getenv func(string) string) CollectionSettings {
+	geminiDir := joinConfigPath(goos, userConfigDir(goos, getenv), "gemini")
+
+	return CollectionSettings{
+		ClaudeCode: CLIToolConfig{
+			ConfigDir:       "~/.claude",
+			SessionDir:      "~/.claude/sessions",
+			HistoryFile:     "~/.claude/history.json",
+			IncludePatterns: []string{"*.json", "*.md", "*.log"},
+			ExcludePatterns: []string{"*.tmp", "*.cache"},
+		},
+		GeminiCLI: CLIToolConfig{
+			ConfigDir:       geminiDir,
+			HistoryFile:     joinConfigPath(goos, geminiDir, "history.json"),
+			LogsDir:         joinConfigPath(goos, geminiDir, "logs"),
+			IncludePatterns: []string{"*.json", "*.log", "*.yaml"},
+			ExcludePatterns: []string{"*.tmp"},
+		},
+		AmazonQ: CLIToolConfig{
+			ConfigDir:       "~/.aws/amazonq",
+			HistoryFile:     "~/.aws/amazonq/history.json",
+			CacheDir:        "~/.aws/amazonq/cache",
+			IncludePatterns: []string{"*.json", "*.log"},
+			ExcludePatterns: []string{"*.tmp"},
+		},
+	}
+}
+
+// userConfigDir은 os.UserConfigDir과 같은 규칙으로 OS별 사용자 설정 디렉토리를 반환합니다
+// 홈 디렉토리 아래 경로는 설정 파일에 그대로 쓸 수 있도록 ~로 시작하는 형태로 반환합니다
+func userConfigDir(goos string, getenv func(string) string) string {
+	switch goos {
+	case "windows":
+		if appData := getenv("APPDATA"); appData != "" {
+			return appData
+		}
+		return "~/AppData/Roaming"
+	case "darwin", "ios":
+		return "~/Library/Application Support"
+	default:
+		// XDG 규칙: 절대 경로만 유효
+		if xdg := getenv("XDG_CONFIG_HOME"); strings.HasPrefix(xdg, "/") {
+			return xdg
+		}
+		return "~/.config"
+	}
+}
+
+// joinConfigPath는 기준 경로에 이름을 붙입니다
+// Windows 절대 경로(%APPDATA% 등)에는 역슬래시를, 그 외에는 슬래시를 사용합니다
+func joinConfigPath(goos, base, name string) string {
+	sep := "/"
+	if goos == "windows" && !strings.HasPrefix(base, "~") {
+		sep = `\`
+	}
+	return strings.TrimRight(base, `/\`) + sep + name
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func envMap(values map[string]string) func(string) string {
+	return func(key string) string {
+		return values[key]
+	}
+}
+
+func TestDefaultCollectionSettings_PerPlatform(t *testing.T) {
+	tests := []struct {
+		name          string
+		goos          string
+		env           map[string]string
+		wantGeminiDir string
+		wantHistory   string
+	}{
+		{
+			name:          "linux",
+			goos:          "linux",
+			wantGeminiDir: "~/.config/gemini",
+			wantHistory:   "~/.config/gemini/history.json",
+		},
+		{
+			name:          "linux with XDG_CONFIG_HOME",
+			goos:          "linux",
+			env:           map[string]string{"XDG_CONFIG_HOME": "/srv/config"},
+			wantGeminiDir: "/srv/config/gemini",
+			wantHistory:   "/srv/config/gemini/history.json",
+		},
+		{
+			name:          "linux ignores relative XDG_CONFIG_HOME",
+			goos:          "linux",
+			env:           map[string]string{"XDG_CONFIG_HOME": "relative/config"},
+			wantGeminiDir: "~/.config/gemini",
+			wantHistory:   "~/.config/gemini/history.json",
+		},
+		{
+			name:          "darwin",
+			goos:          "darwin",
+			wantGeminiDir: "~/Library/Application Support/gemini",
+			wantHistory:   "~/Library/Application Support/gemini/history.json",
+		},
+		{
+			name:          "windows with APPDATA",
+			goos:          "windows",
+			env:           map[string]string{"APPDATA": `C:\Users\me\AppData\Roaming`},
+			wantGeminiDir: `C:\Users\me\AppData\Roaming\gemini`,
+			wantHistory:   `C:\Users\me\AppData\Roaming\gemini\history.json`,
+		},
+		{
+			name:          "windows without APPDATA",
+			goos:          "windows",
+			wantGeminiDir: "~/AppData/Roaming/gemini",
+			wantHistory:   "~/AppData/Roaming/gemini/history.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := defaultCollectionSettings(tt.goos, envMap(tt.env))
+
+			assert.Equal(t, tt.wantGeminiDir, settings.GeminiCLI.ConfigDir)
+			assert.Equal(t, tt.wantHistory, settings.GeminiCLI.HistoryFile)
+			assert.Equal(t, "~/.claude", settings.ClaudeCode.ConfigDir)
+			assert.Equal(t, "~/.aws/amazonq", settings.AmazonQ.ConfigDir)
+		})
+	}
+}
+
+func TestLoadConfig_DefaultsUsePlatformPaths(t *testing.T) {
+	cfg, err := LoadConfig("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultCollectionSettings(), cfg.CollectionSettings)
+}
+
+func TestApplyCollectionDefaults_KeepsResolvedPaths(t *testing.T) {
+	settings := CollectionSettings{
+		ClaudeCode: CLIToolConfig{ConfigDir: "/data/claude", SessionDir: "/data/claude/sessions"},
+	}
+	defaults := defaultCollectionSettings("linux", envMap(nil))
+	applyCollectionDefaults(&settings, defaults)
+
+	// 이미 채워진 경로는 유지하고 빈 항목만 기본값으로 채움
+	assert.Equal(t, "/data/claude", settings.ClaudeCode.ConfigDir)
+	assert.Equal(t, "/data/claude/sessions", settings.ClaudeCode.SessionDir)
+	assert.Equal(t, defaults.ClaudeCode.HistoryFile, settings.ClaudeCode.HistoryFile)
+	assert.Equal(t, defaults.ClaudeCode.IncludePatterns, settings.ClaudeCode.IncludePatterns)
+
+	// 비어 있던 도구는 기본 설정과 같음
+	assert.Equal(t, defaults.GeminiCLI, settings.GeminiCLI)
+	assert.Equal(t, defaults.AmazonQ, settings.AmazonQ)
+}
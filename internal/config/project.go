@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectConfigFileName은 프로젝트별 설정 파일 이름입니다
+// LoadProjectConfig에 넘긴 디렉토리부터 상위로 올라가며 찾은 첫 파일을 전역 설정 위에 덮어씁니다
+const ProjectConfigFileName = ".ssamairc"
+
+// FindProjectConfig는 startDir부터 루트까지 올라가며 .ssamairc 파일을 찾습니다
+// 찾지 못하면 빈 문자열을 반환합니다
+func FindProjectConfig(startDir string) string {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return ""
+	}
+
+	for {
+		candidate := filepath.Join(dir, ProjectConfigFileName)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// mergeProjectConfig는 startDir 기준 프로젝트 설정을 찾아 config 위에 덮어씁니다
+// 프로젝트 파일에 적힌 항목만 바뀌며, 목록 값은 통째로 교체됩니다
+func mergeProjectConfig(config *Config, startDir string) error {
+	path := FindProjectConfig(startDir)
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("프로젝트 설정 파일을 읽을 수 없습니다 (%s): %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return fmt.Errorf("프로젝트 설정 파일 파싱 오류 (%s): %w", path, err)
+	}
+	return config.recordFileSources(data, LayerProject, path)
+}
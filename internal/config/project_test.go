@@ -0,0 +1,100 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindProjectConfig(t *testing.T) {
+	repo := t.TempDir()
+	nested := filepath.Join(repo, "pkg", "deep")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+
+	assert.Empty(t, FindProjectConfig(nested))
+
+	rcPath := filepath.Join(repo, ProjectConfigFileName)
+	require.NoError(t, os.WriteFile(rcPath, []byte("output_settings: {}\n"), 0644))
+	assert.Equal(t, rcPath, FindProjectConfig(nested))
+
+	// 디렉토리 이름이 .ssamairc인 경우는 무시
+	dirRepo := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dirRepo, ProjectConfigFileName), 0755))
+	assert.Empty(t, FindProjectConfig(dirRepo))
+}
+
+func TestLoadConfig_ProjectOverride(t *testing.T) {
+	globalPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(globalPath, []byte(`
+collection_settings:
+  gemini_cli:
+    session_dir: "/global/gemini/sessions"
+    exclude_patterns: ["*.tmp"]
+output_settings:
+  default_template: "comprehensive"
+  generate_toc: true
+  include_metadata: true
+`), 0644))
+
+	repo := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ProjectConfigFileName), []byte(`
+collection_settings:
+  gemini_cli:
+    exclude_patterns: ["*.bak"]
+output_settings:
+  default_template: "minimal"
+  generate_toc: false
+`), 0644))
+	subdir := filepath.Join(repo, "internal", "service")
+	require.NoError(t, os.MkdirAll(subdir, 0755))
+
+	cfg, err := LoadProjectConfig(globalPath, subdir)
+	require.NoError(t, err)
+
+	// 프로젝트 파일에 있는 값은 덮어쓰고, 없는 값은 전역 설정 유지
+	assert.Equal(t, "minimal", cfg.OutputSettings.DefaultTemplate)
+	assert.False(t, cfg.OutputSettings.GenerateTOC)
+	assert.True(t, cfg.OutputSettings.IncludeMetadata)
+	assert.Equal(t, "/global/gemini/sessions", cfg.CollectionSettings.GeminiCLI.SessionDir)
+	assert.Equal(t, []string{"*.bak"}, cfg.CollectionSettings.GeminiCLI.ExcludePatterns)
+}
+
+func TestLoadConfig_ProjectOverrideWithoutGlobal(t *testing.T) {
+	repo := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ProjectConfigFileName), []byte(`
+output_settings:
+  default_template: "technical"
+`), 0644))
+
+	cfg, err := LoadProjectConfig("", repo)
+	require.NoError(t, err)
+	assert.Equal(t, "technical", cfg.OutputSettings.DefaultTemplate)
+	// 나머지는 기본 설정
+	assert.True(t, cfg.OutputSettings.GenerateTOC)
+}
+
+func TestLoadConfig_InvalidProjectConfig(t *testing.T) {
+	repo := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ProjectConfigFileName), []byte("output_settings: [broken"), 0644))
+
+	_, err := LoadProjectConfig("", repo)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "프로젝트 설정 파일 파싱 오류")
+}
+
+func TestLoadConfig_IgnoresProjectConfig(t *testing.T) {
+	repo := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ProjectConfigFileName), []byte(`
+output_settings:
+  default_template: "technical"
+`), 0644))
+	t.Chdir(repo)
+
+	// LoadConfig는 현재 디렉토리의 .ssamairc를 읽지 않음
+	cfg, err := LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, "comprehensive", cfg.OutputSettings.DefaultTemplate)
+}
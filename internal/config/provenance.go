@@ -0,0 +1,61 @@
+package config
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SettingLayer는 설정 값을 정한 계층입니다
+type SettingLayer string
+
+const (
+	LayerGlobal  SettingLayer = "global"  // 전역 설정 파일 (--config)
+	LayerProject SettingLayer = "project" // 프로젝트 설정 파일 (.ssamairc)
+	LayerEnv     SettingLayer = "env"     // SSAMAI_* 환경 변수
+)
+
+// SettingSource는 설정 값을 마지막으로 정한 계층과 그 위치(파일 경로 또는 환경 변수 이름)입니다
+type SettingSource struct {
+	Layer  SettingLayer
+	Origin string
+}
+
+// SourceOf는 key("output_settings.generate_toc" 형식) 값을 정한 출처를 반환합니다
+// 설정 파일이나 환경 변수가 값을 정하지 않았으면(기본값) false를 반환합니다
+func (c *Config) SourceOf(key string) (SettingSource, bool) {
+	source, ok := c.sources[key]
+	return source, ok
+}
+
+// setSource는 key 값의 출처를 기록합니다 (나중 계층이 앞선 계층의 기록을 덮어씀)
+func (c *Config) setSource(key string, source SettingSource) {
+	if c.sources == nil {
+		c.sources = make(map[string]SettingSource)
+	}
+	c.sources[key] = source
+}
+
+// recordFileSources는 YAML 문서에 적힌 모든 값의 키를 해당 파일 출처로 기록합니다
+func (c *Config) recordFileSources(data []byte, layer SettingLayer, path string) error {
+	var document map[string]interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("설정 파일 파싱 오류 (%s): %w", path, err)
+	}
+	c.recordKeys("", document, SettingSource{Layer: layer, Origin: path})
+	return nil
+}
+
+// recordKeys는 중첩된 맵을 따라 내려가며 값이 있는 키를 점으로 이어 기록합니다
+func (c *Config) recordKeys(prefix string, values map[string]interface{}, source SettingSource) {
+	for key, value := range values {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			c.recordKeys(key, nested, source)
+			continue
+		}
+		c.setSource(key, source)
+	}
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadProjectConfig_RecordsSources(t *testing.T) {
+	globalPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(globalPath, []byte(`
+output_settings:
+  default_template: detailed
+  generate_toc: true
+collection_settings:
+  claude_code:
+    session_dir: "/data/claude"
+`), 0644))
+
+	repo := t.TempDir()
+	rcPath := filepath.Join(repo, ProjectConfigFileName)
+	require.NoError(t, os.WriteFile(rcPath, []byte(`
+output_settings:
+  generate_toc: false
+`), 0644))
+	t.Setenv(EnvDefaultTemplate, "minimal")
+
+	cfg, err := LoadProjectConfig(globalPath, repo)
+	require.NoError(t, err)
+
+	// 나중 계층이 앞선 계층의 출처를 덮어씀
+	source, ok := cfg.SourceOf("output_settings.default_template")
+	require.True(t, ok)
+	assert.Equal(t, SettingSource{Layer: LayerEnv, Origin: EnvDefaultTemplate}, source)
+
+	source, ok = cfg.SourceOf("output_settings.generate_toc")
+	require.True(t, ok)
+	assert.Equal(t, SettingSource{Layer: LayerProject, Origin: rcPath}, source)
+
+	source, ok = cfg.SourceOf("collection_settings.claude_code.session_dir")
+	require.True(t, ok)
+	assert.Equal(t, SettingSource{Layer: LayerGlobal, Origin: globalPath}, source)
+
+	// 어느 계층도 정하지 않은 값은 기본값
+	_, ok = cfg.SourceOf("output_settings.format_code_blocks")
+	assert.False(t, ok)
+
+	// LoadConfig는 .ssamairc를 읽지 않으므로 전역 설정 출처가 남음
+	cfg, err = LoadConfig(globalPath)
+	require.NoError(t, err)
+	source, ok = cfg.SourceOf("output_settings.generate_toc")
+	require.True(t, ok)
+	assert.Equal(t, LayerGlobal, source.Layer)
+}
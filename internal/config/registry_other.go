@@ -0,0 +1,6 @@
+//go:build !windows
+
+package config
+
+// applyRegistryPaths는 Windows가 아닌 플랫폼에서는 아무것도 하지 않습니다
+func applyRegistryPaths(settings *CollectionSettings) {}
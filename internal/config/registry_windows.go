@@ -0,0 +1,110 @@
+//go:build windows
+
+package config
+
+import (
+	"path/filepath"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// registryAccessor는 HKEY_CURRENT_USER 아래 레지스트리 문자열 값을 읽습니다 (테스트에서 교체 가능)
+type registryAccessor interface {
+	ReadString(path, name string) (string, error)
+}
+
+// registryLocation은 도구의 데이터 디렉토리가 기록된 레지스트리 키와 값 이름입니다
+// Layout은 데이터 디렉토리 기준 하위 경로로, 세션/히스토리 등의 경로를 만들 때 사용합니다
+type registryLocation struct {
+	Path   string
+	Name   string
+	Layout CLIToolConfig
+}
+
+// 도구별 데이터 디렉토리 레지스트리 위치 (HKEY_CURRENT_USER 기준)
+var (
+	claudeCodeRegistry = registryLocation{
+		Path:   `Software\Anthropic\Claude Code`,
+		Name:   "DataDir",
+		Layout: CLIToolConfig{SessionDir: "sessions", HistoryFile: "history.json"},
+	}
+	geminiCLIRegistry = registryLocation{
+		Path:   `Software\Google\Gemini CLI`,
+		Name:   "DataDir",
+		Layout: CLIToolConfig{HistoryFile: "history.json", LogsDir: "logs"},
+	}
+	amazonQRegistry = registryLocation{
+		Path:   `Software\Amazon\Amazon Q`,
+		Name:   "DataDir",
+		Layout: CLIToolConfig{HistoryFile: "history.json", CacheDir: "cache"},
+	}
+)
+
+// windowsRegistry는 실제 Windows 레지스트리를 읽는 registryAccessor입니다
+type windowsRegistry struct{}
+
+// ReadString은 문자열 값을 읽고, REG_EXPAND_SZ 값의 %VAR%는 환경 변수로 확장합니다
+func (windowsRegistry) ReadString(path, name string) (string, error) {
+	key, err := registry.OpenKey(registry.CURRENT_USER, path, registry.QUERY_VALUE)
+	if err != nil {
+		return "", err
+	}
+	defer key.Close()
+
+	value, valueType, err := key.GetStringValue(name)
+	if err != nil {
+		return "", err
+	}
+	if valueType == registry.EXPAND_SZ {
+		return registry.ExpandString(value)
+	}
+	return value, nil
+}
+
+// applyRegistryPaths는 설정 디렉토리가 비어 있는 도구의 데이터 디렉토리를 레지스트리에서 찾아 채웁니다
+func applyRegistryPaths(settings *CollectionSettings) {
+	resolveRegistryPaths(settings, windowsRegistry{})
+}
+
+// resolveRegistryPaths는 ConfigDir이 빈 도구만 레지스트리 값으로 채우고,
+// 비어 있는 세션/히스토리/로그/캐시 경로를 그 디렉토리 기준으로 만듭니다
+// 키나 값이 없으면 기존 설정을 그대로 둡니다
+func resolveRegistryPaths(settings *CollectionSettings, accessor registryAccessor) {
+	tools := []struct {
+		cfg      *CLIToolConfig
+		location registryLocation
+	}{
+		{&settings.ClaudeCode, claudeCodeRegistry},
+		{&settings.GeminiCLI, geminiCLIRegistry},
+		{&settings.AmazonQ, amazonQRegistry},
+	}
+	for _, tool := range tools {
+		if tool.cfg.ConfigDir != "" {
+			continue
+		}
+		dir, err := accessor.ReadString(tool.location.Path, tool.location.Name)
+		if err != nil || dir == "" {
+			continue
+		}
+		tool.cfg.ConfigDir = dir
+		deriveToolPaths(tool.cfg, dir, tool.location.Layout)
+	}
+}
+
+// deriveToolPaths는 layout에 정의된 하위 경로 중 cfg에서 비어 있는 항목을 dir 기준으로 채웁니다
+func deriveToolPaths(cfg *CLIToolConfig, dir string, layout CLIToolConfig) {
+	fields := []struct {
+		value    *string
+		relative string
+	}{
+		{&cfg.SessionDir, layout.SessionDir},
+		{&cfg.HistoryFile, layout.HistoryFile},
+		{&cfg.LogsDir, layout.LogsDir},
+		{&cfg.CacheDir, layout.CacheDir},
+	}
+	for _, field := range fields {
+		if *field.value == "" && field.relative != "" {
+			*field.value = filepath.Join(dir, field.relative)
+		}
+	}
+}
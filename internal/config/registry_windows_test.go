@@ -0,0 +1,70 @@
+//go:build windows
+
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRegistry는 경로\값 이름을 키로 하는 테스트용 레지스트리
+type fakeRegistry map[string]string
+
+func (r fakeRegistry) ReadString(path, name string) (string, error) {
+	if value, ok := r[path+`\`+name]; ok {
+		return value, nil
+	}
+	return "", errors.New("registry key not found")
+}
+
+func TestResolveRegistryPaths(t *testing.T) {
+	reg := fakeRegistry{
+		claudeCodeRegistry.Path + `\` + claudeCodeRegistry.Name: `D:\Claude`,
+		geminiCLIRegistry.Path + `\` + geminiCLIRegistry.Name:   `D:\Gemini`,
+	}
+
+	settings := CollectionSettings{
+		GeminiCLI: CLIToolConfig{ConfigDir: `C:\Users\dev\AppData\Roaming\gemini`},
+	}
+	resolveRegistryPaths(&settings, reg)
+
+	// 비어 있던 경로만 레지스트리 값으로 채우고, 세션/히스토리 경로도 그 디렉토리 기준으로 만듦
+	assert.Equal(t, `D:\Claude`, settings.ClaudeCode.ConfigDir)
+	assert.Equal(t, `D:\Claude\sessions`, settings.ClaudeCode.SessionDir)
+	assert.Equal(t, `D:\Claude\history.json`, settings.ClaudeCode.HistoryFile)
+	assert.Equal(t, `C:\Users\dev\AppData\Roaming\gemini`, settings.GeminiCLI.ConfigDir)
+	assert.Empty(t, settings.GeminiCLI.HistoryFile)
+
+	// 레지스트리에 없으면 그대로 비어 있음
+	assert.Empty(t, settings.AmazonQ.ConfigDir)
+}
+
+func TestResolveRegistryPaths_EmptyValueIgnored(t *testing.T) {
+	reg := fakeRegistry{amazonQRegistry.Path + `\` + amazonQRegistry.Name: ""}
+
+	var settings CollectionSettings
+	resolveRegistryPaths(&settings, reg)
+	assert.Empty(t, settings.AmazonQ.ConfigDir)
+}
+
+func TestResolveRegistryPaths_BeforeDefaults(t *testing.T) {
+	reg := fakeRegistry{
+		amazonQRegistry.Path + `\` + amazonQRegistry.Name: `E:\AmazonQ`,
+	}
+
+	var settings CollectionSettings
+	resolveRegistryPaths(&settings, reg)
+	defaults := defaultCollectionSettings("windows", func(string) string { return `C:\Users\dev\AppData\Roaming` })
+	applyCollectionDefaults(&settings, defaults)
+
+	// 레지스트리 디렉토리가 기본 경로보다 우선
+	assert.Equal(t, `E:\AmazonQ`, settings.AmazonQ.ConfigDir)
+	assert.Equal(t, `E:\AmazonQ\history.json`, settings.AmazonQ.HistoryFile)
+	assert.Equal(t, `E:\AmazonQ\cache`, settings.AmazonQ.CacheDir)
+	assert.Equal(t, defaults.AmazonQ.IncludePatterns, settings.AmazonQ.IncludePatterns)
+
+	// 레지스트리에 없는 도구는 기본 경로 사용
+	assert.Equal(t, defaults.ClaudeCode, settings.ClaudeCode)
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ContentRewrite는 소스별로 메시지 내용에 적용할 정규식 치환 규칙입니다
+// replacement에서는 $1, ${name} 형식으로 캡처 그룹을 참조할 수 있습니다
+type ContentRewrite struct {
+	Pattern     string `yaml:"pattern"`
+	Replacement string `yaml:"replacement"`
+}
+
+// CompiledRewrite는 컴파일된 치환 규칙입니다
+type CompiledRewrite struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// Apply는 내용에서 패턴과 일치하는 모든 부분을 치환합니다
+func (r CompiledRewrite) Apply(content string) string {
+	return r.pattern.ReplaceAllString(content, r.replacement)
+}
+
+// CompileContentRewrites는 치환 규칙을 한 번에 컴파일합니다
+// 잘못된 정규식이 있으면 몇 번째 규칙인지와 함께 에러를 반환합니다
+func CompileContentRewrites(rules []ContentRewrite) ([]CompiledRewrite, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	compiled := make([]CompiledRewrite, 0, len(rules))
+	for i, rule := range rules {
+		if rule.Pattern == "" {
+			return nil, fmt.Errorf("content_rewrites[%d]: 패턴이 비어 있습니다", i)
+		}
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("content_rewrites[%d]: 잘못된 정규식 %q: %w", i, rule.Pattern, err)
+		}
+		compiled = append(compiled, CompiledRewrite{pattern: pattern, replacement: rule.Replacement})
+	}
+	return compiled, nil
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileContentRewrites(t *testing.T) {
+	rules, err := CompileContentRewrites([]ContentRewrite{
+		{Pattern: `^\[SYSTEM PROMPT\]\s*`, Replacement: ""},
+		{Pattern: `(\w+)@example\.com`, Replacement: "$1@redacted"},
+	})
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+
+	content := "[SYSTEM PROMPT] mail alice@example.com"
+	for _, rule := range rules {
+		content = rule.Apply(content)
+	}
+	assert.Equal(t, "mail alice@redacted", content)
+
+	rules, err = CompileContentRewrites(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, rules)
+}
+
+func TestCompileContentRewrites_Invalid(t *testing.T) {
+	_, err := CompileContentRewrites([]ContentRewrite{
+		{Pattern: `ok`},
+		{Pattern: `([unclosed`},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "content_rewrites[1]")
+
+	_, err = CompileContentRewrites([]ContentRewrite{{Pattern: ""}})
+	assert.Error(t, err)
+}
+
+func TestLoadConfig_ContentRewrites(t *testing.T) {
+	dir := t.TempDir()
+
+	validPath := filepath.Join(dir, "valid.yaml")
+	require.NoError(t, os.WriteFile(validPath, []byte(`
+collection_settings:
+  gemini_cli:
+    config_dir: "~/.gemini"
+    content_rewrites:
+      - pattern: "^Gemini says: "
+        replacement: ""
+`), 0644))
+
+	cfg, err := LoadConfig(validPath)
+	require.NoError(t, err)
+	assert.Equal(t, []ContentRewrite{{Pattern: "^Gemini says: ", Replacement: ""}},
+		cfg.CollectionSettings.GeminiCLI.ContentRewrites)
+	assert.Empty(t, cfg.CollectionSettings.ClaudeCode.ContentRewrites)
+
+	invalidPath := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalidPath, []byte(`
+collection_settings:
+  amazon_q:
+    content_rewrites:
+      - pattern: "(*bad"
+`), 0644))
+
+	_, err = LoadConfig(invalidPath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "collection_settings.amazon_q.content_rewrites[0]")
+}
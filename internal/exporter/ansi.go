@@ -0,0 +1,195 @@
+package exporter
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ansiEscapePattern은 터미널 출력에 섞인 ANSI 이스케이프 시퀀스(CSI, OSC)를 찾습니다
+// 첫 번째 그룹은 SGR(색상/스타일) 시퀀스의 매개변수입니다
+var ansiEscapePattern = regexp.MustCompile(`\x1b\[([0-9;]*)m|\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+
+// ansiPalette는 기본 8색(30-37/40-47)과 밝은 8색(90-97/100-107)의 HTML 색상입니다
+var ansiPalette = [16]string{
+	"#000000", "#cd3131", "#0dbc79", "#e5e510", "#2472c8", "#bc3fbc", "#11a8cd", "#e5e5e5",
+	"#666666", "#f14c4c", "#23d18b", "#f5f543", "#3b8eea", "#d670d6", "#29b8db", "#ffffff",
+}
+
+// HasANSI는 text에 ANSI 이스케이프 시퀀스가 있는지 확인합니다
+func HasANSI(text string) bool {
+	return strings.Contains(text, "\x1b")
+}
+
+// StripANSI는 text에서 ANSI 이스케이프 시퀀스를 모두 제거합니다
+func StripANSI(text string) string {
+	if !HasANSI(text) {
+		return text
+	}
+	return ansiEscapePattern.ReplaceAllString(text, "")
+}
+
+// ansiStyle은 SGR 시퀀스로 바뀌는 현재 글자 스타일입니다
+type ansiStyle struct {
+	foreground string
+	background string
+	bold       bool
+	underline  bool
+}
+
+// css는 스타일을 <span style> 속성 값으로 변환합니다 (기본 스타일이면 빈 문자열)
+func (s ansiStyle) css() string {
+	var rules []string
+	if s.foreground != "" {
+		rules = append(rules, "color:"+s.foreground)
+	}
+	if s.background != "" {
+		rules = append(rules, "background-color:"+s.background)
+	}
+	if s.bold {
+		rules = append(rules, "font-weight:bold")
+	}
+	if s.underline {
+		rules = append(rules, "text-decoration:underline")
+	}
+	return strings.Join(rules, ";")
+}
+
+// apply는 SGR 매개변수(예: "1;31")를 스타일에 반영합니다
+func (s *ansiStyle) apply(params string) {
+	if params == "" {
+		*s = ansiStyle{}
+		return
+	}
+
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		code, err := strconv.Atoi(codes[i])
+		if err != nil {
+			continue
+		}
+		switch {
+		case code == 0:
+			*s = ansiStyle{}
+		case code == 1:
+			s.bold = true
+		case code == 4:
+			s.underline = true
+		case code == 22:
+			s.bold = false
+		case code == 24:
+			s.underline = false
+		case code >= 30 && code <= 37:
+			s.foreground = ansiPalette[code-30]
+		case code >= 90 && code <= 97:
+			s.foreground = ansiPalette[code-90+8]
+		case code == 39:
+			s.foreground = ""
+		case code >= 40 && code <= 47:
+			s.background = ansiPalette[code-40]
+		case code >= 100 && code <= 107:
+			s.background = ansiPalette[code-100+8]
+		case code == 49:
+			s.background = ""
+		case code == 38 || code == 48:
+			color, consumed := ansiExtendedColor(codes[i+1:])
+			i += consumed
+			if code == 38 {
+				s.foreground = color
+			} else {
+				s.background = color
+			}
+		}
+	}
+}
+
+// ansiExtendedColor는 38/48 뒤의 256색(5;n) 또는 트루컬러(2;r;g;b) 매개변수를 해석합니다
+// 해석한 색상과 사용한 매개변수 수를 반환합니다 (해석할 수 없으면 빈 색상)
+func ansiExtendedColor(params []string) (string, int) {
+	values := make([]int, 0, 4)
+	for _, param := range params {
+		value, err := strconv.Atoi(param)
+		if err != nil {
+			break
+		}
+		values = append(values, value)
+	}
+
+	switch {
+	case len(values) >= 2 && values[0] == 5:
+		return ansi256Color(values[1]), 2
+	case len(values) >= 4 && values[0] == 2:
+		return fmt.Sprintf("#%02x%02x%02x", clampByte(values[1]), clampByte(values[2]), clampByte(values[3])), 4
+	default:
+		return "", len(values)
+	}
+}
+
+// ansi256Color는 256색 팔레트 번호를 HTML 색상으로 변환합니다
+func ansi256Color(n int) string {
+	switch {
+	case n < 0 || n > 255:
+		return ""
+	case n < 16:
+		return ansiPalette[n]
+	case n < 232:
+		// 6x6x6 색상 큐브
+		n -= 16
+		level := func(v int) int {
+			if v == 0 {
+				return 0
+			}
+			return 55 + v*40
+		}
+		return fmt.Sprintf("#%02x%02x%02x", level(n/36), level(n/6%6), level(n%6))
+	default:
+		gray := 8 + (n-232)*10
+		return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray)
+	}
+}
+
+func clampByte(v int) int {
+	return max(0, min(v, 255))
+}
+
+// ANSIToHTML은 text의 ANSI 색상/스타일을 <span style>로 바꾼 HTML을 반환합니다
+// 텍스트는 HTML 이스케이프하며, 색상 외의 이스케이프 시퀀스는 제거합니다
+func ANSIToHTML(text string) string {
+	var out strings.Builder
+	var style ansiStyle
+	open := false
+
+	writeText := func(segment string) {
+		if segment == "" {
+			return
+		}
+		if css := style.css(); css != "" && !open {
+			out.WriteString(fmt.Sprintf(`<span style="%s">`, css))
+			open = true
+		}
+		out.WriteString(html.EscapeString(segment))
+	}
+
+	last := 0
+	for _, match := range ansiEscapePattern.FindAllStringSubmatchIndex(text, -1) {
+		writeText(text[last:match[0]])
+		last = match[1]
+
+		// SGR이 아닌 시퀀스(커서 이동 등)는 버림
+		if match[2] < 0 {
+			continue
+		}
+		if open {
+			out.WriteString("</span>")
+			open = false
+		}
+		style.apply(text[match[2]:match[3]])
+	}
+	writeText(text[last:])
+	if open {
+		out.WriteString("</span>")
+	}
+	return out.String()
+}
@@ -0,0 +1,50 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain text", "ok  ssamai/cmd", "ok  ssamai/cmd"},
+		{"colors", "\x1b[32mPASS\x1b[0m TestA\n\x1b[1;31mFAIL\x1b[m TestB", "PASS TestA\nFAIL TestB"},
+		{"256 and true color", "\x1b[38;5;208mwarn\x1b[0m \x1b[48;2;10;20;30mbg\x1b[0m", "warn bg"},
+		{"cursor and erase", "progress\x1b[2K\x1b[1Gdone\x1b[?25h", "progressdone"},
+		{"osc title", "\x1b]0;build\x07output", "output"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, StripANSI(tt.input))
+		})
+	}
+}
+
+func TestANSIToHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain text is escaped", "a < b && c", "a &lt; b &amp;&amp; c"},
+		{"basic color", "\x1b[32mPASS\x1b[0m ok", `<span style="color:#0dbc79">PASS</span> ok`},
+		{"bold bright color", "\x1b[1;91mFAIL\x1b[22m still red\x1b[39m",
+			`<span style="color:#f14c4c;font-weight:bold">FAIL</span><span style="color:#f14c4c"> still red</span>`},
+		{"background", "\x1b[30;47m<x>\x1b[0m", `<span style="color:#000000;background-color:#e5e5e5">&lt;x&gt;</span>`},
+		{"256 color", "\x1b[38;5;196mhot\x1b[0m", `<span style="color:#ff0000">hot</span>`},
+		{"true color", "\x1b[38;2;1;2;3mrgb\x1b[0m", `<span style="color:#010203">rgb</span>`},
+		{"unclosed style and non-color escapes", "\x1b[2K\x1b[4mtail", `<span style="text-decoration:underline">tail</span>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ANSIToHTML(tt.input))
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"ssamai/internal/processor"
+	"ssamai/pkg/models"
+)
+
+// appendJournalTitle은 누적 기록 파일을 처음 만들 때 쓰는 제목입니다
+const appendJournalTitle = "# AI CLI 도구 활동 기록\n\n"
+
+// appendedSessionPattern은 문서에 이미 있는 세션의 내용 해시를 찾습니다
+// 누적 모드가 남긴 표식과 --content-hash 메타데이터 줄을 모두 인식합니다
+var appendedSessionPattern = regexp.MustCompile(`(?m)(?:<!-- ssamai:session ([0-9a-f]{64}) -->|^- content_hash: ([0-9a-f]{64})$)`)
+
+// ExportAppend는 출력 파일에 아직 없는 세션만 날짜별 섹션으로 덧붙이고 추가한 세션 수를 반환합니다
+// 세션은 내용 해시로 식별하므로 같은 대화를 여러 번 내보내도 중복되지 않습니다
+func (e *MarkdownExporter) ExportAppend(ctx context.Context, data processor.ProcessedData, date time.Time) (int, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	default:
+	}
+
+	if err := e.Validate(); err != nil {
+		return 0, fmt.Errorf("내보내기 설정 검증 실패: %w", err)
+	}
+
+	existing, err := os.ReadFile(e.config.OutputPath)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, fmt.Errorf("기존 출력 파일 읽기 실패: %w", err)
+	}
+
+	document, appended := e.appendSessions(string(existing), data.Sessions, date)
+	if appended == 0 {
+		return 0, nil
+	}
+
+	if err := writeOutputFile(e.sink, e.config, []byte(document)); err != nil {
+		return 0, err
+	}
+	return appended, nil
+}
+
+// appendSessions는 기존 문서 끝에 새 세션들을 "## 날짜" 섹션으로 추가한 문서와 추가한 세션 수를 반환합니다
+// 새 세션은 오래된 순서로 기록되어 문서가 시간순 일지가 됩니다
+func (e *MarkdownExporter) appendSessions(document string, sessions []models.SessionData, date time.Time) (string, int) {
+	known := existingSessionHashes(document)
+
+	type pendingSession struct {
+		session models.SessionData
+		hash    string
+	}
+	var pending []pendingSession
+	for _, session := range sessions {
+		hash := processor.SessionContentHash(session)
+		if known[hash] {
+			continue
+		}
+		known[hash] = true
+		pending = append(pending, pendingSession{session: session, hash: hash})
+	}
+	if len(pending) == 0 {
+		return document, 0
+	}
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].session.Timestamp.Before(pending[j].session.Timestamp)
+	})
+
+	var content strings.Builder
+	if strings.TrimSpace(document) == "" {
+		content.WriteString(appendJournalTitle)
+	} else {
+		content.WriteString(strings.TrimRight(document, "\n"))
+		content.WriteString("\n\n")
+	}
+
+	content.WriteString(fmt.Sprintf("## %s\n\n", date.Format("2006-01-02")))
+	for _, p := range pending {
+		content.WriteString(fmt.Sprintf("<!-- ssamai:session %s -->\n", p.hash))
+		e.writeSession(&content, p.session, p.session.Source)
+	}
+
+	return content.String(), len(pending)
+}
+
+// existingSessionHashes는 문서에 기록된 세션 내용 해시 집합을 반환합니다
+func existingSessionHashes(document string) map[string]bool {
+	hashes := make(map[string]bool)
+	for _, match := range appendedSessionPattern.FindAllStringSubmatch(document, -1) {
+		for _, hash := range match[1:] {
+			if hash != "" {
+				hashes[hash] = true
+			}
+		}
+	}
+	return hashes
+}
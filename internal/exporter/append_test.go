@@ -0,0 +1,87 @@
+package exporter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ssamai/internal/processor"
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func appendTestSession(id, content string, ts time.Time) models.SessionData {
+	return models.SessionData{
+		ID:        id,
+		Source:    models.SourceClaudeCode,
+		Title:     "Session " + id,
+		Timestamp: ts,
+		Messages:  []models.Message{{Role: "user", Content: content}},
+	}
+}
+
+func TestMarkdownExporter_ExportAppend(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "journal.md")
+	e := NewMarkdownExporter(&models.ExportConfig{OutputPath: output})
+	ctx := context.Background()
+
+	jan14 := time.Date(2024, 1, 14, 9, 0, 0, 0, time.UTC)
+	first := processor.ProcessedData{Sessions: []models.SessionData{
+		appendTestSession("b", "second question", jan14.Add(time.Hour)),
+		appendTestSession("a", "first question", jan14),
+	}}
+
+	appended, err := e.ExportAppend(ctx, first, time.Date(2024, 1, 14, 18, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 2, appended)
+
+	// 두 번째 실행: 이미 있는 세션 두 개와 새 세션 하나
+	second := processor.ProcessedData{Sessions: append([]models.SessionData{
+		appendTestSession("c", "third question", jan14.Add(24*time.Hour)),
+	}, first.Sessions...)}
+
+	appended, err = e.ExportAppend(ctx, second, time.Date(2024, 1, 15, 18, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 1, appended)
+
+	data, err := os.ReadFile(output)
+	require.NoError(t, err)
+	doc := string(data)
+
+	assert.True(t, strings.HasPrefix(doc, appendJournalTitle+"## 2024-01-14\n\n"), "doc: %q", doc)
+	for _, id := range []string{"a", "b", "c"} {
+		assert.Equal(t, 1, strings.Count(doc, "### Session "+id+" "), id)
+	}
+	assert.Equal(t, 3, strings.Count(doc, "<!-- ssamai:session "))
+
+	// 날짜 섹션 순서와 섹션 안의 시간순 정렬
+	day14 := strings.Index(doc, "## 2024-01-14")
+	day15 := strings.Index(doc, "## 2024-01-15")
+	require.Positive(t, day15)
+	assert.Less(t, day14, strings.Index(doc, "Session a"))
+	assert.Less(t, strings.Index(doc, "Session a"), strings.Index(doc, "Session b"))
+	assert.Less(t, strings.Index(doc, "Session b"), day15)
+	assert.Less(t, day15, strings.Index(doc, "Session c"))
+
+	// 새 세션이 없으면 파일을 건드리지 않음
+	appended, err = e.ExportAppend(ctx, second, time.Date(2024, 1, 16, 18, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Zero(t, appended)
+	unchanged, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.Equal(t, doc, string(unchanged))
+}
+
+func TestExistingSessionHashes(t *testing.T) {
+	hash := strings.Repeat("ab", 32)
+	other := strings.Repeat("cd", 32)
+	doc := "<!-- ssamai:session " + hash + " -->\n### Title\n\n**메타데이터**:\n- content_hash: " + other + "\n"
+
+	assert.Equal(t, map[string]bool{hash: true, other: true}, existingSessionHashes(doc))
+	assert.Empty(t, existingSessionHashes("# 제목\n\n- content_hash: short\n"))
+}
@@ -0,0 +1,54 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// WriteFileAtomic은 data를 같은 디렉토리의 임시 파일에 쓴 뒤 name으로 이름을 바꿉니다
+// 쓰는 도중 실패하거나 중단되어도 name에는 기존 파일(또는 파일 없음)만 남고 잘린 파일은 보이지 않습니다
+func WriteFileAtomic(name string, data []byte, perm os.FileMode) error {
+	return WriteAtomic(name, perm, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// WriteAtomic은 write가 임시 파일에 내용을 모두 쓰고 성공한 경우에만 name을 교체합니다
+// name이 이미 있으면 기존 파일의 권한을 유지하고, 없으면 perm을 사용합니다
+func WriteAtomic(name string, perm os.FileMode, write func(io.Writer) error) (err error) {
+	if info, statErr := os.Stat(name); statErr == nil {
+		perm = info.Mode().Perm()
+	}
+
+	// rename이 원자적이려면 임시 파일이 같은 파일 시스템(같은 디렉토리)에 있어야 함
+	tmp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("임시 파일 생성 실패: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if err := write(tmp); err != nil {
+		return fmt.Errorf("임시 파일 쓰기 실패: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return fmt.Errorf("임시 파일 동기화 실패: %w", err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		return fmt.Errorf("임시 파일 권한 설정 실패: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("임시 파일 닫기 실패: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return fmt.Errorf("파일 교체 실패: %w", err)
+	}
+	return nil
+}
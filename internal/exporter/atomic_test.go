@@ -0,0 +1,75 @@
+package exporter
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// partialWrite는 내용의 절반만 쓰고 실패하는 쓰기 함수를 반환합니다 (디스크 부족 등으로 중단된 경우)
+func partialWrite(data []byte) func(io.Writer) error {
+	return func(w io.Writer) error {
+		if _, err := w.Write(data[:len(data)/2]); err != nil {
+			return err
+		}
+		return errors.New("no space left on device")
+	}
+}
+
+func TestWriteAtomic_FailureKeepsOriginal(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "summary.md")
+	require.NoError(t, os.WriteFile(path, []byte("# original\n"), 0644))
+
+	err := WriteAtomic(path, 0644, partialWrite([]byte("# replacement document\n")))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no space left on device")
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# original\n", string(content))
+
+	// 임시 파일도 남지 않음
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "summary.md", entries[0].Name())
+}
+
+func TestWriteAtomic_FailureLeavesNoFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "summary.md")
+
+	require.Error(t, WriteAtomic(path, 0644, partialWrite([]byte("# new document\n"))))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestWriteFileAtomic_ReplacesAndKeepsMode(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "summary.md")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0600))
+
+	require.NoError(t, WriteFileAtomic(path, []byte("new"), 0644))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(content))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// 새 파일은 지정한 권한으로 생성
+	created := filepath.Join(dir, "created.md")
+	require.NoError(t, WriteFileAtomic(created, []byte("x"), 0644))
+	info, err = os.Stat(created)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+}
@@ -0,0 +1,152 @@
+package exporter
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"ssamai/pkg/models"
+)
+
+// codeDirName은 추출한 코드 블록을 저장하는 디렉토리 이름입니다 (출력 파일 기준 상대 경로)
+const codeDirName = "code"
+
+// codeExtensions는 코드 블록 언어 태그별 파일 확장자입니다
+// 목록에 없는 언어는 영숫자로 된 태그를 그대로 확장자로 사용합니다
+var codeExtensions = map[string]string{
+	"golang":     "go",
+	"python":     "py",
+	"python3":    "py",
+	"javascript": "js",
+	"typescript": "ts",
+	"bash":       "sh",
+	"shell":      "sh",
+	"zsh":        "sh",
+	"yml":        "yaml",
+	"rust":       "rs",
+	"ruby":       "rb",
+	"c++":        "cpp",
+	"csharp":     "cs",
+	"kotlin":     "kt",
+	"markdown":   "md",
+	"text":       "txt",
+	"plaintext":  "txt",
+}
+
+// codeExtraction은 한 번의 내보내기에서 추출한 코드 파일들을 모읍니다
+type codeExtraction struct {
+	files map[string]string // code/ 아래 파일 이름 -> 내용
+	order []string
+}
+
+func newCodeExtraction() *codeExtraction {
+	return &codeExtraction{files: make(map[string]string)}
+}
+
+// add는 코드 파일을 등록하고 실제 사용된(중복되지 않는) 파일 이름을 반환합니다
+func (c *codeExtraction) add(name, code string) string {
+	candidate := name
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for i := 2; ; i++ {
+		if _, exists := c.files[candidate]; !exists {
+			break
+		}
+		candidate = fmt.Sprintf("%s_%d%s", base, i, ext)
+	}
+
+	c.files[candidate] = code
+	c.order = append(c.order, candidate)
+	return candidate
+}
+
+// write는 추출한 코드 파일을 baseDir/code/ 아래에 씁니다
+func (c *codeExtraction) write(sink Sink, baseDir string) ([]string, error) {
+	written := make([]string, 0, len(c.order))
+	for _, name := range c.order {
+		filePath := filepath.Join(baseDir, codeDirName, name)
+		if err := sink.Write(filePath, []byte(c.files[name])); err != nil {
+			return written, fmt.Errorf("코드 파일 쓰기 실패 (%s): %w", filePath, err)
+		}
+		written = append(written, filePath)
+	}
+	return written, nil
+}
+
+// extractSessionCode는 세션 메시지의 언어가 지정된 코드 블록을 파일로 분리하고 링크로 바꾼 세션 사본을 반환합니다
+// 파일 이름은 "<소스>-<세션ID>-<번호>.<확장자>" 형식입니다
+func (e *MarkdownExporter) extractSessionCode(session models.SessionData, source models.CollectionSource) models.SessionData {
+	if len(session.Messages) == 0 {
+		return session
+	}
+
+	prefix := strings.TrimSuffix(e.sessionFileName(source, session.ID), ".md")
+	count := 0
+
+	messages := make([]models.Message, len(session.Messages))
+	copy(messages, session.Messages)
+	for i := range messages {
+		messages[i].Content = extractCodeBlocks(messages[i].Content, func(lang, code string) string {
+			count++
+			return e.extractedCode.add(fmt.Sprintf("%s-%d.%s", prefix, count, codeExtension(lang)), code)
+		})
+	}
+
+	session.Messages = messages
+	return session
+}
+
+// extractCodeBlocks는 언어 태그가 있는 닫힌 코드 블록을 save가 반환한 파일에 대한 링크로 바꿉니다
+// 언어가 없거나 닫히지 않은 코드 블록은 그대로 둡니다
+func extractCodeBlocks(content string, save func(lang, code string) string) string {
+	if !strings.Contains(content, "```") {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	result := make([]string, 0, len(lines))
+
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		lang := strings.TrimSpace(strings.TrimPrefix(trimmed, "```"))
+		if !strings.HasPrefix(trimmed, "```") || lang == "" {
+			result = append(result, lines[i])
+			continue
+		}
+
+		end := -1
+		for j := i + 1; j < len(lines); j++ {
+			if strings.TrimSpace(lines[j]) == "```" {
+				end = j
+				break
+			}
+		}
+		if end < 0 {
+			result = append(result, lines[i:]...)
+			break
+		}
+
+		code := strings.Join(lines[i+1:end], "\n") + "\n"
+		name := save(lang, code)
+		result = append(result, fmt.Sprintf("📎 [see %s](%s) (%s, %d줄)",
+			name, path.Join(codeDirName, name), lang, end-i-1))
+		i = end
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// codeExtension은 언어 태그에 맞는 파일 확장자를 반환합니다
+func codeExtension(lang string) string {
+	lang = strings.ToLower(strings.Fields(lang)[0])
+	if ext, ok := codeExtensions[lang]; ok {
+		return ext
+	}
+	for _, r := range lang {
+		if !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') {
+			return "txt"
+		}
+	}
+	return lang
+}
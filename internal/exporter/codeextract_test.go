@@ -0,0 +1,105 @@
+package exporter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ssamai/internal/processor"
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractCodeBlocks(t *testing.T) {
+	content := "Here it is:\n```go\npackage main\n\nfunc main() {}\n```\nAnd a plain block:\n```\nno language\n```\nUnclosed:\n```python\nprint(1)"
+
+	var saved []string
+	result := extractCodeBlocks(content, func(lang, code string) string {
+		saved = append(saved, lang+":"+code)
+		return "s-1.go"
+	})
+
+	assert.Equal(t, []string{"go:package main\n\nfunc main() {}\n"}, saved)
+	assert.Equal(t, "Here it is:\n📎 [see s-1.go](code/s-1.go) (go, 3줄)\nAnd a plain block:\n```\nno language\n```\nUnclosed:\n```python\nprint(1)", result)
+}
+
+func TestCodeExtension(t *testing.T) {
+	for lang, want := range map[string]string{
+		"go":           "go",
+		"Python":       "py",
+		"ts":           "ts",
+		"bash":         "sh",
+		"c++":          "cpp",
+		"js title=app": "js",
+		"objective-c":  "txt",
+	} {
+		assert.Equal(t, want, codeExtension(lang), lang)
+	}
+}
+
+func TestMarkdownExporter_ExtractCode(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &models.ExportConfig{OutputPath: filepath.Join(dir, "summary.md"), ExtractCode: true}
+
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	sessions := []models.SessionData{{
+		ID:        "session1",
+		Source:    models.SourceGeminiCLI,
+		Timestamp: now,
+		Title:     "Code Session",
+		Messages: []models.Message{
+			{Role: "user", Content: "write it", Timestamp: now},
+			{Role: "assistant", Content: "```go\npackage main\n```\n\n```python\nprint('hi')\n```", Timestamp: now},
+		},
+	}}
+	data, err := processor.NewProcessor(cfg).Process(context.Background(), sessions)
+	require.NoError(t, err)
+
+	_, err = NewMarkdownExporter(cfg).ExportWithResult(context.Background(), data)
+	require.NoError(t, err)
+
+	goCode, err := os.ReadFile(filepath.Join(dir, "code", "gemini_cli-session1-1.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main\n", string(goCode))
+
+	pyCode, err := os.ReadFile(filepath.Join(dir, "code", "gemini_cli-session1-2.py"))
+	require.NoError(t, err)
+	assert.Equal(t, "print('hi')\n", string(pyCode))
+
+	markdown, err := os.ReadFile(cfg.OutputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(markdown), "[see gemini_cli-session1-1.go](code/gemini_cli-session1-1.go)")
+	assert.Contains(t, string(markdown), "[see gemini_cli-session1-2.py](code/gemini_cli-session1-2.py)")
+	assert.NotContains(t, string(markdown), "package main")
+
+	// 옵션을 끄면 코드 블록을 그대로 둠
+	cfg.ExtractCode = false
+	cfg.OutputPath = filepath.Join(dir, "inline.md")
+	_, err = NewMarkdownExporter(cfg).ExportWithResult(context.Background(), data)
+	require.NoError(t, err)
+	markdown, err = os.ReadFile(cfg.OutputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(markdown), "```go\npackage main\n```")
+}
+
+func TestMarkdownExporter_ExtractCodePerSession(t *testing.T) {
+	cfg := &models.ExportConfig{ExtractCode: true}
+	data := processor.ProcessedData{
+		Sessions: []models.SessionData{{ID: "s1", Source: models.SourceClaudeCode,
+			Messages: []models.Message{{Role: "assistant", Content: "```sh\nls\n```"}}}},
+	}
+	data.SourceGroups = map[models.CollectionSource][]models.SessionData{models.SourceClaudeCode: data.Sessions}
+
+	sink := newMemorySink()
+	files, err := NewMarkdownExporter(cfg).WithSink(sink).ExportPerSession(context.Background(), data, "wiki")
+	require.NoError(t, err)
+
+	codePath := filepath.Join("wiki", "code", "claude_code-s1-1.sh")
+	assert.Contains(t, files, codePath)
+	assert.Equal(t, "ls\n", string(sink.files[codePath]))
+	assert.Contains(t, string(sink.files[filepath.Join("wiki", "claude_code-s1.md")]), "(code/claude_code-s1-1.sh)")
+}
@@ -0,0 +1,179 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"ssamai/internal/interfaces"
+	"ssamai/internal/processor"
+	"ssamai/pkg/models"
+)
+
+// FormatCSV는 CSV 내보내기 형식 이름입니다
+const FormatCSV = "csv"
+
+// CSV 내보내기 단위
+const (
+	CSVLevelMessage = "message"
+	CSVLevelSession = "session"
+)
+
+// CSVExporter는 세션 데이터를 CSV로 내보냅니다
+// 기본은 메시지당 한 행이며, session 단위에서는 세션당 한 행을 출력합니다
+type CSVExporter struct {
+	config *models.ExportConfig
+	sink   Sink
+}
+
+// CSVExporter가 내보내기 인터페이스들을 구현하는지 컴파일 타임에 확인
+var _ interfaces.FullDataExporter = (*CSVExporter)(nil)
+
+// NewCSVExporter는 새로운 CSV 내보내기 도구를 생성합니다
+func NewCSVExporter(config *models.ExportConfig) *CSVExporter {
+	return &CSVExporter{
+		config: config,
+		sink:   &LocalSink{},
+	}
+}
+
+// WithSink는 출력 저장소 의존성 주입
+func (e *CSVExporter) WithSink(sink Sink) *CSVExporter {
+	e.sink = sink
+	return e
+}
+
+// Export는 처리된 데이터를 CSV 파일로 내보냅니다
+func (e *CSVExporter) Export(ctx context.Context, data interface{}) error {
+	if err := e.Validate(); err != nil {
+		return fmt.Errorf("내보내기 설정 검증 실패: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := e.ExportToWriter(ctx, data, &buf); err != nil {
+		return err
+	}
+
+	return writeOutputFile(e.sink, e.config, buf.Bytes())
+}
+
+// ExportToWriter는 처리된 데이터를 CSV로 Writer에 출력합니다
+func (e *CSVExporter) ExportToWriter(ctx context.Context, data interface{}, writer io.Writer) error {
+	// context 취소 확인
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	processedData, ok := data.(processor.ProcessedData)
+	if !ok {
+		return fmt.Errorf("잘못된 데이터 타입입니다. processor.ProcessedData가 필요합니다")
+	}
+
+	location, err := e.config.DisplayLocation()
+	if err != nil {
+		return err
+	}
+
+	var records [][]string
+	if e.level() == CSVLevelSession {
+		records = sessionRecords(processedData.Sessions, location)
+	} else {
+		records = messageRecords(processedData.Sessions, location)
+	}
+
+	w := csv.NewWriter(writer)
+	if err := w.WriteAll(records); err != nil {
+		return fmt.Errorf("CSV 출력 실패: %w", err)
+	}
+	return nil
+}
+
+// GetFormat은 내보내기 형식을 반환합니다
+func (e *CSVExporter) GetFormat() string {
+	return FormatCSV
+}
+
+// Validate는 내보내기 설정이 유효한지 검증합니다
+func (e *CSVExporter) Validate() error {
+	if e.config == nil {
+		return fmt.Errorf("내보내기 설정이 nil입니다")
+	}
+	if e.config.OutputPath == "" {
+		return fmt.Errorf("출력 경로가 지정되지 않았습니다")
+	}
+	if _, err := e.config.DisplayLocation(); err != nil {
+		return err
+	}
+	switch e.config.CSVLevel {
+	case "", CSVLevelMessage, CSVLevelSession:
+	default:
+		return fmt.Errorf("지원하지 않는 CSV 단위입니다: %s (message 또는 session)", e.config.CSVLevel)
+	}
+	return nil
+}
+
+// GetSupportedTemplates는 지원하는 CSV 단위를 반환합니다 (CSV는 템플릿을 사용하지 않음)
+func (e *CSVExporter) GetSupportedTemplates() []string {
+	return []string{CSVLevelMessage, CSVLevelSession}
+}
+
+// level은 설정된 CSV 단위를 반환합니다 (기본값: message)
+func (e *CSVExporter) level() string {
+	if e.config == nil || e.config.CSVLevel == "" {
+		return CSVLevelMessage
+	}
+	return e.config.CSVLevel
+}
+
+// messageRecords는 메시지당 한 행의 CSV 레코드를 생성합니다
+func messageRecords(sessions []models.SessionData, location *time.Location) [][]string {
+	records := [][]string{{"source", "session_id", "message_id", "role", "timestamp", "content"}}
+	for _, session := range sessions {
+		for _, message := range session.Messages {
+			records = append(records, []string{
+				string(session.Source),
+				session.ID,
+				message.ID,
+				message.Role,
+				formatCSVTime(message.Timestamp, location),
+				message.Content,
+			})
+		}
+	}
+	return records
+}
+
+// sessionRecords는 세션당 한 행의 CSV 레코드를 생성합니다
+func sessionRecords(sessions []models.SessionData, location *time.Location) [][]string {
+	records := [][]string{{"source", "id", "title", "timestamp", "message_count", "command_count", "file_count"}}
+	for _, session := range sessions {
+		records = append(records, []string{
+			string(session.Source),
+			session.ID,
+			session.Title,
+			formatCSVTime(session.Timestamp, location),
+			strconv.Itoa(len(session.Messages)),
+			strconv.Itoa(len(session.Commands)),
+			strconv.Itoa(len(session.Files)),
+		})
+	}
+	return records
+}
+
+// formatCSVTime은 시간을 RFC3339 형식으로 변환합니다 (zero 값은 빈 문자열)
+// location이 있으면 해당 시간대로 변환해 표시합니다
+func formatCSVTime(t time.Time, location *time.Location) string {
+	if t.IsZero() {
+		return ""
+	}
+	if location != nil {
+		t = t.In(location)
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,88 @@
+package exporter
+
+import (
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readCSV(t *testing.T, content string) [][]string {
+	t.Helper()
+
+	records, err := csv.NewReader(strings.NewReader(content)).ReadAll()
+	require.NoError(t, err)
+	return records
+}
+
+func TestCSVExporter_SessionLevel(t *testing.T) {
+	cfg := &models.ExportConfig{CSVLevel: CSVLevelSession}
+	data := newTestProcessedData(t, cfg)
+
+	var buf strings.Builder
+	require.NoError(t, NewCSVExporter(cfg).ExportToWriter(context.Background(), data, &buf))
+
+	records := readCSV(t, buf.String())
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"source", "id", "title", "timestamp", "message_count", "command_count", "file_count"}, records[0])
+
+	rows := map[string][]string{}
+	for _, record := range records[1:] {
+		rows[record[1]] = record
+	}
+	assert.Equal(t, []string{"claude_code", "claude-1", "Claude Session", "2024-01-02T12:00:00Z", "2", "1", "1"}, rows["claude-1"])
+	assert.Equal(t, []string{"gemini_cli", "gemini-1", "Gemini Session", "2024-01-02T11:00:00Z", "1", "0", "0"}, rows["gemini-1"])
+}
+
+func TestCSVExporter_DisplayTimezone(t *testing.T) {
+	cfg := &models.ExportConfig{CSVLevel: CSVLevelSession, DisplayTimezone: "Asia/Seoul"}
+	data := newTestProcessedData(t, cfg)
+
+	var buf strings.Builder
+	require.NoError(t, NewCSVExporter(cfg).ExportToWriter(context.Background(), data, &buf))
+
+	rows := map[string][]string{}
+	for _, record := range readCSV(t, buf.String())[1:] {
+		rows[record[1]] = record
+	}
+	assert.Equal(t, "2024-01-02T21:00:00+09:00", rows["claude-1"][3])
+
+	cfg.DisplayTimezone = "Invalid/Zone"
+	err := NewCSVExporter(cfg).ExportToWriter(context.Background(), data, &buf)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid/Zone")
+}
+
+func TestCSVExporter_MessageLevel(t *testing.T) {
+	cfg := &models.ExportConfig{}
+	data := newTestProcessedData(t, cfg)
+
+	var buf strings.Builder
+	require.NoError(t, NewCSVExporter(cfg).ExportToWriter(context.Background(), data, &buf))
+
+	records := readCSV(t, buf.String())
+	require.Len(t, records, 4)
+	assert.Equal(t, "content", records[0][5])
+}
+
+func TestCSVExporter_ExportWithSink(t *testing.T) {
+	cfg := &models.ExportConfig{OutputPath: "sessions.csv", CSVLevel: CSVLevelSession}
+	data := newTestProcessedData(t, cfg)
+
+	sink := newMemorySink()
+	require.NoError(t, NewCSVExporter(cfg).WithSink(sink).Export(context.Background(), data))
+
+	records := readCSV(t, string(sink.files["sessions.csv"]))
+	assert.Len(t, records, 3)
+}
+
+func TestCSVExporter_ValidateLevel(t *testing.T) {
+	err := NewCSVExporter(&models.ExportConfig{OutputPath: "out.csv", CSVLevel: "turn"}).Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "지원하지 않는 CSV 단위입니다")
+}
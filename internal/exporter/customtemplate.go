@@ -0,0 +1,52 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"ssamai/internal/processor"
+)
+
+// CustomTemplateExt는 템플릿 디렉토리에 두는 사용자 정의 템플릿 파일의 확장자입니다
+const CustomTemplateExt = ".tmpl"
+
+// CustomTemplatePath는 사용자 정의 템플릿 이름을 파일 경로로 바꿉니다
+// 이름이 경로이거나 확장자를 포함하면 그대로 사용하고, 아니면 templateDir의 NAME.tmpl을 사용합니다
+func CustomTemplatePath(templateDir, name string) string {
+	if filepath.Ext(name) != "" || strings.ContainsAny(name, `/\`) {
+		return name
+	}
+	return filepath.Join(templateDir, name+CustomTemplateExt)
+}
+
+// ParseCustomTemplate는 사용자 정의 템플릿을 text/template으로 파싱합니다
+// 없는 맵 키를 참조하면 빈 값 대신 실행 에러가 나도록 설정합니다
+func ParseCustomTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("템플릿 파싱 실패: %w", err)
+	}
+	return tmpl, nil
+}
+
+// ValidateCustomTemplate는 템플릿 파일을 파싱하고 data로 실행해 보되 결과는 버립니다
+// 에러 메시지에는 text/template이 보고하는 "이름:줄[:열]" 위치가 포함됩니다
+func ValidateCustomTemplate(path string, data processor.ProcessedData) error {
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("템플릿 파일을 읽을 수 없습니다: %w", err)
+	}
+
+	tmpl, err := ParseCustomTemplate(filepath.Base(path), string(text))
+	if err != nil {
+		return err
+	}
+	if err := tmpl.Execute(io.Discard, data); err != nil {
+		return fmt.Errorf("템플릿 실행 실패: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package exporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTemplateFile(t *testing.T, name, text string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(text), 0644))
+	return path
+}
+
+func TestCustomTemplatePath(t *testing.T) {
+	assert.Equal(t, filepath.Join("templates", "weekly.tmpl"), CustomTemplatePath("templates", "weekly"))
+	assert.Equal(t, "weekly.tmpl", CustomTemplatePath("templates", "weekly.tmpl"))
+	assert.Equal(t, "./custom/weekly", CustomTemplatePath("templates", "./custom/weekly"))
+}
+
+func TestValidateCustomTemplate(t *testing.T) {
+	data := newTestProcessedData(t, &models.ExportConfig{})
+
+	good := writeTemplateFile(t, "good.tmpl", `# 세션 {{ .Statistics.TotalSessions }}개
+{{ range .Sessions }}- {{ .Title }} ({{ .Source }})
+{{ end }}`)
+	assert.NoError(t, ValidateCustomTemplate(good, data))
+
+	// 닫히지 않은 액션은 파싱 에러와 줄 번호
+	broken := writeTemplateFile(t, "broken.tmpl", "# 제목\n\n{{ range .Sessions }}\n- {{ .Title\n")
+	err := ValidateCustomTemplate(broken, data)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "템플릿 파싱 실패")
+	assert.Contains(t, err.Error(), "broken.tmpl:4")
+
+	// 없는 필드는 실행 에러와 줄:열 위치
+	badField := writeTemplateFile(t, "field.tmpl", "# 제목\n{{ .Statistics.TotalSessions }}\n{{ .NoSuchField }}\n")
+	err = ValidateCustomTemplate(badField, data)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "템플릿 실행 실패")
+	assert.Contains(t, err.Error(), "field.tmpl:3:3")
+
+	err = ValidateCustomTemplate(filepath.Join(t.TempDir(), "missing.tmpl"), data)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "템플릿 파일을 읽을 수 없습니다")
+}
@@ -3,20 +3,76 @@ package exporter
 import (
 	"context"
 	"fmt"
+	"html"
 	"io"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"ssamai/internal/interfaces"
 	"ssamai/internal/processor"
 	"ssamai/pkg/models"
 )
 
+// Sink는 내보내기 결과를 저장하기 위한 인터페이스 (클라우드 저장소 등 확장용)
+type Sink interface {
+	Write(name string, data []byte) error
+}
+
+// RenamingSink는 기존 파일을 확인하고 옮길 수 있는 Sink가 추가로 구현하는 선택 인터페이스입니다
+// 덮어쓰기 정책(--no-clobber, --backup)은 이 인터페이스를 구현한 Sink에서만 사용할 수 있습니다
+type RenamingSink interface {
+	// Exists는 name에 파일이 이미 있는지 확인합니다 (디렉토리는 false)
+	Exists(name string) (bool, error)
+	// Rename은 oldName 파일을 newName으로 옮깁니다
+	Rename(oldName, newName string) error
+}
+
+var _ RenamingSink = (*LocalSink)(nil)
+
+// LocalSink는 Sink의 기본 구현으로 로컬 파일 시스템에 저장합니다
+// 임시 파일에 쓴 뒤 이름을 바꾸므로 쓰기가 중단되어도 잘린 파일이 남지 않습니다
+type LocalSink struct{}
+
+func (s *LocalSink) Write(name string, data []byte) error {
+	dir := filepath.Dir(name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		if blocking, found := findBlockingFile(dir); found {
+			return fmt.Errorf("출력 디렉토리 생성 실패: %s 경로가 파일입니다: %w", blocking, err)
+		}
+		return fmt.Errorf("출력 디렉토리 생성 실패: %w", err)
+	}
+	return WriteFileAtomic(name, data, 0644)
+}
+
+func (s *LocalSink) Exists(name string) (bool, error) {
+	info, err := os.Lstat(name)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return !info.IsDir(), nil
+}
+
+func (s *LocalSink) Rename(oldName, newName string) error {
+	return os.Rename(oldName, newName)
+}
+
 // MarkdownExporter는 마크다운 내보내기를 담당합니다
 type MarkdownExporter struct {
 	config *models.ExportConfig
+	sink   Sink
+	// extractedCode는 파일 내보내기 중 분리한 코드 블록 (ExtractCode, 내보내기 중에만 nil이 아님)
+	extractedCode *codeExtraction
+	// location은 DisplayTimezone에서 처음 해석한 표시 시간대 (displayTime에서 지연 초기화)
+	location *time.Location
 }
 
 // MarkdownExporter가 모든 관련 인터페이스들을 구현하는지 컴파일 타임에 확인 (ISP 적용)
@@ -29,49 +85,92 @@ var _ interfaces.FullDataExporter = (*MarkdownExporter)(nil)
 func NewMarkdownExporter(config *models.ExportConfig) *MarkdownExporter {
 	return &MarkdownExporter{
 		config: config,
+		sink:   &LocalSink{},
 	}
 }
 
+// WithSink는 출력 저장소 의존성 주입
+func (e *MarkdownExporter) WithSink(sink Sink) *MarkdownExporter {
+	e.sink = sink
+	return e
+}
+
+// ExportResult는 내보내기 결과 정보를 나타냅니다
+type ExportResult struct {
+	OutputPath   string                    `json:"output_path"`
+	BytesWritten int64                     `json:"bytes_written"`
+	SessionCount int                       `json:"session_count"`
+	Sources      []models.CollectionSource `json:"sources"`
+	Duration     time.Duration             `json:"duration"`
+}
+
 // Export는 처리된 데이터를 마크다운 파일로 내보냅니다 (인터페이스 호환)
 func (e *MarkdownExporter) Export(ctx context.Context, data interface{}) error {
+	_, err := e.ExportWithResult(ctx, data)
+	return err
+}
+
+// ExportWithResult는 처리된 데이터를 마크다운 파일로 내보내고 결과 정보를 반환합니다
+func (e *MarkdownExporter) ExportWithResult(ctx context.Context, data interface{}) (*ExportResult, error) {
+	startTime := time.Now()
+
 	// context 취소 확인
 	select {
 	case <-ctx.Done():
-		return ctx.Err()
+		return nil, ctx.Err()
 	default:
 	}
 
 	// 타입 캐스팅
 	processedData, ok := data.(processor.ProcessedData)
 	if !ok {
-		return fmt.Errorf("잘못된 데이터 타입입니다. processor.ProcessedData가 필요합니다")
+		return nil, fmt.Errorf("잘못된 데이터 타입입니다. processor.ProcessedData가 필요합니다")
 	}
 
-	// 출력 디렉토리 생성
-	outputDir := filepath.Dir(e.config.OutputPath)
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		return fmt.Errorf("출력 디렉토리 생성 실패: %w", err)
+	// 출력 경로 검증 (파일이 디렉토리 생성을 막는 경우 등)
+	if err := e.Validate(); err != nil {
+		return nil, fmt.Errorf("내보내기 설정 검증 실패: %w", err)
 	}
 
-	// context 취소 확인
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	default:
+	// 코드 블록 분리 (--extract-code)
+	if e.config.ExtractCode {
+		e.extractedCode = newCodeExtraction()
+		defer func() { e.extractedCode = nil }()
 	}
 
 	// 템플릿 선택 및 내용 생성
 	content, err := e.generateMarkdownContent(&processedData)
 	if err != nil {
-		return fmt.Errorf("마크다운 내용 생성 실패: %w", err)
+		return nil, fmt.Errorf("마크다운 내용 생성 실패: %w", err)
 	}
 
-	// 파일 쓰기
-	if err := os.WriteFile(e.config.OutputPath, []byte(content), 0644); err != nil {
-		return fmt.Errorf("파일 쓰기 실패: %w", err)
+	// context 취소 확인
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
 	}
 
-	return nil
+	// 출력 저장소에 쓰기 (--no-clobber, --backup 적용)
+	if err := writeOutputFile(e.sink, e.config, []byte(content)); err != nil {
+		return nil, err
+	}
+	if e.extractedCode != nil {
+		if _, err := e.extractedCode.write(e.sink, filepath.Dir(e.config.OutputPath)); err != nil {
+			return nil, err
+		}
+	}
+
+	// 결과에 포함된 소스 (출력 순서 기준)
+	sources := e.orderedSources(processedData.SourceGroups)
+
+	return &ExportResult{
+		OutputPath:   e.config.OutputPath,
+		BytesWritten: int64(len(content)),
+		SessionCount: len(processedData.Sessions),
+		Sources:      sources,
+		Duration:     time.Since(startTime),
+	}, nil
 }
 
 // ExportToWriter는 처리된 데이터를 Writer에 출력합니다
@@ -103,6 +202,83 @@ func (e *MarkdownExporter) ExportToWriter(ctx context.Context, data interface{},
 	return nil
 }
 
+// ExportPerSession은 세션마다 개별 마크다운 파일을 생성하고 이를 연결하는 index.md를 작성합니다
+// 생성된 파일 경로 목록(index.md 포함)을 반환합니다
+func (e *MarkdownExporter) ExportPerSession(ctx context.Context, data interface{}, dir string) ([]string, error) {
+	// 타입 캐스팅
+	processedData, ok := data.(processor.ProcessedData)
+	if !ok {
+		return nil, fmt.Errorf("잘못된 데이터 타입입니다. processor.ProcessedData가 필요합니다")
+	}
+
+	if dir == "" {
+		return nil, fmt.Errorf("출력 디렉토리가 지정되지 않았습니다")
+	}
+
+	// 코드 블록 분리 (--extract-code)
+	if e.config.ExtractCode {
+		e.extractedCode = newCodeExtraction()
+		defer func() { e.extractedCode = nil }()
+	}
+
+	var index strings.Builder
+	index.WriteString("# AI CLI 도구 세션 목록\n\n")
+
+	written := make([]string, 0, len(processedData.Sessions)+1)
+	usedNames := make(map[string]bool)
+
+	for _, source := range e.orderedSources(processedData.SourceGroups) {
+		sessions := processedData.SourceGroups[source]
+
+		sourceName := source.DisplayName()
+		index.WriteString(fmt.Sprintf("## %s\n\n", sourceName))
+
+		for _, session := range sessions {
+			// context 취소 확인
+			select {
+			case <-ctx.Done():
+				return written, ctx.Err()
+			default:
+			}
+
+			fileName := e.uniqueFileName(usedNames, e.sessionFileName(source, session.ID))
+
+			var content strings.Builder
+			content.WriteString(fmt.Sprintf("# %s\n\n", sourceName))
+			e.writeSession(&content, session, source)
+
+			path := filepath.Join(dir, fileName)
+			if err := e.sink.Write(path, []byte(content.String())); err != nil {
+				return written, fmt.Errorf("세션 파일 쓰기 실패 (%s): %w", path, err)
+			}
+			written = append(written, path)
+
+			title := session.Title
+			if title == "" {
+				title = fmt.Sprintf("세션 %s", session.ID)
+			}
+			index.WriteString(fmt.Sprintf("- [%s](%s)\n", title, fileName))
+		}
+		index.WriteString("\n")
+	}
+
+	indexPath := filepath.Join(dir, "index.md")
+	if err := e.sink.Write(indexPath, []byte(index.String())); err != nil {
+		return written, fmt.Errorf("인덱스 파일 쓰기 실패: %w", err)
+	}
+	written = append(written, indexPath)
+
+	if e.extractedCode != nil {
+		codeFiles, err := e.extractedCode.write(e.sink, dir)
+		written = append(written, codeFiles...)
+		if err != nil {
+			return written, err
+		}
+	}
+
+	return written, nil
+}
+
 // GetFormat은 내보내기 형식을 반환합니다
 func (e *MarkdownExporter) GetFormat() string {
 	return "markdown"
@@ -118,21 +294,59 @@ func (e *MarkdownExporter) Validate() error {
 		return fmt.Errorf("출력 경로가 지정되지 않았습니다")
 	}
 
+	if _, err := e.config.DisplayLocation(); err != nil {
+		return err
+	}
+
 	// 출력 디렉토리가 존재하는지 확인 (없으면 생성 가능한지 확인)
 	outputDir := filepath.Dir(e.config.OutputPath)
 	if outputDir != "" && outputDir != "." {
-		if info, err := os.Stat(outputDir); err != nil {
-			if !os.IsNotExist(err) {
-				return fmt.Errorf("출력 디렉토리 확인 실패: %w", err)
-			}
-		} else if !info.IsDir() {
-			return fmt.Errorf("출력 경로의 부모가 디렉토리가 아닙니다: %s", outputDir)
+		if blocking, found := findBlockingFile(outputDir); found {
+			return fmt.Errorf("출력 경로의 부모가 디렉토리가 아닙니다: %s (%s 경로가 파일이어서 디렉토리를 만들 수 없습니다)", outputDir, blocking)
+		}
+		if _, err := os.Stat(outputDir); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("출력 디렉토리 확인 실패: %w", err)
 		}
 	}
 
 	return nil
 }
 
+// displayTime은 DisplayTimezone이 설정된 경우 시각을 해당 시간대로 변환합니다
+// 시간대를 해석할 수 없으면 원래 시각을 그대로 반환합니다 (Validate에서 에러로 보고)
+func (e *MarkdownExporter) displayTime(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	if e.location == nil {
+		location, err := e.config.DisplayLocation()
+		if err != nil || location == nil {
+			return t
+		}
+		e.location = location
+	}
+	return t.In(e.location)
+}
+
+// findBlockingFile은 dir 또는 그 상위 경로 중 디렉토리 생성을 막는 일반 파일을 찾습니다
+func findBlockingFile(dir string) (string, bool) {
+	for current := filepath.Clean(dir); ; {
+		info, err := os.Stat(current)
+		if err == nil {
+			if info.IsDir() {
+				return "", false
+			}
+			return current, true
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", false
+		}
+		current = parent
+	}
+}
+
 // GetSupportedTemplates는 지원하는 템플릿들을 반환합니다
 func (e *MarkdownExporter) GetSupportedTemplates() []string {
 	return []string{"default", "detailed", "summary", "compact"}
@@ -144,8 +358,8 @@ func (e *MarkdownExporter) generateMarkdownContent(data *processor.ProcessedData
 	// 헤더 생성
 	e.writeHeader(&content, data)
 
-	// 목차 생성
-	if e.config.GenerateTOC {
+	// 목차 생성 (아웃라인 모드에서는 항상 포함)
+	if e.config.GenerateTOC || e.config.OutlineOnly {
 		e.writeTableOfContents(&content, data.TableOfContents)
 	}
 
@@ -155,11 +369,26 @@ func (e *MarkdownExporter) generateMarkdownContent(data *processor.ProcessedData
 	// 통계 섹션
 	e.writeStatistics(&content, data.Statistics)
 
+	// 수집 문제 섹션
+	if e.config.IncludeErrors && len(data.Errors) > 0 {
+		e.writeCollectionIssues(&content, data.Errors)
+	}
+
+	// 요일/시간대별 활동 히트맵
+	if data.Heatmap != nil {
+		e.writeActivityHeatmap(&content, data.Heatmap)
+	}
+
+	// 세션 타임라인 (Mermaid)
+	if e.config.MermaidTimeline && len(data.Sessions) > 0 {
+		e.writeMermaidTimeline(&content, data)
+	}
+
 	// 소스별 세션 내용
 	e.writeSourceSections(&content, data)
 
-	// 푸터 생성
-	if e.config.IncludeMetadata {
+	// 푸터 생성 (--no-footer면 세션 메타데이터만 남기고 생략)
+	if e.config.IncludeMetadata && !e.config.NoFooter {
 		e.writeFooter(&content, data)
 	}
 
@@ -171,13 +400,13 @@ func (e *MarkdownExporter) writeHeader(content *strings.Builder, data *processor
 	
 	if e.config.IncludeTimestamps {
 		content.WriteString(fmt.Sprintf("**생성 시간**: %s\n\n", 
-			data.ProcessedAt.Format("2006-01-02 15:04:05")))
+			e.displayTime(data.ProcessedAt).Format("2006-01-02 15:04:05")))
 	}
 
 	if len(data.Sessions) > 0 && data.Statistics.DateRange != nil {
 		content.WriteString(fmt.Sprintf("**활동 기간**: %s ~ %s\n\n",
-			data.Statistics.DateRange.Start.Format("2006-01-02"),
-			data.Statistics.DateRange.End.Format("2006-01-02")))
+			e.displayTime(data.Statistics.DateRange.Start).Format("2006-01-02"),
+			e.displayTime(data.Statistics.DateRange.End).Format("2006-01-02")))
 	}
 }
 
@@ -215,12 +444,21 @@ func (e *MarkdownExporter) writeOverview(content *strings.Builder, data *process
 	content.WriteString(fmt.Sprintf("총 **%d개**의 AI 도구 세션이 수집되었습니다.\n\n", 
 		data.Statistics.TotalSessions))
 
+	// 자연어 요약 문단
+	if e.config.NarrativeOverview {
+		if narrative := processor.NarrativeOverview(data.Statistics); narrative != "" {
+			content.WriteString(narrative + "\n\n")
+		}
+	}
+
 	// 소스별 요약
 	content.WriteString("### 소스별 활동 현황\n\n")
 	content.WriteString("| AI 도구 | 세션 수 | 메시지 수 |\n")
 	content.WriteString("|---------|---------|----------|\n")
 	
-	for source, sessions := range data.SourceGroups {
+	// 본문 섹션과 같은 순서로 출력 (맵 순회 순서에 의존하지 않도록)
+	for _, source := range e.orderedSources(data.SourceGroups) {
+		sessions := data.SourceGroups[source]
 		if len(sessions) == 0 {
 			continue
 		}
@@ -230,11 +468,51 @@ func (e *MarkdownExporter) writeOverview(content *strings.Builder, data *process
 			messageCount += len(session.Messages)
 		}
 		
-		sourceName := e.getSourceDisplayName(source)
+		sourceName := source.DisplayName()
 		content.WriteString(fmt.Sprintf("| %s | %d | %d |\n", 
 			sourceName, len(sessions), messageCount))
 	}
 	content.WriteString("\n")
+
+	// 최근 활동 요약
+	if e.config.RecentWindow > 0 {
+		e.writeRecentActivity(content, data)
+	}
+}
+
+// writeRecentActivity는 ProcessedAt 기준 최근 기간 내의 세션/메시지 수를 요약합니다
+func (e *MarkdownExporter) writeRecentActivity(content *strings.Builder, data *processor.ProcessedData) {
+	end := data.ProcessedAt
+	if end.IsZero() {
+		end = time.Now()
+	}
+	start := end.Add(-e.config.RecentWindow)
+
+	sessionCount, messageCount := 0, 0
+	for _, session := range data.Sessions {
+		if session.Timestamp.Before(start) || session.Timestamp.After(end) {
+			continue
+		}
+		sessionCount++
+		messageCount += len(session.Messages)
+	}
+
+	content.WriteString(fmt.Sprintf("### 최근 활동 (최근 %s)\n\n", formatWindow(e.config.RecentWindow)))
+	if sessionCount == 0 {
+		content.WriteString("이 기간에 활동한 세션이 없습니다.\n\n")
+		return
+	}
+	content.WriteString(fmt.Sprintf("- **세션 수**: %d개\n", sessionCount))
+	content.WriteString(fmt.Sprintf("- **메시지 수**: %d개\n\n", messageCount))
+}
+
+// formatWindow는 기간을 사람이 읽기 쉬운 형태로 변환합니다 (일 단위로 나누어 떨어지면 일로 표시)
+func formatWindow(window time.Duration) string {
+	day := 24 * time.Hour
+	if window%day == 0 {
+		return fmt.Sprintf("%d일", window/day)
+	}
+	return window.String()
 }
 
 func (e *MarkdownExporter) writeStatistics(content *strings.Builder, stats processor.Statistics) {
@@ -253,70 +531,422 @@ func (e *MarkdownExporter) writeStatistics(content *strings.Builder, stats proce
 	}
 	
 	if stats.MostActiveSource != "" {
-		sourceName := e.getSourceDisplayName(stats.MostActiveSource)
-		content.WriteString(fmt.Sprintf("- **가장 활발한 도구**: %s\n", sourceName))
+		sourceName := stats.MostActiveSource.DisplayName()
+		metric := "세션 수"
+		if stats.MostActiveSourceMetric == processor.ActiveSourceByMessages {
+			metric = "메시지 수"
+		}
+		content.WriteString(fmt.Sprintf("- **가장 활발한 도구**: %s (%s 기준)\n", sourceName, metric))
+	}
+	
+	if len(stats.MessagesByRole) > 0 {
+		roles := make([]string, 0, len(stats.MessagesByRole))
+		for role := range stats.MessagesByRole {
+			roles = append(roles, role)
+		}
+		sort.Strings(roles)
+
+		parts := make([]string, 0, len(roles))
+		for _, role := range roles {
+			parts = append(parts, fmt.Sprintf("%s %d개", role, stats.MessagesByRole[role]))
+		}
+		content.WriteString(fmt.Sprintf("- **역할별 메시지 수**: %s\n", strings.Join(parts, ", ")))
+	}
+	
+	if stats.UniquePrompts > 0 {
+		content.WriteString(fmt.Sprintf("- **고유 프롬프트 수**: %d개 (중복률 %.1f%%)\n",
+			stats.UniquePrompts, stats.DuplicatePromptRate*100))
 	}
 	
 	if stats.AverageSessionTime > 0 {
 		content.WriteString(fmt.Sprintf("- **평균 세션 지속 시간**: %v\n", 
 			stats.AverageSessionTime.Round(time.Second)))
 	}
+
+	if len(stats.ResponseLatency) > 0 {
+		content.WriteString("- **응답 지연 (사용자 → 어시스턴트)**:\n")
+		for _, source := range e.sourceOrder() {
+			latency, ok := stats.ResponseLatency[source]
+			if !ok {
+				continue
+			}
+			content.WriteString(fmt.Sprintf("  - %s: 평균 %v, 중앙값 %v (%d쌍)\n",
+				source.DisplayName(),
+				latency.Average.Round(time.Millisecond),
+				latency.Median.Round(time.Millisecond),
+				latency.Samples))
+		}
+	}
 	
 	content.WriteString("\n")
+
+	if len(stats.MessageLengths) > 0 {
+		content.WriteString("### 메시지 길이 분포\n\n")
+		content.WriteString("| 길이 (문자) | 메시지 수 |\n")
+		content.WriteString("|-------------|-----------|\n")
+		for _, bucket := range stats.MessageLengths {
+			content.WriteString(fmt.Sprintf("| %s | %d |\n", bucket.Label, bucket.Count))
+		}
+		content.WriteString("\n")
+	}
+}
+
+// heatmapLevels는 히트맵 칸의 강도 문자입니다 (0은 세션 없음, 마지막이 가장 많음)
+var heatmapLevels = []rune{'·', '░', '▒', '▓', '█'}
+
+// heatmapWeekdays는 히트맵 행 순서(월요일부터)와 요일 이름입니다
+var heatmapWeekdays = []struct {
+	day  time.Weekday
+	name string
+}{
+	{time.Monday, "월"},
+	{time.Tuesday, "화"},
+	{time.Wednesday, "수"},
+	{time.Thursday, "목"},
+	{time.Friday, "금"},
+	{time.Saturday, "토"},
+	{time.Sunday, "일"},
+}
+
+// writeActivityHeatmap은 요일(행)과 시간대(열, 0-23시)별 세션 수를 강도 문자 격자로 출력합니다
+func (e *MarkdownExporter) writeActivityHeatmap(content *strings.Builder, heatmap *processor.ActivityHeatmap) {
+	content.WriteString("## 활동 히트맵 {#heatmap}\n\n")
+	content.WriteString("```text\n")
+	content.WriteString("   00    06    12    18\n")
+	for _, weekday := range heatmapWeekdays {
+		content.WriteString(weekday.name + " ")
+		for hour := 0; hour < 24; hour++ {
+			content.WriteRune(heatmapCell(heatmap.Counts[weekday.day][hour], heatmap.Max))
+		}
+		content.WriteString("\n")
+	}
+	content.WriteString("```\n\n")
+	content.WriteString(fmt.Sprintf("범례: · 없음, ░▒▓█ 적음 → 많음 (한 칸 최대 %d개 세션)\n\n", heatmap.Max))
+}
+
+// heatmapCell은 최대값 대비 세션 수에 맞는 강도 문자를 반환합니다
+func heatmapCell(count, max int) rune {
+	if count <= 0 || max <= 0 {
+		return heatmapLevels[0]
+	}
+	steps := len(heatmapLevels) - 1
+	level := (count*steps + max - 1) / max // 올림: 세션이 하나라도 있으면 최소 1단계
+	return heatmapLevels[level]
+}
+
+// minTimelineDuration은 타임라인 막대가 보이도록 하는 최소 세션 길이입니다
+const minTimelineDuration = time.Minute
+
+// writeMermaidTimeline은 소스별 세션을 시간축에 배치한 Mermaid gantt 다이어그램을 출력합니다
+// 세션 길이는 첫 메시지와 마지막 메시지의 시간차이며, 너무 짧으면 최소 길이로 표시합니다
+func (e *MarkdownExporter) writeMermaidTimeline(content *strings.Builder, data *processor.ProcessedData) {
+	content.WriteString("## 타임라인 {#timeline}\n\n")
+	content.WriteString("```mermaid\n")
+	content.WriteString("gantt\n")
+	content.WriteString("    title 세션 타임라인\n")
+	content.WriteString("    dateFormat YYYY-MM-DDTHH:mm:ss\n")
+	content.WriteString("    axisFormat %m-%d %H:%M\n")
+
+	for _, source := range e.orderedSources(data.SourceGroups) {
+		var sessions []models.SessionData
+		for _, session := range data.Sessions {
+			if session.Source == source && !session.Timestamp.IsZero() {
+				sessions = append(sessions, session)
+			}
+		}
+		if len(sessions) == 0 {
+			continue
+		}
+		sort.SliceStable(sessions, func(i, j int) bool {
+			return sessions[i].Timestamp.Before(sessions[j].Timestamp)
+		})
+
+		content.WriteString(fmt.Sprintf("    section %s\n", source.DisplayName()))
+		for _, session := range sessions {
+			title := session.Title
+			if title == "" {
+				title = fmt.Sprintf("세션 %s", session.ID)
+			}
+			content.WriteString(fmt.Sprintf("    %s :%s, %ds\n",
+				mermaidTaskName(title),
+				session.Timestamp.UTC().Format("2006-01-02T15:04:05"),
+				int(timelineDuration(session)/time.Second)))
+		}
+	}
+
+	content.WriteString("```\n\n")
+}
+
+// timelineDuration은 타임라인에 표시할 세션 길이를 계산합니다
+func timelineDuration(session models.SessionData) time.Duration {
+	duration := time.Duration(0)
+	if len(session.Messages) > 1 {
+		first := session.Messages[0].Timestamp
+		last := session.Messages[len(session.Messages)-1].Timestamp
+		if !first.IsZero() && last.After(first) {
+			duration = last.Sub(first)
+		}
+	}
+	if duration < minTimelineDuration {
+		duration = minTimelineDuration
+	}
+	return duration
+}
+
+// mermaidTaskName은 Mermaid gantt 작업 이름에서 구문을 깨뜨리는 문자를 제거합니다
+func mermaidTaskName(title string) string {
+	name := strings.NewReplacer(":", " ", "#", " ", ";", " ", "\n", " ", "\r", " ").Replace(title)
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" {
+		return "세션"
+	}
+	return name
+}
+
+func (e *MarkdownExporter) writeCollectionIssues(content *strings.Builder, errors []string) {
+	content.WriteString("## 수집 문제 {#collection-issues}\n\n")
+	content.WriteString(fmt.Sprintf("수집 중 %d개의 문제가 발생했습니다. 일부 데이터가 누락되었을 수 있습니다.\n\n", len(errors)))
+
+	for _, errMsg := range errors {
+		content.WriteString(fmt.Sprintf("- ⚠️ %s\n", errMsg))
+	}
+	content.WriteString("\n")
+}
+
+// sourceOrder는 소스 섹션을 출력할 순서를 반환합니다 (--source-order가 없으면 Claude Code, Gemini CLI, Amazon Q)
+func (e *MarkdownExporter) sourceOrder() []models.CollectionSource {
+	return models.SectionOrder(e.config.SourceOrder)
+}
+
+// orderedSources는 세션이 있는 소스를 출력 순서대로 반환합니다
+// 알려진 소스를 sourceOrder 순서로 먼저 두고, 플러그인 등 그 밖의 소스는 이름순으로 뒤에 둡니다
+func (e *MarkdownExporter) orderedSources(groups map[models.CollectionSource][]models.SessionData) []models.CollectionSource {
+	sources := make([]models.CollectionSource, 0, len(groups))
+	known := make(map[models.CollectionSource]bool)
+	for _, source := range e.sourceOrder() {
+		known[source] = true
+		if len(groups[source]) > 0 {
+			sources = append(sources, source)
+		}
+	}
+
+	var others []models.CollectionSource
+	for source, sessions := range groups {
+		if !known[source] && len(sessions) > 0 {
+			others = append(others, source)
+		}
+	}
+	sort.Slice(others, func(i, j int) bool { return others[i] < others[j] })
+
+	return append(sources, others...)
 }
 
 func (e *MarkdownExporter) writeSourceSections(content *strings.Builder, data *processor.ProcessedData) {
-	// 소스별로 정렬된 순서로 처리
-	sources := []models.CollectionSource{
-		models.SourceClaudeCode,
-		models.SourceGeminiCLI,
-		models.SourceAmazonQ,
+	// --group-by-meta 설정 시 소스 대신 메타데이터 값별 섹션
+	if data.MetaGroups != nil {
+		e.writeMetaGroupSections(content, data.MetaGroups)
+		return
+	}
+
+	// 소스별로 정렬된 순서로 처리 (알려지지 않은 소스도 뒤에 출력)
+	sources := e.orderedSources(data.SourceGroups)
+
+	// 코드 추출은 파일 이름 중복 처리가 렌더링 순서에 의존하므로 순차로 처리
+	if e.config.Workers <= 1 || len(sources) <= 1 || e.extractedCode != nil {
+		for _, source := range sources {
+			e.writeSourceSection(content, source, data.SourceGroups[source])
+		}
+		return
+	}
+
+	for _, section := range e.renderSourceSections(sources, data.SourceGroups) {
+		content.WriteString(section)
+	}
+}
+
+// renderSourceSections는 서로 독립적인 소스 섹션들을 최대 Workers개씩 동시에 렌더링합니다
+// 결과는 sources 순서대로 반환하므로 순차 렌더링과 같은 문서가 만들어집니다
+func (e *MarkdownExporter) renderSourceSections(sources []models.CollectionSource, groups map[models.CollectionSource][]models.SessionData) []string {
+	// displayTime의 지연 초기화가 고루틴 사이에서 경합하지 않도록 미리 해석
+	if e.location == nil {
+		if location, err := e.config.DisplayLocation(); err == nil {
+			e.location = location
+		}
+	}
+
+	sections := make([]string, len(sources))
+	semaphore := make(chan struct{}, e.config.Workers)
+	var wg sync.WaitGroup
+
+	for i, source := range sources {
+		wg.Add(1)
+		go func(i int, source models.CollectionSource) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			var section strings.Builder
+			e.writeSourceSection(&section, source, groups[source])
+			sections[i] = section.String()
+		}(i, source)
+	}
+
+	wg.Wait()
+	return sections
+}
+
+// writeSourceSection은 소스 하나의 제목, 세션 수, 세션 목록을 출력합니다
+func (e *MarkdownExporter) writeSourceSection(content *strings.Builder, source models.CollectionSource, sessions []models.SessionData) {
+	sourceName := source.DisplayName()
+	anchor := e.generateAnchor(sourceName)
+
+	content.WriteString(fmt.Sprintf("## %s {#%s}\n\n", sourceName, anchor))
+	content.WriteString(fmt.Sprintf("총 %d개의 세션이 수집되었습니다.\n\n", len(sessions)))
+
+	e.writeSectionSessions(content, sessions)
+}
+
+// writeMetaGroupSections는 메타데이터 값별 섹션을 출력합니다 (값이 없는 세션은 unknown 섹션)
+func (e *MarkdownExporter) writeMetaGroupSections(content *strings.Builder, groups []processor.MetaGroup) {
+	key := e.config.GroupByMeta
+	for _, group := range groups {
+		anchor := e.generateAnchor(fmt.Sprintf("%s-%s", key, group.Value))
+		content.WriteString(fmt.Sprintf("## %s: %s {#%s}\n\n", key, group.Value, anchor))
+		content.WriteString(fmt.Sprintf("총 %d개의 세션이 있습니다.\n\n", len(group.Sessions)))
+
+		e.writeSectionSessions(content, group.Sessions)
 	}
+}
 
-	for _, source := range sources {
-		sessions, exists := data.SourceGroups[source]
-		if !exists || len(sessions) == 0 {
+// writeSectionSessions는 한 섹션의 세션들을 출력합니다 (--source-budget 설정 시 섹션별 내용 한도 적용)
+func (e *MarkdownExporter) writeSectionSessions(content *strings.Builder, sessions []models.SessionData) {
+	remaining := e.config.SourceBudget
+	for _, session := range sessions {
+		if e.config.SourceBudget <= 0 || e.config.OutlineOnly {
+			e.writeSession(content, session, session.Source)
 			continue
 		}
 
-		sourceName := e.getSourceDisplayName(source)
-		anchor := e.generateAnchor(sourceName)
-		
-		content.WriteString(fmt.Sprintf("## %s {#%s}\n\n", sourceName, anchor))
-		content.WriteString(fmt.Sprintf("총 %d개의 세션이 수집되었습니다.\n\n", len(sessions)))
+		var omitted int
+		session, omitted = applyContentBudget(session, &remaining)
+		note := ""
+		if omitted > 0 {
+			note = fmt.Sprintf("소스별 내용 한도(%d자)에 도달해 메시지 %d개를 생략하거나 줄였습니다.",
+				e.config.SourceBudget, omitted)
+		}
+		e.writeSessionWithNote(content, session, session.Source, note)
+	}
+}
 
-		// 각 세션 내용
-		for _, session := range sessions {
-			e.writeSession(content, session, source)
+// applyContentBudget은 남은 글자 수 안에서 세션 메시지를 유지하고 넘치는 부분은 자르거나 생략합니다
+// 원본 메시지 슬라이스는 변경하지 않으며, 잘리거나 생략된 메시지 수를 반환합니다
+func applyContentBudget(session models.SessionData, remaining *int) (models.SessionData, int) {
+	if len(session.Messages) == 0 {
+		return session, 0
+	}
+
+	kept := make([]models.Message, 0, len(session.Messages))
+	omitted := 0
+	for _, message := range session.Messages {
+		length := utf8.RuneCountInString(message.Content)
+		switch {
+		case length <= *remaining:
+			*remaining -= length
+			kept = append(kept, message)
+		case *remaining > 0:
+			message.Content = string([]rune(message.Content)[:*remaining]) + "…"
+			*remaining = 0
+			kept = append(kept, message)
+			omitted++
+		default:
+			omitted++
 		}
 	}
+
+	session.Messages = kept
+	return session, omitted
 }
 
 func (e *MarkdownExporter) writeSession(content *strings.Builder, session models.SessionData, source models.CollectionSource) {
+	e.writeSessionWithNote(content, session, source, "")
+}
+
+// writeSessionWithNote는 세션을 출력하고, note가 있으면 세션 끝에 안내 문구를 추가합니다
+func (e *MarkdownExporter) writeSessionWithNote(content *strings.Builder, session models.SessionData, source models.CollectionSource, note string) {
 	// 세션 제목
 	title := session.Title
 	if title == "" {
 		title = fmt.Sprintf("세션 %s", session.ID)
 	}
 	
-	sourceName := e.getSourceDisplayName(source)
+	sourceName := source.DisplayName()
 	anchor := e.generateAnchor(fmt.Sprintf("%s-%s", sourceName, session.ID))
 	
 	content.WriteString(fmt.Sprintf("### %s {#%s}\n\n", title, anchor))
 
+	// 사용자 주석 (--annotations)
+	if annotation := strings.TrimSpace(e.config.Annotations[session.ID]); annotation != "" {
+		e.writeAnnotation(content, annotation)
+	}
+
+	// 아웃라인 모드에서는 제목과 개수만 출력
+	if e.config.OutlineOnly {
+		content.WriteString(fmt.Sprintf("메시지 %d개, 명령어 %d개, 파일 %d개\n\n",
+			len(session.Messages), len(session.Commands), len(session.Files)))
+		return
+	}
+
+	// 코드 블록을 별도 파일로 분리하고 링크로 대체
+	if e.config.ExtractCode && e.extractedCode != nil {
+		session = e.extractSessionCode(session, source)
+	}
+
+	// 긴 세션은 본문을 접을 수 있는 블록으로 감쌈 (목차 링크는 위의 제목을 그대로 가리킴)
+	collapsed := e.shouldCollapse(session)
+	if collapsed {
+		content.WriteString(fmt.Sprintf("<details>\n<summary>%s (메시지 %d개)</summary>\n\n",
+			html.EscapeString(title), len(session.Messages)))
+	}
+
 	// 세션 메타데이터
 	if e.config.IncludeMetadata {
 		content.WriteString(fmt.Sprintf("**세션 ID**: `%s`\n", session.ID))
 		
 		if e.config.IncludeTimestamps {
 			content.WriteString(fmt.Sprintf("**시간**: %s\n", 
-				session.Timestamp.Format("2006-01-02 15:04:05")))
+				e.displayTime(session.Timestamp).Format("2006-01-02 15:04:05")))
 		}
 		
-		if len(session.Metadata) > 0 {
+		// 작업 공간 정보 (프로젝트, 작업 디렉토리, 브랜치)
+		if workspace := e.formatWorkspace(session.Metadata); workspace != "" {
+			content.WriteString(fmt.Sprintf("**작업 공간**: %s\n", workspace))
+		}
+
+		// 원본 파일 링크 (--source-links)
+		if e.config.IncludeSourceLinks {
+			if link := sourceFileLink(session.Metadata["file_path"]); link != "" {
+				content.WriteString(fmt.Sprintf("**원본 파일**: %s\n", link))
+			}
+		}
+
+		// 맵 순회 순서는 매번 달라지므로 키를 정렬해 출력을 고정
+		keys := make([]string, 0, len(session.Metadata))
+		for key := range session.Metadata {
+			if workspaceMetadataKeys[key] {
+				continue
+			}
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var metadataLines []string
+		for _, key := range keys {
+			metadataLines = append(metadataLines, fmt.Sprintf("- %s: %s\n", key, session.Metadata[key]))
+		}
+		if len(metadataLines) > 0 {
 			content.WriteString("**메타데이터**:\n")
-			for key, value := range session.Metadata {
-				content.WriteString(fmt.Sprintf("- %s: %s\n", key, value))
+			for _, line := range metadataLines {
+				content.WriteString(line)
 			}
 		}
 		content.WriteString("\n")
@@ -325,8 +955,12 @@ func (e *MarkdownExporter) writeSession(content *strings.Builder, session models
 	// 메시지들
 	if len(session.Messages) > 0 {
 		content.WriteString("#### 대화 내용\n\n")
-		for i, message := range session.Messages {
-			e.writeMessage(content, message, i+1)
+		if e.config.ThreadMessages && hasThreading(session.Messages) {
+			e.writeMessageThread(content, session.Messages)
+		} else {
+			for i, message := range session.Messages {
+				e.writeMessage(content, message, i+1)
+			}
 		}
 	}
 
@@ -348,32 +982,135 @@ func (e *MarkdownExporter) writeSession(content *strings.Builder, session models
 			}
 			if e.config.IncludeTimestamps {
 				content.WriteString(fmt.Sprintf("  - 수정시간: %s\n", 
-					file.ModTime.Format("2006-01-02 15:04:05")))
+					e.displayTime(file.ModTime).Format("2006-01-02 15:04:05")))
 			}
 		}
 		content.WriteString("\n")
 	}
 
-	content.WriteString("---\n\n")
+	if note != "" {
+		content.WriteString(fmt.Sprintf("> ✂️ %s\n\n", note))
+	}
+
+	if collapsed {
+		content.WriteString("</details>\n\n")
+	}
+
+	content.WriteString(e.sessionSeparator())
+}
+
+// shouldCollapse는 세션 메시지 수가 CollapseSessionsOver를 넘는지 확인합니다
+func (e *MarkdownExporter) shouldCollapse(session models.SessionData) bool {
+	return e.config.CollapseSessionsOver > 0 && len(session.Messages) > e.config.CollapseSessionsOver
+}
+
+// 세션 구분자 특수 값
+const (
+	SessionSeparatorNone  = "none"  // 구분자 생략
+	SessionSeparatorBlank = "blank" // 빈 줄 하나
+)
+
+// sessionSeparator는 세션 뒤에 출력할 구분자를 반환합니다
+// 일부 렌더러가 "---"를 새 front matter로 해석하므로 SessionSeparator로 바꿀 수 있습니다
+func (e *MarkdownExporter) sessionSeparator() string {
+	switch separator := strings.TrimSpace(e.config.SessionSeparator); separator {
+	case "":
+		return "---\n\n"
+	case SessionSeparatorNone:
+		return ""
+	case SessionSeparatorBlank:
+		return "\n"
+	default:
+		return separator + "\n\n"
+	}
+}
+
+// writeAnnotation은 세션 제목 아래에 사용자 주석을 인용 블록으로 출력합니다
+func (e *MarkdownExporter) writeAnnotation(content *strings.Builder, note string) {
+	lines := strings.Split(strings.ReplaceAll(note, "\r\n", "\n"), "\n")
+	content.WriteString(fmt.Sprintf("> 📝 **메모**: %s\n", lines[0]))
+	for _, line := range lines[1:] {
+		if line == "" {
+			content.WriteString(">\n")
+			continue
+		}
+		content.WriteString("> " + line + "\n")
+	}
+	content.WriteString("\n")
+}
+
+// workspaceMetadataKeys는 작업 공간 줄에 별도로 표시되는 메타데이터 키입니다
+var workspaceMetadataKeys = map[string]bool{
+	"project": true,
+	"cwd":     true,
+	"branch":  true,
+}
+
+// formatWorkspace는 세션 메타데이터의 작업 공간 정보를 한 줄로 포맷합니다
+func (e *MarkdownExporter) formatWorkspace(metadata map[string]string) string {
+	var parts []string
+	if project := metadata["project"]; project != "" {
+		parts = append(parts, project)
+	}
+	if cwd := metadata["cwd"]; cwd != "" {
+		parts = append(parts, fmt.Sprintf("`%s`", cwd))
+	}
+	if branch := metadata["branch"]; branch != "" {
+		parts = append(parts, fmt.Sprintf("브랜치 `%s`", branch))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// sourceFileLink는 세션 원본 파일 경로를 마크다운 링크로 만듭니다
+// 절대 경로는 file:// URL로, 상대 경로는 그대로 이스케이프한 상대 링크로 출력합니다
+func sourceFileLink(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	slashed := filepath.ToSlash(path)
+	target := url.URL{Path: slashed}
+	if filepath.IsAbs(path) || strings.HasPrefix(slashed, "/") {
+		if !strings.HasPrefix(slashed, "/") {
+			slashed = "/" + slashed // Windows 드라이브 경로 (C:/...)
+		}
+		target = url.URL{Scheme: "file", Path: slashed}
+	}
+
+	text := strings.NewReplacer("`", "'", "\n", " ").Replace(path)
+	return fmt.Sprintf("[`%s`](<%s>)", text, target.String())
+}
+
+// defaultRoleIcons는 역할별 기본 아이콘입니다
+var defaultRoleIcons = map[string]string{
+	"user":      "👤",
+	"assistant": "🤖",
+	"system":    "⚙️",
+}
+
+// roleIcon은 역할에 사용할 아이콘을 반환합니다
+// 사용자 설정(RoleIcons)을 우선하고, 없으면 기본 아이콘을 사용합니다
+func (e *MarkdownExporter) roleIcon(role string) string {
+	if e.config.NoIcons {
+		return ""
+	}
+	if icon, ok := e.config.RoleIcons[role]; ok {
+		return icon
+	}
+	return defaultRoleIcons[role]
 }
 
 func (e *MarkdownExporter) writeMessage(content *strings.Builder, message models.Message, index int) {
-	roleIcon := ""
-	switch message.Role {
-	case "user":
-		roleIcon = "👤"
-	case "assistant":
-		roleIcon = "🤖"
-	case "system":
-		roleIcon = "⚙️"
+	label := strings.Title(message.Role)
+	if icon := e.roleIcon(message.Role); icon != "" {
+		label = icon + " " + label
 	}
 
-	content.WriteString(fmt.Sprintf("**%s %s** (%d)\n\n", roleIcon, 
-		strings.Title(message.Role), index))
+	content.WriteString(fmt.Sprintf("**%s** (%d)\n\n", label, index))
 
 	if e.config.IncludeTimestamps {
 		content.WriteString(fmt.Sprintf("*%s*\n\n", 
-			message.Timestamp.Format("15:04:05")))
+			e.displayTime(message.Timestamp).Format("15:04:05")))
 	}
 
 	// 메시지 내용 처리
@@ -384,6 +1121,106 @@ func (e *MarkdownExporter) writeMessage(content *strings.Builder, message models
 
 	content.WriteString(messageContent)
 	content.WriteString("\n\n")
+
+	// 첨부 파일 (메타데이터 포함 시)
+	if e.config.IncludeMetadata && len(message.Attachments) > 0 {
+		content.WriteString("*첨부 파일*\n\n")
+		for _, attachment := range message.Attachments {
+			line := fmt.Sprintf("- **%s** (`%s`)", attachment.Name, attachment.Path)
+			if attachment.Size > 0 {
+				line += fmt.Sprintf(" - %d bytes", attachment.Size)
+			}
+			content.WriteString(line + "\n")
+		}
+		content.WriteString("\n")
+	}
+}
+
+// threadParentKey는 메시지의 부모 메시지 ID를 담는 메타데이터 키입니다
+const threadParentKey = "parent_id"
+
+// hasThreading은 세션 내 다른 메시지를 부모로 가리키는 메시지가 있는지 확인합니다
+func hasThreading(messages []models.Message) bool {
+	ids := make(map[string]bool, len(messages))
+	for _, message := range messages {
+		if message.ID != "" {
+			ids[message.ID] = true
+		}
+	}
+	for _, message := range messages {
+		if parent := message.Metadata[threadParentKey]; parent != "" && ids[parent] {
+			return true
+		}
+	}
+	return false
+}
+
+// writeMessageThread는 parent_id를 따라 답변을 인용 블록으로 중첩해 대화를 트리로 출력합니다
+// 부모를 찾을 수 없는 메시지는 최상위에 표시되며, 번호는 원래 메시지 순서를 따릅니다
+func (e *MarkdownExporter) writeMessageThread(content *strings.Builder, messages []models.Message) {
+	ids := make(map[string]bool, len(messages))
+	for _, message := range messages {
+		if message.ID != "" {
+			ids[message.ID] = true
+		}
+	}
+
+	children := make(map[string][]int)
+	var roots []int
+	for i, message := range messages {
+		parent := message.Metadata[threadParentKey]
+		if parent == "" || parent == message.ID || !ids[parent] {
+			roots = append(roots, i)
+			continue
+		}
+		children[parent] = append(children[parent], i)
+	}
+
+	visited := make(map[int]bool, len(messages))
+	var walk func(index, depth int)
+	walk = func(index, depth int) {
+		if visited[index] {
+			return
+		}
+		visited[index] = true
+
+		var message strings.Builder
+		e.writeMessage(&message, messages[index], index+1)
+		content.WriteString(indentQuote(message.String(), depth))
+
+		for _, child := range children[messages[index].ID] {
+			walk(child, depth+1)
+		}
+	}
+
+	for _, root := range roots {
+		walk(root, 0)
+	}
+
+	// 순환 참조로 루트에서 닿지 않는 메시지도 빠뜨리지 않음
+	for i := range messages {
+		walk(i, 0)
+	}
+}
+
+// indentQuote는 depth만큼 인용 블록 접두사를 붙여 내용을 들여씁니다
+func indentQuote(text string, depth int) string {
+	if depth == 0 {
+		return text
+	}
+
+	prefix := strings.Repeat(">", depth)
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	var out strings.Builder
+	for _, line := range lines {
+		if line == "" {
+			out.WriteString(prefix + "\n")
+			continue
+		}
+		out.WriteString(prefix + " " + line + "\n")
+	}
+	out.WriteString("\n")
+	return out.String()
 }
 
 func (e *MarkdownExporter) writeCommand(content *strings.Builder, cmd models.Command, index int) {
@@ -400,34 +1237,54 @@ func (e *MarkdownExporter) writeCommand(content *strings.Builder, cmd models.Com
 	// 실행 정보
 	if e.config.IncludeTimestamps {
 		content.WriteString(fmt.Sprintf("- **실행시간**: %s\n", 
-			cmd.Timestamp.Format("2006-01-02 15:04:05")))
+			e.displayTime(cmd.Timestamp).Format("2006-01-02 15:04:05")))
 	}
 	content.WriteString(fmt.Sprintf("- **종료코드**: %d\n", cmd.ExitCode))
 	if cmd.Duration > 0 {
 		content.WriteString(fmt.Sprintf("- **소요시간**: %v\n", cmd.Duration))
 	}
 
+	// 환경 변수는 값 없이 이름만 표시 (--command-env-keys)
+	if e.config.IncludeMetadata && e.config.CommandEnvKeysOnly && len(cmd.Environment) > 0 {
+		keys := make([]string, 0, len(cmd.Environment))
+		for key := range cmd.Environment {
+			keys = append(keys, fmt.Sprintf("`%s`", key))
+		}
+		sort.Strings(keys)
+		content.WriteString(fmt.Sprintf("- **환경 변수**: %s\n", strings.Join(keys, ", ")))
+	}
+
 	// 출력 결과
 	if cmd.Output != "" {
 		content.WriteString("\n**출력**:\n")
-		content.WriteString(fmt.Sprintf("```\n%s\n```\n", cmd.Output))
+		e.writeCommandOutput(content, cmd.Output)
 	}
 
 	// 에러 메시지
 	if cmd.Error != "" {
 		content.WriteString("\n**에러**:\n")
-		content.WriteString(fmt.Sprintf("```\n%s\n```\n", cmd.Error))
+		e.writeCommandOutput(content, cmd.Error)
 	}
 
 	content.WriteString("\n")
 }
 
+// writeCommandOutput은 명령어 출력을 코드 블록으로 씁니다
+// ANSI 이스케이프 코드는 기본적으로 제거하고, --ansi-html이면 색상을 살린 <pre> HTML 블록으로 씁니다
+func (e *MarkdownExporter) writeCommandOutput(content *strings.Builder, output string) {
+	if e.config.ANSIHTML && HasANSI(output) {
+		content.WriteString(fmt.Sprintf("<pre>%s</pre>\n", ANSIToHTML(output)))
+		return
+	}
+	content.WriteString(fmt.Sprintf("```\n%s\n```\n", StripANSI(output)))
+}
+
 func (e *MarkdownExporter) writeFooter(content *strings.Builder, data *processor.ProcessedData) {
 	content.WriteString("---\n\n")
 	content.WriteString("## 메타데이터\n\n")
 	content.WriteString(fmt.Sprintf("- **문서 생성 도구**: summerise-genai\n"))
 	content.WriteString(fmt.Sprintf("- **생성 시간**: %s\n", 
-		data.ProcessedAt.Format("2006-01-02 15:04:05")))
+		e.displayTime(data.ProcessedAt).Format("2006-01-02 15:04:05")))
 	content.WriteString(fmt.Sprintf("- **템플릿**: %s\n", e.config.Template))
 	
 	if len(e.config.CustomFields) > 0 {
@@ -460,17 +1317,35 @@ func (e *MarkdownExporter) formatCodeInContent(content string) string {
 	return strings.TrimSuffix(formatted.String(), "\n")
 }
 
-func (e *MarkdownExporter) getSourceDisplayName(source models.CollectionSource) string {
-	switch source {
-	case models.SourceClaudeCode:
-		return "Claude Code"
-	case models.SourceGeminiCLI:
-		return "Gemini CLI"
-	case models.SourceAmazonQ:
-		return "Amazon Q"
-	default:
-		return string(source)
+// sessionFileName은 세션 ID에서 경로에 안전한 파일 이름을 생성합니다
+func (e *MarkdownExporter) sessionFileName(source models.CollectionSource, sessionID string) string {
+	var safe strings.Builder
+	for _, r := range sessionID {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' || r == '.' {
+			safe.WriteRune(r)
+		} else {
+			safe.WriteRune('_')
+		}
+	}
+
+	// 숨김 파일이나 상위 경로로 해석되지 않도록 앞쪽 점 제거
+	id := strings.TrimLeft(safe.String(), ".")
+	if id == "" {
+		id = "session"
+	}
+
+	return fmt.Sprintf("%s-%s.md", source, id)
+}
+
+// uniqueFileName은 이미 사용된 파일 이름과 겹치지 않도록 번호를 붙입니다
+func (e *MarkdownExporter) uniqueFileName(used map[string]bool, name string) string {
+	candidate := name
+	base := strings.TrimSuffix(name, ".md")
+	for i := 2; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s-%d.md", base, i)
 	}
+	used[candidate] = true
+	return candidate
 }
 
 func (e *MarkdownExporter) generateAnchor(text string) string {
@@ -0,0 +1,1167 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ssamai/internal/processor"
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestProcessedData는 테스트용 처리 데이터를 생성합니다
+func newTestProcessedData(t *testing.T, cfg *models.ExportConfig) processor.ProcessedData {
+	t.Helper()
+
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	sessions := []models.SessionData{
+		{
+			ID:        "claude-1",
+			Source:    models.SourceClaudeCode,
+			Timestamp: now,
+			Title:     "Claude Session",
+			Messages: []models.Message{
+				{ID: "m1", Role: "user", Content: "claude question body", Timestamp: now},
+				{ID: "m2", Role: "assistant", Content: "claude answer body", Timestamp: now.Add(time.Minute)},
+			},
+			Commands: []models.Command{
+				{ID: "c1", Command: "go", Args: []string{"build", "./..."}, Timestamp: now},
+			},
+			Files: []models.FileReference{
+				{Path: "./main.go", Name: "main.go", Size: 10, ModTime: now},
+			},
+		},
+		{
+			ID:        "gemini-1",
+			Source:    models.SourceGeminiCLI,
+			Timestamp: now.Add(-time.Hour),
+			Title:     "Gemini Session",
+			Messages: []models.Message{
+				{ID: "m3", Role: "user", Content: "gemini question body", Timestamp: now.Add(-time.Hour)},
+			},
+		},
+	}
+
+	result, err := processor.NewProcessor(cfg).Process(context.Background(), sessions)
+	require.NoError(t, err)
+
+	data, ok := result.(processor.ProcessedData)
+	require.True(t, ok)
+	return data
+}
+
+func TestMarkdownExporter_OutlineOnly(t *testing.T) {
+	cfg := &models.ExportConfig{
+		IncludeMetadata:   true,
+		IncludeTimestamps: true,
+		OutlineOnly:       true,
+	}
+	data := newTestProcessedData(t, cfg)
+
+	var buf strings.Builder
+	err := NewMarkdownExporter(cfg).ExportToWriter(context.Background(), data, &buf)
+	require.NoError(t, err)
+	output := buf.String()
+
+	// 목차와 제목은 포함
+	assert.Contains(t, output, "## 목차")
+	for _, entry := range data.TableOfContents {
+		assert.Contains(t, output, "- ["+entry.Title+"](#"+entry.Anchor+")")
+		for _, child := range entry.Children {
+			assert.Contains(t, output, "- ["+child.Title+"](#"+child.Anchor+")")
+		}
+	}
+	assert.Contains(t, output, "## Claude Code {#claude-code}")
+	assert.Contains(t, output, "## Gemini CLI {#gemini-cli}")
+	assert.Contains(t, output, "### Claude Session")
+	assert.Contains(t, output, "### Gemini Session")
+	assert.Contains(t, output, "메시지 2개, 명령어 1개, 파일 1개")
+
+	// 본문, 명령어, 파일은 제외
+	assert.NotContains(t, output, "claude question body")
+	assert.NotContains(t, output, "claude answer body")
+	assert.NotContains(t, output, "gemini question body")
+	assert.NotContains(t, output, "#### 대화 내용")
+	assert.NotContains(t, output, "#### 실행된 명령어")
+	assert.NotContains(t, output, "#### 참조된 파일")
+}
+
+func TestMarkdownExporter_OutlineOnlyDisabled(t *testing.T) {
+	cfg := &models.ExportConfig{
+		IncludeMetadata: true,
+		GenerateTOC:     true,
+	}
+	data := newTestProcessedData(t, cfg)
+
+	var buf strings.Builder
+	err := NewMarkdownExporter(cfg).ExportToWriter(context.Background(), data, &buf)
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "claude question body")
+	assert.Contains(t, buf.String(), "#### 실행된 명령어")
+}
+
+func TestMarkdownExporter_ExportPerSession(t *testing.T) {
+	cfg := &models.ExportConfig{IncludeMetadata: true}
+	data := newTestProcessedData(t, cfg)
+	dir := t.TempDir()
+
+	files, err := NewMarkdownExporter(cfg).ExportPerSession(context.Background(), data, dir)
+	require.NoError(t, err)
+
+	// 세션 2개 + index.md
+	assert.Len(t, files, 3)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+
+	claudeFile := filepath.Join(dir, "claude_code-claude-1.md")
+	content, err := os.ReadFile(claudeFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "### Claude Session")
+	assert.Contains(t, string(content), "claude question body")
+	assert.NotContains(t, string(content), "gemini question body")
+
+	index, err := os.ReadFile(filepath.Join(dir, "index.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(index), "- [Claude Session](claude_code-claude-1.md)")
+	assert.Contains(t, string(index), "- [Gemini Session](gemini_cli-gemini-1.md)")
+}
+
+func TestMarkdownExporter_sessionFileName(t *testing.T) {
+	e := NewMarkdownExporter(&models.ExportConfig{})
+
+	tests := []struct {
+		name     string
+		id       string
+		expected string
+	}{
+		{"plain id", "abc-123", "claude_code-abc-123.md"},
+		{"path separators", "../etc/passwd", "claude_code-_etc_passwd.md"},
+		{"unsafe chars", "a b:c*d", "claude_code-a_b_c_d.md"},
+		{"empty id", "", "claude_code-session.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, e.sessionFileName(models.SourceClaudeCode, tt.id))
+		})
+	}
+
+	used := map[string]bool{}
+	assert.Equal(t, "a.md", e.uniqueFileName(used, "a.md"))
+	assert.Equal(t, "a-2.md", e.uniqueFileName(used, "a.md"))
+}
+
+func TestMarkdownExporter_ExportWithResult(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "out", "summary.md")
+	cfg := &models.ExportConfig{
+		OutputPath:      outputPath,
+		IncludeMetadata: true,
+		GenerateTOC:     true,
+	}
+	data := newTestProcessedData(t, cfg)
+
+	result, err := NewMarkdownExporter(cfg).ExportWithResult(context.Background(), data)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	info, err := os.Stat(outputPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, outputPath, result.OutputPath)
+	assert.Equal(t, info.Size(), result.BytesWritten)
+	assert.Equal(t, 2, result.SessionCount)
+	assert.Equal(t, []models.CollectionSource{models.SourceClaudeCode, models.SourceGeminiCLI}, result.Sources)
+	assert.GreaterOrEqual(t, result.Duration, time.Duration(0))
+}
+
+func TestMarkdownExporter_ExportWithResult_InvalidData(t *testing.T) {
+	cfg := &models.ExportConfig{OutputPath: filepath.Join(t.TempDir(), "summary.md")}
+
+	result, err := NewMarkdownExporter(cfg).ExportWithResult(context.Background(), "invalid")
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestMarkdownExporter_WorkspaceMetadata(t *testing.T) {
+	cfg := &models.ExportConfig{IncludeMetadata: true}
+	e := NewMarkdownExporter(cfg)
+
+	var buf strings.Builder
+	e.writeSession(&buf, models.SessionData{
+		ID:    "s1",
+		Title: "Workspace Session",
+		Metadata: map[string]string{
+			"project": "ssamai",
+			"cwd":     "/work/ssamai",
+			"branch":  "main",
+			"model":   "claude",
+		},
+	}, models.SourceClaudeCode)
+
+	output := buf.String()
+	assert.Contains(t, output, "**작업 공간**: ssamai · `/work/ssamai` · 브랜치 `main`")
+	assert.Contains(t, output, "- model: claude")
+	assert.NotContains(t, output, "- cwd:")
+
+	// 메타데이터 제외 시 작업 공간도 출력하지 않음
+	buf.Reset()
+	NewMarkdownExporter(&models.ExportConfig{}).writeSession(&buf, models.SessionData{
+		ID:       "s1",
+		Metadata: map[string]string{"project": "ssamai"},
+	}, models.SourceClaudeCode)
+	assert.NotContains(t, buf.String(), "작업 공간")
+}
+
+func TestMarkdownExporter_SourceLinks(t *testing.T) {
+	e := NewMarkdownExporter(&models.ExportConfig{IncludeMetadata: true, IncludeSourceLinks: true})
+
+	var buf strings.Builder
+	e.writeSession(&buf, models.SessionData{
+		ID:       "s1",
+		Metadata: map[string]string{"file_path": "/home/me/.gemini/my sessions/s1.json"},
+	}, models.SourceGeminiCLI)
+	assert.Contains(t, buf.String(),
+		"**원본 파일**: [`/home/me/.gemini/my sessions/s1.json`](<file:///home/me/.gemini/my%20sessions/s1.json>)")
+
+	// file_path가 없으면 링크를 출력하지 않음
+	buf.Reset()
+	e.writeSession(&buf, models.SessionData{ID: "s2", Metadata: map[string]string{"model": "gemini"}}, models.SourceGeminiCLI)
+	assert.NotContains(t, buf.String(), "원본 파일")
+
+	// 옵션을 끄면 file_path가 있어도 링크를 출력하지 않음
+	buf.Reset()
+	NewMarkdownExporter(&models.ExportConfig{IncludeMetadata: true}).writeSession(&buf, models.SessionData{
+		ID:       "s3",
+		Metadata: map[string]string{"file_path": "/tmp/s3.json"},
+	}, models.SourceGeminiCLI)
+	assert.NotContains(t, buf.String(), "원본 파일")
+}
+
+func TestSourceFileLink(t *testing.T) {
+	assert.Equal(t, "", sourceFileLink(""))
+	assert.Equal(t, "[`sessions/a b#1.json`](<sessions/a%20b%231.json>)", sourceFileLink("sessions/a b#1.json"))
+	assert.Equal(t, "[`/tmp/s.json`](<file:///tmp/s.json>)", sourceFileLink("/tmp/s.json"))
+}
+
+func TestMarkdownExporter_SessionSeparator(t *testing.T) {
+	tests := []struct {
+		separator string
+		want      string
+	}{
+		{"", "---\n\n"},
+		{"***", "***\n\n"},
+		{SessionSeparatorBlank, "\n"},
+		{SessionSeparatorNone, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.separator, func(t *testing.T) {
+			e := NewMarkdownExporter(&models.ExportConfig{SessionSeparator: tt.separator})
+
+			var buf strings.Builder
+			e.writeSession(&buf, models.SessionData{ID: "s1"}, models.SourceGeminiCLI)
+
+			assert.Equal(t, "### 세션 s1 {#gemini-cli-s1}\n\n"+tt.want, buf.String())
+		})
+	}
+}
+
+func TestMarkdownExporter_CollapseSessionsOver(t *testing.T) {
+	e := NewMarkdownExporter(&models.ExportConfig{CollapseSessionsOver: 2})
+
+	long := models.SessionData{ID: "long", Title: "Refactor <parser>", Messages: []models.Message{
+		{Role: "user", Content: "one"},
+		{Role: "assistant", Content: "two"},
+		{Role: "user", Content: "three"},
+	}}
+	short := models.SessionData{ID: "short", Messages: long.Messages[:2]}
+
+	var buf strings.Builder
+	e.writeSession(&buf, long, models.SourceClaudeCode)
+	output := buf.String()
+
+	// 목차가 가리키는 제목은 접힌 블록 밖에 남아 있어야 함
+	heading := "### Refactor <parser> {#claude-code-long}\n\n"
+	summary := "<details>\n<summary>Refactor &lt;parser&gt; (메시지 3개)</summary>\n\n"
+	assert.True(t, strings.HasPrefix(output, heading+summary), "output: %q", output)
+	assert.Contains(t, output, "#### 대화 내용")
+	assert.True(t, strings.HasSuffix(output, "</details>\n\n---\n\n"), "output: %q", output)
+	assert.Less(t, strings.Index(output, "three"), strings.Index(output, "</details>"))
+
+	// 기준 이하의 세션은 그대로 출력
+	buf.Reset()
+	e.writeSession(&buf, short, models.SourceClaudeCode)
+	assert.NotContains(t, buf.String(), "<details>")
+
+	// 기본값(0)에서는 접지 않음
+	buf.Reset()
+	NewMarkdownExporter(&models.ExportConfig{}).writeSession(&buf, long, models.SourceClaudeCode)
+	assert.NotContains(t, buf.String(), "<details>")
+}
+
+func TestMarkdownExporter_DisplayTimezone(t *testing.T) {
+	utc := time.Date(2024, 1, 15, 23, 30, 0, 0, time.UTC)
+	session := models.SessionData{
+		ID:        "tz",
+		Timestamp: utc,
+		Messages:  []models.Message{{Role: "user", Content: "hi", Timestamp: utc}},
+	}
+
+	var buf strings.Builder
+	e := NewMarkdownExporter(&models.ExportConfig{IncludeMetadata: true, IncludeTimestamps: true, DisplayTimezone: "Asia/Seoul"})
+	e.writeSession(&buf, session, models.SourceClaudeCode)
+	assert.Contains(t, buf.String(), "**시간**: 2024-01-16 08:30:00")
+	assert.Contains(t, buf.String(), "08:30:00")
+
+	// 설정하지 않으면 기록된 시간대 그대로
+	buf.Reset()
+	NewMarkdownExporter(&models.ExportConfig{IncludeMetadata: true, IncludeTimestamps: true}).
+		writeSession(&buf, session, models.SourceClaudeCode)
+	assert.Contains(t, buf.String(), "**시간**: 2024-01-15 23:30:00")
+}
+
+func TestMarkdownExporter_MostActiveSourceMetric(t *testing.T) {
+	e := NewMarkdownExporter(&models.ExportConfig{})
+
+	var buf strings.Builder
+	e.writeStatistics(&buf, processor.Statistics{
+		MostActiveSource:       models.SourceClaudeCode,
+		MostActiveSourceMetric: processor.ActiveSourceByMessages,
+	})
+	assert.Contains(t, buf.String(), "- **가장 활발한 도구**: Claude Code (메시지 수 기준)\n")
+
+	buf.Reset()
+	e.writeStatistics(&buf, processor.Statistics{MostActiveSource: models.SourceGeminiCLI})
+	assert.Contains(t, buf.String(), "- **가장 활발한 도구**: Gemini CLI (세션 수 기준)\n")
+}
+
+func TestMarkdownExporter_InvalidTimezone(t *testing.T) {
+	e := NewMarkdownExporter(&models.ExportConfig{OutputPath: "out.md", DisplayTimezone: "Nowhere/Special"})
+	err := e.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "알 수 없는 시간대입니다: Nowhere/Special")
+}
+
+func TestMarkdownExporter_IncludeErrors(t *testing.T) {
+	cfg := &models.ExportConfig{
+		IncludeMetadata: true,
+		GenerateTOC:     true,
+		IncludeErrors:   true,
+	}
+	result := &models.CollectionResult{
+		Sessions: newTestProcessedData(t, cfg).Sessions,
+		Errors: []string{
+			"amazon_q: failed to read history",
+			"gemini_cli: permission denied",
+		},
+	}
+
+	data, err := processor.NewProcessor(cfg).ProcessCollectionResult(context.Background(), result)
+	require.NoError(t, err)
+	assert.Equal(t, result.Errors, data.Errors)
+
+	var buf strings.Builder
+	err = NewMarkdownExporter(cfg).ExportToWriter(context.Background(), data, &buf)
+	require.NoError(t, err)
+	output := buf.String()
+
+	assert.Contains(t, output, "- [수집 문제](#collection-issues)")
+	assert.Contains(t, output, "## 수집 문제 {#collection-issues}")
+	assert.Contains(t, output, "수집 중 2개의 문제가 발생했습니다")
+	assert.Contains(t, output, "- ⚠️ amazon_q: failed to read history")
+	assert.Contains(t, output, "- ⚠️ gemini_cli: permission denied")
+
+	// 통계 다음, 소스 섹션 이전에 출력
+	issuesIdx := strings.Index(output, "## 수집 문제")
+	assert.Greater(t, issuesIdx, strings.Index(output, "## 통계"))
+	assert.Less(t, issuesIdx, strings.Index(output, "## Claude Code"))
+}
+
+func TestMarkdownExporter_IncludeErrorsDisabled(t *testing.T) {
+	cfg := &models.ExportConfig{GenerateTOC: true}
+	result := &models.CollectionResult{
+		Sessions: newTestProcessedData(t, cfg).Sessions,
+		Errors:   []string{"amazon_q: failed to read history"},
+	}
+
+	data, err := processor.NewProcessor(cfg).ProcessCollectionResult(context.Background(), result)
+	require.NoError(t, err)
+	// 에러는 항상 전달되지만 옵션이 꺼져 있으면 출력하지 않음
+	assert.Equal(t, result.Errors, data.Errors)
+
+	var buf strings.Builder
+	err = NewMarkdownExporter(cfg).ExportToWriter(context.Background(), data, &buf)
+	require.NoError(t, err)
+
+	assert.NotContains(t, buf.String(), "수집 문제")
+	assert.NotContains(t, buf.String(), "failed to read history")
+}
+
+// memorySink는 테스트용 메모리 출력 저장소
+type memorySink struct {
+	files map[string][]byte
+	err   error
+}
+
+func newMemorySink() *memorySink {
+	return &memorySink{files: make(map[string][]byte)}
+}
+
+func (s *memorySink) Write(name string, data []byte) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.files[name] = append([]byte(nil), data...)
+	return nil
+}
+
+func (s *memorySink) Exists(name string) (bool, error) {
+	_, ok := s.files[name]
+	return ok, nil
+}
+
+func (s *memorySink) Rename(oldName, newName string) error {
+	data, ok := s.files[oldName]
+	if !ok {
+		return os.ErrNotExist
+	}
+	delete(s.files, oldName)
+	s.files[newName] = data
+	return nil
+}
+
+func TestMarkdownExporter_WithSink(t *testing.T) {
+	cfg := &models.ExportConfig{
+		OutputPath:      "s3://bucket/summary.md",
+		IncludeMetadata: true,
+		GenerateTOC:     true,
+	}
+	data := newTestProcessedData(t, cfg)
+	// 헤더의 생성 시간이 비교에 영향을 주지 않도록 처리 시각을 고정
+	data.ProcessedAt = time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC)
+	sink := newMemorySink()
+
+	result, err := NewMarkdownExporter(cfg).WithSink(sink).ExportWithResult(context.Background(), data)
+	require.NoError(t, err)
+
+	require.Len(t, sink.files, 1)
+	content, ok := sink.files["s3://bucket/summary.md"]
+	require.True(t, ok)
+	assert.Equal(t, int64(len(content)), result.BytesWritten)
+
+	var expected strings.Builder
+	require.NoError(t, NewMarkdownExporter(cfg).ExportToWriter(context.Background(), data, &expected))
+	assert.Equal(t, expected.String(), string(content))
+	assert.Contains(t, string(content), "2024-01-03 09:00:00")
+}
+
+func TestMarkdownExporter_WithSink_PerSession(t *testing.T) {
+	cfg := &models.ExportConfig{IncludeMetadata: true}
+	data := newTestProcessedData(t, cfg)
+	sink := newMemorySink()
+
+	files, err := NewMarkdownExporter(cfg).WithSink(sink).ExportPerSession(context.Background(), data, "wiki")
+	require.NoError(t, err)
+
+	assert.Len(t, sink.files, 3)
+	for _, file := range files {
+		assert.Contains(t, sink.files, file)
+	}
+	assert.Contains(t, string(sink.files[filepath.Join("wiki", "claude_code-claude-1.md")]), "claude question body")
+	assert.Contains(t, string(sink.files[filepath.Join("wiki", "index.md")]), "- [Gemini Session](gemini_cli-gemini-1.md)")
+
+	// 로컬 파일 시스템에는 아무것도 쓰지 않음
+	_, err = os.Stat("wiki")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestMarkdownExporter_WithSink_Error(t *testing.T) {
+	cfg := &models.ExportConfig{OutputPath: "summary.md"}
+	data := newTestProcessedData(t, cfg)
+	sink := newMemorySink()
+	sink.err = errors.New("upload failed")
+
+	result, err := NewMarkdownExporter(cfg).WithSink(sink).ExportWithResult(context.Background(), data)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, sink.err)
+	assert.Nil(t, result)
+}
+
+func TestMarkdownExporter_UniquePromptStatistics(t *testing.T) {
+	e := NewMarkdownExporter(&models.ExportConfig{})
+
+	var buf strings.Builder
+	e.writeStatistics(&buf, processor.Statistics{
+		TotalSessions:       1,
+		TotalMessages:       4,
+		UniquePrompts:       3,
+		DuplicatePromptRate: 0.25,
+	})
+
+	assert.Contains(t, buf.String(), "- **고유 프롬프트 수**: 3개 (중복률 25.0%)")
+}
+
+func TestMarkdownExporter_RecentActivity(t *testing.T) {
+	processedAt := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	data := processor.ProcessedData{
+		Sessions: []models.SessionData{
+			{ID: "in-1", Timestamp: processedAt.Add(-24 * time.Hour), Messages: make([]models.Message, 2)},
+			{ID: "in-2", Timestamp: processedAt.Add(-6 * 24 * time.Hour), Messages: make([]models.Message, 3)},
+			{ID: "out", Timestamp: processedAt.Add(-8 * 24 * time.Hour), Messages: make([]models.Message, 5)},
+		},
+		Statistics:  processor.Statistics{TotalSessions: 3},
+		ProcessedAt: processedAt,
+	}
+
+	var buf strings.Builder
+	NewMarkdownExporter(&models.ExportConfig{RecentWindow: 7 * 24 * time.Hour}).writeOverview(&buf, &data)
+	output := buf.String()
+
+	assert.Contains(t, output, "### 최근 활동 (최근 7일)")
+	assert.Contains(t, output, "- **세션 수**: 2개")
+	assert.Contains(t, output, "- **메시지 수**: 5개")
+
+	// 기간 내 세션이 없는 경우
+	buf.Reset()
+	NewMarkdownExporter(&models.ExportConfig{RecentWindow: 12 * time.Hour}).writeOverview(&buf, &data)
+	assert.Contains(t, buf.String(), "### 최근 활동 (최근 12h0m0s)")
+	assert.Contains(t, buf.String(), "이 기간에 활동한 세션이 없습니다.")
+
+	// 설정하지 않으면 출력하지 않음
+	buf.Reset()
+	NewMarkdownExporter(&models.ExportConfig{}).writeOverview(&buf, &data)
+	assert.NotContains(t, buf.String(), "최근 활동")
+}
+
+func TestMarkdownExporter_PruneEmptyMetadata(t *testing.T) {
+	cfg := &models.ExportConfig{IncludeMetadata: true, PruneEmptyMetadata: true}
+	sessions := []models.SessionData{
+		{
+			ID:       "q1",
+			Source:   models.SourceAmazonQ,
+			Title:    "Amazon Q Session",
+			Metadata: map[string]string{"service": "lambda", "region": "", "user_id": ""},
+		},
+	}
+
+	processed, err := processor.NewProcessor(cfg).Process(context.Background(), sessions)
+	require.NoError(t, err)
+
+	var buf strings.Builder
+	require.NoError(t, NewMarkdownExporter(cfg).ExportToWriter(context.Background(), processed, &buf))
+
+	assert.Contains(t, buf.String(), "- service: lambda")
+	assert.NotContains(t, buf.String(), "- region:")
+	assert.NotContains(t, buf.String(), "- user_id:")
+}
+
+func TestMarkdownExporter_MessagesByRoleStatistics(t *testing.T) {
+	var buf strings.Builder
+	NewMarkdownExporter(&models.ExportConfig{}).writeStatistics(&buf, processor.Statistics{
+		TotalMessages:  5,
+		MessagesByRole: map[string]int{"user": 2, "assistant": 2, "system": 1},
+	})
+
+	assert.Contains(t, buf.String(), "- **역할별 메시지 수**: assistant 2개, system 1개, user 2개")
+}
+
+func TestMarkdownExporter_MessageLengthStatistics(t *testing.T) {
+	var buf strings.Builder
+	NewMarkdownExporter(&models.ExportConfig{}).writeStatistics(&buf, processor.Statistics{
+		TotalMessages: 6,
+		MessageLengths: []processor.LengthBucket{
+			{Label: "<100", Max: 100, Count: 3},
+			{Label: "100–500", Min: 100, Max: 500, Count: 2},
+			{Label: "500–2000", Min: 500, Max: 2000, Count: 0},
+			{Label: "2000+", Min: 2000, Count: 1},
+		},
+	})
+
+	assert.Contains(t, buf.String(), "### 메시지 길이 분포\n\n"+
+		"| 길이 (문자) | 메시지 수 |\n"+
+		"|-------------|-----------|\n"+
+		"| <100 | 3 |\n"+
+		"| 100–500 | 2 |\n"+
+		"| 500–2000 | 0 |\n"+
+		"| 2000+ | 1 |\n")
+
+	// 메시지가 없으면 표를 출력하지 않음
+	buf.Reset()
+	NewMarkdownExporter(&models.ExportConfig{}).writeStatistics(&buf, processor.Statistics{})
+	assert.NotContains(t, buf.String(), "메시지 길이 분포")
+}
+
+func TestMarkdownExporter_ExportParentIsFile(t *testing.T) {
+	root := t.TempDir()
+	blocking := filepath.Join(root, "reports")
+	require.NoError(t, os.WriteFile(blocking, []byte("not a directory"), 0644))
+
+	cfg := &models.ExportConfig{OutputPath: filepath.Join(blocking, "2024", "summary.md")}
+	data := newTestProcessedData(t, cfg)
+
+	result, err := NewMarkdownExporter(cfg).ExportWithResult(context.Background(), data)
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "출력 경로의 부모가 디렉토리가 아닙니다")
+	assert.Contains(t, err.Error(), blocking+" 경로가 파일이어서 디렉토리를 만들 수 없습니다")
+}
+
+func TestLocalSink_WriteParentIsFile(t *testing.T) {
+	root := t.TempDir()
+	blocking := filepath.Join(root, "out")
+	require.NoError(t, os.WriteFile(blocking, []byte("file"), 0644))
+
+	err := (&LocalSink{}).Write(filepath.Join(blocking, "nested", "index.md"), []byte("# index"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), blocking+" 경로가 파일입니다")
+}
+
+func TestMarkdownExporter_ThreadMessages(t *testing.T) {
+	session := models.SessionData{
+		ID:     "branched",
+		Source: models.SourceClaudeCode,
+		Title:  "Branched Session",
+		Messages: []models.Message{
+			{ID: "m1", Role: "user", Content: "question"},
+			{ID: "m2", Role: "assistant", Content: "first answer", Metadata: map[string]string{"parent_id": "m1"}},
+			{ID: "m3", Role: "assistant", Content: "regenerated answer", Metadata: map[string]string{"parent_id": "m1"}},
+			{ID: "m4", Role: "user", Content: "follow up", Metadata: map[string]string{"parent_id": "m3"}},
+		},
+	}
+
+	var buf strings.Builder
+	NewMarkdownExporter(&models.ExportConfig{ThreadMessages: true}).writeSession(&buf, session, models.SourceClaudeCode)
+	out := buf.String()
+
+	assert.Contains(t, out, "**👤 User** (1)\n\nquestion\n\n")
+	assert.Contains(t, out, "> **🤖 Assistant** (2)\n>\n> first answer\n")
+	assert.Contains(t, out, "> **🤖 Assistant** (3)\n>\n> regenerated answer\n")
+	assert.Contains(t, out, ">> **👤 User** (4)\n>>\n>> follow up\n")
+
+	// 답변은 부모 아래에, 재생성된 분기의 후속 질문은 해당 분기 아래에 위치
+	assert.Less(t, strings.Index(out, "first answer"), strings.Index(out, "regenerated answer"))
+	assert.Less(t, strings.Index(out, "regenerated answer"), strings.Index(out, "follow up"))
+}
+
+func TestMarkdownExporter_ThreadMessagesFallsBackToFlat(t *testing.T) {
+	session := models.SessionData{
+		ID:     "flat",
+		Source: models.SourceGeminiCLI,
+		Messages: []models.Message{
+			{ID: "m1", Role: "user", Content: "question"},
+			{ID: "m2", Role: "assistant", Content: "answer", Metadata: map[string]string{"parent_id": "missing"}},
+		},
+	}
+
+	var buf strings.Builder
+	NewMarkdownExporter(&models.ExportConfig{ThreadMessages: true}).writeSession(&buf, session, models.SourceGeminiCLI)
+
+	assert.Contains(t, buf.String(), "**🤖 Assistant** (2)\n\nanswer\n\n")
+	assert.NotContains(t, buf.String(), "> ")
+}
+
+func TestMarkdownExporter_ThreadMessagesCycle(t *testing.T) {
+	messages := []models.Message{
+		{ID: "a", Role: "user", Content: "alpha", Metadata: map[string]string{"parent_id": "b"}},
+		{ID: "b", Role: "assistant", Content: "beta", Metadata: map[string]string{"parent_id": "a"}},
+	}
+
+	var buf strings.Builder
+	NewMarkdownExporter(&models.ExportConfig{}).writeMessageThread(&buf, messages)
+
+	assert.Equal(t, 1, strings.Count(buf.String(), "alpha"))
+	assert.Equal(t, 1, strings.Count(buf.String(), "beta"))
+}
+
+func TestMarkdownExporter_ResponseLatencyStatistics(t *testing.T) {
+	var buf strings.Builder
+	NewMarkdownExporter(&models.ExportConfig{}).writeStatistics(&buf, processor.Statistics{
+		ResponseLatency: map[models.CollectionSource]processor.ResponseLatency{
+			models.SourceGeminiCLI:  {Samples: 1, Average: 1500 * time.Millisecond, Median: 1500 * time.Millisecond},
+			models.SourceClaudeCode: {Samples: 3, Average: 6 * time.Second, Median: 4 * time.Second},
+		},
+	})
+
+	out := buf.String()
+	assert.Contains(t, out, "- **응답 지연 (사용자 → 어시스턴트)**:\n")
+	assert.Contains(t, out, "  - Claude Code: 평균 6s, 중앙값 4s (3쌍)\n")
+	assert.Contains(t, out, "  - Gemini CLI: 평균 1.5s, 중앙값 1.5s (1쌍)\n")
+	assert.Less(t, strings.Index(out, "Claude Code: 평균"), strings.Index(out, "Gemini CLI: 평균"))
+}
+
+func TestMarkdownExporter_RoleIcons(t *testing.T) {
+	cfg := &models.ExportConfig{RoleIcons: map[string]string{"user": "🧑", "tool": "🔧"}}
+	exporter := NewMarkdownExporter(cfg)
+
+	var buf strings.Builder
+	exporter.writeMessage(&buf, models.Message{Role: "user", Content: "hi"}, 1)
+	exporter.writeMessage(&buf, models.Message{Role: "assistant", Content: "hello"}, 2)
+	exporter.writeMessage(&buf, models.Message{Role: "tool", Content: "ran"}, 3)
+	exporter.writeMessage(&buf, models.Message{Role: "observer", Content: "noted"}, 4)
+
+	out := buf.String()
+	assert.Contains(t, out, "**🧑 User** (1)")
+	// 매핑되지 않은 역할은 기본 아이콘 사용
+	assert.Contains(t, out, "**🤖 Assistant** (2)")
+	assert.Contains(t, out, "**🔧 Tool** (3)")
+	assert.Contains(t, out, "**Observer** (4)")
+}
+
+func TestMarkdownExporter_NoIcons(t *testing.T) {
+	cfg := &models.ExportConfig{NoIcons: true, RoleIcons: map[string]string{"user": "🧑"}}
+
+	var buf strings.Builder
+	NewMarkdownExporter(cfg).writeMessage(&buf, models.Message{Role: "user", Content: "hi"}, 1)
+	NewMarkdownExporter(cfg).writeMessage(&buf, models.Message{Role: "assistant", Content: "hello"}, 2)
+
+	assert.Contains(t, buf.String(), "**User** (1)")
+	assert.Contains(t, buf.String(), "**Assistant** (2)")
+	assert.NotContains(t, buf.String(), "🧑")
+	assert.NotContains(t, buf.String(), "🤖")
+}
+
+func TestMarkdownExporter_Annotations(t *testing.T) {
+	cfg := &models.ExportConfig{Annotations: map[string]string{
+		"claude-1": "Root cause of the build break\nFollow up next sprint",
+		"unknown":  "never rendered",
+	}}
+	data := newTestProcessedData(t, cfg)
+
+	var buf strings.Builder
+	require.NoError(t, NewMarkdownExporter(cfg).ExportToWriter(context.Background(), data, &buf))
+	out := buf.String()
+
+	assert.Contains(t, out, "### Claude Session {#claude-code-claude-1}\n\n> 📝 **메모**: Root cause of the build break\n> Follow up next sprint\n\n")
+	assert.Equal(t, 1, strings.Count(out, "📝 **메모**"))
+	assert.NotContains(t, out, "never rendered")
+}
+
+func TestMarkdownExporter_MermaidTimeline(t *testing.T) {
+	cfg := &models.ExportConfig{MermaidTimeline: true, GenerateTOC: true}
+	data := newTestProcessedData(t, cfg)
+	data.Sessions[0].Title = "Fix: build #42"
+	data.SourceGroups[models.SourceClaudeCode][0].Title = "Fix: build #42"
+
+	var buf strings.Builder
+	require.NoError(t, NewMarkdownExporter(cfg).ExportToWriter(context.Background(), data, &buf))
+	out := buf.String()
+
+	start := strings.Index(out, "```mermaid\n")
+	require.GreaterOrEqual(t, start, 0)
+	end := strings.Index(out[start+len("```mermaid\n"):], "```")
+	require.GreaterOrEqual(t, end, 0)
+	block := out[start+len("```mermaid\n") : start+len("```mermaid\n")+end]
+
+	lines := strings.Split(strings.TrimRight(block, "\n"), "\n")
+	assert.Equal(t, "gantt", lines[0])
+	assert.Contains(t, block, "    dateFormat YYYY-MM-DDTHH:mm:ss\n")
+	assert.Contains(t, block, "    section Claude Code\n    Fix build 42 :2024-01-02T12:00:00, 60s\n")
+	assert.Contains(t, block, "    section Gemini CLI\n    Gemini Session :2024-01-02T11:00:00, 60s\n")
+
+	// 모든 작업 줄은 "이름 :시작, 길이" 형식이며 이름에 콜론이 없어야 함
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "title ") || strings.HasPrefix(trimmed, "dateFormat ") ||
+			strings.HasPrefix(trimmed, "axisFormat ") || strings.HasPrefix(trimmed, "section ") {
+			continue
+		}
+		assert.Equal(t, 1, strings.Count(trimmed, " :"), "task line: %q", line)
+		assert.Regexp(t, `:\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}, \d+s$`, trimmed)
+	}
+
+	assert.Contains(t, out, "## 타임라인 {#timeline}")
+	assert.Contains(t, out, "[타임라인](#timeline)")
+}
+
+func TestMarkdownExporter_MermaidTimelineDisabled(t *testing.T) {
+	cfg := &models.ExportConfig{}
+	data := newTestProcessedData(t, cfg)
+
+	var buf strings.Builder
+	require.NoError(t, NewMarkdownExporter(cfg).ExportToWriter(context.Background(), data, &buf))
+	assert.NotContains(t, buf.String(), "```mermaid")
+}
+
+func TestMarkdownExporter_ActivityHeatmap(t *testing.T) {
+	cfg := &models.ExportConfig{ActivityHeatmap: true, GenerateTOC: true}
+	data := newTestProcessedData(t, cfg)
+
+	var buf strings.Builder
+	require.NoError(t, NewMarkdownExporter(cfg).ExportToWriter(context.Background(), data, &buf))
+	out := buf.String()
+
+	// 테스트 세션은 2024-01-02(화) 11시와 12시
+	empty := strings.Repeat("·", 24)
+	assert.Contains(t, out, "## 활동 히트맵 {#heatmap}\n\n```text\n   00    06    12    18\n월 "+empty+"\n")
+	assert.Contains(t, out, "\n화 "+strings.Repeat("·", 11)+"██"+strings.Repeat("·", 11)+"\n")
+	assert.Contains(t, out, "\n일 "+empty+"\n```\n")
+	assert.Contains(t, out, "한 칸 최대 1개 세션")
+	assert.Contains(t, out, "[활동 히트맵](#heatmap)")
+	assert.Less(t, strings.Index(out, "## 통계"), strings.Index(out, "## 활동 히트맵"))
+}
+
+func TestMarkdownExporter_GroupByMeta(t *testing.T) {
+	cfg := &models.ExportConfig{GroupByMeta: "service", GenerateTOC: true}
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	sessions := []models.SessionData{
+		{ID: "pay-1", Source: models.SourceClaudeCode, Title: "Pay", Timestamp: now, Metadata: map[string]string{"service": "payments"}},
+		{ID: "auth-1", Source: models.SourceGeminiCLI, Title: "Auth", Timestamp: now.Add(-time.Hour), Metadata: map[string]string{"service": "auth"}},
+		{ID: "misc-1", Source: models.SourceClaudeCode, Title: "Misc", Timestamp: now.Add(-2 * time.Hour)},
+	}
+	result, err := processor.NewProcessor(cfg).Process(context.Background(), sessions)
+	require.NoError(t, err)
+
+	var buf strings.Builder
+	require.NoError(t, NewMarkdownExporter(cfg).ExportToWriter(context.Background(), result, &buf))
+	out := buf.String()
+
+	authIdx := strings.Index(out, "## service: auth {#service-auth}\n\n총 1개의 세션이 있습니다.\n\n### Auth {#gemini-cli-auth-1}")
+	paymentsIdx := strings.Index(out, "## service: payments {#service-payments}\n\n총 1개의 세션이 있습니다.\n\n### Pay {#claude-code-pay-1}")
+	unknownIdx := strings.Index(out, "## service: unknown {#service-unknown}\n\n총 1개의 세션이 있습니다.\n\n### Misc {#claude-code-misc-1}")
+	require.GreaterOrEqual(t, authIdx, 0)
+	require.Greater(t, paymentsIdx, authIdx)
+	require.Greater(t, unknownIdx, paymentsIdx)
+
+	// 소스별 섹션은 출력하지 않음
+	assert.NotContains(t, out, "## Claude Code {#claude-code}")
+	assert.Contains(t, out, "[service: payments (1개 세션)](#service-payments)")
+}
+
+func TestHeatmapCell(t *testing.T) {
+	assert.Equal(t, '·', heatmapCell(0, 8))
+	assert.Equal(t, '░', heatmapCell(1, 8))
+	assert.Equal(t, '░', heatmapCell(2, 8))
+	assert.Equal(t, '▒', heatmapCell(3, 8))
+	assert.Equal(t, '▓', heatmapCell(6, 8))
+	assert.Equal(t, '█', heatmapCell(8, 8))
+}
+
+func TestMarkdownExporter_SourceBudget(t *testing.T) {
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	chatty := func(id string, offset time.Duration, fill string) models.SessionData {
+		return models.SessionData{
+			ID:        id,
+			Source:    models.SourceClaudeCode,
+			Title:     id,
+			Timestamp: now.Add(offset),
+			Messages:  []models.Message{{Role: "user", Content: strings.Repeat(fill, 100)}},
+		}
+	}
+	sessions := []models.SessionData{
+		chatty("claude-new", 0, "a"),
+		chatty("claude-mid", -time.Hour, "b"),
+		chatty("claude-old", -2*time.Hour, "c"),
+		{
+			ID:        "gemini-quiet",
+			Source:    models.SourceGeminiCLI,
+			Title:     "gemini-quiet",
+			Timestamp: now,
+			Messages:  []models.Message{{Role: "user", Content: "short gemini question"}},
+		},
+	}
+
+	cfg := &models.ExportConfig{SourceBudget: 150}
+	processed, err := processor.NewProcessor(cfg).Process(context.Background(), sessions)
+	require.NoError(t, err)
+
+	var buf strings.Builder
+	require.NoError(t, NewMarkdownExporter(cfg).ExportToWriter(context.Background(), processed, &buf))
+	out := buf.String()
+
+	// 최신 세션은 그대로, 다음 세션은 남은 50자로 잘리고, 마지막 세션은 생략
+	assert.Contains(t, out, strings.Repeat("a", 100)+"\n\n")
+	assert.Contains(t, out, strings.Repeat("b", 50)+"…\n\n")
+	assert.NotContains(t, out, strings.Repeat("b", 51))
+	assert.NotContains(t, out, "ccc")
+	assert.Equal(t, 2, strings.Count(out, "소스별 내용 한도(150자)에 도달해"))
+
+	// 조용한 소스는 영향 없음
+	geminiSection := out[strings.Index(out, "## Gemini CLI"):]
+	assert.Contains(t, geminiSection, "short gemini question")
+	assert.NotContains(t, geminiSection, "소스별 내용 한도")
+
+	// 원본 세션은 변경하지 않음
+	assert.Len(t, sessions[2].Messages, 1)
+}
+
+func TestApplyContentBudget(t *testing.T) {
+	session := models.SessionData{Messages: []models.Message{
+		{Role: "user", Content: "한글메시지"},
+		{Role: "assistant", Content: "answer"},
+	}}
+
+	remaining := 7
+	budgeted, omitted := applyContentBudget(session, &remaining)
+	require.Len(t, budgeted.Messages, 2)
+	assert.Equal(t, "한글메시지", budgeted.Messages[0].Content)
+	assert.Equal(t, "an…", budgeted.Messages[1].Content)
+	assert.Equal(t, 1, omitted)
+	assert.Equal(t, 0, remaining)
+	assert.Equal(t, "answer", session.Messages[1].Content)
+}
+
+func TestMarkdownExporter_CustomSourceSections(t *testing.T) {
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	session := func(id string, source models.CollectionSource) models.SessionData {
+		return models.SessionData{
+			ID:        id,
+			Source:    source,
+			Title:     id,
+			Timestamp: now,
+			Messages:  []models.Message{{Role: "user", Content: id + " body"}},
+		}
+	}
+	sessions := []models.SessionData{
+		session("zeta-1", "zeta_cli"),
+		session("codex-1", "codex"),
+		session("gemini-1", models.SourceGeminiCLI),
+		session("claude-1", models.SourceClaudeCode),
+	}
+
+	cfg := &models.ExportConfig{}
+	processed, err := processor.NewProcessor(cfg).Process(context.Background(), sessions)
+	require.NoError(t, err)
+
+	var buf strings.Builder
+	require.NoError(t, NewMarkdownExporter(cfg).ExportToWriter(context.Background(), processed, &buf))
+	out := buf.String()
+
+	// 알려진 소스가 고정 순서로 먼저, 그 밖의 소스는 이름순으로 뒤에
+	headings := []string{"## Claude Code {#claude-code}", "## Gemini CLI {#gemini-cli}", "## codex {#codex}", "## zeta_cli {#zeta-cli}"}
+	last := -1
+	for _, heading := range headings {
+		index := strings.Index(out, heading)
+		require.NotEqual(t, -1, index, "missing section %q", heading)
+		assert.Greater(t, index, last, "section %q out of order", heading)
+		last = index
+	}
+	assert.Contains(t, out, "codex-1 body")
+	assert.Contains(t, out, "zeta-1 body")
+
+	assert.Equal(t, []models.CollectionSource{models.SourceClaudeCode, models.SourceGeminiCLI, "codex", "zeta_cli"},
+		NewMarkdownExporter(cfg).orderedSources(processed.(processor.ProcessedData).SourceGroups))
+}
+
+func TestMarkdownExporter_SourceOrder(t *testing.T) {
+	session := func(id string, source models.CollectionSource) models.SessionData {
+		return models.SessionData{
+			ID:        id,
+			Source:    source,
+			Title:     id,
+			Timestamp: time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC),
+			Messages:  []models.Message{{Role: "user", Content: id + " body"}},
+		}
+	}
+	sessions := []models.SessionData{
+		session("claude-1", models.SourceClaudeCode),
+		session("gemini-1", models.SourceGeminiCLI),
+		session("amazonq-1", models.SourceAmazonQ),
+		session("codex-1", "codex"),
+	}
+
+	// 나열한 소스가 먼저, 나열하지 않은 알려진 소스는 기본 순서로, 그 밖의 소스는 맨 뒤에
+	cfg := &models.ExportConfig{GenerateTOC: true, SourceOrder: []models.CollectionSource{models.SourceAmazonQ, models.SourceGeminiCLI}}
+	processed, err := processor.NewProcessor(cfg).Process(context.Background(), sessions)
+	require.NoError(t, err)
+
+	var buf strings.Builder
+	require.NoError(t, NewMarkdownExporter(cfg).ExportToWriter(context.Background(), processed, &buf))
+	out := buf.String()
+
+	headings := []string{"## Amazon Q {#amazon-q}", "## Gemini CLI {#gemini-cli}", "## Claude Code {#claude-code}", "## codex {#codex}"}
+	last := -1
+	for _, heading := range headings {
+		index := strings.Index(out, heading)
+		require.NotEqual(t, -1, index, "missing section %q", heading)
+		assert.Greater(t, index, last, "section %q out of order", heading)
+		last = index
+	}
+
+	// 목차도 같은 순서
+	var tocSources []string
+	for _, entry := range processed.(processor.ProcessedData).TableOfContents {
+		if len(entry.Children) > 0 {
+			tocSources = append(tocSources, entry.Title)
+		}
+	}
+	assert.Equal(t, []string{"Amazon Q (1개 세션)", "Gemini CLI (1개 세션)", "Claude Code (1개 세션)", "codex (1개 세션)"}, tocSources)
+}
+
+func TestMarkdownExporter_ConcurrentSectionsMatchSequential(t *testing.T) {
+	base := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	sources := []models.CollectionSource{models.SourceClaudeCode, models.SourceGeminiCLI, models.SourceAmazonQ, "codex", "zeta_cli"}
+
+	var sessions []models.SessionData
+	for i := 0; i < 60; i++ {
+		source := sources[i%len(sources)]
+		sessions = append(sessions, models.SessionData{
+			ID:        fmt.Sprintf("%s-%d", source, i),
+			Source:    source,
+			Title:     fmt.Sprintf("세션 %d", i),
+			Timestamp: base.Add(time.Duration(i) * time.Minute),
+			Metadata: map[string]string{
+				"model":       fmt.Sprintf("model-%d", i%3),
+				"session_key": fmt.Sprintf("key-%d", i),
+				"source_type": "fixture",
+				"tokens":      fmt.Sprintf("%d", i*10),
+				"project":     "ssamai",
+			},
+			Messages: []models.Message{
+				{Role: "user", Content: fmt.Sprintf("질문 %d", i), Timestamp: base},
+				{Role: "assistant", Content: "```go\nfmt.Println(\"hi\")\n```", Timestamp: base.Add(time.Second)},
+			},
+		})
+	}
+
+	processed, err := processor.NewProcessor(&models.ExportConfig{}).Process(context.Background(), sessions)
+	require.NoError(t, err)
+	data := processed.(processor.ProcessedData)
+
+	render := func(workers int) string {
+		cfg := &models.ExportConfig{Workers: workers, SourceBudget: 200, DisplayTimezone: "Asia/Seoul", IncludeMetadata: true}
+
+		var content strings.Builder
+		NewMarkdownExporter(cfg).writeSourceSections(&content, &data)
+		return content.String()
+	}
+
+	sequential := render(0)
+	assert.Contains(t, sequential, "- model: model-0\n- session_key: key-0\n- source_type: fixture\n- tokens: 0\n")
+	assert.Equal(t, sequential, render(1))
+	assert.Equal(t, sequential, render(3))
+	assert.Equal(t, sequential, render(16))
+}
+
+func TestMarkdownExporter_CommandEnvKeysOnly(t *testing.T) {
+	command := models.Command{
+		Command:     "go",
+		Args:        []string{"test", "./..."},
+		Environment: map[string]string{"GOFLAGS": "-mod=mod", "AWS_SECRET_ACCESS_KEY": "s3cr3t", "HOME": "/home/dev"},
+	}
+
+	render := func(cfg *models.ExportConfig) string {
+		var content strings.Builder
+		NewMarkdownExporter(cfg).writeCommand(&content, command, 1)
+		return content.String()
+	}
+
+	out := render(&models.ExportConfig{IncludeMetadata: true, CommandEnvKeysOnly: true})
+	assert.Contains(t, out, "- **환경 변수**: `AWS_SECRET_ACCESS_KEY`, `GOFLAGS`, `HOME`\n")
+	for _, value := range command.Environment {
+		assert.NotContains(t, out, value)
+	}
+
+	// 옵션을 켜지 않았거나 메타데이터를 제외하면 환경 변수를 표시하지 않음
+	assert.NotContains(t, render(&models.ExportConfig{IncludeMetadata: true}), "환경 변수")
+	assert.NotContains(t, render(&models.ExportConfig{CommandEnvKeysOnly: true}), "환경 변수")
+}
+
+func TestMarkdownExporter_CommandOutputANSI(t *testing.T) {
+	command := models.Command{
+		Command: "go",
+		Args:    []string{"test"},
+		Output:  "\x1b[32mok\x1b[0m  ssamai/cmd",
+		Error:   "\x1b[31mFAIL\x1b[0m <nil>",
+	}
+
+	render := func(cfg *models.ExportConfig) string {
+		var content strings.Builder
+		NewMarkdownExporter(cfg).writeCommand(&content, command, 1)
+		return content.String()
+	}
+
+	// 기본값은 이스케이프 코드를 제거한 코드 블록
+	out := render(&models.ExportConfig{})
+	assert.Contains(t, out, "**출력**:\n```\nok  ssamai/cmd\n```\n")
+	assert.Contains(t, out, "**에러**:\n```\nFAIL <nil>\n```\n")
+	assert.NotContains(t, out, "\x1b")
+
+	// --ansi-html이면 색상을 <span style>로 살림
+	out = render(&models.ExportConfig{ANSIHTML: true})
+	assert.Contains(t, out, "**출력**:\n<pre><span style=\"color:#0dbc79\">ok</span>  ssamai/cmd</pre>\n")
+	assert.Contains(t, out, "**에러**:\n<pre><span style=\"color:#cd3131\">FAIL</span> &lt;nil&gt;</pre>\n")
+	assert.NotContains(t, out, "\x1b")
+}
+
+func TestMarkdownExporter_MessageAttachments(t *testing.T) {
+	message := models.Message{
+		Role:    "user",
+		Content: "이 스크린샷을 봐주세요",
+		Attachments: []models.FileReference{
+			{Path: "/tmp/screen.png", Name: "screen.png", Size: 2048},
+			{Path: "/docs/spec.pdf", Name: "spec.pdf"},
+		},
+	}
+
+	render := func(cfg *models.ExportConfig) string {
+		var content strings.Builder
+		NewMarkdownExporter(cfg).writeMessage(&content, message, 1)
+		return content.String()
+	}
+
+	out := render(&models.ExportConfig{IncludeMetadata: true})
+	assert.Contains(t, out, "이 스크린샷을 봐주세요\n\n*첨부 파일*\n\n"+
+		"- **screen.png** (`/tmp/screen.png`) - 2048 bytes\n"+
+		"- **spec.pdf** (`/docs/spec.pdf`)\n\n")
+
+	// 메타데이터를 제외하면 첨부 파일을 표시하지 않음
+	assert.NotContains(t, render(&models.ExportConfig{}), "첨부 파일")
+}
+
+func TestMarkdownExporter_NoFooter(t *testing.T) {
+	render := func(cfg *models.ExportConfig) string {
+		var buf strings.Builder
+		require.NoError(t, NewMarkdownExporter(cfg).ExportToWriter(context.Background(), newTestProcessedData(t, cfg), &buf))
+		return buf.String()
+	}
+
+	cfg := &models.ExportConfig{
+		Template:        "comprehensive",
+		IncludeMetadata: true,
+		CustomFields:    map[string]string{"author": "dev"},
+	}
+	// NoFooter를 지정하지 않은 설정은 기존처럼 푸터 출력
+	output := render(cfg)
+	assert.Contains(t, output, "## 메타데이터\n")
+	assert.Contains(t, output, "- **문서 생성 도구**: summerise-genai\n")
+	assert.Contains(t, output, "  - author: dev\n")
+
+	// 푸터만 제외하고 세션 메타데이터(명령어, 참조 파일)는 유지
+	cfg.NoFooter = true
+	output = render(cfg)
+	assert.NotContains(t, output, "## 메타데이터\n")
+	assert.NotContains(t, output, "문서 생성 도구")
+	assert.NotContains(t, output, "author: dev")
+	assert.Contains(t, output, "#### 실행된 명령어\n")
+	assert.Contains(t, output, "#### 참조된 파일\n")
+}
+
+func TestMarkdownExporter_NarrativeOverview(t *testing.T) {
+	cfg := &models.ExportConfig{NarrativeOverview: true}
+	data := newTestProcessedData(t, cfg)
+
+	var buf strings.Builder
+	require.NoError(t, NewMarkdownExporter(cfg).ExportToWriter(context.Background(), data, &buf))
+	output := buf.String()
+
+	narrative := processor.NarrativeOverview(data.Statistics)
+	require.NotEmpty(t, narrative)
+	assert.Contains(t, output, "의 AI 도구 세션이 수집되었습니다.\n\n"+narrative+"\n\n")
+
+	// 기본값에서는 요약 문단 없음
+	cfg.NarrativeOverview = false
+	buf.Reset()
+	require.NoError(t, NewMarkdownExporter(cfg).ExportToWriter(context.Background(), data, &buf))
+	assert.NotContains(t, buf.String(), narrative)
+}
@@ -0,0 +1,155 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"ssamai/internal/interfaces"
+	"ssamai/internal/processor"
+	"ssamai/pkg/models"
+)
+
+// FormatOneline은 세션당 한 줄 요약 내보내기 형식 이름입니다
+const FormatOneline = "oneline"
+
+// OnelineExporter는 세션당 한 줄짜리 요약 목록을 내보냅니다
+// 예: 2024-01-15 | gemini_cli | Title | 12 msgs
+type OnelineExporter struct {
+	config *models.ExportConfig
+	sink   Sink
+}
+
+// OnelineExporter가 내보내기 인터페이스들을 구현하는지 컴파일 타임에 확인
+var _ interfaces.FullDataExporter = (*OnelineExporter)(nil)
+
+// NewOnelineExporter는 새로운 한 줄 요약 내보내기 도구를 생성합니다
+func NewOnelineExporter(config *models.ExportConfig) *OnelineExporter {
+	return &OnelineExporter{
+		config: config,
+		sink:   &LocalSink{},
+	}
+}
+
+// WithSink는 출력 저장소 의존성 주입
+func (e *OnelineExporter) WithSink(sink Sink) *OnelineExporter {
+	e.sink = sink
+	return e
+}
+
+// Export는 처리된 데이터를 한 줄 요약 파일로 내보냅니다
+func (e *OnelineExporter) Export(ctx context.Context, data interface{}) error {
+	if err := e.Validate(); err != nil {
+		return fmt.Errorf("내보내기 설정 검증 실패: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := e.ExportToWriter(ctx, data, &buf); err != nil {
+		return err
+	}
+
+	return writeOutputFile(e.sink, e.config, buf.Bytes())
+}
+
+// ExportToWriter는 처리된 데이터를 세션당 한 줄로 Writer에 출력합니다
+// 세션 순서는 프로세서가 정렬한 순서(최신순)를 그대로 따릅니다
+func (e *OnelineExporter) ExportToWriter(ctx context.Context, data interface{}, writer io.Writer) error {
+	// context 취소 확인
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	processedData, ok := data.(processor.ProcessedData)
+	if !ok {
+		return fmt.Errorf("잘못된 데이터 타입입니다. processor.ProcessedData가 필요합니다")
+	}
+
+	location, err := e.config.DisplayLocation()
+	if err != nil {
+		return err
+	}
+
+	for _, line := range onelineSummary(processedData.Sessions, location) {
+		if _, err := fmt.Fprintln(writer, line); err != nil {
+			return fmt.Errorf("요약 출력 실패: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetFormat은 내보내기 형식을 반환합니다
+func (e *OnelineExporter) GetFormat() string {
+	return FormatOneline
+}
+
+// Validate는 내보내기 설정이 유효한지 검증합니다
+func (e *OnelineExporter) Validate() error {
+	if e.config == nil {
+		return fmt.Errorf("내보내기 설정이 nil입니다")
+	}
+	if e.config.OutputPath == "" {
+		return fmt.Errorf("출력 경로가 지정되지 않았습니다")
+	}
+	if _, err := e.config.DisplayLocation(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetSupportedTemplates는 지원하는 템플릿을 반환합니다 (한 줄 요약은 템플릿을 사용하지 않음)
+func (e *OnelineExporter) GetSupportedTemplates() []string {
+	return nil
+}
+
+// onelineSummary는 세션마다 "날짜 | 소스 | 제목 | N msgs" 형식의 줄을 만듭니다
+// 소스와 제목 열은 가장 긴 값에 맞춰 공백으로 정렬하고, location이 있으면 날짜를 해당 시간대 기준으로 표시합니다
+func onelineSummary(sessions []models.SessionData, location *time.Location) []string {
+	titles := make([]string, len(sessions))
+	sourceWidth, titleWidth := 0, 0
+	for i, session := range sessions {
+		titles[i] = onelineTitle(session)
+		sourceWidth = max(sourceWidth, utf8.RuneCountInString(string(session.Source)))
+		titleWidth = max(titleWidth, utf8.RuneCountInString(titles[i]))
+	}
+
+	lines := make([]string, len(sessions))
+	for i, session := range sessions {
+		date := "----------"
+		if !session.Timestamp.IsZero() {
+			timestamp := session.Timestamp
+			if location != nil {
+				timestamp = timestamp.In(location)
+			}
+			date = timestamp.Format("2006-01-02")
+		}
+		lines[i] = fmt.Sprintf("%s | %s | %s | %d msgs",
+			date,
+			padRight(string(session.Source), sourceWidth),
+			padRight(titles[i], titleWidth),
+			len(session.Messages))
+	}
+	return lines
+}
+
+// onelineTitle은 한 줄에 들어가도록 정리한 세션 제목을 반환합니다 (제목이 없으면 세션 ID 사용)
+func onelineTitle(session models.SessionData) string {
+	title := strings.Join(strings.Fields(session.Title), " ")
+	if title == "" {
+		title = fmt.Sprintf("세션 %s", session.ID)
+	}
+	return title
+}
+
+// padRight는 문자 수 기준으로 문자열 오른쪽을 공백으로 채웁니다
+func padRight(text string, width int) string {
+	if padding := width - utf8.RuneCountInString(text); padding > 0 {
+		return text + strings.Repeat(" ", padding)
+	}
+	return text
+}
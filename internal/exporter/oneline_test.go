@@ -0,0 +1,67 @@
+package exporter
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ssamai/internal/processor"
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnelineSummary(t *testing.T) {
+	sessions := []models.SessionData{
+		{
+			ID:        "g1",
+			Source:    models.SourceGeminiCLI,
+			Title:     "Refactor parser",
+			Timestamp: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+			Messages:  make([]models.Message, 12),
+		},
+		{
+			ID:        "c1",
+			Source:    models.SourceClaudeCode,
+			Title:     "Fix\nbug",
+			Timestamp: time.Date(2024, 1, 14, 9, 0, 0, 0, time.UTC),
+			Messages:  make([]models.Message, 3),
+		},
+		{
+			ID:       "q1",
+			Source:   models.SourceAmazonQ,
+			Messages: nil,
+		},
+	}
+
+	assert.Equal(t, []string{
+		"2024-01-15 | gemini_cli  | Refactor parser | 12 msgs",
+		"2024-01-14 | claude_code | Fix bug         | 3 msgs",
+		"---------- | amazon_q    | 세션 q1           | 0 msgs",
+	}, onelineSummary(sessions, nil))
+}
+
+func TestOnelineExporter_Export(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "index.txt")
+	sink := newMemorySink()
+	e := NewOnelineExporter(&models.ExportConfig{OutputPath: outputPath}).WithSink(sink)
+
+	data := processor.ProcessedData{Sessions: []models.SessionData{
+		{ID: "s1", Source: models.SourceGeminiCLI, Title: "One", Timestamp: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+	}}
+	require.NoError(t, e.Export(context.Background(), data))
+
+	assert.Equal(t, "2024-01-15 | gemini_cli | One | 0 msgs\n", string(sink.files[outputPath]))
+	assert.Equal(t, FormatOneline, e.GetFormat())
+}
+
+func TestOnelineExporter_Validate(t *testing.T) {
+	assert.Error(t, NewOnelineExporter(nil).Validate())
+	assert.Error(t, NewOnelineExporter(&models.ExportConfig{}).Validate())
+
+	err := NewOnelineExporter(&models.ExportConfig{OutputPath: "out.txt"}).Export(context.Background(), "invalid")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "ProcessedData")
+}
@@ -0,0 +1,84 @@
+package exporter
+
+import (
+	"fmt"
+	"time"
+
+	"ssamai/pkg/models"
+)
+
+// backupTimeFormat은 백업 파일 이름에 붙는 시각 형식입니다 (<이름>.bak-20240115-103000)
+const backupTimeFormat = "20060102-150405"
+
+// backupClock은 백업 파일 이름에 쓰는 현재 시각입니다 (테스트에서 교체 가능)
+var backupClock = time.Now
+
+// writeOutputFile은 덮어쓰기 정책을 적용한 뒤 data를 출력 파일에 씁니다
+// 쓰기가 실패하면 --backup으로 옮긴 기존 파일을 원래 이름으로 되돌려 출력 경로가 비지 않게 합니다
+func writeOutputFile(sink Sink, config *models.ExportConfig, data []byte) error {
+	backupPath, err := guardOutputFile(sink, config)
+	if err != nil {
+		return err
+	}
+
+	if err := sink.Write(config.OutputPath, data); err != nil {
+		if backupPath != "" {
+			if restoreErr := sink.(RenamingSink).Rename(backupPath, config.OutputPath); restoreErr != nil {
+				return fmt.Errorf("파일 쓰기 실패: %w (기존 파일은 %s에 남아 있습니다: %v)", err, backupPath, restoreErr)
+			}
+		}
+		return fmt.Errorf("파일 쓰기 실패: %w", err)
+	}
+	return nil
+}
+
+// guardOutputFile은 출력 파일을 쓰기 직전에 덮어쓰기 정책을 적용하고, 기존 파일을 옮긴 백업 경로를 반환합니다
+// 파일이 이미 있을 때 NoClobber면 에러를 반환하고, Backup이면 기존 파일을 백업 이름으로 옮깁니다
+// 확인과 이동은 출력 저장소(sink)를 통해 하므로 sink가 RenamingSink를 구현하지 않으면 두 옵션을 거부합니다
+func guardOutputFile(sink Sink, config *models.ExportConfig) (string, error) {
+	if !config.NoClobber && !config.Backup {
+		return "", nil
+	}
+
+	renaming, ok := sink.(RenamingSink)
+	if !ok {
+		return "", fmt.Errorf("출력 저장소가 기존 파일 확인과 이동을 지원하지 않아 --no-clobber, --backup을 사용할 수 없습니다")
+	}
+
+	exists, err := renaming.Exists(config.OutputPath)
+	if err != nil {
+		return "", fmt.Errorf("출력 파일 확인 실패: %w", err)
+	}
+	if !exists {
+		return "", nil
+	}
+
+	if config.NoClobber {
+		return "", fmt.Errorf("출력 파일이 이미 존재합니다 (--no-clobber): %s", config.OutputPath)
+	}
+
+	backupPath, err := backupFileName(renaming, config.OutputPath, backupClock())
+	if err != nil {
+		return "", fmt.Errorf("백업 파일 확인 실패: %w", err)
+	}
+	if err := renaming.Rename(config.OutputPath, backupPath); err != nil {
+		return "", fmt.Errorf("기존 출력 파일 백업 실패: %w", err)
+	}
+	return backupPath, nil
+}
+
+// backupFileName은 저장소에 아직 없는 백업 파일 이름을 반환합니다 (같은 초에 여러 번 백업하면 _2, _3... 추가)
+func backupFileName(sink RenamingSink, path string, now time.Time) (string, error) {
+	base := fmt.Sprintf("%s.bak-%s", path, now.Format(backupTimeFormat))
+	candidate := base
+	for i := 2; ; i++ {
+		exists, err := sink.Exists(candidate)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s_%d", base, i)
+	}
+}
@@ -0,0 +1,163 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubBackupClock은 백업 파일 이름에 쓰는 시각을 테스트 동안 고정합니다
+func stubBackupClock(t *testing.T, now time.Time) {
+	t.Helper()
+	saved := backupClock
+	backupClock = func() time.Time { return now }
+	t.Cleanup(func() { backupClock = saved })
+}
+
+func TestMarkdownExporter_Backup(t *testing.T) {
+	stubBackupClock(t, time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC))
+
+	outputPath := filepath.Join(t.TempDir(), "report.md")
+	require.NoError(t, os.WriteFile(outputPath, []byte("old report"), 0644))
+
+	cfg := &models.ExportConfig{OutputPath: outputPath, Backup: true}
+	require.NoError(t, NewMarkdownExporter(cfg).Export(context.Background(), newTestProcessedData(t, cfg)))
+
+	backup, err := os.ReadFile(outputPath + ".bak-20240115-103000")
+	require.NoError(t, err)
+	assert.Equal(t, "old report", string(backup))
+
+	current, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.NotEqual(t, "old report", string(current))
+
+	// 같은 초에 다시 백업해도 기존 백업을 덮어쓰지 않음
+	require.NoError(t, NewMarkdownExporter(cfg).Export(context.Background(), newTestProcessedData(t, cfg)))
+	_, err = os.Stat(outputPath + ".bak-20240115-103000_2")
+	assert.NoError(t, err)
+}
+
+func TestMarkdownExporter_BackupWithoutExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	outputPath := filepath.Join(dir, "report.md")
+
+	cfg := &models.ExportConfig{OutputPath: outputPath, Backup: true}
+	require.NoError(t, NewMarkdownExporter(cfg).Export(context.Background(), newTestProcessedData(t, cfg)))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "백업할 파일이 없으면 출력 파일만 생성")
+	assert.Equal(t, "report.md", entries[0].Name())
+}
+
+func TestExporters_NoClobber(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name   string
+		export func(cfg *models.ExportConfig) error
+	}{
+		{"markdown", func(cfg *models.ExportConfig) error {
+			return NewMarkdownExporter(cfg).Export(context.Background(), newTestProcessedData(t, cfg))
+		}},
+		{"csv", func(cfg *models.ExportConfig) error {
+			return NewCSVExporter(cfg).Export(context.Background(), newTestProcessedData(t, cfg))
+		}},
+		{"oneline", func(cfg *models.ExportConfig) error {
+			return NewOnelineExporter(cfg).Export(context.Background(), newTestProcessedData(t, cfg))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputPath := filepath.Join(dir, tt.name+".out")
+			require.NoError(t, os.WriteFile(outputPath, []byte("keep me"), 0644))
+
+			err := tt.export(&models.ExportConfig{OutputPath: outputPath, NoClobber: true})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "--no-clobber")
+
+			data, err := os.ReadFile(outputPath)
+			require.NoError(t, err)
+			assert.Equal(t, "keep me", string(data))
+		})
+	}
+
+	// 파일이 없으면 정상적으로 생성
+	outputPath := filepath.Join(dir, "fresh.md")
+	cfg := &models.ExportConfig{OutputPath: outputPath, NoClobber: true}
+	require.NoError(t, NewMarkdownExporter(cfg).Export(context.Background(), newTestProcessedData(t, cfg)))
+	assert.FileExists(t, outputPath)
+}
+
+func TestGuardOutputFile_UsesSink(t *testing.T) {
+	stubBackupClock(t, time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC))
+
+	// 로컬 파일 시스템이 아닌 저장소에서도 백업과 --no-clobber가 동작
+	sink := newMemorySink()
+	sink.files["remote/report.md"] = []byte("old report")
+	sink.files["remote/report.md.bak-20240115-103000"] = []byte("older report")
+
+	cfg := &models.ExportConfig{OutputPath: "remote/report.md", Template: "minimal", Backup: true}
+	require.NoError(t, NewMarkdownExporter(cfg).WithSink(sink).Export(context.Background(), newTestProcessedData(t, cfg)))
+	assert.Equal(t, "old report", string(sink.files["remote/report.md.bak-20240115-103000_2"]))
+	assert.Equal(t, "older report", string(sink.files["remote/report.md.bak-20240115-103000"]))
+	assert.NotEqual(t, "old report", string(sink.files["remote/report.md"]))
+
+	cfg = &models.ExportConfig{OutputPath: "remote/report.md", Template: "minimal", NoClobber: true}
+	err := NewMarkdownExporter(cfg).WithSink(sink).Export(context.Background(), newTestProcessedData(t, cfg))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--no-clobber")
+}
+
+func TestGuardOutputFile_RestoresBackupOnWriteFailure(t *testing.T) {
+	stubBackupClock(t, time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC))
+
+	sink := newMemorySink()
+	sink.files["remote/report.md"] = []byte("old report")
+	sink.err = errors.New("upload failed")
+
+	// 쓰기가 실패하면 백업으로 옮긴 기존 파일이 원래 이름으로 돌아와야 함
+	cfg := &models.ExportConfig{OutputPath: "remote/report.md", Template: "minimal", Backup: true}
+	err := NewMarkdownExporter(cfg).WithSink(sink).Export(context.Background(), newTestProcessedData(t, cfg))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "upload failed")
+
+	assert.Equal(t, "old report", string(sink.files["remote/report.md"]))
+	assert.NotContains(t, sink.files, "remote/report.md.bak-20240115-103000")
+}
+
+// writeOnlySink는 RenamingSink를 구현하지 않는 외부 저장소를 흉내 냅니다
+type writeOnlySink struct {
+	files map[string][]byte
+}
+
+func (s *writeOnlySink) Write(name string, data []byte) error {
+	s.files[name] = append([]byte(nil), data...)
+	return nil
+}
+
+func TestGuardOutputFile_RequiresRenamingSink(t *testing.T) {
+	sink := &writeOnlySink{files: make(map[string][]byte)}
+
+	// 덮어쓰기 정책이 없으면 Write만 있는 저장소도 그대로 사용
+	cfg := &models.ExportConfig{OutputPath: "remote/report.md", Template: "minimal"}
+	require.NoError(t, NewMarkdownExporter(cfg).WithSink(sink).Export(context.Background(), newTestProcessedData(t, cfg)))
+	assert.Contains(t, sink.files, "remote/report.md")
+
+	for _, cfg := range []*models.ExportConfig{
+		{OutputPath: "remote/report.md", Template: "minimal", Backup: true},
+		{OutputPath: "remote/report.md", Template: "minimal", NoClobber: true},
+	} {
+		err := NewMarkdownExporter(cfg).WithSink(sink).Export(context.Background(), newTestProcessedData(t, cfg))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--no-clobber, --backup을 사용할 수 없습니다")
+	}
+}
@@ -0,0 +1,126 @@
+package exporter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ssamai/internal/processor"
+)
+
+// prometheusMetricPrefix는 모든 메트릭 이름 앞에 붙는 접두사입니다
+const prometheusMetricPrefix = "ssamai_"
+
+// WritePrometheusMetrics는 처리된 통계를 Prometheus 텍스트 노출 형식으로 씁니다
+// 소스별 세션 수는 source 라벨, 역할별 메시지 수는 role 라벨로 구분됩니다
+func WritePrometheusMetrics(w io.Writer, stats processor.Statistics) error {
+	bw := bufio.NewWriter(w)
+	m := &prometheusWriter{w: bw}
+
+	m.header("total_sessions", "Number of exported sessions per source.")
+	for _, source := range sortedMetricKeys(stats.SourceCounts) {
+		m.sample("total_sessions", []string{"source", string(source)}, float64(stats.SourceCounts[source]))
+	}
+
+	m.gauge("total_messages", "Total number of messages across all sessions.", float64(stats.TotalMessages))
+	m.gauge("total_commands", "Total number of commands across all sessions.", float64(stats.TotalCommands))
+	m.gauge("total_files", "Total number of file references across all sessions.", float64(stats.TotalFiles))
+	m.gauge("unique_prompts", "Number of distinct user prompts.", float64(stats.UniquePrompts))
+	m.gauge("duplicate_prompt_rate", "Ratio of user prompts that repeat an earlier prompt.", stats.DuplicatePromptRate)
+	m.gauge("average_session_seconds", "Average session duration in seconds.", stats.AverageSessionTime.Seconds())
+
+	if len(stats.MessagesByRole) > 0 {
+		m.header("messages", "Number of messages per role.")
+		for _, role := range sortedMetricKeys(stats.MessagesByRole) {
+			m.sample("messages", []string{"role", role}, float64(stats.MessagesByRole[role]))
+		}
+	}
+
+	if len(stats.ResponseLatency) > 0 {
+		latencySources := sortedMetricKeys(stats.ResponseLatency)
+		m.header("response_latency_average_seconds", "Average assistant response latency per source in seconds.")
+		for _, source := range latencySources {
+			m.sample("response_latency_average_seconds", []string{"source", string(source)}, stats.ResponseLatency[source].Average.Seconds())
+		}
+		m.header("response_latency_median_seconds", "Median assistant response latency per source in seconds.")
+		for _, source := range latencySources {
+			m.sample("response_latency_median_seconds", []string{"source", string(source)}, stats.ResponseLatency[source].Median.Seconds())
+		}
+		m.header("response_latency_samples", "Number of user/assistant pairs measured per source.")
+		for _, source := range latencySources {
+			m.sample("response_latency_samples", []string{"source", string(source)}, float64(stats.ResponseLatency[source].Samples))
+		}
+	}
+
+	if m.err != nil {
+		return fmt.Errorf("메트릭 쓰기 실패: %w", m.err)
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("메트릭 쓰기 실패: %w", err)
+	}
+	return nil
+}
+
+// sortedMetricKeys는 출력 순서가 실행마다 같도록 맵의 키를 정렬해 반환합니다
+func sortedMetricKeys[K ~string, V any](values map[K]V) []K {
+	keys := make([]K, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// prometheusWriter는 첫 번째 쓰기 에러를 기억하며 메트릭 줄을 씁니다
+type prometheusWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (m *prometheusWriter) printf(format string, args ...interface{}) {
+	if m.err != nil {
+		return
+	}
+	_, m.err = fmt.Fprintf(m.w, format, args...)
+}
+
+// header는 메트릭의 HELP/TYPE 줄을 씁니다. 모든 메트릭은 gauge 타입입니다
+func (m *prometheusWriter) header(name, help string) {
+	m.printf("# HELP %s%s %s\n", prometheusMetricPrefix, name, help)
+	m.printf("# TYPE %s%s gauge\n", prometheusMetricPrefix, name)
+}
+
+// gauge는 라벨 없는 단일 값 메트릭을 씁니다
+func (m *prometheusWriter) gauge(name, help string, value float64) {
+	m.header(name, help)
+	m.sample(name, nil, value)
+}
+
+// sample은 라벨 이름/값 쌍 목록과 함께 샘플 한 줄을 씁니다
+func (m *prometheusWriter) sample(name string, labels []string, value float64) {
+	var b strings.Builder
+	b.WriteString(prometheusMetricPrefix)
+	b.WriteString(name)
+	if len(labels) > 0 {
+		b.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(labels[i])
+			b.WriteString(`="`)
+			b.WriteString(escapePrometheusLabel(labels[i+1]))
+			b.WriteByte('"')
+		}
+		b.WriteByte('}')
+	}
+	m.printf("%s %s\n", b.String(), strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+// escapePrometheusLabel은 라벨 값의 역슬래시, 큰따옴표, 줄바꿈을 이스케이프합니다
+func escapePrometheusLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
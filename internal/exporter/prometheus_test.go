@@ -0,0 +1,90 @@
+package exporter
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ssamai/internal/processor"
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWritePrometheusMetrics(t *testing.T) {
+	stats := processor.Statistics{
+		TotalSessions: 45,
+		TotalMessages: 120,
+		TotalCommands: 7,
+		TotalFiles:    3,
+		SourceCounts: map[models.CollectionSource]int{
+			models.SourceGeminiCLI:  42,
+			models.SourceClaudeCode: 3,
+		},
+		AverageSessionTime:  90 * time.Second,
+		UniquePrompts:       30,
+		DuplicatePromptRate: 0.25,
+		MessagesByRole:      map[string]int{"user": 60, "assistant": 60},
+		ResponseLatency: map[models.CollectionSource]processor.ResponseLatency{
+			models.SourceClaudeCode: {Samples: 4, Average: 1500 * time.Millisecond, Median: time.Second},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WritePrometheusMetrics(&buf, stats))
+	output := buf.String()
+
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	var samples []string
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "#") {
+			samples = append(samples, line)
+		}
+	}
+
+	assert.Equal(t, []string{
+		`ssamai_total_sessions{source="claude_code"} 3`,
+		`ssamai_total_sessions{source="gemini_cli"} 42`,
+		`ssamai_total_messages 120`,
+		`ssamai_total_commands 7`,
+		`ssamai_total_files 3`,
+		`ssamai_unique_prompts 30`,
+		`ssamai_duplicate_prompt_rate 0.25`,
+		`ssamai_average_session_seconds 90`,
+		`ssamai_messages{role="assistant"} 60`,
+		`ssamai_messages{role="user"} 60`,
+		`ssamai_response_latency_average_seconds{source="claude_code"} 1.5`,
+		`ssamai_response_latency_median_seconds{source="claude_code"} 1`,
+		`ssamai_response_latency_samples{source="claude_code"} 4`,
+	}, samples)
+
+	assert.Contains(t, output, "# TYPE ssamai_total_sessions gauge\n")
+	assert.Contains(t, output, "# HELP ssamai_total_messages ")
+}
+
+func TestWritePrometheusMetrics_OmitsEmptyBreakdowns(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WritePrometheusMetrics(&buf, processor.Statistics{}))
+
+	output := buf.String()
+	assert.Contains(t, output, "ssamai_total_messages 0\n")
+	assert.NotContains(t, output, "ssamai_messages{")
+	assert.NotContains(t, output, "ssamai_response_latency")
+}
+
+func TestEscapePrometheusLabel(t *testing.T) {
+	assert.Equal(t, `a\\b\"c\nd`, escapePrometheusLabel("a\\b\"c\nd"))
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestWritePrometheusMetrics_WriteError(t *testing.T) {
+	err := WritePrometheusMetrics(failingWriter{}, processor.Statistics{TotalMessages: 1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "disk full")
+}
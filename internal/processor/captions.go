@@ -0,0 +1,97 @@
+package processor
+
+import (
+	"regexp"
+	"strings"
+)
+
+// codeCaptionPatterns는 코드 블록 바로 앞 문장에서 파일명을 찾는 패턴입니다
+// 오탐을 줄이기 위해 명시적인 저장/파일 지시 표현만 인식합니다
+var codeCaptionPatterns = []*regexp.Regexp{
+	// "Save as main.go", "save this file as `cmd/root.go`"
+	regexp.MustCompile("(?i)\\bsave(?:d)?\\s+(?:it\\s+|this\\s+|this\\s+file\\s+|the\\s+following\\s+|the\\s+code\\s+)?as\\s+`?([\\w./-]+)`?"),
+	// "File: main.go", "**Filename:** `app.py`", "파일명: main.go"
+	regexp.MustCompile("(?i)(?:^|\\s|\\*)(?:file\\s?name|file|파일명|파일)\\**\\s*:\\s*\\**\\s*`?([\\w./-]+)`?"),
+	// "main.go로 저장하세요", "`app.py` 파일에 저장"
+	regexp.MustCompile("`?([\\w./-]+)`?\\s*(?:파일)?(?:으로|로|에)\\s*저장"),
+	// "Put this in `main.go`:" (백틱으로 감싼 파일명 + 콜론으로 끝나는 문장)
+	regexp.MustCompile("`([\\w./-]+)`\\s*:\\s*$"),
+}
+
+// codeCaptionFilename은 확장자가 있는 파일명 형태인지 확인합니다 (예: main.go, src/app.test.ts)
+var codeCaptionFilename = regexp.MustCompile(`^[\w][\w./-]*\.[A-Za-z][A-Za-z0-9]{0,9}$`)
+
+// captionCodeBlocks는 파일명을 지시하는 문장 바로 뒤에 오는 코드 블록 위에 파일명 캡션을 추가합니다
+// 문장과 코드 블록 사이에는 빈 줄이 최대 한 줄까지만 허용되며, 이미 캡션이 있으면 추가하지 않습니다
+func captionCodeBlocks(content string) string {
+	if !strings.Contains(content, "```") {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	result := make([]string, 0, len(lines))
+
+	inFence := false
+	for i, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "```") {
+			result = append(result, line)
+			continue
+		}
+
+		if !inFence {
+			if name := precedingFilename(lines[:i]); name != "" {
+				result = append(result, codeCaption(name), "")
+			}
+		}
+		inFence = !inFence
+		result = append(result, line)
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// precedingFilename은 코드 블록 직전 문장에서 지시된 파일명을 찾습니다
+func precedingFilename(before []string) string {
+	index := len(before) - 1
+	if index >= 0 && strings.TrimSpace(before[index]) == "" {
+		index--
+	}
+	if index < 0 {
+		return ""
+	}
+
+	line := strings.TrimSpace(before[index])
+	if line == "" || strings.HasPrefix(line, "```") || strings.Contains(line, "://") {
+		return ""
+	}
+	// 파일명만 있는 줄은 이미 캡션 역할을 하므로 중복 추가하지 않음
+	if isBareFilename(line) {
+		return ""
+	}
+
+	for _, pattern := range codeCaptionPatterns {
+		match := pattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		name := strings.TrimRight(match[1], ".")
+		if codeCaptionFilename.MatchString(name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// isBareFilename은 줄이 장식(**, `, :, 캡션 아이콘)을 제외하면 파일명 하나로만 이루어졌는지 확인합니다
+func isBareFilename(line string) bool {
+	trimmed := strings.TrimSpace(strings.TrimPrefix(line, codeCaptionIcon))
+	return codeCaptionFilename.MatchString(strings.Trim(trimmed, "*`: "))
+}
+
+// codeCaptionIcon은 파일명 캡션 앞에 붙는 아이콘입니다
+const codeCaptionIcon = "📄"
+
+// codeCaption은 코드 블록 위에 표시할 파일명 캡션을 만듭니다
+func codeCaption(name string) string {
+	return codeCaptionIcon + " `" + name + "`"
+}
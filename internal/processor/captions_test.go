@@ -0,0 +1,110 @@
+package processor
+
+import (
+	"testing"
+
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaptionCodeBlocks(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "save as",
+			input:    "Save as main.go:\n```go\npackage main\n```",
+			expected: "Save as main.go:\n📄 `main.go`\n\n```go\npackage main\n```",
+		},
+		{
+			name:     "save this file as with path and blank line",
+			input:    "Save this file as `cmd/root.go`.\n\n```go\npackage cmd\n```",
+			expected: "Save this file as `cmd/root.go`.\n\n📄 `cmd/root.go`\n\n```go\npackage cmd\n```",
+		},
+		{
+			name:     "file label",
+			input:    "**File:** app.py\n```python\nprint(1)\n```",
+			expected: "**File:** app.py\n📄 `app.py`\n\n```python\nprint(1)\n```",
+		},
+		{
+			name:     "korean instruction",
+			input:    "아래 내용을 config.yaml 파일로 저장하세요\n```yaml\nkey: value\n```",
+			expected: "아래 내용을 config.yaml 파일로 저장하세요\n📄 `config.yaml`\n\n```yaml\nkey: value\n```",
+		},
+		{
+			name:     "backticked filename ending with colon",
+			input:    "Put this in `src/index.ts`:\n```ts\nexport {}\n```",
+			expected: "Put this in `src/index.ts`:\n📄 `src/index.ts`\n\n```ts\nexport {}\n```",
+		},
+		{
+			name:     "only the block after the instruction",
+			input:    "Save as a.go\n```go\nA\n```\nAnd another:\n```go\nB\n```",
+			expected: "Save as a.go\n📄 `a.go`\n\n```go\nA\n```\nAnd another:\n```go\nB\n```",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, captionCodeBlocks(tt.input))
+		})
+	}
+}
+
+func TestCaptionCodeBlocks_NoFalsePositives(t *testing.T) {
+	inputs := []string{
+		// 파일명 지시가 없는 일반 문장
+		"Here is an example using version 1.2:\n```go\nfmt.Println()\n```",
+		// 파일명이 언급되지만 저장 지시가 아님
+		"I looked at main.go and found the bug:\n```go\nx := 1\n```",
+		// URL
+		"Download from https://example.com/install.sh:\n```sh\ncurl ...\n```",
+		// 이미 파일명 캡션이 있음
+		"**main.go**\n```go\npackage main\n```",
+		// 지시와 코드 블록 사이가 멀리 떨어짐
+		"Save as main.go\n\n\n```go\npackage main\n```",
+		// 코드 블록 내부의 문장은 무시
+		"```\nSave as inner.go\n```",
+		// 코드 블록이 없음
+		"Save as main.go",
+	}
+
+	for _, input := range inputs {
+		assert.Equal(t, input, captionCodeBlocks(input), input)
+	}
+}
+
+func TestProcessor_CodeCaptions(t *testing.T) {
+	content := "Save as main.go\n```go\npackage main\n```"
+	original := []models.Message{
+		{Role: "user", Content: content},
+		{Role: "assistant", Content: content},
+		{Role: " Model ", Content: content},
+	}
+	sessions := []models.SessionData{
+		{ID: "s1", Source: models.SourceClaudeCode, Messages: original},
+	}
+
+	data := processSessions(t, &models.ExportConfig{CodeCaptions: true}, sessions)
+	assert.Equal(t, content, data.Sessions[0].Messages[0].Content, "user messages are left as is")
+	assert.Contains(t, data.Sessions[0].Messages[1].Content, "📄 `main.go`\n\n```go")
+	// 제공자별 역할 이름(model 등)도 어시스턴트로 취급
+	assert.Contains(t, data.Sessions[0].Messages[2].Content, "📄 `main.go`\n\n```go")
+
+	// 원본 메시지는 변경하지 않음
+	assert.Equal(t, content, original[1].Content)
+}
+
+func TestProcessor_CodeCaptionsDisabled(t *testing.T) {
+	content := "Save as main.go\n```go\npackage main\n```"
+	sessions := []models.SessionData{
+		{ID: "s1", Source: models.SourceClaudeCode, Messages: []models.Message{
+			{Role: "assistant", Content: content},
+		}},
+	}
+
+	data := processSessions(t, &models.ExportConfig{}, sessions)
+	assert.Equal(t, content, data.Sessions[0].Messages[0].Content)
+}
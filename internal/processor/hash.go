@@ -0,0 +1,44 @@
+package processor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"ssamai/pkg/models"
+)
+
+// contentHashKey는 세션 내용 해시를 저장하는 메타데이터 키입니다
+const contentHashKey = "content_hash"
+
+// SessionContentHash는 세션 메시지 내용의 안정적인 해시(sha256 hex)를 계산합니다
+// 역할 이름과 공백(CRLF, 후행 공백, 과도한 빈 줄)을 정규화하고 메시지 순서와
+// 타임스탬프, 메타데이터는 반영하지 않으므로, 해시가 다르면 대화 내용이 바뀐 것입니다
+func SessionContentHash(session models.SessionData) string {
+	entries := make([]string, 0, len(session.Messages))
+	for _, message := range session.Messages {
+		content := strings.TrimSpace(normalizeWhitespace(message.Content))
+		// 길이를 앞에 붙여 경계가 모호해지지 않도록 함
+		entries = append(entries, fmt.Sprintf("%s\x00%d\x00%s", normalizeRole(message.Role), len(content), content))
+	}
+	sort.Strings(entries)
+
+	hash := sha256.New()
+	for _, entry := range entries {
+		hash.Write([]byte(entry))
+		hash.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// withContentHash는 content_hash 항목을 추가한 메타데이터 사본을 반환합니다
+func withContentHash(metadata map[string]string, hash string) map[string]string {
+	result := make(map[string]string, len(metadata)+1)
+	for key, value := range metadata {
+		result[key] = value
+	}
+	result[contentHashKey] = hash
+	return result
+}
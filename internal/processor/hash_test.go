@@ -0,0 +1,91 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionContentHash_Stable(t *testing.T) {
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	original := models.SessionData{
+		ID: "s1",
+		Messages: []models.Message{
+			{Role: "user", Content: "Fix the build", Timestamp: now},
+			{Role: "assistant", Content: "Done.\nRan go build", Timestamp: now.Add(time.Second)},
+		},
+	}
+
+	// 순서, 역할 별칭, 공백, 타임스탬프, 메타데이터만 다른 세션
+	equivalent := models.SessionData{
+		ID:       "s2",
+		Metadata: map[string]string{"cwd": "/tmp"},
+		Messages: []models.Message{
+			{Role: "model", Content: "Done.  \r\nRan go build\n\n\n", Timestamp: now.Add(time.Hour)},
+			{Role: " User", Content: "  Fix the build"},
+		},
+	}
+
+	hash := SessionContentHash(original)
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, SessionContentHash(original))
+	assert.Equal(t, hash, SessionContentHash(equivalent))
+}
+
+func TestSessionContentHash_ChangesWithContent(t *testing.T) {
+	base := models.SessionData{Messages: []models.Message{
+		{Role: "user", Content: "Fix the build"},
+		{Role: "assistant", Content: "Done"},
+	}}
+	hash := SessionContentHash(base)
+
+	edited := models.SessionData{Messages: []models.Message{
+		{Role: "user", Content: "Fix the tests"},
+		{Role: "assistant", Content: "Done"},
+	}}
+	assert.NotEqual(t, hash, SessionContentHash(edited))
+
+	// 역할이 바뀌어도 다른 내용으로 취급
+	swapped := models.SessionData{Messages: []models.Message{
+		{Role: "assistant", Content: "Fix the build"},
+		{Role: "user", Content: "Done"},
+	}}
+	assert.NotEqual(t, hash, SessionContentHash(swapped))
+
+	appended := models.SessionData{Messages: append(append([]models.Message(nil), base.Messages...),
+		models.Message{Role: "user", Content: "Thanks"})}
+	assert.NotEqual(t, hash, SessionContentHash(appended))
+}
+
+func TestProcessor_ContentHash(t *testing.T) {
+	metadata := map[string]string{"project": "ssamai"}
+	sessions := []models.SessionData{
+		{
+			ID:       "s1",
+			Source:   models.SourceClaudeCode,
+			Metadata: metadata,
+			Messages: []models.Message{{Role: "user", Content: "hello"}},
+		},
+	}
+
+	data := processSessions(t, &models.ExportConfig{ContentHash: true}, sessions)
+
+	require.Len(t, data.Sessions, 1)
+	assert.Equal(t, SessionContentHash(sessions[0]), data.Sessions[0].Metadata["content_hash"])
+	assert.Equal(t, "ssamai", data.Sessions[0].Metadata["project"])
+	// 원본 메타데이터는 변경하지 않음
+	assert.NotContains(t, metadata, "content_hash")
+}
+
+func TestProcessor_ContentHashDisabled(t *testing.T) {
+	sessions := []models.SessionData{
+		{ID: "s1", Source: models.SourceClaudeCode, Messages: []models.Message{{Role: "user", Content: "hello"}}},
+	}
+
+	data := processSessions(t, &models.ExportConfig{}, sessions)
+	assert.NotContains(t, data.Sessions[0].Metadata, "content_hash")
+}
@@ -0,0 +1,36 @@
+package processor
+
+import (
+	"time"
+
+	"ssamai/pkg/models"
+)
+
+// ActivityHeatmap은 요일(time.Weekday 순서, 일요일=0)과 시간대(0-23시)별 세션 수입니다
+type ActivityHeatmap struct {
+	Counts [7][24]int `json:"counts"`
+	Max    int        `json:"max"` // 가장 많은 칸의 세션 수
+}
+
+// BuildActivityHeatmap은 세션 시작 시각으로 요일/시간대별 세션 수를 집계합니다
+// location이 있으면 해당 시간대 기준으로 집계하고, 시각이 없는 세션은 제외합니다
+func BuildActivityHeatmap(sessions []models.SessionData, location *time.Location) *ActivityHeatmap {
+	heatmap := &ActivityHeatmap{}
+	for _, session := range sessions {
+		if session.Timestamp.IsZero() {
+			continue
+		}
+
+		timestamp := session.Timestamp
+		if location != nil {
+			timestamp = timestamp.In(location)
+		}
+
+		cell := &heatmap.Counts[timestamp.Weekday()][timestamp.Hour()]
+		*cell++
+		if *cell > heatmap.Max {
+			heatmap.Max = *cell
+		}
+	}
+	return heatmap
+}
@@ -0,0 +1,62 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildActivityHeatmap(t *testing.T) {
+	monday9 := time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC)
+	sessions := []models.SessionData{
+		{ID: "a", Timestamp: monday9},
+		{ID: "b", Timestamp: monday9.Add(20 * time.Minute)},
+		{ID: "c", Timestamp: time.Date(2024, 1, 20, 23, 0, 0, 0, time.UTC)}, // 토요일 23시
+		{ID: "no-time"},
+	}
+
+	heatmap := BuildActivityHeatmap(sessions, nil)
+	assert.Equal(t, 2, heatmap.Counts[time.Monday][9])
+	assert.Equal(t, 1, heatmap.Counts[time.Saturday][23])
+	assert.Equal(t, 2, heatmap.Max)
+
+	total := 0
+	for _, hours := range heatmap.Counts {
+		for _, count := range hours {
+			total += count
+		}
+	}
+	assert.Equal(t, 3, total, "시각이 없는 세션은 제외")
+}
+
+func TestBuildActivityHeatmap_Location(t *testing.T) {
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	require.NoError(t, err)
+
+	// UTC 토요일 23시는 서울 기준 일요일 8시
+	sessions := []models.SessionData{{ID: "c", Timestamp: time.Date(2024, 1, 20, 23, 0, 0, 0, time.UTC)}}
+	heatmap := BuildActivityHeatmap(sessions, seoul)
+	assert.Equal(t, 1, heatmap.Counts[time.Sunday][8])
+	assert.Equal(t, 0, heatmap.Counts[time.Saturday][23])
+}
+
+func TestProcessor_ActivityHeatmap(t *testing.T) {
+	sessions := []models.SessionData{
+		{ID: "s1", Source: models.SourceClaudeCode, Timestamp: time.Date(2024, 1, 16, 14, 0, 0, 0, time.UTC)},
+	}
+
+	data := processSessions(t, &models.ExportConfig{}, sessions)
+	assert.Nil(t, data.Heatmap)
+
+	data = processSessions(t, &models.ExportConfig{ActivityHeatmap: true}, sessions)
+	require.NotNil(t, data.Heatmap)
+	assert.Equal(t, 1, data.Heatmap.Counts[time.Tuesday][14])
+
+	require.GreaterOrEqual(t, len(data.TableOfContents), 3)
+	assert.Equal(t, "statistics", data.TableOfContents[1].Anchor)
+	assert.Equal(t, "heatmap", data.TableOfContents[2].Anchor)
+}
@@ -0,0 +1,94 @@
+package processor
+
+import (
+	"strings"
+	"unicode"
+
+	"ssamai/pkg/models"
+)
+
+// languageKey는 감지한 세션 주 언어를 저장하는 메타데이터 키입니다
+const languageKey = "language"
+
+// 감지 결과로 쓰는 언어 태그입니다 (문자 체계로 판단하므로 라틴 문자는 모두 en으로 봅니다)
+const (
+	LanguageKorean   = "ko"
+	LanguageJapanese = "ja"
+	LanguageChinese  = "zh"
+	LanguageRussian  = "ru"
+	LanguageEnglish  = "en"
+)
+
+// DetectLanguage는 세션 메시지에서 가장 많이 쓰인 문자 체계로 주 언어 태그를 추정합니다
+// 코드 블록 안의 내용은 세지 않으며, 글자가 없으면 빈 문자열을 반환합니다
+func DetectLanguage(session models.SessionData) string {
+	var hangul, kana, han, cyrillic, latin int
+	for _, message := range session.Messages {
+		inFence := false
+		for _, line := range strings.Split(message.Content, "\n") {
+			if strings.HasPrefix(strings.TrimSpace(line), "```") {
+				inFence = !inFence
+				continue
+			}
+			if inFence {
+				continue
+			}
+
+			for _, r := range line {
+				switch {
+				case unicode.Is(unicode.Hangul, r):
+					hangul++
+				case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+					kana++
+				case unicode.Is(unicode.Han, r):
+					han++
+				case unicode.Is(unicode.Cyrillic, r):
+					cyrillic++
+				case unicode.Is(unicode.Latin, r):
+					latin++
+				}
+			}
+		}
+	}
+
+	// 가나가 섞인 한자는 일본어로, 한자만 있으면 중국어로 봄
+	japanese, chinese := 0, han
+	if kana > 0 {
+		japanese, chinese = kana+han, 0
+	}
+
+	// 동률이면 앞의 언어를 선택
+	candidates := []struct {
+		tag   string
+		count int
+	}{
+		{LanguageKorean, hangul},
+		{LanguageJapanese, japanese},
+		{LanguageChinese, chinese},
+		{LanguageRussian, cyrillic},
+		{LanguageEnglish, latin},
+	}
+
+	language, best := "", 0
+	for _, candidate := range candidates {
+		if candidate.count > best {
+			language, best = candidate.tag, candidate.count
+		}
+	}
+	return language
+}
+
+// withLanguage는 language 항목을 추가한 메타데이터 사본을 반환합니다
+// 이미 언어가 기록되어 있거나 감지한 언어가 없으면 원본 맵을 그대로 반환합니다
+func withLanguage(metadata map[string]string, language string) map[string]string {
+	if language == "" || metadata[languageKey] != "" {
+		return metadata
+	}
+
+	result := make(map[string]string, len(metadata)+1)
+	for key, value := range metadata {
+		result[key] = value
+	}
+	result[languageKey] = language
+	return result
+}
@@ -0,0 +1,92 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// languageSession은 주어진 내용의 메시지로 테스트 세션을 만듭니다
+func languageSession(id string, contents ...string) models.SessionData {
+	session := models.SessionData{ID: id, Source: models.SourceClaudeCode}
+	for _, content := range contents {
+		session.Messages = append(session.Messages, models.Message{Role: "user", Content: content})
+	}
+	return session
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		session  models.SessionData
+		expected string
+	}{
+		{"korean", languageSession("ko", "이 함수의 에러 처리를 개선해 주세요", "네, 수정했습니다"), LanguageKorean},
+		{"english", languageSession("en", "Please improve error handling in this function"), LanguageEnglish},
+		{"korean with english terms", languageSession("mixed", "goroutine 누수가 있는지 확인하고 context 취소를 처리해 주세요"), LanguageKorean},
+		{"japanese", languageSession("ja", "この関数のエラー処理を改善してください"), LanguageJapanese},
+		{"chinese", languageSession("zh", "请改进这个函数的错误处理"), LanguageChinese},
+		{"no letters", languageSession("empty", "12345 !!!"), ""},
+		{"no messages", languageSession("none"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DetectLanguage(tt.session))
+		})
+	}
+}
+
+func TestDetectLanguage_IgnoresCodeBlocks(t *testing.T) {
+	session := languageSession("code",
+		"다음 코드를 실행하세요",
+		"```go\nfunc main() {\n\tfmt.Println(\"hello world from a long english code sample\")\n}\n```")
+
+	assert.Equal(t, LanguageKorean, DetectLanguage(session))
+}
+
+func TestProcess_DetectLanguage(t *testing.T) {
+	sessions := []models.SessionData{
+		languageSession("ko", "배포 스크립트를 작성해 주세요"),
+		languageSession("en", "Write a deployment script"),
+		{
+			ID:       "preset",
+			Source:   models.SourceGeminiCLI,
+			Metadata: map[string]string{"language": "fr"},
+			Messages: []models.Message{{Role: "user", Content: "Write a deployment script"}},
+		},
+	}
+	original := sessions[2].Metadata
+
+	cfg := &models.ExportConfig{DetectLanguage: true, GroupByMeta: "language"}
+	result, err := NewProcessor(cfg).Process(context.Background(), sessions)
+	require.NoError(t, err)
+	data := result.(ProcessedData)
+
+	languages := make(map[string]string)
+	for _, session := range data.Sessions {
+		languages[session.ID] = session.Metadata["language"]
+	}
+	assert.Equal(t, map[string]string{"ko": "ko", "en": "en", "preset": "fr"}, languages)
+	assert.Equal(t, map[string]string{"language": "fr"}, original, "기존 언어 값은 유지")
+
+	// 감지한 언어로 섹션을 나눌 수 있음
+	var groups []string
+	for _, group := range data.MetaGroups {
+		groups = append(groups, group.Value)
+	}
+	assert.Equal(t, []string{"en", "fr", "ko"}, groups)
+}
+
+func TestProcess_DetectLanguageDisabled(t *testing.T) {
+	result, err := NewProcessor(&models.ExportConfig{}).Process(context.Background(),
+		[]models.SessionData{languageSession("ko", "안녕하세요")})
+	require.NoError(t, err)
+
+	_, ok := result.(ProcessedData).Sessions[0].Metadata["language"]
+	assert.False(t, ok)
+}
@@ -0,0 +1,76 @@
+package processor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"ssamai/pkg/models"
+)
+
+// UnknownMetaGroup은 그룹 기준 메타데이터가 없는 세션이 모이는 그룹 이름입니다
+const UnknownMetaGroup = "unknown"
+
+// MetaGroup은 같은 메타데이터 값을 가진 세션 묶음입니다
+type MetaGroup struct {
+	Value    string               `json:"value"`
+	Sessions []models.SessionData `json:"sessions"`
+}
+
+// GroupSessionsByMeta는 세션을 메타데이터 key의 값별로 묶습니다
+// 그룹은 값 이름순이며 값이 없는 세션은 마지막 unknown 그룹에 모입니다. 그룹 안의 세션 순서는 입력 순서를 따릅니다
+func GroupSessionsByMeta(sessions []models.SessionData, key string) []MetaGroup {
+	index := make(map[string]int)
+	var groups []MetaGroup
+	for _, session := range sessions {
+		value := strings.TrimSpace(session.Metadata[key])
+		if value == "" {
+			value = UnknownMetaGroup
+		}
+
+		i, ok := index[value]
+		if !ok {
+			i = len(groups)
+			index[value] = i
+			groups = append(groups, MetaGroup{Value: value})
+		}
+		groups[i].Sessions = append(groups[i].Sessions, session)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if (groups[i].Value == UnknownMetaGroup) != (groups[j].Value == UnknownMetaGroup) {
+			return groups[j].Value == UnknownMetaGroup
+		}
+		return groups[i].Value < groups[j].Value
+	})
+	return groups
+}
+
+// generateMetaGroupTOC는 메타데이터 그룹별 목차 항목을 생성합니다
+func (p *Processor) generateMetaGroupTOC(key string, groups []MetaGroup) []TOCEntry {
+	toc := make([]TOCEntry, 0, len(groups))
+	for _, group := range groups {
+		entry := TOCEntry{
+			Title:    fmt.Sprintf("%s: %s (%d개 세션)", key, group.Value, len(group.Sessions)),
+			Level:    1,
+			Anchor:   p.generateAnchor(fmt.Sprintf("%s-%s", key, group.Value)),
+			Children: make([]TOCEntry, 0, len(group.Sessions)),
+		}
+
+		for _, session := range group.Sessions {
+			sessionTitle := session.Title
+			if sessionTitle == "" {
+				sessionTitle = fmt.Sprintf("세션 %s", session.ID)
+			}
+			sourceAnchor := p.generateAnchor(session.Source.DisplayName())
+			entry.Children = append(entry.Children, TOCEntry{
+				Title:  sessionTitle,
+				Level:  2,
+				Anchor: p.generateAnchor(fmt.Sprintf("%s-%s", sourceAnchor, session.ID)),
+			})
+		}
+
+		toc = append(toc, entry)
+	}
+	return toc
+}
@@ -0,0 +1,64 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupSessionsByMeta(t *testing.T) {
+	session := func(id string, metadata map[string]string) models.SessionData {
+		return models.SessionData{ID: id, Source: models.SourceClaudeCode, Metadata: metadata}
+	}
+	sessions := []models.SessionData{
+		session("a", map[string]string{"service": "payments"}),
+		session("b", nil),
+		session("c", map[string]string{"service": "auth"}),
+		session("d", map[string]string{"service": "payments"}),
+		session("e", map[string]string{"service": "  ", "model": "gpt"}),
+	}
+
+	groups := GroupSessionsByMeta(sessions, "service")
+
+	ids := func(group MetaGroup) []string {
+		var result []string
+		for _, s := range group.Sessions {
+			result = append(result, s.ID)
+		}
+		return result
+	}
+
+	require.Len(t, groups, 3)
+	assert.Equal(t, "auth", groups[0].Value)
+	assert.Equal(t, []string{"c"}, ids(groups[0]))
+	assert.Equal(t, "payments", groups[1].Value)
+	assert.Equal(t, []string{"a", "d"}, ids(groups[1]))
+	assert.Equal(t, UnknownMetaGroup, groups[2].Value)
+	assert.Equal(t, []string{"b", "e"}, ids(groups[2]))
+}
+
+func TestProcessor_GroupByMeta(t *testing.T) {
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	sessions := []models.SessionData{
+		{ID: "s1", Source: models.SourceClaudeCode, Title: "Billing fix", Timestamp: now, Metadata: map[string]string{"service": "billing"}},
+		{ID: "s2", Source: models.SourceGeminiCLI, Timestamp: now.Add(-time.Hour)},
+	}
+
+	data := processSessions(t, &models.ExportConfig{GroupByMeta: "service"}, sessions)
+	require.Len(t, data.MetaGroups, 2)
+
+	var anchors []string
+	for _, entry := range data.TableOfContents {
+		anchors = append(anchors, entry.Anchor)
+	}
+	assert.Equal(t, []string{"overview", "statistics", "service-billing", "service-unknown"}, anchors)
+	assert.Equal(t, "service: billing (1개 세션)", data.TableOfContents[2].Title)
+	assert.Equal(t, "claude-code-s1", data.TableOfContents[2].Children[0].Anchor)
+
+	// 설정하지 않으면 그룹 없음
+	assert.Nil(t, processSessions(t, &models.ExportConfig{}, sessions).MetaGroups)
+}
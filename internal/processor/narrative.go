@@ -0,0 +1,74 @@
+package processor
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NarrativeOverview는 통계를 한 문단의 자연어 요약으로 만듭니다
+// 예: "5일 동안 3개 도구에서 총 42개의 세션을 진행했습니다. 가장 활발한 도구는 Gemini CLI입니다. 세션당 평균 6개의 메시지를 주고받았습니다."
+// 세션이 없으면 빈 문자열을 반환하며, 알 수 없는 기간이나 메시지가 없는 경우 해당 문장은 생략합니다
+func NarrativeOverview(stats Statistics) string {
+	if stats.TotalSessions == 0 {
+		return ""
+	}
+
+	var subject strings.Builder
+	if days := statisticsDays(stats); days == 1 {
+		subject.WriteString("하루 동안 ")
+	} else if days > 1 {
+		subject.WriteString(fmt.Sprintf("%d일 동안 ", days))
+	}
+
+	tools := 0
+	var onlySource string
+	for source, count := range stats.SourceCounts {
+		if count > 0 {
+			tools++
+			onlySource = source.DisplayName()
+		}
+	}
+	if tools == 1 {
+		subject.WriteString(fmt.Sprintf("%s에서 ", onlySource))
+	} else if tools > 1 {
+		subject.WriteString(fmt.Sprintf("%d개 도구에서 ", tools))
+	}
+
+	sentences := []string{fmt.Sprintf("%s총 %d개의 세션을 진행했습니다.", subject.String(), stats.TotalSessions)}
+
+	// 도구가 하나뿐이면 가장 활발한 도구는 앞 문장과 같으므로 생략
+	if tools > 1 && stats.MostActiveSource != "" {
+		sentences = append(sentences, fmt.Sprintf("가장 활발한 도구는 %s입니다.", stats.MostActiveSource.DisplayName()))
+	}
+
+	if stats.TotalMessages > 0 {
+		average := float64(stats.TotalMessages) / float64(stats.TotalSessions)
+		sentences = append(sentences, fmt.Sprintf("세션당 평균 %s개의 메시지를 주고받았습니다.", formatAverage(average)))
+	}
+
+	return strings.Join(sentences, " ")
+}
+
+// statisticsDays는 통계 기간이 걸쳐 있는 날짜 수를 반환합니다 (기간을 알 수 없으면 0)
+func statisticsDays(stats Statistics) int {
+	if stats.DateRange == nil || stats.DateRange.Start.IsZero() || stats.DateRange.End.IsZero() {
+		return 0
+	}
+
+	startYear, startMonth, startDay := stats.DateRange.Start.Date()
+	endYear, endMonth, endDay := stats.DateRange.End.Date()
+	start := time.Date(startYear, startMonth, startDay, 0, 0, 0, 0, time.UTC)
+	end := time.Date(endYear, endMonth, endDay, 0, 0, 0, 0, time.UTC)
+	if end.Before(start) {
+		return 0
+	}
+	return int(end.Sub(start).Hours()/24) + 1
+}
+
+// formatAverage는 평균값을 소수점 한 자리까지 표시합니다 (정수면 소수점 생략)
+func formatAverage(value float64) string {
+	return strconv.FormatFloat(math.Round(value*10)/10, 'f', -1, 64)
+}
@@ -0,0 +1,72 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNarrativeOverview(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		stats    Statistics
+		expected string
+	}{
+		{
+			name: "multiple days and tools",
+			stats: Statistics{
+				TotalSessions: 42,
+				TotalMessages: 252,
+				SourceCounts: map[models.CollectionSource]int{
+					models.SourceClaudeCode: 10,
+					models.SourceGeminiCLI:  30,
+					models.SourceAmazonQ:    2,
+				},
+				DateRange:        &models.DateRange{Start: start, End: start.AddDate(0, 0, 4).Add(3 * time.Hour)},
+				MostActiveSource: models.SourceGeminiCLI,
+			},
+			expected: "5일 동안 3개 도구에서 총 42개의 세션을 진행했습니다. 가장 활발한 도구는 Gemini CLI입니다. 세션당 평균 6개의 메시지를 주고받았습니다.",
+		},
+		{
+			name: "single day and tool",
+			stats: Statistics{
+				TotalSessions:    2,
+				TotalMessages:    3,
+				SourceCounts:     map[models.CollectionSource]int{models.SourceClaudeCode: 2},
+				DateRange:        &models.DateRange{Start: start, End: start.Add(2 * time.Hour)},
+				MostActiveSource: models.SourceClaudeCode,
+			},
+			expected: "하루 동안 Claude Code에서 총 2개의 세션을 진행했습니다. 세션당 평균 1.5개의 메시지를 주고받았습니다.",
+		},
+		{
+			name: "unknown period and no messages",
+			stats: Statistics{
+				TotalSessions: 1,
+				SourceCounts:  map[models.CollectionSource]int{models.SourceAmazonQ: 1},
+			},
+			expected: "Amazon Q에서 총 1개의 세션을 진행했습니다.",
+		},
+		{
+			name:     "no sessions",
+			stats:    Statistics{},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NarrativeOverview(tt.stats))
+		})
+	}
+}
+
+func TestFormatAverage(t *testing.T) {
+	assert.Equal(t, "6", formatAverage(6))
+	assert.Equal(t, "2.3", formatAverage(7.0/3))
+	assert.Equal(t, "0.5", formatAverage(0.5))
+}
@@ -54,27 +54,115 @@ func (p *Processor) Process(ctx context.Context, sessions []models.SessionData)
 	default:
 	}
 
-	// 소스별로 그룹화
-	sourceGroups := make(map[models.CollectionSource][]models.SessionData)
-	for _, session := range sessions {
-		sourceGroups[session.Source] = append(sourceGroups[session.Source], session)
+	// 소스별 최신 세션만 유지 (--latest-per-source)
+	if p.config != nil && p.config.LatestPerSource {
+		sessions = latestPerSource(sessions)
 	}
 
+	// 메시지 내용 변환 적용
+	sessions = p.applyTransforms(sessions)
+
+	// 소스별로 그룹화
+	sourceGroups := groupSessionsBySource(sessions)
+
 	// 통계 생성
 	stats := p.generateStatistics(sessions, sourceGroups)
 
-	// TOC 생성
+	// 통계와 히트맵은 전체 세션 기준으로 두고, 출력할 세션만 가장 활발한 N개로 줄임 (--top)
+	allSessions := sessions
+	if p.config != nil && p.config.Top > 0 {
+		sessions = topSessions(sessions, p.config.Top, p.config.TopBy)
+		sourceGroups = groupSessionsBySource(sessions)
+	}
+
+	// TOC 생성 (--group-by-meta 설정 시 소스 대신 메타데이터 값별 섹션)
 	toc := p.generateTableOfContents(sourceGroups)
+	var metaGroups []MetaGroup
+	if p.config != nil && p.config.GroupByMeta != "" {
+		metaGroups = GroupSessionsByMeta(sessions, p.config.GroupByMeta)
+		toc = append(p.generateTableOfContents(nil), p.generateMetaGroupTOC(p.config.GroupByMeta, metaGroups)...)
+	}
+
+	// 타임라인 섹션을 출력하는 경우 목차에도 추가
+	if p.config != nil && p.config.MermaidTimeline {
+		toc = insertTOCAfter(toc, "statistics", TOCEntry{
+			Title:  "타임라인",
+			Level:  1,
+			Anchor: "timeline",
+		})
+	}
+
+	// 활동 히트맵 (통계 바로 다음 섹션)
+	var heatmap *ActivityHeatmap
+	if p.config != nil && p.config.ActivityHeatmap {
+		location, err := p.config.DisplayLocation()
+		if err != nil {
+			return ProcessedData{}, err
+		}
+		heatmap = BuildActivityHeatmap(allSessions, location)
+		toc = insertTOCAfter(toc, "statistics", TOCEntry{
+			Title:  "활동 히트맵",
+			Level:  1,
+			Anchor: "heatmap",
+		})
+	}
 
 	return ProcessedData{
 		Sessions:        sessions,
 		SourceGroups:    sourceGroups,
 		Statistics:      stats,
 		TableOfContents: toc,
+		Heatmap:         heatmap,
+		MetaGroups:      metaGroups,
 		ProcessedAt:     time.Now(),
 	}, nil
 }
 
+// ProcessCollectionResult는 수집 결과 전체를 처리하여 세션과 함께 수집 에러도 전달합니다
+func (p *Processor) ProcessCollectionResult(ctx context.Context, result *models.CollectionResult) (ProcessedData, error) {
+	if result == nil {
+		return ProcessedData{}, fmt.Errorf("수집 결과가 nil입니다")
+	}
+
+	processed, err := p.Process(ctx, result.Sessions)
+	if err != nil {
+		return ProcessedData{}, err
+	}
+
+	data, ok := processed.(ProcessedData)
+	if !ok {
+		return ProcessedData{}, fmt.Errorf("데이터 처리 결과 타입 변환 실패")
+	}
+
+	if messages := result.ErrorMessages(); len(messages) > 0 {
+		data.Errors = append([]string(nil), messages...)
+
+		// 수집 문제 섹션을 출력하는 경우 목차에도 추가
+		if p.config != nil && p.config.IncludeErrors {
+			data.TableOfContents = insertTOCAfter(data.TableOfContents, "statistics", TOCEntry{
+				Title:  "수집 문제",
+				Level:  1,
+				Anchor: "collection-issues",
+			})
+		}
+	}
+
+	return data, nil
+}
+
+// insertTOCAfter는 지정한 앵커 항목 바로 뒤에 목차 항목을 삽입합니다 (없으면 끝에 추가)
+func insertTOCAfter(toc []TOCEntry, anchor string, entry TOCEntry) []TOCEntry {
+	for i, existing := range toc {
+		if existing.Anchor == anchor {
+			result := make([]TOCEntry, 0, len(toc)+1)
+			result = append(result, toc[:i+1]...)
+			result = append(result, entry)
+			return append(result, toc[i+1:]...)
+		}
+	}
+	return append(toc, entry)
+}
+
 // Validate는 처리기 설정이 유효한지 검증합니다
 func (p *Processor) Validate() error {
 	if p.config == nil {
@@ -94,7 +182,10 @@ type ProcessedData struct {
 	SourceGroups    map[models.CollectionSource][]models.SessionData       `json:"source_groups"`
 	Statistics      Statistics                                             `json:"statistics"`
 	TableOfContents []TOCEntry                                             `json:"table_of_contents"`
+	Heatmap         *ActivityHeatmap                                       `json:"heatmap,omitempty"`
+	MetaGroups      []MetaGroup                                            `json:"meta_groups,omitempty"`
 	ProcessedAt     time.Time                                              `json:"processed_at"`
+	Errors          []string                                               `json:"errors,omitempty"`
 }
 
 // Statistics는 통계 정보를 나타냅니다
@@ -106,7 +197,44 @@ type Statistics struct {
 	SourceCounts       map[models.CollectionSource]int       `json:"source_counts"`
 	DateRange          *models.DateRange                      `json:"date_range,omitempty"`
 	MostActiveSource   models.CollectionSource                `json:"most_active_source"`
+	MostActiveSourceMetric string                             `json:"most_active_source_metric,omitempty"`
 	AverageSessionTime time.Duration                          `json:"average_session_time"`
+	UniquePrompts       int                                   `json:"unique_prompts"`
+	DuplicatePromptRate float64                               `json:"duplicate_prompt_rate"`
+	MessagesByRole      map[string]int                        `json:"messages_by_role,omitempty"`
+	ResponseLatency     map[models.CollectionSource]ResponseLatency `json:"response_latency,omitempty"`
+	MessageLengths      []LengthBucket                        `json:"message_lengths,omitempty"`
+}
+
+// messageLengthBounds는 메시지 길이 히스토그램 구간의 경계(문자 수)입니다
+// 구간은 <100, 100–500, 500–2000, 2000+ 입니다
+var messageLengthBounds = []int{100, 500, 2000}
+
+// LengthBucket은 메시지 내용 길이 히스토그램의 한 구간입니다 (Min 이상 Max 미만, Max가 0이면 상한 없음)
+type LengthBucket struct {
+	Label string `json:"label"`
+	Min   int    `json:"min"`
+	Max   int    `json:"max,omitempty"`
+	Count int    `json:"count"`
+}
+
+// 가장 활발한 소스를 판단하는 기준입니다
+const (
+	ActiveSourceBySessions = "sessions" // 세션 수 기준 (기본값)
+	ActiveSourceByMessages = "messages" // 메시지 수 기준
+)
+
+// --top으로 출력할 세션을 고르는 기준입니다
+const (
+	TopByMessages = "messages" // 메시지 수 기준 (기본값)
+	TopByDuration = "duration" // 세션 지속 시간 기준
+)
+
+// ResponseLatency는 사용자 메시지와 뒤따르는 어시스턴트 메시지 사이의 응답 지연 통계입니다
+type ResponseLatency struct {
+	Samples int           `json:"samples"`
+	Average time.Duration `json:"average"`
+	Median  time.Duration `json:"median"`
 }
 
 // TOCEntry는 목차 항목을 나타냅니다
@@ -117,80 +245,194 @@ type TOCEntry struct {
 	Children []TOCEntry  `json:"children,omitempty"`
 }
 
-func (p *Processor) generateStatistics(sessions []models.SessionData, sourceGroups map[models.CollectionSource][]models.SessionData) Statistics {
-	stats := Statistics{
-		TotalSessions: len(sessions),
-		SourceCounts:  make(map[models.CollectionSource]int),
+// latestPerSource는 최신순으로 정렬된 세션에서 소스별로 가장 최근 세션 하나씩만 반환합니다
+func latestPerSource(sessions []models.SessionData) []models.SessionData {
+	seen := make(map[models.CollectionSource]bool)
+	var latest []models.SessionData
+	for _, session := range sessions {
+		if seen[session.Source] {
+			continue
+		}
+		seen[session.Source] = true
+		latest = append(latest, session)
 	}
+	return latest
+}
 
-	var totalMessages, totalCommands, totalFiles int
-	var oldestTime, newestTime time.Time
-	var sessionDurations []time.Duration
+// groupSessionsBySource는 세션을 소스별로 묶습니다 (각 그룹은 입력 순서 유지)
+func groupSessionsBySource(sessions []models.SessionData) map[models.CollectionSource][]models.SessionData {
+	sourceGroups := make(map[models.CollectionSource][]models.SessionData)
+	for _, session := range sessions {
+		sourceGroups[session.Source] = append(sourceGroups[session.Source], session)
+	}
+	return sourceGroups
+}
 
-	// 초기값 설정
-	if len(sessions) > 0 {
-		oldestTime = sessions[0].Timestamp
-		newestTime = sessions[0].Timestamp
+// topSessions는 by 기준(messages: 메시지 수, duration: 지속 시간)으로 가장 활발한 세션 n개를 반환합니다
+// 값이 같으면 입력 순서가 앞선 세션을 고르며, 결과는 입력 순서(최신순)를 유지합니다
+func topSessions(sessions []models.SessionData, n int, by string) []models.SessionData {
+	if n >= len(sessions) {
+		return sessions
 	}
 
-	// 통계 계산
-	for source, sourceSessions := range sourceGroups {
-		stats.SourceCounts[source] = len(sourceSessions)
-		
+	metric := func(session models.SessionData) int64 {
+		return int64(len(session.Messages))
+	}
+	if by == TopByDuration {
+		metric = func(session models.SessionData) int64 {
+			return int64(sessionDuration(session))
+		}
+	}
+
+	ranked := make([]int, len(sessions))
+	for i := range ranked {
+		ranked[i] = i
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return metric(sessions[ranked[i]]) > metric(sessions[ranked[j]])
+	})
+
+	selected := ranked[:n]
+	sort.Ints(selected)
+	top := make([]models.SessionData, 0, n)
+	for _, index := range selected {
+		top = append(top, sessions[index])
+	}
+	return top
+}
+
+// sessionDuration은 첫 메시지와 마지막 메시지 사이의 시간입니다 (메시지가 두 개 미만이면 0)
+func sessionDuration(session models.SessionData) time.Duration {
+	if len(session.Messages) < 2 {
+		return 0
+	}
+	return session.Messages[len(session.Messages)-1].Timestamp.Sub(session.Messages[0].Timestamp)
+}
+
+func (p *Processor) generateStatistics(sessions []models.SessionData, sourceGroups map[models.CollectionSource][]models.SessionData) Statistics {
+	metric := ActiveSourceBySessions
+	if p.config != nil {
+		metric = p.config.ActiveSourceMetric
+	}
+
+	accumulator := NewStatisticsAccumulator(metric)
+	for _, sourceSessions := range sourceGroups {
 		for _, session := range sourceSessions {
-			// 메시지, 명령어, 파일 수 계산
-			totalMessages += len(session.Messages)
-			totalCommands += len(session.Commands)
-			totalFiles += len(session.Files)
-			
-			// 날짜 범위 계산
-			if session.Timestamp.Before(oldestTime) {
-				oldestTime = session.Timestamp
+			accumulator.Add(session)
+		}
+	}
+	return accumulator.Statistics()
+}
+
+// responseLatencies는 각 사용자 메시지와 바로 다음 어시스턴트 메시지 사이의 시간차를 계산합니다
+// 사용자 메시지가 연속되면 마지막 메시지를 기준으로 하며, 타임스탬프가 없거나
+// 응답이 질문보다 앞선(순서가 뒤바뀐) 쌍은 제외합니다
+func responseLatencies(messages []models.Message) []time.Duration {
+	var result []time.Duration
+	var pending *time.Time
+
+	for i := range messages {
+		message := &messages[i]
+		switch normalizeRole(message.Role) {
+		case "user":
+			if message.Timestamp.IsZero() {
+				pending = nil
+				continue
 			}
-			if session.Timestamp.After(newestTime) {
-				newestTime = session.Timestamp
+			pending = &message.Timestamp
+		case "assistant":
+			if pending == nil || message.Timestamp.IsZero() {
+				continue
 			}
-			
-			// 세션 지속 시간 계산 (메시지 간 시간차 기반)
-			if len(session.Messages) > 1 {
-				first := session.Messages[0].Timestamp
-				last := session.Messages[len(session.Messages)-1].Timestamp
-				sessionDurations = append(sessionDurations, last.Sub(first))
+			if gap := message.Timestamp.Sub(*pending); gap >= 0 {
+				result = append(result, gap)
 			}
+			pending = nil
 		}
 	}
 
-	stats.TotalMessages = totalMessages
-	stats.TotalCommands = totalCommands
-	stats.TotalFiles = totalFiles
+	return result
+}
 
-	// 날짜 범위 설정
-	if len(sessions) > 0 {
-		stats.DateRange = &models.DateRange{
-			Start: oldestTime,
-			End:   newestTime,
+// messageLengthBucket은 문자 수 length가 속하는 히스토그램 구간 번호를 반환합니다
+func messageLengthBucket(length int) int {
+	for i, bound := range messageLengthBounds {
+		if length < bound {
+			return i
 		}
 	}
+	return len(messageLengthBounds)
+}
 
-	// 가장 활발한 소스 찾기
-	maxCount := 0
-	for source, count := range stats.SourceCounts {
-		if count > maxCount {
-			maxCount = count
-			stats.MostActiveSource = source
+// buildLengthBuckets는 구간별 메시지 수로 히스토그램 구간 목록을 만듭니다
+func buildLengthBuckets(counts []int) []LengthBucket {
+	buckets := make([]LengthBucket, len(counts))
+	for i := range counts {
+		bucket := LengthBucket{Count: counts[i]}
+		switch {
+		case i == 0:
+			bucket.Max = messageLengthBounds[0]
+			bucket.Label = fmt.Sprintf("<%d", bucket.Max)
+		case i == len(messageLengthBounds):
+			bucket.Min = messageLengthBounds[i-1]
+			bucket.Label = fmt.Sprintf("%d+", bucket.Min)
+		default:
+			bucket.Min, bucket.Max = messageLengthBounds[i-1], messageLengthBounds[i]
+			bucket.Label = fmt.Sprintf("%d–%d", bucket.Min, bucket.Max)
 		}
+		buckets[i] = bucket
 	}
+	return buckets
+}
 
-	// 평균 세션 시간 계산
-	if len(sessionDurations) > 0 {
-		var total time.Duration
-		for _, duration := range sessionDurations {
-			total += duration
-		}
-		stats.AverageSessionTime = total / time.Duration(len(sessionDurations))
+// summarizeLatencies는 응답 지연 목록의 평균과 중앙값을 계산합니다
+func summarizeLatencies(samples []time.Duration) ResponseLatency {
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, sample := range sorted {
+		total += sample
+	}
+
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+
+	return ResponseLatency{
+		Samples: len(sorted),
+		Average: total / time.Duration(len(sorted)),
+		Median:  median,
+	}
+}
+
+// roleAliases는 도구별로 다른 역할 이름을 공통 이름으로 매핑합니다
+var roleAliases = map[string]string{
+	"human":       "user",
+	"model":       "assistant",
+	"ai":          "assistant",
+	"bot":         "assistant",
+	"function":    "tool",
+	"tool_result": "tool",
+	"tool_use":    "tool",
+}
+
+// normalizeRole은 역할 이름을 소문자 공통 이름(user, assistant, system, tool 등)으로 정규화합니다
+func normalizeRole(role string) string {
+	role = strings.ToLower(strings.TrimSpace(role))
+	if role == "" {
+		return "unknown"
+	}
+	if alias, ok := roleAliases[role]; ok {
+		return alias
 	}
+	return role
+}
 
-	return stats
+// normalizePrompt는 중복 비교를 위해 프롬프트를 소문자화하고 공백을 정리합니다
+func normalizePrompt(content string) string {
+	return strings.Join(strings.Fields(strings.ToLower(content)), " ")
 }
 
 func (p *Processor) generateTableOfContents(sourceGroups map[models.CollectionSource][]models.SessionData) []TOCEntry {
@@ -216,8 +458,21 @@ func (p *Processor) generateTableOfContents(sourceGroups map[models.CollectionSo
 		sources = append(sources, source)
 	}
 	
-	// 소스 정렬
+	// 소스 정렬 (--source-order가 있으면 본문 섹션과 같은 순서, 없으면 이름순)
+	rank := make(map[models.CollectionSource]int)
+	if p.config != nil && len(p.config.SourceOrder) > 0 {
+		for i, source := range models.SectionOrder(p.config.SourceOrder) {
+			rank[source] = i + 1
+		}
+	}
 	sort.Slice(sources, func(i, j int) bool {
+		ri, rj := rank[sources[i]], rank[sources[j]]
+		if ri > 0 && rj > 0 {
+			return ri < rj
+		}
+		if ri > 0 || rj > 0 {
+			return ri > 0
+		}
 		return string(sources[i]) < string(sources[j])
 	})
 
@@ -227,7 +482,7 @@ func (p *Processor) generateTableOfContents(sourceGroups map[models.CollectionSo
 			continue
 		}
 
-		sourceTitle := p.getSourceDisplayName(source)
+		sourceTitle := source.DisplayName()
 		sourceAnchor := p.generateAnchor(sourceTitle)
 		
 		sourceEntry := TOCEntry{
@@ -258,19 +513,6 @@ func (p *Processor) generateTableOfContents(sourceGroups map[models.CollectionSo
 	return toc
 }
 
-func (p *Processor) getSourceDisplayName(source models.CollectionSource) string {
-	switch source {
-	case models.SourceClaudeCode:
-		return "Claude Code"
-	case models.SourceGeminiCLI:
-		return "Gemini CLI"
-	case models.SourceAmazonQ:
-		return "Amazon Q"
-	default:
-		return string(source)
-	}
-}
-
 func (p *Processor) generateAnchor(text string) string {
 	// 소문자 변환 및 공백을 하이픈으로 변경
 	anchor := strings.ToLower(text)
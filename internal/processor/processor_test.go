@@ -0,0 +1,300 @@
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func processSessions(t *testing.T, cfg *models.ExportConfig, sessions []models.SessionData) ProcessedData {
+	t.Helper()
+
+	result, err := NewProcessor(cfg).Process(context.Background(), sessions)
+	require.NoError(t, err)
+
+	data, ok := result.(ProcessedData)
+	require.True(t, ok)
+	return data
+}
+
+func TestProcessor_UniquePrompts(t *testing.T) {
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	sessions := []models.SessionData{
+		{
+			ID:        "s1",
+			Source:    models.SourceClaudeCode,
+			Timestamp: now,
+			Messages: []models.Message{
+				{Role: "user", Content: "Run the tests"},
+				{Role: "assistant", Content: "Run the tests"},
+				{Role: "user", Content: "Fix the build"},
+			},
+		},
+		{
+			ID:        "s2",
+			Source:    models.SourceGeminiCLI,
+			Timestamp: now.Add(-time.Hour),
+			Messages: []models.Message{
+				// 대소문자와 공백만 다른 프롬프트는 중복으로 취급
+				{Role: "user", Content: "  run   the TESTS\n"},
+				{Role: "user", Content: "Explain this code"},
+				{Role: "user", Content: "   "},
+			},
+		},
+	}
+
+	stats := processSessions(t, &models.ExportConfig{}, sessions).Statistics
+
+	// 프롬프트 4개 중 고유 3개
+	assert.Equal(t, 3, stats.UniquePrompts)
+	assert.InDelta(t, 0.25, stats.DuplicatePromptRate, 0.0001)
+}
+
+func TestProcessor_MostActiveSourceMetric(t *testing.T) {
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	messages := func(n int) []models.Message {
+		result := make([]models.Message, n)
+		for i := range result {
+			result[i] = models.Message{Role: "user", Content: "message"}
+		}
+		return result
+	}
+
+	// Gemini는 짧은 세션 3개, Claude는 긴 세션 1개
+	sessions := []models.SessionData{
+		{ID: "g1", Source: models.SourceGeminiCLI, Timestamp: now, Messages: messages(1)},
+		{ID: "g2", Source: models.SourceGeminiCLI, Timestamp: now, Messages: messages(1)},
+		{ID: "g3", Source: models.SourceGeminiCLI, Timestamp: now, Messages: messages(1)},
+		{ID: "c1", Source: models.SourceClaudeCode, Timestamp: now, Messages: messages(20)},
+	}
+
+	tests := []struct {
+		metric     string
+		wantSource models.CollectionSource
+		wantMetric string
+	}{
+		{metric: "", wantSource: models.SourceGeminiCLI, wantMetric: ActiveSourceBySessions},
+		{metric: ActiveSourceBySessions, wantSource: models.SourceGeminiCLI, wantMetric: ActiveSourceBySessions},
+		{metric: ActiveSourceByMessages, wantSource: models.SourceClaudeCode, wantMetric: ActiveSourceByMessages},
+	}
+
+	for _, tt := range tests {
+		t.Run("metric="+tt.metric, func(t *testing.T) {
+			stats := processSessions(t, &models.ExportConfig{ActiveSourceMetric: tt.metric}, sessions).Statistics
+			assert.Equal(t, tt.wantSource, stats.MostActiveSource)
+			assert.Equal(t, tt.wantMetric, stats.MostActiveSourceMetric)
+		})
+	}
+}
+
+func TestProcessor_UniquePrompts_NoUserMessages(t *testing.T) {
+	sessions := []models.SessionData{
+		{
+			ID:     "s1",
+			Source: models.SourceAmazonQ,
+			Messages: []models.Message{
+				{Role: "assistant", Content: "hello"},
+			},
+		},
+	}
+
+	stats := processSessions(t, &models.ExportConfig{}, sessions).Statistics
+
+	assert.Equal(t, 0, stats.UniquePrompts)
+	assert.Equal(t, 0.0, stats.DuplicatePromptRate)
+}
+
+func TestProcessor_MessagesByRole(t *testing.T) {
+	sessions := []models.SessionData{
+		{
+			ID:     "s1",
+			Source: models.SourceClaudeCode,
+			Messages: []models.Message{
+				{Role: "user", Content: "a"},
+				{Role: "assistant", Content: "b"},
+				{Role: "tool_use", Content: "c"},
+				{Role: "System", Content: "d"},
+			},
+		},
+		{
+			ID:     "s2",
+			Source: models.SourceGeminiCLI,
+			Messages: []models.Message{
+				{Role: " USER ", Content: "e"},
+				{Role: "model", Content: "f"},
+				{Role: "", Content: "g"},
+			},
+		},
+	}
+
+	stats := processSessions(t, &models.ExportConfig{}, sessions).Statistics
+
+	assert.Equal(t, map[string]int{
+		"user":      2,
+		"assistant": 2,
+		"tool":      1,
+		"system":    1,
+		"unknown":   1,
+	}, stats.MessagesByRole)
+}
+
+func TestProcessor_ResponseLatency(t *testing.T) {
+	base := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return base.Add(time.Duration(seconds) * time.Second) }
+
+	sessions := []models.SessionData{
+		{
+			ID:     "claude-1",
+			Source: models.SourceClaudeCode,
+			Messages: []models.Message{
+				{Role: "user", Content: "q1", Timestamp: at(0)},
+				{Role: "assistant", Content: "a1", Timestamp: at(2)},
+				// 연속된 사용자 메시지는 마지막 메시지 기준
+				{Role: "user", Content: "q2", Timestamp: at(10)},
+				{Role: "user", Content: "q2 more", Timestamp: at(20)},
+				{Role: "assistant", Content: "a2", Timestamp: at(24)},
+				// 짝이 없는 어시스턴트 메시지는 무시
+				{Role: "assistant", Content: "a3", Timestamp: at(30)},
+			},
+		},
+		{
+			ID:     "claude-2",
+			Source: models.SourceClaudeCode,
+			Messages: []models.Message{
+				{Role: "human", Content: "q", Timestamp: at(100)},
+				{Role: "model", Content: "a", Timestamp: at(112)},
+			},
+		},
+		{
+			ID:     "gemini-1",
+			Source: models.SourceGeminiCLI,
+			Messages: []models.Message{
+				// 순서가 뒤바뀐 쌍과 타임스탬프 없는 쌍은 제외
+				{Role: "user", Content: "q1", Timestamp: at(50)},
+				{Role: "assistant", Content: "a1", Timestamp: at(40)},
+				{Role: "user", Content: "q2"},
+				{Role: "assistant", Content: "a2", Timestamp: at(60)},
+			},
+		},
+	}
+
+	stats := processSessions(t, &models.ExportConfig{}, sessions).Statistics
+
+	// claude: 2s, 4s, 12s
+	require.Contains(t, stats.ResponseLatency, models.SourceClaudeCode)
+	claude := stats.ResponseLatency[models.SourceClaudeCode]
+	assert.Equal(t, 3, claude.Samples)
+	assert.Equal(t, 6*time.Second, claude.Average)
+	assert.Equal(t, 4*time.Second, claude.Median)
+
+	assert.NotContains(t, stats.ResponseLatency, models.SourceGeminiCLI)
+}
+
+func TestSummarizeLatencies_EvenMedian(t *testing.T) {
+	latency := summarizeLatencies([]time.Duration{4 * time.Second, time.Second, 3 * time.Second, 10 * time.Second})
+
+	assert.Equal(t, 4, latency.Samples)
+	assert.Equal(t, 4500*time.Millisecond, latency.Average)
+	assert.Equal(t, 3500*time.Millisecond, latency.Median)
+}
+
+func TestProcess_Top(t *testing.T) {
+	base := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	// count개의 메시지를 span 동안 고르게 배치한 세션
+	session := func(id string, source models.CollectionSource, offset time.Duration, count int, span time.Duration) models.SessionData {
+		start := base.Add(offset)
+		messages := make([]models.Message, count)
+		for i := range messages {
+			at := start
+			if count > 1 {
+				at = start.Add(span * time.Duration(i) / time.Duration(count-1))
+			}
+			messages[i] = models.Message{Role: "user", Content: id, Timestamp: at}
+		}
+		return models.SessionData{ID: id, Source: source, Timestamp: start, Messages: messages}
+	}
+	newSessions := func() []models.SessionData {
+		return []models.SessionData{
+			session("chatty", models.SourceClaudeCode, 0, 10, time.Minute),
+			session("long", models.SourceGeminiCLI, -time.Hour, 3, 2*time.Hour),
+			session("medium", models.SourceClaudeCode, -2*time.Hour, 6, 30*time.Minute),
+			session("short", models.SourceAmazonQ, -3*time.Hour, 1, 0),
+		}
+	}
+	ids := func(sessions []models.SessionData) []string {
+		result := make([]string, 0, len(sessions))
+		for _, s := range sessions {
+			result = append(result, s.ID)
+		}
+		return result
+	}
+	process := func(cfg *models.ExportConfig) ProcessedData {
+		result, err := NewProcessor(cfg).Process(context.Background(), newSessions())
+		require.NoError(t, err)
+		return result.(ProcessedData)
+	}
+
+	// 메시지 수 기준 (기본값): 결과는 최신순 유지
+	data := process(&models.ExportConfig{Top: 2})
+	assert.Equal(t, []string{"chatty", "medium"}, ids(data.Sessions))
+	require.Len(t, data.SourceGroups, 1)
+	assert.Len(t, data.SourceGroups[models.SourceClaudeCode], 2)
+
+	// 통계는 전체 세션 기준
+	assert.Equal(t, 4, data.Statistics.TotalSessions)
+	assert.Equal(t, 20, data.Statistics.TotalMessages)
+
+	// 지속 시간 기준
+	data = process(&models.ExportConfig{Top: 2, TopBy: TopByDuration})
+	assert.Equal(t, []string{"long", "medium"}, ids(data.Sessions))
+	assert.Equal(t, 4, data.Statistics.TotalSessions)
+
+	// 세션 수보다 크거나 설정하지 않으면 모두 출력
+	assert.Len(t, process(&models.ExportConfig{Top: 10}).Sessions, 4)
+	assert.Len(t, process(&models.ExportConfig{}).Sessions, 4)
+}
+
+func TestProcess_LatestPerSource(t *testing.T) {
+	base := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	session := func(id string, source models.CollectionSource, offset time.Duration) models.SessionData {
+		return models.SessionData{
+			ID:        id,
+			Source:    source,
+			Timestamp: base.Add(offset),
+			Messages:  []models.Message{{Role: "user", Content: id}},
+		}
+	}
+	sessions := []models.SessionData{
+		session("claude-old", models.SourceClaudeCode, -2*time.Hour),
+		session("gemini-new", models.SourceGeminiCLI, time.Hour),
+		session("claude-new", models.SourceClaudeCode, 0),
+		session("gemini-old", models.SourceGeminiCLI, -time.Hour),
+		session("claude-mid", models.SourceClaudeCode, -time.Hour),
+	}
+
+	result, err := NewProcessor(&models.ExportConfig{LatestPerSource: true}).Process(context.Background(), sessions)
+	require.NoError(t, err)
+	data := result.(ProcessedData)
+
+	ids := make([]string, 0, len(data.Sessions))
+	for _, s := range data.Sessions {
+		ids = append(ids, s.ID)
+	}
+	assert.Equal(t, []string{"gemini-new", "claude-new"}, ids)
+
+	// 그룹과 통계도 남은 세션 기준
+	require.Len(t, data.SourceGroups, 2)
+	assert.Len(t, data.SourceGroups[models.SourceClaudeCode], 1)
+	assert.Len(t, data.SourceGroups[models.SourceGeminiCLI], 1)
+	assert.Equal(t, 2, data.Statistics.TotalSessions)
+
+	// 설정하지 않으면 모든 세션 유지
+	result, err = NewProcessor(&models.ExportConfig{}).Process(context.Background(), sessions)
+	require.NoError(t, err)
+	assert.Len(t, result.(ProcessedData).Sessions, 5)
+}
@@ -0,0 +1,172 @@
+package processor
+
+import (
+	"crypto/sha256"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	"ssamai/pkg/models"
+)
+
+// StatisticsAccumulator는 세션을 하나씩 받아 Statistics를 누적 계산합니다
+// 세션 자체는 보관하지 않으므로 여러 데이터 파일을 스트리밍하며 합산할 때 메모리 사용량이 세션 수에 비례해 늘지 않습니다
+type StatisticsAccumulator struct {
+	activeSourceMetric string
+
+	sessions       int
+	messages       int
+	commands       int
+	files          int
+	sourceCounts   map[models.CollectionSource]int
+	sourceMessages map[models.CollectionSource]int
+	messagesByRole map[string]int
+
+	prompts      int
+	promptHashes map[[sha256.Size]byte]struct{}
+
+	oldest, newest time.Time
+
+	durationTotal time.Duration
+	durationCount int
+
+	latencies map[models.CollectionSource][]time.Duration
+
+	lengthCounts []int // 메시지 길이 히스토그램 구간별 메시지 수
+}
+
+// NewStatisticsAccumulator는 가장 활발한 소스를 activeSourceMetric 기준으로 판단하는 누적기를 생성합니다
+// activeSourceMetric이 ActiveSourceByMessages가 아니면 세션 수 기준을 사용합니다
+func NewStatisticsAccumulator(activeSourceMetric string) *StatisticsAccumulator {
+	return &StatisticsAccumulator{
+		activeSourceMetric: activeSourceMetric,
+		sourceCounts:       make(map[models.CollectionSource]int),
+		sourceMessages:     make(map[models.CollectionSource]int),
+		messagesByRole:     make(map[string]int),
+		promptHashes:       make(map[[sha256.Size]byte]struct{}),
+		latencies:          make(map[models.CollectionSource][]time.Duration),
+		lengthCounts:       make([]int, len(messageLengthBounds)+1),
+	}
+}
+
+// Add는 세션 하나를 통계에 반영합니다
+func (a *StatisticsAccumulator) Add(session models.SessionData) {
+	// 날짜 범위 계산
+	if a.sessions == 0 || session.Timestamp.Before(a.oldest) {
+		a.oldest = session.Timestamp
+	}
+	if a.sessions == 0 || session.Timestamp.After(a.newest) {
+		a.newest = session.Timestamp
+	}
+
+	a.sessions++
+	a.sourceCounts[session.Source]++
+
+	// 메시지, 명령어, 파일 수 계산
+	a.messages += len(session.Messages)
+	a.sourceMessages[session.Source] += len(session.Messages)
+	a.commands += len(session.Commands)
+	a.files += len(session.Files)
+
+	// 사용자 프롬프트 중복 검출 (정규화된 내용의 해시 기준)
+	for _, message := range session.Messages {
+		a.messagesByRole[normalizeRole(message.Role)]++
+		a.lengthCounts[messageLengthBucket(utf8.RuneCountInString(message.Content))]++
+
+		if message.Role != "user" {
+			continue
+		}
+		normalized := normalizePrompt(message.Content)
+		if normalized == "" {
+			continue
+		}
+		a.prompts++
+		a.promptHashes[sha256.Sum256([]byte(normalized))] = struct{}{}
+	}
+
+	// 응답 지연 계산 (사용자 → 어시스턴트)
+	a.latencies[session.Source] = append(a.latencies[session.Source], responseLatencies(session.Messages)...)
+
+	// 세션 지속 시간 계산 (메시지 간 시간차 기반)
+	if len(session.Messages) > 1 {
+		a.durationTotal += sessionDuration(session)
+		a.durationCount++
+	}
+}
+
+// Statistics는 지금까지 추가한 세션의 통계를 반환합니다
+func (a *StatisticsAccumulator) Statistics() Statistics {
+	stats := Statistics{
+		TotalSessions:  a.sessions,
+		TotalMessages:  a.messages,
+		TotalCommands:  a.commands,
+		TotalFiles:     a.files,
+		SourceCounts:   make(map[models.CollectionSource]int, len(a.sourceCounts)),
+		MessagesByRole: make(map[string]int, len(a.messagesByRole)),
+	}
+	for source, count := range a.sourceCounts {
+		stats.SourceCounts[source] = count
+	}
+	for role, count := range a.messagesByRole {
+		stats.MessagesByRole[role] = count
+	}
+
+	// 날짜 범위 설정
+	if a.sessions > 0 {
+		stats.DateRange = &models.DateRange{
+			Start: a.oldest,
+			End:   a.newest,
+		}
+	}
+
+	// 가장 활발한 소스 찾기 (설정한 기준의 값이 같으면 이름순으로 앞선 소스)
+	activity := a.sourceCounts
+	stats.MostActiveSourceMetric = ActiveSourceBySessions
+	if a.activeSourceMetric == ActiveSourceByMessages {
+		activity = a.sourceMessages
+		stats.MostActiveSourceMetric = ActiveSourceByMessages
+	}
+	activeSources := make([]models.CollectionSource, 0, len(activity))
+	for source := range activity {
+		activeSources = append(activeSources, source)
+	}
+	sort.Slice(activeSources, func(i, j int) bool {
+		return activeSources[i] < activeSources[j]
+	})
+	maxCount := 0
+	for _, source := range activeSources {
+		if activity[source] > maxCount {
+			maxCount = activity[source]
+			stats.MostActiveSource = source
+		}
+	}
+
+	// 고유 프롬프트 통계
+	stats.UniquePrompts = len(a.promptHashes)
+	if a.prompts > 0 {
+		stats.DuplicatePromptRate = float64(a.prompts-stats.UniquePrompts) / float64(a.prompts)
+	}
+
+	// 평균 세션 시간 계산
+	if a.durationCount > 0 {
+		stats.AverageSessionTime = a.durationTotal / time.Duration(a.durationCount)
+	}
+
+	// 소스별 응답 지연 집계
+	for source, samples := range a.latencies {
+		if len(samples) == 0 {
+			continue
+		}
+		if stats.ResponseLatency == nil {
+			stats.ResponseLatency = make(map[models.CollectionSource]ResponseLatency)
+		}
+		stats.ResponseLatency[source] = summarizeLatencies(samples)
+	}
+
+	// 메시지 길이 히스토그램 (메시지가 있을 때만)
+	if a.messages > 0 {
+		stats.MessageLengths = buildLengthBuckets(a.lengthCounts)
+	}
+
+	return stats
+}
@@ -0,0 +1,69 @@
+package processor
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatisticsAccumulator_MatchesProcessedStatistics(t *testing.T) {
+	start := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	sessions := []models.SessionData{
+		{ID: "1", Source: models.SourceClaudeCode, Timestamp: start, Messages: []models.Message{
+			{Role: "user", Content: "Fix the build", Timestamp: start},
+			{Role: "assistant", Content: "ok", Timestamp: start.Add(4 * time.Second)},
+		}},
+		{ID: "2", Source: models.SourceGeminiCLI, Timestamp: start.Add(time.Hour), Messages: []models.Message{
+			{Role: "user", Content: "fix  the build", Timestamp: start.Add(time.Hour)},
+			{Role: "model", Content: "done", Timestamp: start.Add(time.Hour + 2*time.Second)},
+			{Role: "user", Content: "thanks", Timestamp: start.Add(time.Hour + time.Minute)},
+		}},
+		{ID: "3", Source: models.SourceGeminiCLI, Timestamp: start.Add(-time.Hour)},
+	}
+
+	cfg := &models.ExportConfig{ActiveSourceMetric: ActiveSourceByMessages}
+	expected := processSessions(t, cfg, append([]models.SessionData(nil), sessions...)).Statistics
+
+	accumulator := NewStatisticsAccumulator(ActiveSourceByMessages)
+	for _, session := range sessions {
+		accumulator.Add(session)
+	}
+	assert.Equal(t, expected, accumulator.Statistics())
+}
+
+func TestStatisticsAccumulator_Empty(t *testing.T) {
+	stats := NewStatisticsAccumulator("").Statistics()
+
+	assert.Zero(t, stats.TotalSessions)
+	assert.Nil(t, stats.DateRange)
+	assert.Nil(t, stats.ResponseLatency)
+	assert.Nil(t, stats.MessageLengths)
+	assert.Equal(t, ActiveSourceBySessions, stats.MostActiveSourceMetric)
+	assert.Empty(t, stats.SourceCounts)
+}
+
+func TestProcessor_MessageLengthHistogram(t *testing.T) {
+	message := func(length int) models.Message {
+		return models.Message{Role: "user", Content: strings.Repeat("가", length)}
+	}
+	sessions := []models.SessionData{
+		{ID: "1", Source: models.SourceClaudeCode, Messages: []models.Message{
+			message(0), message(99), message(100), message(499),
+		}},
+		{ID: "2", Source: models.SourceGeminiCLI, Messages: []models.Message{
+			message(500), message(1999), message(2000), message(10000), message(50),
+		}},
+	}
+
+	stats := processSessions(t, &models.ExportConfig{}, sessions).Statistics
+	assert.Equal(t, []LengthBucket{
+		{Label: "<100", Min: 0, Max: 100, Count: 3},
+		{Label: "100–500", Min: 100, Max: 500, Count: 2},
+		{Label: "500–2000", Min: 500, Max: 2000, Count: 2},
+		{Label: "2000+", Min: 2000, Count: 2},
+	}, stats.MessageLengths)
+}
@@ -0,0 +1,148 @@
+package processor
+
+import (
+	"sort"
+	"strings"
+
+	"ssamai/pkg/models"
+)
+
+// applyTransforms는 설정에 따라 세션 메시지 내용을 변환합니다
+// 원본 세션의 메시지 슬라이스는 변경하지 않습니다
+func (p *Processor) applyTransforms(sessions []models.SessionData) []models.SessionData {
+	if p.config == nil || (!p.config.NormalizeWhitespace && !p.config.SortMessages && !p.config.PruneEmptyMetadata && !p.config.ContentHash && !p.config.CodeCaptions && !p.config.DetectLanguage) {
+		return sessions
+	}
+
+	for i := range sessions {
+		if p.config.PruneEmptyMetadata {
+			sessions[i].Metadata = pruneEmptyMetadata(sessions[i].Metadata)
+		}
+
+		if p.config.ContentHash {
+			sessions[i].Metadata = withContentHash(sessions[i].Metadata, SessionContentHash(sessions[i]))
+		}
+
+		if p.config.DetectLanguage {
+			sessions[i].Metadata = withLanguage(sessions[i].Metadata, DetectLanguage(sessions[i]))
+		}
+
+		if len(sessions[i].Messages) == 0 {
+			continue
+		}
+		messages := make([]models.Message, len(sessions[i].Messages))
+		copy(messages, sessions[i].Messages)
+
+		if p.config.SortMessages {
+			sortMessages(messages)
+		}
+
+		if p.config.NormalizeWhitespace {
+			for j := range messages {
+				messages[j].Content = normalizeWhitespace(messages[j].Content)
+			}
+		}
+
+		if p.config.CodeCaptions {
+			for j := range messages {
+				if normalizeRole(messages[j].Role) == "assistant" {
+					messages[j].Content = captionCodeBlocks(messages[j].Content)
+				}
+			}
+		}
+
+		if p.config.PruneEmptyMetadata {
+			for j := range messages {
+				messages[j].Metadata = pruneEmptyMetadata(messages[j].Metadata)
+			}
+		}
+		sessions[i].Messages = messages
+	}
+
+	return sessions
+}
+
+// sortMessages는 메시지를 시간 순으로 정렬합니다
+// 모든 메시지에 제공자가 명시한 순번이 있으면 타임스탬프 대신 순번을 사용하고,
+// 정렬 기준이 같은 메시지는 원래 순서를 유지합니다
+func sortMessages(messages []models.Message) {
+	for _, message := range messages {
+		if message.Sequence == nil {
+			sort.SliceStable(messages, func(a, b int) bool {
+				return messages[a].Timestamp.Before(messages[b].Timestamp)
+			})
+			return
+		}
+	}
+
+	sort.SliceStable(messages, func(a, b int) bool {
+		return *messages[a].Sequence < *messages[b].Sequence
+	})
+}
+
+// pruneEmptyMetadata는 값이 비어 있는 메타데이터 항목을 제외한 새 맵을 반환합니다
+// 제거할 항목이 없으면 원본 맵을 그대로 반환합니다
+func pruneEmptyMetadata(metadata map[string]string) map[string]string {
+	empty := 0
+	for _, value := range metadata {
+		if strings.TrimSpace(value) == "" {
+			empty++
+		}
+	}
+	if empty == 0 {
+		return metadata
+	}
+
+	pruned := make(map[string]string, len(metadata)-empty)
+	for key, value := range metadata {
+		if strings.TrimSpace(value) != "" {
+			pruned[key] = value
+		}
+	}
+	return pruned
+}
+
+// normalizeWhitespace는 코드 블록 밖의 후행 공백을 제거하고 CRLF를 LF로 변환하며
+// 3줄 이상 연속된 빈 줄을 한 줄로 줄입니다. 코드 블록 내부는 그대로 유지합니다
+func normalizeWhitespace(content string) string {
+	lines := strings.Split(content, "\n")
+	result := make([]string, 0, len(lines))
+
+	inFence := false
+	blankRun := 0
+	flushBlanks := func() {
+		if blankRun >= 3 {
+			blankRun = 1
+		}
+		for ; blankRun > 0; blankRun-- {
+			result = append(result, "")
+		}
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimRight(line, " \t\r")
+
+		if strings.HasPrefix(strings.TrimSpace(trimmed), "```") {
+			flushBlanks()
+			inFence = !inFence
+			result = append(result, trimmed)
+			continue
+		}
+
+		if inFence {
+			result = append(result, line)
+			continue
+		}
+
+		if trimmed == "" {
+			blankRun++
+			continue
+		}
+
+		flushBlanks()
+		result = append(result, trimmed)
+	}
+	flushBlanks()
+
+	return strings.Join(result, "\n")
+}
@@ -0,0 +1,178 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"ssamai/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeWhitespace(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "trailing whitespace and CRLF",
+			input:    "first line   \r\nsecond\t\r\nthird",
+			expected: "first line\nsecond\nthird",
+		},
+		{
+			name:     "collapse three or more blank lines",
+			input:    "a\n\n\n\n\nb\n\nc",
+			expected: "a\n\nb\n\nc",
+		},
+		{
+			name:     "whitespace-only lines count as blank",
+			input:    "a\n  \n\t\n \r\nb",
+			expected: "a\n\nb",
+		},
+		{
+			name:     "code fence untouched",
+			input:    "intro  \r\n```go\r\nfunc main() {   \r\n\n\n\n}\r\n```\r\noutro  ",
+			expected: "intro\n```go\nfunc main() {   \r\n\n\n\n}\r\n```\noutro",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, normalizeWhitespace(tt.input))
+		})
+	}
+}
+
+func TestProcessor_NormalizeWhitespace(t *testing.T) {
+	original := []models.Message{
+		{Role: "user", Content: "hello   \r\n\n\n\nworld"},
+	}
+	sessions := []models.SessionData{
+		{ID: "s1", Source: models.SourceClaudeCode, Messages: original},
+	}
+
+	data := processSessions(t, &models.ExportConfig{NormalizeWhitespace: true}, sessions)
+	assert.Equal(t, "hello\n\nworld", data.Sessions[0].Messages[0].Content)
+	assert.Equal(t, "hello\n\nworld", data.SourceGroups[models.SourceClaudeCode][0].Messages[0].Content)
+
+	// 원본 메시지는 변경하지 않음
+	assert.Equal(t, "hello   \r\n\n\n\nworld", original[0].Content)
+}
+
+func TestProcessor_NormalizeWhitespaceDisabled(t *testing.T) {
+	sessions := []models.SessionData{
+		{ID: "s1", Source: models.SourceClaudeCode, Messages: []models.Message{
+			{Role: "user", Content: "hello   \r\n"},
+		}},
+	}
+
+	data := processSessions(t, &models.ExportConfig{}, sessions)
+	assert.Equal(t, "hello   \r\n", data.Sessions[0].Messages[0].Content)
+}
+
+func TestProcessor_SortMessages(t *testing.T) {
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	original := []models.Message{
+		{ID: "m3", Timestamp: base.Add(2 * time.Minute)},
+		{ID: "m1", Timestamp: base},
+		{ID: "m2a", Timestamp: base.Add(time.Minute)},
+		{ID: "m2b", Timestamp: base.Add(time.Minute)},
+	}
+	sessions := []models.SessionData{
+		{ID: "s1", Source: models.SourceGeminiCLI, Messages: original},
+	}
+
+	data := processSessions(t, &models.ExportConfig{SortMessages: true}, sessions)
+
+	var ids []string
+	for _, message := range data.Sessions[0].Messages {
+		ids = append(ids, message.ID)
+	}
+	// 같은 타임스탬프는 원래 순서 유지
+	assert.Equal(t, []string{"m1", "m2a", "m2b", "m3"}, ids)
+
+	// 원본 메시지 순서는 변경하지 않음
+	assert.Equal(t, "m3", original[0].ID)
+}
+
+func TestProcessor_SortMessagesBySequence(t *testing.T) {
+	seq := func(n int) *int { return &n }
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	// 모든 메시지가 같은 타임스탬프를 공유해도 순번으로 정렬
+	sessions := []models.SessionData{
+		{ID: "s1", Source: models.SourceGeminiCLI, Messages: []models.Message{
+			{ID: "third", Timestamp: base, Sequence: seq(2)},
+			{ID: "first", Timestamp: base, Sequence: seq(0)},
+			{ID: "second", Timestamp: base, Sequence: seq(1)},
+		}},
+		// 순번이 일부 메시지에만 있으면 타임스탬프 기준 유지
+		{ID: "s2", Source: models.SourceGeminiCLI, Messages: []models.Message{
+			{ID: "late", Timestamp: base.Add(time.Minute), Sequence: seq(0)},
+			{ID: "early", Timestamp: base},
+		}},
+	}
+
+	data := processSessions(t, &models.ExportConfig{SortMessages: true}, sessions)
+
+	ids := func(session models.SessionData) []string {
+		var result []string
+		for _, message := range session.Messages {
+			result = append(result, message.ID)
+		}
+		return result
+	}
+	for _, session := range data.Sessions {
+		switch session.ID {
+		case "s1":
+			assert.Equal(t, []string{"first", "second", "third"}, ids(session))
+		case "s2":
+			assert.Equal(t, []string{"early", "late"}, ids(session))
+		}
+	}
+}
+
+func TestProcessor_SortMessagesDisabled(t *testing.T) {
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	sessions := []models.SessionData{
+		{ID: "s1", Source: models.SourceGeminiCLI, Messages: []models.Message{
+			{ID: "m2", Timestamp: base.Add(time.Minute)},
+			{ID: "m1", Timestamp: base},
+		}},
+	}
+
+	data := processSessions(t, &models.ExportConfig{}, sessions)
+	assert.Equal(t, "m2", data.Sessions[0].Messages[0].ID)
+}
+
+func TestProcessor_PruneEmptyMetadata(t *testing.T) {
+	sessionMetadata := map[string]string{"service": "lambda", "region": "", "user_id": "  "}
+	sessions := []models.SessionData{
+		{
+			ID:       "s1",
+			Source:   models.SourceAmazonQ,
+			Metadata: sessionMetadata,
+			Messages: []models.Message{
+				{ID: "m1", Metadata: map[string]string{"service": "", "message_type": "chat"}},
+			},
+		},
+	}
+
+	data := processSessions(t, &models.ExportConfig{PruneEmptyMetadata: true}, sessions)
+
+	assert.Equal(t, map[string]string{"service": "lambda"}, data.Sessions[0].Metadata)
+	assert.Equal(t, map[string]string{"message_type": "chat"}, data.Sessions[0].Messages[0].Metadata)
+
+	// 원본 메타데이터 맵은 변경하지 않음
+	assert.Len(t, sessionMetadata, 3)
+}
+
+func TestProcessor_PruneEmptyMetadataDisabled(t *testing.T) {
+	sessions := []models.SessionData{
+		{ID: "s1", Source: models.SourceAmazonQ, Metadata: map[string]string{"region": ""}},
+	}
+
+	data := processSessions(t, &models.ExportConfig{}, sessions)
+	assert.Contains(t, data.Sessions[0].Metadata, "region")
+}
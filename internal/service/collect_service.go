@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"ssamai/internal/collector"
@@ -21,6 +23,8 @@ type CollectService struct {
 	exporterValidator  interfaces.ExporterValidator
 	// config는 collector factory에서 필요하므로 구체 타입을 사용 (일부 DIP 완화)
 	config    *config.Config
+	// workerPool은 모든 collector가 공유하는 동시성 제한 (nil이면 collector별 기본값 사용)
+	workerPool collector.WorkerLimiter
 }
 
 // NewCollectService는 새로운 수집 서비스를 생성합니다.
@@ -40,19 +44,26 @@ func NewCollectService(
 	}
 }
 
+// WithWorkerPool은 모든 collector가 공유할 워커 풀을 주입합니다.
+func (s *CollectService) WithWorkerPool(pool collector.WorkerLimiter) *CollectService {
+	s.workerPool = pool
+	return s
+}
+
 // Execute는 데이터 수집 과정을 조율합니다. (SRP 적용: 조율 책임만 담당)
 func (s *CollectService) Execute(ctx context.Context, collectConfig *models.CollectionConfig) (*models.CollectionResult, error) {
 	// 1. 결과 초기화 (SRP: 초기화 책임 분리)
 	result := s.initializeCollectionResult(collectConfig)
 	
 	// 2. 설정 준비 (SRP: 설정 관리 책임 분리)
-	collectorConfigs, err := s.prepareCollectorConfigs()
+	collectorConfigs, err := s.prepareCollectorConfigs(collectConfig.Sources)
 	if err != nil {
 		return nil, fmt.Errorf("설정 준비 실패: %w", err)
 	}
 	
 	// 3. 데이터 수집 실행 (SRP: 수집 조율 책임 분리)
-	err = s.executeCollection(ctx, collectConfig, collectorConfigs, result)
+	pool := s.resolveWorkerPool(collectConfig)
+	err = s.executeCollection(ctx, collectConfig, collectorConfigs, pool, result)
 	if err != nil {
 		return nil, fmt.Errorf("데이터 수집 실행 실패: %w", err)
 	}
@@ -65,17 +76,38 @@ func (s *CollectService) Execute(ctx context.Context, collectConfig *models.Coll
 
 // initializeCollectionResult는 수집 결과를 초기화합니다. (SRP: 초기화 전용)
 func (s *CollectService) initializeCollectionResult(collectConfig *models.CollectionConfig) *models.CollectionResult {
-	return &models.CollectionResult{
+	result := &models.CollectionResult{
 		Sources:     collectConfig.Sources,
 		CollectedAt: time.Now(),
 		Sessions:    make([]models.SessionData, 0),
 		Errors:      make([]string, 0),
 	}
+	if collectConfig.CaptureEnv {
+		result.Metadata = CaptureEnvironment(collectConfig.ConfigPath)
+	}
+	return result
 }
 
-// prepareCollectorConfigs는 컬렉터 설정을 준비합니다. (SRP: 설정 준비 전용)
-func (s *CollectService) prepareCollectorConfigs() (map[models.CollectionSource]interface{}, error) {
-	return s.getCollectorConfigs()
+// prepareCollectorConfigs는 요청된 소스의 컬렉터 설정만 준비합니다. (SRP: 설정 준비 전용)
+func (s *CollectService) prepareCollectorConfigs(sources []models.CollectionSource) (map[models.CollectionSource]interface{}, error) {
+	if s.config == nil {
+		return nil, fmt.Errorf("설정이 없습니다")
+	}
+
+	// 소스가 지정되지 않은 경우 기본 도구 전체
+	if len(sources) == 0 {
+		sources = []models.CollectionSource{models.SourceClaudeCode, models.SourceGeminiCLI, models.SourceAmazonQ}
+	}
+
+	// 요청된 도구의 설정만 만들어 요청되지 않은 도구의 설정은 건드리지 않음
+	configs := make(map[models.CollectionSource]interface{}, len(sources))
+	for _, source := range sources {
+		if cfg, ok := s.collectorConfig(source); ok {
+			configs[source] = cfg
+		}
+	}
+
+	return configs, nil
 }
 
 // executeCollection은 실제 데이터 수집을 실행합니다. (SRP: 수집 실행 전용)
@@ -83,6 +115,7 @@ func (s *CollectService) executeCollection(
 	ctx context.Context, 
 	collectConfig *models.CollectionConfig,
 	collectorConfigs map[models.CollectionSource]interface{},
+	pool collector.WorkerLimiter,
 	result *models.CollectionResult) error {
 	
 	for _, source := range collectConfig.Sources {
@@ -92,13 +125,71 @@ func (s *CollectService) executeCollection(
 		}
 
 		// 소스별 수집 및 에러 처리 (SRP: 수집과 에러 처리 책임 분리)
-		sessions, err := s.collectFromSource(ctx, source, collectConfig, collectorConfigs)
+		sessions, reports, err := s.collectFromSource(ctx, source, collectConfig, collectorConfigs, pool)
+		sessions = s.filterExcludedKeywords(sessions, collectConfig.ExcludeKeywords)
+		if collectConfig.MergeConversations {
+			sessions = mergeConversations(sessions)
+		}
 		s.handleCollectionResult(source, sessions, err, result)
+		for _, issue := range rejectedLineIssues(source, reports.rejected) {
+			result.AddIssue(issue)
+		}
+		result.ScanStats = append(result.ScanStats, reports.scanStats...)
 	}
 	
 	return nil
 }
 
+// filterExcludedKeywords는 제외 키워드가 포함된 메시지가 있는 세션을 제거합니다. (대소문자 무시)
+func (s *CollectService) filterExcludedKeywords(sessions []models.SessionData, keywords []string) []models.SessionData {
+	lowered := make([]string, 0, len(keywords))
+	for _, keyword := range keywords {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			lowered = append(lowered, strings.ToLower(keyword))
+		}
+	}
+	if len(lowered) == 0 || len(sessions) == 0 {
+		return sessions
+	}
+
+	filtered := make([]models.SessionData, 0, len(sessions))
+	for _, session := range sessions {
+		if !sessionContainsKeyword(session, lowered) {
+			filtered = append(filtered, session)
+		}
+	}
+	return filtered
+}
+
+// sessionContainsKeyword는 세션의 메시지 중 하나라도 키워드를 포함하는지 확인합니다.
+func sessionContainsKeyword(session models.SessionData, loweredKeywords []string) bool {
+	for _, message := range session.Messages {
+		content := strings.ToLower(message.Content)
+		for _, keyword := range loweredKeywords {
+			if strings.Contains(content, keyword) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resolveWorkerPool은 이번 수집에 사용할 공유 워커 풀을 결정합니다. (주입된 풀 우선)
+// ReadRate가 설정되면 초당 파일 읽기 수 제한을 함께 적용합니다.
+func (s *CollectService) resolveWorkerPool(collectConfig *models.CollectionConfig) collector.WorkerLimiter {
+	var pool collector.WorkerLimiter
+	if s.workerPool != nil {
+		pool = s.workerPool
+	} else if collectConfig.Workers > 0 {
+		pool = collector.NewWorkerPool(collectConfig.Workers)
+	}
+
+	if collectConfig.ReadRate > 0 {
+		return collector.NewRateLimiter(pool, collectConfig.ReadRate)
+	}
+	return pool
+}
+
 // checkContextCancellation은 컨텍스트 취소를 확인합니다. (SRP: 취소 확인 전용)
 func (s *CollectService) checkContextCancellation(ctx context.Context) error {
 	select {
@@ -117,8 +208,12 @@ func (s *CollectService) handleCollectionResult(
 	result *models.CollectionResult) {
 	
 	if err != nil {
-		errMsg := fmt.Sprintf("소스 '%s' 수집 실패: %v", source, err)
-		result.Errors = append(result.Errors, errMsg)
+		result.AddIssue(models.CollectionIssue{
+			Source:   source,
+			Phase:    models.IssuePhaseCollect,
+			Severity: models.IssueSeverityError,
+			Message:  fmt.Sprintf("소스 '%s' 수집 실패: %v", source, err),
+		})
 		return
 	}
 	
@@ -129,28 +224,78 @@ func (s *CollectService) handleCollectionResult(
 func (s *CollectService) finalizeCollectionResult(result *models.CollectionResult) {
 	result.TotalCount = len(result.Sessions)
 	result.Duration = time.Since(result.CollectedAt)
+	result.UpdateFallback()
+}
+
+// collectorReports는 collector가 수집과 함께 보고한 진단 정보입니다.
+type collectorReports struct {
+	rejected  []collector.RejectedLines
+	scanStats []models.DirScanStats
 }
 
 // collectFromSource는 특정 소스에서 데이터를 수집합니다.
-func (s *CollectService) collectFromSource(ctx context.Context, source models.CollectionSource, collectConfig *models.CollectionConfig, configs map[models.CollectionSource]interface{}) ([]models.SessionData, error) {
+// 거부된 라인 보고는 collector가 RejectionReporter를 구현하고 ReportRejected가 설정된 경우에만 반환됩니다.
+// 디렉토리 스캔 결과는 collector가 ScanReporter를 구현하면 항상 반환됩니다.
+func (s *CollectService) collectFromSource(ctx context.Context, source models.CollectionSource, collectConfig *models.CollectionConfig, configs map[models.CollectionSource]interface{}, pool collector.WorkerLimiter) ([]models.SessionData, collectorReports, error) {
+	var reports collectorReports
+
 	// 팩토리를 통해 Collector 가져오기
 	collectorConfig, exists := configs[source]
 	if !exists {
-		return nil, fmt.Errorf("소스 '%s'에 대한 설정이 없습니다", source)
+		return nil, reports, fmt.Errorf("소스 '%s'에 대한 설정이 없습니다", source)
 	}
 
 	c, err := collector.GetCollector(source, collectorConfig)
 	if err != nil {
-		return nil, fmt.Errorf("collector 생성 실패: %w", err)
+		return nil, reports, fmt.Errorf("collector 생성 실패: %w", err)
+	}
+
+	// 공유 워커 풀 주입
+	if aware, ok := c.(collector.WorkerPoolAware); ok && pool != nil {
+		aware.SetWorkerPool(pool)
+	}
+
+	// 파일 시스템 재시도 정책 주입 (--fs-retries)
+	if aware, ok := c.(collector.RetryPolicyAware); ok && collectConfig.FSRetries > 0 {
+		aware.SetRetryPolicy(collector.NewRetryPolicy(collectConfig.FSRetries))
 	}
 
 	// 데이터 수집
 	sessions, err := c.Collect(ctx, collectConfig)
 	if err != nil {
-		return nil, fmt.Errorf("데이터 수집 실패: %w", err)
+		return nil, reports, fmt.Errorf("데이터 수집 실패: %w", err)
 	}
 
-	return sessions, nil
+	// 거부된 라인 보고 (--report-rejected)
+	if reporter, ok := c.(collector.RejectionReporter); ok && collectConfig.ReportRejected {
+		reports.rejected = reporter.RejectedLines()
+	}
+
+	// 세션 디렉토리 스캔 결과
+	if reporter, ok := c.(collector.ScanReporter); ok {
+		reports.scanStats = reporter.ScanStats()
+	}
+
+	return sessions, reports, nil
+}
+
+// rejectedLineIssues는 거부된 라인 보고를 파싱 단계 경고로 변환합니다.
+func rejectedLineIssues(source models.CollectionSource, reports []collector.RejectedLines) []models.CollectionIssue {
+	issues := make([]models.CollectionIssue, 0, len(reports))
+	for _, report := range reports {
+		samples := make([]string, len(report.SampleLines))
+		for i, line := range report.SampleLines {
+			samples[i] = strconv.Itoa(line)
+		}
+		issues = append(issues, models.CollectionIssue{
+			Source:   source,
+			Phase:    models.IssuePhaseParse,
+			Severity: models.IssueSeverityWarning,
+			Message: fmt.Sprintf("소스 '%s': %s에서 %d개 라인을 건너뛰었습니다 (라인 %s)",
+				source, report.File, report.Count, strings.Join(samples, ", ")),
+		})
+	}
+	return issues
 }
 
 // ProcessAndExport는 수집된 데이터를 처리하고 내보냅니다.
@@ -189,17 +334,18 @@ func (s *CollectService) ValidateConfig() error {
 	return nil
 }
 
-// getCollectorConfigs는 설정에서 컬렉터 설정을 추출합니다.
-func (s *CollectService) getCollectorConfigs() (map[models.CollectionSource]interface{}, error) {
-	if s.config == nil {
-		return nil, fmt.Errorf("설정이 없습니다")
+// collectorConfig는 설정에서 source 컬렉터의 설정을 꺼냅니다. (설정 항목이 없는 소스는 false)
+func (s *CollectService) collectorConfig(source models.CollectionSource) (interface{}, bool) {
+	switch source {
+	case models.SourceClaudeCode:
+		return s.config.CollectionSettings.ClaudeCode, true
+	case models.SourceGeminiCLI:
+		return s.config.CollectionSettings.GeminiCLI, true
+	case models.SourceAmazonQ:
+		return s.config.CollectionSettings.AmazonQ, true
+	default:
+		return nil, false
 	}
-	
-	return map[models.CollectionSource]interface{}{
-		models.SourceClaudeCode: s.config.CollectionSettings.ClaudeCode,
-		models.SourceGeminiCLI:  s.config.CollectionSettings.GeminiCLI,
-		models.SourceAmazonQ:    s.config.CollectionSettings.AmazonQ,
-	}, nil
 }
 
 // GetSupportedSources는 지원하는 모든 소스를 반환합니다.
@@ -0,0 +1,468 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"ssamai/internal/collector"
+	"ssamai/internal/config"
+	"ssamai/pkg/models"
+)
+
+// stubCollector는 테스트용 collector
+type stubCollector struct {
+	source   models.CollectionSource
+	sessions []models.SessionData
+}
+
+func (c *stubCollector) Collect(ctx context.Context, cfg *models.CollectionConfig) ([]models.SessionData, error) {
+	if c.sessions != nil {
+		return c.sessions, nil
+	}
+	return []models.SessionData{{ID: string(c.source) + "-1", Source: c.source}}, nil
+}
+
+func (c *stubCollector) GetSource() models.CollectionSource { return c.source }
+func (c *stubCollector) Validate() error                    { return nil }
+func (c *stubCollector) GetSupportedFormats() []string      { return []string{"json"} }
+
+// registerStubCollectors는 테스트 동안 모든 소스를 생성 횟수를 기록하는 stub으로 교체합니다
+// 테스트가 끝나면 전역 수집기 등록 상태를 테스트 전으로 되돌립니다
+func registerStubCollectors(t *testing.T) map[models.CollectionSource]int {
+	t.Helper()
+	t.Cleanup(collector.SnapshotRegistry())
+
+	created := make(map[models.CollectionSource]int)
+	for _, source := range []models.CollectionSource{
+		models.SourceClaudeCode,
+		models.SourceGeminiCLI,
+		models.SourceAmazonQ,
+	} {
+		source := source
+		collector.Register(source, func(interface{}) models.Collector {
+			created[source]++
+			return &stubCollector{source: source}
+		})
+	}
+	return created
+}
+
+func TestCollectService_prepareCollectorConfigs_FiltersBySources(t *testing.T) {
+	s := NewCollectService(nil, nil, nil, nil, &config.Config{})
+
+	configs, err := s.prepareCollectorConfigs([]models.CollectionSource{models.SourceGeminiCLI})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(configs) != 1 {
+		t.Fatalf("expected 1 config, got %d", len(configs))
+	}
+	if _, ok := configs[models.SourceGeminiCLI]; !ok {
+		t.Error("expected gemini config to be prepared")
+	}
+
+	// 설정 항목이 없는 소스는 설정을 만들지 않음
+	configs, err = s.prepareCollectorConfigs([]models.CollectionSource{"codex"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(configs) != 0 {
+		t.Errorf("expected no configs for unknown source, got %v", configs)
+	}
+}
+
+func TestCollectService_prepareCollectorConfigs_EmptySources(t *testing.T) {
+	s := NewCollectService(nil, nil, nil, nil, &config.Config{})
+
+	configs, err := s.prepareCollectorConfigs(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(configs) != 3 {
+		t.Errorf("expected all 3 configs, got %d", len(configs))
+	}
+}
+
+func TestCollectService_Execute_OnlyRequestedSource(t *testing.T) {
+	created := registerStubCollectors(t)
+	s := NewCollectService(nil, nil, nil, nil, &config.Config{})
+
+	result, err := s.Execute(context.Background(), &models.CollectionConfig{
+		Sources: []models.CollectionSource{models.SourceGeminiCLI},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if created[models.SourceGeminiCLI] != 1 {
+		t.Errorf("expected gemini collector to be created once, got %d", created[models.SourceGeminiCLI])
+	}
+	if created[models.SourceClaudeCode] != 0 || created[models.SourceAmazonQ] != 0 {
+		t.Errorf("expected unrelated collectors not to be created, got %v", created)
+	}
+	if result.TotalCount != 1 {
+		t.Errorf("expected 1 session, got %d", result.TotalCount)
+	}
+}
+
+func TestCollectService_Execute_CaptureEnv(t *testing.T) {
+	registerStubCollectors(t)
+	s := NewCollectService(nil, nil, nil, nil, &config.Config{})
+
+	// 기본값에서는 환경 정보를 기록하지 않음
+	result, err := s.Execute(context.Background(), &models.CollectionConfig{
+		Sources:    []models.CollectionSource{models.SourceGeminiCLI},
+		ConfigPath: "configs/config.yaml",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Metadata != nil {
+		t.Errorf("expected no environment metadata by default, got %v", result.Metadata)
+	}
+
+	result, err = s.Execute(context.Background(), &models.CollectionConfig{
+		Sources:    []models.CollectionSource{models.SourceGeminiCLI},
+		CaptureEnv: true,
+		ConfigPath: "configs/config.yaml",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, key := range []string{EnvMetadataOS, EnvMetadataGoVersion, EnvMetadataToolVersion} {
+		if result.Metadata[key] == "" {
+			t.Errorf("expected %s metadata to be captured, got %v", key, result.Metadata)
+		}
+	}
+	if !strings.HasSuffix(result.Metadata[EnvMetadataConfigPath], filepath.Join("configs", "config.yaml")) {
+		t.Errorf("expected config path metadata, got %q", result.Metadata[EnvMetadataConfigPath])
+	}
+}
+
+func TestCollectService_Execute_ExcludeKeywords(t *testing.T) {
+	registerStubCollectors(t)
+	collector.Register(models.SourceGeminiCLI, func(interface{}) models.Collector {
+		return &stubCollector{
+			source: models.SourceGeminiCLI,
+			sessions: []models.SessionData{
+				{ID: "keep", Source: models.SourceGeminiCLI, Messages: []models.Message{{Content: "public question"}}},
+				{ID: "drop", Source: models.SourceGeminiCLI, Messages: []models.Message{{Content: "hello"}, {Content: "my SECRET token"}}},
+			},
+		}
+	})
+	s := NewCollectService(nil, nil, nil, nil, &config.Config{})
+
+	result, err := s.Execute(context.Background(), &models.CollectionConfig{
+		Sources:         []models.CollectionSource{models.SourceGeminiCLI},
+		ExcludeKeywords: []string{"secret"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.TotalCount != 1 {
+		t.Fatalf("expected 1 session after filtering, got %d", result.TotalCount)
+	}
+	if result.Sessions[0].ID != "keep" {
+		t.Errorf("expected session 'keep', got %s", result.Sessions[0].ID)
+	}
+}
+
+func TestCollectService_Execute_IsFallback(t *testing.T) {
+	fallback := models.SessionData{ID: "gemini-session-fallback", Metadata: map[string]string{"fallback": "true"}}
+	dummy := models.SessionData{ID: "amazonq-dummy-1", Metadata: map[string]string{"source_type": "amazon_q_dummy"}}
+	real := models.SessionData{ID: "gemini-1"}
+
+	tests := []struct {
+		name     string
+		sessions map[models.CollectionSource][]models.SessionData
+		expected bool
+	}{
+		{
+			name: "모든 소스가 대체 데이터",
+			sessions: map[models.CollectionSource][]models.SessionData{
+				models.SourceGeminiCLI: {fallback},
+				models.SourceAmazonQ:   {dummy},
+			},
+			expected: true,
+		},
+		{
+			name: "한 소스라도 실제 데이터",
+			sessions: map[models.CollectionSource][]models.SessionData{
+				models.SourceGeminiCLI: {real},
+				models.SourceAmazonQ:   {dummy},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registerStubCollectors(t)
+			var sources []models.CollectionSource
+			for source, sessions := range tt.sessions {
+				source, sessions := source, sessions
+				sources = append(sources, source)
+				collector.Register(source, func(interface{}) models.Collector {
+					return &stubCollector{source: source, sessions: sessions}
+				})
+			}
+
+			s := NewCollectService(nil, nil, nil, nil, &config.Config{})
+			result, err := s.Execute(context.Background(), &models.CollectionConfig{Sources: sources})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.IsFallback != tt.expected {
+				t.Errorf("expected IsFallback=%v, got %v", tt.expected, result.IsFallback)
+			}
+		})
+	}
+}
+
+func TestCollectService_filterExcludedKeywords(t *testing.T) {
+	s := NewCollectService(nil, nil, nil, nil, &config.Config{})
+	sessions := []models.SessionData{
+		{ID: "a", Messages: []models.Message{{Content: "Password is hunter2"}}},
+		{ID: "b", Messages: []models.Message{{Content: "nothing here"}}},
+	}
+
+	if got := s.filterExcludedKeywords(sessions, nil); len(got) != 2 {
+		t.Errorf("expected no filtering without keywords, got %d sessions", len(got))
+	}
+	if got := s.filterExcludedKeywords(sessions, []string{"  "}); len(got) != 2 {
+		t.Errorf("expected blank keywords to be ignored, got %d sessions", len(got))
+	}
+
+	got := s.filterExcludedKeywords(sessions, []string{"PASSWORD"})
+	if len(got) != 1 || got[0].ID != "b" {
+		t.Errorf("expected only session 'b' to remain, got %v", got)
+	}
+}
+
+// poolAwareStub은 워커 풀 주입 여부를 기록하는 stub collector
+type poolAwareStub struct {
+	stubCollector
+	pool collector.WorkerLimiter
+}
+
+func (c *poolAwareStub) SetWorkerPool(pool collector.WorkerLimiter) { c.pool = pool }
+
+func TestCollectService_Execute_ThreadsWorkerPool(t *testing.T) {
+	registerStubCollectors(t)
+	var created []*poolAwareStub
+	collector.Register(models.SourceGeminiCLI, func(interface{}) models.Collector {
+		c := &poolAwareStub{stubCollector: stubCollector{source: models.SourceGeminiCLI}}
+		created = append(created, c)
+		return c
+	})
+
+	// 수집 설정의 Workers로 풀 생성
+	s := NewCollectService(nil, nil, nil, nil, &config.Config{})
+	_, err := s.Execute(context.Background(), &models.CollectionConfig{
+		Sources: []models.CollectionSource{models.SourceGeminiCLI},
+		Workers: 2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(created) != 1 || created[0].pool == nil {
+		t.Fatalf("expected worker pool to be injected into collector")
+	}
+	if pool, ok := created[0].pool.(*collector.WorkerPool); !ok || pool.Size() != 2 {
+		t.Errorf("expected worker pool of size 2, got %#v", created[0].pool)
+	}
+
+	// 주입된 풀이 우선
+	injected := collector.NewWorkerPool(5)
+	_, err = s.WithWorkerPool(injected).Execute(context.Background(), &models.CollectionConfig{
+		Sources: []models.CollectionSource{models.SourceGeminiCLI},
+		Workers: 2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created[1].pool != injected {
+		t.Errorf("expected injected worker pool to be used")
+	}
+}
+
+// retryAwareStub은 재시도 정책 주입 여부를 기록하는 stub collector
+type retryAwareStub struct {
+	stubCollector
+	policy *collector.RetryPolicy
+}
+
+func (c *retryAwareStub) SetRetryPolicy(policy *collector.RetryPolicy) { c.policy = policy }
+
+func TestCollectService_Execute_ThreadsRetryPolicy(t *testing.T) {
+	registerStubCollectors(t)
+	var created []*retryAwareStub
+	collector.Register(models.SourceGeminiCLI, func(interface{}) models.Collector {
+		c := &retryAwareStub{stubCollector: stubCollector{source: models.SourceGeminiCLI}}
+		created = append(created, c)
+		return c
+	})
+
+	s := NewCollectService(nil, nil, nil, nil, &config.Config{})
+	for _, retries := range []int{0, 3} {
+		_, err := s.Execute(context.Background(), &models.CollectionConfig{
+			Sources:   []models.CollectionSource{models.SourceGeminiCLI},
+			FSRetries: retries,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(created) != 2 {
+		t.Fatalf("expected 2 collectors, got %d", len(created))
+	}
+	if created[0].policy != nil {
+		t.Errorf("expected no retry policy without --fs-retries, got %#v", created[0].policy)
+	}
+	if created[1].policy == nil || created[1].policy.Retries != 3 {
+		t.Errorf("expected retry policy with 3 retries, got %#v", created[1].policy)
+	}
+}
+
+func TestCollectService_resolveWorkerPool_ReadRate(t *testing.T) {
+	s := NewCollectService(nil, nil, nil, nil, &config.Config{})
+
+	if pool := s.resolveWorkerPool(&models.CollectionConfig{}); pool != nil {
+		t.Errorf("expected no limiter without workers or read rate, got %#v", pool)
+	}
+
+	pool := s.resolveWorkerPool(&models.CollectionConfig{Workers: 2, ReadRate: 10})
+	if _, ok := pool.(*collector.RateLimiter); !ok {
+		t.Errorf("expected rate limiter when read rate is set, got %#v", pool)
+	}
+}
+
+// rejectingStub은 거부된 라인을 보고하는 테스트용 collector
+type rejectingStub struct {
+	stubCollector
+}
+
+func (c *rejectingStub) RejectedLines() []collector.RejectedLines {
+	return []collector.RejectedLines{{File: "/tmp/history.jsonl", Count: 7, SampleLines: []int{2, 3, 4, 5, 6}}}
+}
+
+func TestCollectService_Execute_ReportRejected(t *testing.T) {
+	registerStubCollectors(t)
+	collector.Register(models.SourceGeminiCLI, func(interface{}) models.Collector {
+		return &rejectingStub{stubCollector: stubCollector{source: models.SourceGeminiCLI}}
+	})
+
+	s := NewCollectService(nil, nil, nil, nil, &config.Config{})
+	result, err := s.Execute(context.Background(), &models.CollectionConfig{
+		Sources:        []models.CollectionSource{models.SourceGeminiCLI},
+		ReportRejected: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "소스 'gemini_cli': /tmp/history.jsonl에서 7개 라인을 건너뛰었습니다 (라인 2, 3, 4, 5, 6)"
+	if len(result.Errors) != 1 || result.Errors[0] != want {
+		t.Errorf("expected rejection report %q, got %v", want, result.Errors)
+	}
+	if len(result.Issues) != 1 {
+		t.Fatalf("expected 1 structured issue, got %v", result.Issues)
+	}
+	if issue := result.Issues[0]; issue.Source != models.SourceGeminiCLI ||
+		issue.Phase != models.IssuePhaseParse || issue.Severity != models.IssueSeverityWarning {
+		t.Errorf("expected gemini parse warning, got %+v", issue)
+	}
+
+	result, err = s.Execute(context.Background(), &models.CollectionConfig{
+		Sources: []models.CollectionSource{models.SourceGeminiCLI},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("expected no errors without ReportRejected, got %v", result.Errors)
+	}
+}
+
+// scanningStub은 세션 디렉토리 스캔 결과를 보고하는 테스트용 collector
+type scanningStub struct {
+	stubCollector
+}
+
+func (c *scanningStub) ScanStats() []models.DirScanStats {
+	return []models.DirScanStats{{Source: c.source, Dir: "/tmp/sessions", FilesScanned: 3}}
+}
+
+func TestCollectService_Execute_ScanStats(t *testing.T) {
+	registerStubCollectors(t)
+	collector.Register(models.SourceGeminiCLI, func(interface{}) models.Collector {
+		return &scanningStub{stubCollector: stubCollector{source: models.SourceGeminiCLI}}
+	})
+
+	s := NewCollectService(nil, nil, nil, nil, &config.Config{})
+	result, err := s.Execute(context.Background(), &models.CollectionConfig{
+		Sources: []models.CollectionSource{models.SourceGeminiCLI, models.SourceClaudeCode},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []models.DirScanStats{{Source: models.SourceGeminiCLI, Dir: "/tmp/sessions", FilesScanned: 3}}
+	if !reflect.DeepEqual(result.ScanStats, want) {
+		t.Errorf("expected scan stats %+v, got %+v", want, result.ScanStats)
+	}
+	// 스캔 진단은 경고가 아니라 별도 필드로만 보고됨
+	if len(result.Errors) != 0 {
+		t.Errorf("expected no errors, got %v", result.Errors)
+	}
+}
+
+// failingStub은 항상 수집에 실패하는 테스트용 collector
+type failingStub struct {
+	stubCollector
+}
+
+func (c *failingStub) Collect(ctx context.Context, cfg *models.CollectionConfig) ([]models.SessionData, error) {
+	return nil, errors.New("history not readable")
+}
+
+func TestCollectService_Execute_RecordsCollectIssue(t *testing.T) {
+	registerStubCollectors(t)
+	collector.Register(models.SourceAmazonQ, func(interface{}) models.Collector {
+		return &failingStub{stubCollector: stubCollector{source: models.SourceAmazonQ}}
+	})
+
+	s := NewCollectService(nil, nil, nil, nil, &config.Config{})
+	result, err := s.Execute(context.Background(), &models.CollectionConfig{
+		Sources: []models.CollectionSource{models.SourceClaudeCode, models.SourceAmazonQ},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Issues) != 1 {
+		t.Fatalf("expected 1 structured issue, got %v", result.Issues)
+	}
+	issue := result.Issues[0]
+	if issue.Source != models.SourceAmazonQ {
+		t.Errorf("expected issue source %q, got %q", models.SourceAmazonQ, issue.Source)
+	}
+	if issue.Phase != models.IssuePhaseCollect || issue.Severity != models.IssueSeverityError {
+		t.Errorf("expected collect error, got phase=%q severity=%q", issue.Phase, issue.Severity)
+	}
+	if !strings.Contains(issue.Message, "history not readable") {
+		t.Errorf("expected message to include cause, got %q", issue.Message)
+	}
+
+	// 이전 형식의 문자열 목록도 함께 채워져야 함
+	if got := result.ErrorMessages(); len(got) != 1 || got[0] != issue.Message || result.Errors[0] != issue.Message {
+		t.Errorf("expected legacy errors to mirror issue message, got %v / %v", got, result.Errors)
+	}
+}
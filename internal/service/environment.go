@@ -0,0 +1,49 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+)
+
+// 수집 환경 메타데이터 키
+const (
+	EnvMetadataHost        = "host"
+	EnvMetadataOS          = "os"
+	EnvMetadataGoVersion   = "go_version"
+	EnvMetadataToolVersion = "tool_version"
+	EnvMetadataConfigPath  = "config_path"
+)
+
+// CaptureEnvironment는 결과 비교를 위해 수집을 실행한 환경 정보를 반환합니다
+// 호스트 이름 등 민감할 수 있는 정보가 포함되므로 --capture-env를 지정한 경우에만 사용합니다
+func CaptureEnvironment(configPath string) map[string]string {
+	metadata := map[string]string{
+		EnvMetadataOS:          runtime.GOOS + "/" + runtime.GOARCH,
+		EnvMetadataGoVersion:   runtime.Version(),
+		EnvMetadataToolVersion: toolVersion(),
+	}
+
+	if host, err := os.Hostname(); err == nil && host != "" {
+		metadata[EnvMetadataHost] = host
+	}
+
+	if configPath != "" {
+		if absPath, err := filepath.Abs(configPath); err == nil {
+			configPath = absPath
+		}
+		metadata[EnvMetadataConfigPath] = configPath
+	}
+
+	return metadata
+}
+
+// toolVersion은 빌드 정보에 기록된 모듈 버전을 반환합니다 (없으면 "devel")
+func toolVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Version == "" || info.Main.Version == "(devel)" {
+		return "devel"
+	}
+	return info.Main.Version
+}
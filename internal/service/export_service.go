@@ -33,6 +33,10 @@ func (s *ExportService) ExportFromFile(ctx context.Context, inputPath, outputPat
 		return fmt.Errorf("데이터 로드 실패: %w", err)
 	}
 
+	if err := exportConfig.CheckMinSessions(len(data.Sessions)); err != nil {
+		return err
+	}
+
 	// 데이터 처리
 	if s.processor != nil {
 		processedData, err := s.processor.Process(ctx, data.Sessions)
@@ -56,6 +60,10 @@ func (s *ExportService) ExportFromFile(ctx context.Context, inputPath, outputPat
 
 // ExportFromResult는 수집 결과를 직접 내보냅니다.
 func (s *ExportService) ExportFromResult(ctx context.Context, result *models.CollectionResult, exportConfig *models.ExportConfig) error {
+	if err := exportConfig.CheckMinSessions(len(result.Sessions)); err != nil {
+		return err
+	}
+
 	// 데이터 처리
 	if s.processor != nil {
 		processedData, err := s.processor.Process(ctx, result.Sessions)
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"ssamai/pkg/models"
+)
+
+// conversationIDKey는 대화 단위를 식별하는 세션 메타데이터 키입니다
+const conversationIDKey = "conversation_id"
+
+// mergeConversations는 같은 소스에서 conversation_id가 같은 세션들을 하나로 합칩니다.
+// 합친 세션은 가장 이른 세션의 ID와 타임스탬프를 사용하고, 메시지는 타임스탬프 순으로 정렬합니다.
+// 역할, 내용, 타임스탬프가 모두 같은 메시지는 히스토리와 세션 파일의 중복으로 보고 한 번만 남기며,
+// 중복 메시지의 메타데이터는 남긴 메시지에 병합합니다.
+// conversation_id가 없는 세션은 그대로 둡니다.
+func mergeConversations(sessions []models.SessionData) []models.SessionData {
+	type groupKey struct {
+		source         models.CollectionSource
+		conversationID string
+	}
+
+	groups := make(map[groupKey][]int)
+	for i, session := range sessions {
+		if id := session.Metadata[conversationIDKey]; id != "" {
+			key := groupKey{source: session.Source, conversationID: id}
+			groups[key] = append(groups[key], i)
+		}
+	}
+
+	merged := make([]models.SessionData, 0, len(sessions))
+	for i, session := range sessions {
+		id := session.Metadata[conversationIDKey]
+		if id == "" {
+			merged = append(merged, session)
+			continue
+		}
+
+		indexes := groups[groupKey{source: session.Source, conversationID: id}]
+		if indexes[0] != i {
+			// 그룹의 첫 세션 위치에서 한 번만 추가
+			continue
+		}
+		if len(indexes) == 1 {
+			merged = append(merged, session)
+			continue
+		}
+
+		group := make([]models.SessionData, len(indexes))
+		for j, index := range indexes {
+			group[j] = sessions[index]
+		}
+		merged = append(merged, mergeSessionGroup(group))
+	}
+
+	return merged
+}
+
+// mergeSessionGroup은 같은 대화에 속한 세션들을 하나의 세션으로 합칩니다.
+func mergeSessionGroup(group []models.SessionData) models.SessionData {
+	sort.SliceStable(group, func(a, b int) bool {
+		return group[a].Timestamp.Before(group[b].Timestamp)
+	})
+
+	combined := group[0]
+	combined.Metadata = nil
+	combined.Messages = nil
+	combined.Files = nil
+	combined.Commands = nil
+
+	type messageKey struct {
+		role, content string
+		unixNano      int64
+	}
+	seen := make(map[messageKey]int) // 이미 추가한 메시지의 combined.Messages 위치
+	ids := make([]string, 0, len(group))
+
+	for _, session := range group {
+		ids = append(ids, session.ID)
+		if combined.Title == "" {
+			combined.Title = session.Title
+		}
+
+		// 앞선 세션의 메타데이터 값을 우선하고, 충돌한 값은 merged_<key>로 보존
+		combined.Metadata = models.MergeMetadata(combined.Metadata, session.Metadata)
+
+		for _, message := range session.Messages {
+			key := messageKey{role: message.Role, content: message.Content, unixNano: message.Timestamp.UnixNano()}
+			if index, exists := seen[key]; exists {
+				kept := &combined.Messages[index]
+				kept.Metadata = models.MergeMetadata(kept.Metadata, message.Metadata)
+				continue
+			}
+			seen[key] = len(combined.Messages)
+			combined.Messages = append(combined.Messages, message)
+		}
+		combined.Files = append(combined.Files, session.Files...)
+		combined.Commands = append(combined.Commands, session.Commands...)
+	}
+
+	sort.SliceStable(combined.Messages, func(a, b int) bool {
+		return combined.Messages[a].Timestamp.Before(combined.Messages[b].Timestamp)
+	})
+	if combined.Metadata == nil {
+		combined.Metadata = make(map[string]string, 1)
+	}
+	combined.Metadata["merged_sessions"] = strings.Join(ids, ",")
+
+	return combined
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ssamai/internal/collector"
+	"ssamai/internal/config"
+	"ssamai/pkg/models"
+)
+
+func conversationSession(id, conversationID string, source models.CollectionSource, start time.Time, messages ...models.Message) models.SessionData {
+	metadata := map[string]string{"source_type": string(source) + "_" + id}
+	if conversationID != "" {
+		metadata["conversation_id"] = conversationID
+	}
+	return models.SessionData{ID: id, Source: source, Timestamp: start, Messages: messages, Metadata: metadata}
+}
+
+func TestMergeConversations(t *testing.T) {
+	base := time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return base.Add(time.Duration(seconds) * time.Second) }
+
+	sessions := []models.SessionData{
+		// 히스토리 항목 (뒤쪽 메시지)
+		conversationSession("history-1", "conv-a", models.SourceAmazonQ, at(60),
+			models.Message{Role: "user", Content: "follow-up", Timestamp: at(60)},
+			models.Message{Role: "assistant", Content: "answer 2", Timestamp: at(65)},
+		),
+		conversationSession("standalone", "", models.SourceAmazonQ, at(30)),
+		// 세션 파일 (앞쪽 메시지, 히스토리와 한 메시지 중복)
+		conversationSession("session-file", "conv-a", models.SourceAmazonQ, at(0),
+			models.Message{Role: "user", Content: "question", Timestamp: at(0)},
+			models.Message{Role: "assistant", Content: "answer 1", Timestamp: at(5)},
+			models.Message{Role: "user", Content: "follow-up", Timestamp: at(60)},
+		),
+		// 다른 소스의 같은 conversation_id는 병합하지 않음
+		conversationSession("other-source", "conv-a", models.SourceGeminiCLI, at(0)),
+	}
+
+	merged := mergeConversations(sessions)
+	if len(merged) != 3 {
+		t.Fatalf("expected 3 sessions after merge, got %d", len(merged))
+	}
+
+	combined := merged[0]
+	if combined.ID != "session-file" {
+		t.Errorf("expected merged session to keep earliest ID, got %q", combined.ID)
+	}
+	if !combined.Timestamp.Equal(at(0)) {
+		t.Errorf("expected earliest timestamp, got %v", combined.Timestamp)
+	}
+	if combined.Metadata["merged_sessions"] != "session-file,history-1" {
+		t.Errorf("unexpected merged_sessions metadata: %q", combined.Metadata["merged_sessions"])
+	}
+	// 앞선 세션의 source_type을 유지하고 다른 세션의 값도 보존
+	if combined.Metadata["source_type"] != "amazon_q_session-file" || combined.Metadata["merged_source_type"] != "amazon_q_history-1" {
+		t.Errorf("expected source_type provenance to be preserved, got %v", combined.Metadata)
+	}
+
+	want := []string{"question", "answer 1", "follow-up", "answer 2"}
+	if len(combined.Messages) != len(want) {
+		t.Fatalf("expected %d messages, got %+v", len(want), combined.Messages)
+	}
+	for i, content := range want {
+		if combined.Messages[i].Content != content {
+			t.Errorf("message %d: expected %q, got %q", i, content, combined.Messages[i].Content)
+		}
+	}
+
+	if merged[1].ID != "standalone" || merged[2].ID != "other-source" {
+		t.Errorf("expected untouched sessions to keep order, got %q, %q", merged[1].ID, merged[2].ID)
+	}
+	if len(sessions[2].Messages) != 3 {
+		t.Errorf("expected input sessions to be left unchanged")
+	}
+}
+
+func TestMergeConversations_DedupedMessageMetadata(t *testing.T) {
+	at := time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC)
+	fileMeta := map[string]string{"part_index": "0", "service": "q"}
+	sessions := []models.SessionData{
+		conversationSession("session-file", "conv-a", models.SourceAmazonQ, at,
+			models.Message{Role: "assistant", Content: "answer", Timestamp: at, Metadata: fileMeta}),
+		conversationSession("history", "conv-a", models.SourceAmazonQ, at.Add(time.Second),
+			models.Message{Role: "assistant", Content: "answer", Timestamp: at, Metadata: map[string]string{"part_index": "3", "history_line": "12"}}),
+	}
+
+	merged := mergeConversations(sessions)
+	if len(merged) != 1 || len(merged[0].Messages) != 1 {
+		t.Fatalf("expected one session with one deduped message, got %+v", merged)
+	}
+
+	// 중복으로 버린 메시지의 출처 정보도 남긴 메시지에 병합
+	metadata := merged[0].Messages[0].Metadata
+	want := map[string]string{"part_index": "0", "merged_part_index": "3", "service": "q", "history_line": "12"}
+	for key, value := range want {
+		if metadata[key] != value {
+			t.Errorf("metadata[%s] = %q, want %q (got %v)", key, metadata[key], value, metadata)
+		}
+	}
+	if len(fileMeta) != 2 {
+		t.Errorf("expected input metadata to be left unchanged, got %v", fileMeta)
+	}
+}
+
+// conversationStub은 같은 conversation_id를 가진 세션들을 반환하는 테스트용 collector
+type conversationStub struct {
+	stubCollector
+}
+
+func (c *conversationStub) Collect(ctx context.Context, cfg *models.CollectionConfig) ([]models.SessionData, error) {
+	base := time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC)
+	return []models.SessionData{
+		conversationSession("history", "conv-1", c.source, base.Add(time.Minute),
+			models.Message{Role: "user", Content: "second", Timestamp: base.Add(time.Minute)}),
+		conversationSession("file", "conv-1", c.source, base,
+			models.Message{Role: "user", Content: "first", Timestamp: base}),
+	}, nil
+}
+
+func TestCollectService_Execute_MergeConversations(t *testing.T) {
+	registerStubCollectors(t)
+	collector.Register(models.SourceAmazonQ, func(interface{}) models.Collector {
+		return &conversationStub{stubCollector: stubCollector{source: models.SourceAmazonQ}}
+	})
+
+	s := NewCollectService(nil, nil, nil, nil, &config.Config{})
+	cfg := &models.CollectionConfig{Sources: []models.CollectionSource{models.SourceAmazonQ}}
+
+	result, err := s.Execute(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Sessions) != 2 {
+		t.Errorf("expected sessions to stay separate without the option, got %d", len(result.Sessions))
+	}
+
+	cfg.MergeConversations = true
+	result, err = s.Execute(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Sessions) != 1 {
+		t.Fatalf("expected 1 merged session, got %d", len(result.Sessions))
+	}
+	if messages := result.Sessions[0].Messages; len(messages) != 2 || messages[0].Content != "first" {
+		t.Errorf("expected messages ordered by timestamp, got %+v", messages)
+	}
+	if result.TotalCount != 1 {
+		t.Errorf("expected total count 1, got %d", result.TotalCount)
+	}
+}
@@ -42,10 +42,9 @@ func main() {
 		dataProcessor,        // ProcessorValidator 인터페이스 (같은 객체가 여러 인터페이스 구현)
 		markdownExporter,     // ExporterValidator 인터페이스
 		cfg)
-	exportSvc := service.NewExportService(dataProcessor, markdownExporter)
 
 	// 4. 루트 명령어 생성 및 서비스 주입
-	rootCmd := cmd.NewRootCmd(collectSvc, exportSvc)
+	rootCmd := cmd.NewRootCmd(collectSvc)
 
 	// 5. 애플리케이션 실행
 	if err := rootCmd.Execute(); err != nil {
@@ -0,0 +1,35 @@
+//go:build (linux || darwin) && cgo
+
+package models
+
+import (
+	"fmt"
+	"plugin"
+)
+
+// pluginConstructorSymbol은 수집기 플러그인이 내보내야 하는 생성자 심볼 이름입니다
+const pluginConstructorSymbol = "New"
+
+// openCollectorPlugin은 공유 라이브러리를 열고 New 심볼로 수집기 플러그인을 생성합니다
+func openCollectorPlugin(path string) (CollectorPlugin, error) {
+	lib, err := plugin.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	symbol, err := lib.Lookup(pluginConstructorSymbol)
+	if err != nil {
+		return nil, err
+	}
+
+	constructor, ok := symbol.(func() CollectorPlugin)
+	if !ok {
+		return nil, fmt.Errorf("%s 심볼의 타입이 func() models.CollectorPlugin이 아닙니다: %T", pluginConstructorSymbol, symbol)
+	}
+
+	collectorPlugin := constructor()
+	if collectorPlugin == nil {
+		return nil, fmt.Errorf("%s()가 nil 플러그인을 반환했습니다", pluginConstructorSymbol)
+	}
+	return collectorPlugin, nil
+}
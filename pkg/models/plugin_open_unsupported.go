@@ -0,0 +1,13 @@
+//go:build !((linux || darwin) && cgo)
+
+package models
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// openCollectorPlugin은 Go plugin 패키지를 지원하지 않는 플랫폼에서 항상 에러를 반환합니다
+func openCollectorPlugin(path string) (CollectorPlugin, error) {
+	return nil, fmt.Errorf("이 플랫폼(%s/%s)에서는 플러그인을 지원하지 않습니다 (Linux/macOS, cgo 필요)", runtime.GOOS, runtime.GOARCH)
+}
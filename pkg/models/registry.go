@@ -215,21 +215,65 @@ func (f *MarkdownExporterFactory) GetConfigType() interface{} {
 
 // DefaultPluginManager는 기본 플러그인 관리자 구현입니다
 type DefaultPluginManager struct {
-	mu      sync.RWMutex
-	plugins map[string]Plugin
+	mu         sync.RWMutex
+	plugins    map[string]Plugin
+	collectors CollectorRegistry // 로드한 수집기 플러그인의 팩토리를 등록할 레지스트리
+	open       func(path string) (CollectorPlugin, error)
 }
 
 // NewPluginManager는 새로운 플러그인 관리자를 생성합니다
 func NewPluginManager() PluginManager {
+	return NewPluginManagerWithRegistry(NewCollectorRegistry())
+}
+
+// NewPluginManagerWithRegistry는 로드한 수집기를 지정한 레지스트리에 등록하는 플러그인 관리자를 생성합니다
+func NewPluginManagerWithRegistry(collectors CollectorRegistry) PluginManager {
 	return &DefaultPluginManager{
-		plugins: make(map[string]Plugin),
+		plugins:    make(map[string]Plugin),
+		collectors: collectors,
+		open:       openCollectorPlugin,
 	}
 }
 
-// LoadPlugin은 플러그인을 로드합니다
+// LoadPlugin은 공유 라이브러리(.so)에서 수집기 플러그인을 로드합니다
+// 플러그인은 `func New() models.CollectorPlugin` 심볼을 내보내야 하며,
+// 초기화 후 지원하는 소스마다 수집기 팩토리가 레지스트리에 등록됩니다 (Linux/macOS 전용)
 func (pm *DefaultPluginManager) LoadPlugin(path string) (Plugin, error) {
-	// 실제 구현에서는 동적 라이브러리 로딩 로직이 필요
-	return nil, fmt.Errorf("구현 필요: 플러그인 로딩")
+	plugin, err := pm.open(path)
+	if err != nil {
+		return nil, fmt.Errorf("플러그인 로드 실패 (%s): %w", path, err)
+	}
+
+	if err := plugin.Initialize(nil); err != nil {
+		return nil, fmt.Errorf("플러그인 초기화 실패 (%s): %w", plugin.GetName(), err)
+	}
+
+	if err := pm.RegisterPlugin(plugin); err != nil {
+		return nil, err
+	}
+
+	if pm.collectors != nil {
+		for _, source := range plugin.GetSupportedSources() {
+			pm.collectors.Register(source, &pluginCollectorFactory{plugin: plugin})
+		}
+	}
+
+	return plugin, nil
+}
+
+// pluginCollectorFactory는 수집기 플러그인을 CollectorFactory로 감쌉니다
+type pluginCollectorFactory struct {
+	plugin CollectorPlugin
+}
+
+// Create는 플러그인으로 수집기를 생성합니다
+func (f *pluginCollectorFactory) Create(config interface{}) (Collector, error) {
+	return f.plugin.CreateCollector(config)
+}
+
+// GetConfigType은 플러그인 수집기의 설정 타입을 반환합니다 (플러그인이 정의하므로 알 수 없음)
+func (f *pluginCollectorFactory) GetConfigType() interface{} {
+	return nil
 }
 
 // RegisterPlugin은 플러그인을 등록합니다
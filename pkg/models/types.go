@@ -1,6 +1,10 @@
 package models
 
 import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -13,6 +17,45 @@ const (
 	SourceAmazonQ    CollectionSource = "amazon_q"
 )
 
+// sourceDisplayNames는 지원하는 소스별 표시 이름입니다
+// 새 소스를 추가할 때는 이 목록만 갱신하면 됩니다
+var sourceDisplayNames = map[CollectionSource]string{
+	SourceClaudeCode: "Claude Code",
+	SourceGeminiCLI:  "Gemini CLI",
+	SourceAmazonQ:    "Amazon Q",
+}
+
+// ParseSource는 소스 이름(claude_code, gemini_cli, amazon_q)을 CollectionSource로 변환합니다
+func ParseSource(name string) (CollectionSource, error) {
+	source := CollectionSource(name)
+	if _, ok := sourceDisplayNames[source]; !ok {
+		return "", fmt.Errorf("알 수 없는 데이터 소스: %s", name)
+	}
+	return source, nil
+}
+
+// defaultSourceOrder는 소스 섹션의 기본 출력 순서입니다
+var defaultSourceOrder = []CollectionSource{SourceClaudeCode, SourceGeminiCLI, SourceAmazonQ}
+
+// SectionOrder는 custom 순서의 소스 뒤에 custom에 없는 알려진 소스를 기본 순서로 이어 붙인 출력 순서를 반환합니다
+func SectionOrder(custom []CollectionSource) []CollectionSource {
+	order := append([]CollectionSource(nil), custom...)
+	for _, source := range defaultSourceOrder {
+		if !slices.Contains(custom, source) {
+			order = append(order, source)
+		}
+	}
+	return order
+}
+
+// DisplayName은 사람이 읽기 좋은 소스 이름을 반환합니다 (알 수 없는 소스는 원래 값)
+func (s CollectionSource) DisplayName() string {
+	if name, ok := sourceDisplayNames[s]; ok {
+		return name
+	}
+	return string(s)
+}
+
 // SessionData는 AI 도구의 세션 데이터를 나타냅니다
 type SessionData struct {
 	ID          string            `json:"id" yaml:"id"`
@@ -25,13 +68,68 @@ type SessionData struct {
 	Commands    []Command         `json:"commands,omitempty" yaml:"commands,omitempty"`
 }
 
+// FallbackMetadataKey는 실제 데이터 대신 만든 대체(더미) 세션에 "true"로 기록되는 메타데이터 키입니다
+const FallbackMetadataKey = "fallback"
+
+// IsFallback은 세션이 실제 수집 데이터가 아닌 대체(더미) 세션인지 확인합니다
+// fallback 메타데이터 또는 "_dummy"로 끝나는 source_type으로 판단합니다
+func (s SessionData) IsFallback() bool {
+	return s.Metadata[FallbackMetadataKey] == "true" || strings.HasSuffix(s.Metadata["source_type"], "_dummy")
+}
+
 // Message는 대화 메시지를 나타냅니다
 type Message struct {
-	ID        string            `json:"id" yaml:"id"`
-	Role      string            `json:"role" yaml:"role"` // user, assistant, system
-	Content   string            `json:"content" yaml:"content"`
-	Timestamp time.Time         `json:"timestamp" yaml:"timestamp"`
-	Metadata  map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	ID          string            `json:"id" yaml:"id"`
+	Role        string            `json:"role" yaml:"role"` // user, assistant, system
+	Content     string            `json:"content" yaml:"content"`
+	Timestamp   time.Time         `json:"timestamp" yaml:"timestamp"`
+	Sequence    *int              `json:"sequence,omitempty" yaml:"sequence,omitempty"` // 제공자가 명시한 메시지 순번 (seq/index)
+	Metadata    map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	Attachments []FileReference   `json:"attachments,omitempty" yaml:"attachments,omitempty"` // 메시지에 첨부된 파일 (스크린샷, 문서 등)
+}
+
+// MergedMetadataPrefix는 메타데이터 병합 시 충돌한 값을 보존할 키 접두사입니다
+const MergedMetadataPrefix = "merged_"
+
+// MergeMetadata는 메타데이터를 합집합으로 병합한 새 맵을 반환합니다 (입력 맵은 변경하지 않음)
+// 키가 충돌하면 first의 값을 유지하고, 다른 값은 "merged_<key>" 키에 쉼표로 이어 보존합니다
+// 메시지나 세션을 합치거나 중복을 제거할 때 part_index, service 같은 출처 정보를 잃지 않도록 사용합니다
+func MergeMetadata(first, second map[string]string) map[string]string {
+	if len(first) == 0 && len(second) == 0 {
+		return nil
+	}
+
+	merged := make(map[string]string, len(first)+len(second))
+	for key, value := range first {
+		merged[key] = value
+	}
+
+	keys := make([]string, 0, len(second))
+	for key := range second {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := second[key]
+		existing, exists := merged[key]
+		if !exists {
+			merged[key] = value
+			continue
+		}
+		if existing == value {
+			continue
+		}
+
+		conflictKey := MergedMetadataPrefix + key
+		if previous, ok := merged[conflictKey]; ok && previous != "" {
+			merged[conflictKey] = previous + "," + value
+		} else {
+			merged[conflictKey] = value
+		}
+	}
+
+	return merged
 }
 
 // FileReference는 파일 참조 정보를 나타냅니다
@@ -65,6 +163,15 @@ type CollectionConfig struct {
 	DateRange     *DateRange         `json:"date_range,omitempty" yaml:"date_range,omitempty"`
 	OutputPath    string             `json:"output_path" yaml:"output_path"`
 	Template      string             `json:"template" yaml:"template"`
+	ExcludeKeywords []string         `json:"exclude_keywords,omitempty" yaml:"exclude_keywords,omitempty"`
+	Workers       int                `json:"workers,omitempty" yaml:"workers,omitempty"`
+	ReadRate      float64            `json:"read_rate,omitempty" yaml:"read_rate,omitempty"`
+	FSRetries     int                `json:"fs_retries,omitempty" yaml:"fs_retries,omitempty"` // 일시적인 파일 시스템 오류 재시도 횟수 (0이면 재시도 안 함)
+	ReportRejected bool              `json:"report_rejected,omitempty" yaml:"report_rejected,omitempty"`
+	MergeConversations bool          `json:"merge_conversations,omitempty" yaml:"merge_conversations,omitempty"`
+	CaptureEnv    bool               `json:"capture_env,omitempty" yaml:"capture_env,omitempty"`
+	ConfigPath    string             `json:"config_path,omitempty" yaml:"config_path,omitempty"` // 수집에 사용한 설정 파일 (환경 기록용)
+	Files         []string           `json:"files,omitempty" yaml:"files,omitempty"` // 지정하면 디렉토리 탐색 없이 이 파일들만 파싱
 }
 
 // DateRange는 날짜 범위를 나타냅니다
@@ -73,24 +180,305 @@ type DateRange struct {
 	End   time.Time `json:"end" yaml:"end"`
 }
 
+// Contains는 시각이 날짜 범위 안에 있는지 확인합니다 (zero 값인 경계는 제한하지 않음)
+func (d *DateRange) Contains(t time.Time) bool {
+	if d == nil {
+		return true
+	}
+	if !d.Start.IsZero() && t.Before(d.Start) {
+		return false
+	}
+	if !d.End.IsZero() && t.After(d.End) {
+		return false
+	}
+	return true
+}
+
+// FilterSessionsByDateRange는 타임스탬프가 날짜 범위 안에 있는 세션만 반환합니다
+// dateRange가 nil이면 입력을 그대로 반환합니다
+func FilterSessionsByDateRange(sessions []SessionData, dateRange *DateRange) []SessionData {
+	if dateRange == nil {
+		return sessions
+	}
+
+	filtered := make([]SessionData, 0, len(sessions))
+	for _, session := range sessions {
+		if dateRange.Contains(session.Timestamp) {
+			filtered = append(filtered, session)
+		}
+	}
+	return filtered
+}
+
 // ExportConfig는 마크다운 내보내기 설정을 나타냅니다
 type ExportConfig struct {
 	Template         string            `json:"template" yaml:"template"`
 	OutputPath       string            `json:"output_path" yaml:"output_path"`
 	IncludeMetadata  bool              `json:"include_metadata" yaml:"include_metadata"`
+	NoFooter         bool              `json:"no_footer,omitempty" yaml:"no_footer,omitempty"` // 메타데이터를 포함해도 문서 끝 메타데이터(생성 도구/시간/사용자 정의 필드)는 제외
 	IncludeTimestamps bool             `json:"include_timestamps" yaml:"include_timestamps"`
 	FormatCodeBlocks bool              `json:"format_code_blocks" yaml:"format_code_blocks"`
 	GenerateTOC      bool              `json:"generate_toc" yaml:"generate_toc"`
+	OutlineOnly      bool              `json:"outline_only,omitempty" yaml:"outline_only,omitempty"`
+	PerSessionDir    string            `json:"per_session_dir,omitempty" yaml:"per_session_dir,omitempty"`
+	IncludeErrors    bool              `json:"include_errors,omitempty" yaml:"include_errors,omitempty"`
+	NormalizeWhitespace bool           `json:"normalize_whitespace,omitempty" yaml:"normalize_whitespace,omitempty"`
+	RecentWindow     time.Duration     `json:"recent_window,omitempty" yaml:"recent_window,omitempty"`
+	SortMessages     bool              `json:"sort_messages,omitempty" yaml:"sort_messages,omitempty"`
+	PruneEmptyMetadata bool            `json:"prune_empty_metadata,omitempty" yaml:"prune_empty_metadata,omitempty"`
+	ThreadMessages   bool              `json:"thread_messages,omitempty" yaml:"thread_messages,omitempty"`
+	CSVLevel         string            `json:"csv_level,omitempty" yaml:"csv_level,omitempty"`
+	DateRange        *DateRange        `json:"date_range,omitempty" yaml:"date_range,omitempty"`
+	RoleIcons        map[string]string `json:"role_icons,omitempty" yaml:"role_icons,omitempty"`
+	NoIcons          bool              `json:"no_icons,omitempty" yaml:"no_icons,omitempty"`
+	ContentHash      bool              `json:"content_hash,omitempty" yaml:"content_hash,omitempty"`
+	DetectLanguage   bool              `json:"detect_language,omitempty" yaml:"detect_language,omitempty"` // 세션 주 언어를 metadata["language"]에 기록
+	Annotations      map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	MermaidTimeline  bool              `json:"mermaid_timeline,omitempty" yaml:"mermaid_timeline,omitempty"`
+	GroupByMeta      string            `json:"group_by_meta,omitempty" yaml:"group_by_meta,omitempty"` // 소스 대신 이 메타데이터 키의 값별로 세션 섹션을 나눔
+	ActivityHeatmap  bool              `json:"activity_heatmap,omitempty" yaml:"activity_heatmap,omitempty"` // 요일/시간대별 세션 수 히트맵 섹션 추가
+	NarrativeOverview bool             `json:"narrative_overview,omitempty" yaml:"narrative_overview,omitempty"` // 개요에 통계를 풀어 쓴 자연어 요약 문단 추가
+	SourceBudget     int               `json:"source_budget,omitempty" yaml:"source_budget,omitempty"`
+	LatestPerSource  bool              `json:"latest_per_source,omitempty" yaml:"latest_per_source,omitempty"` // 소스별로 가장 최근 세션 하나만 내보냄
+	Top              int               `json:"top,omitempty" yaml:"top,omitempty"` // 가장 활발한 세션 N개만 내보냄 (통계는 전체 기준, 0이면 사용 안 함)
+	TopBy            string            `json:"top_by,omitempty" yaml:"top_by,omitempty"` // --top 선택 기준 (messages: 메시지 수(기본값), duration: 지속 시간)
+	MinSessions      int               `json:"min_sessions,omitempty" yaml:"min_sessions,omitempty"`
+	CodeCaptions     bool              `json:"code_captions,omitempty" yaml:"code_captions,omitempty"`
+	SinceLastExport  bool              `json:"since_last_export,omitempty" yaml:"since_last_export,omitempty"`
+	MetricsOut       string            `json:"metrics_out,omitempty" yaml:"metrics_out,omitempty"`
+	IncludeSourceLinks bool            `json:"include_source_links,omitempty" yaml:"include_source_links,omitempty"`
+	ExtractCode      bool              `json:"extract_code,omitempty" yaml:"extract_code,omitempty"`
+	Format           string            `json:"format,omitempty" yaml:"format,omitempty"` // markdown, csv, oneline (비어 있으면 출력 확장자로 결정)
+	ActiveSourceMetric string          `json:"active_source_metric,omitempty" yaml:"active_source_metric,omitempty"` // 가장 활발한 소스 판단 기준 (sessions: 세션 수(기본값), messages: 메시지 수)
+	DisplayTimezone  string            `json:"display_timezone,omitempty" yaml:"display_timezone,omitempty"` // 시각 표시 시간대 (Local, UTC, Asia/Seoul 등, 비어 있으면 원래 시간대)
+	Append           bool              `json:"append,omitempty" yaml:"append,omitempty"` // 기존 마크다운 파일에 새 세션만 날짜별 섹션으로 추가
+	Backup           bool              `json:"backup,omitempty" yaml:"backup,omitempty"` // 기존 출력 파일을 <이름>.bak-<시각>으로 옮긴 뒤 쓰기
+	NoClobber        bool              `json:"no_clobber,omitempty" yaml:"no_clobber,omitempty"` // 출력 파일이 이미 있으면 덮어쓰지 않고 실패
+	CollapseSessionsOver int           `json:"collapse_sessions_over,omitempty" yaml:"collapse_sessions_over,omitempty"` // 메시지가 이 수보다 많은 세션 본문을 <details>로 접음 (0이면 사용 안 함)
+	SessionSeparator string            `json:"session_separator,omitempty" yaml:"session_separator,omitempty"` // 세션 사이 구분자 (비어 있으면 "---", "none"이면 생략, "blank"이면 빈 줄)
+	Workers          int               `json:"workers,omitempty" yaml:"workers,omitempty"` // 마크다운 소스 섹션을 동시에 렌더링할 최대 수 (0 또는 1이면 순차)
+	SourceOrder      []CollectionSource `json:"source_order,omitempty" yaml:"source_order,omitempty"` // 소스 섹션 출력 순서 (나열하지 않은 소스는 뒤에 기본 순서로)
+	CommandEnvKeysOnly bool            `json:"command_env_keys_only,omitempty" yaml:"command_env_keys_only,omitempty"` // 메타데이터 포함 시 명령어 환경 변수의 이름만 정렬해 표시 (값은 제외)
+	ANSIHTML         bool              `json:"ansi_html,omitempty" yaml:"ansi_html,omitempty"` // 명령어 출력의 ANSI 색상을 <span style>로 변환 (기본값: 이스케이프 코드 제거)
 	CustomFields     map[string]string `json:"custom_fields,omitempty" yaml:"custom_fields,omitempty"`
 }
 
+// DisplayLocation은 DisplayTimezone에 해당하는 시간대를 반환합니다
+// 설정되지 않았으면 nil을 반환하며, 이 경우 시각은 원래 시간대로 표시됩니다
+func (c *ExportConfig) DisplayLocation() (*time.Location, error) {
+	if c == nil || c.DisplayTimezone == "" {
+		return nil, nil
+	}
+	location, err := time.LoadLocation(c.DisplayTimezone)
+	if err != nil {
+		return nil, fmt.Errorf("알 수 없는 시간대입니다: %s: %w", c.DisplayTimezone, err)
+	}
+	return location, nil
+}
+
+// CheckMinSessions는 내보낼 세션 수가 MinSessions 이상인지 확인합니다
+// 기준에 못 미치면 기존 출력 파일을 덮어쓰지 않도록 에러를 반환합니다
+func (c *ExportConfig) CheckMinSessions(count int) error {
+	if c == nil || c.MinSessions <= 0 || count >= c.MinSessions {
+		return nil
+	}
+	return fmt.Errorf("내보낼 세션이 %d개로 최소 기준(%d개)보다 적어 출력 파일을 쓰지 않습니다", count, c.MinSessions)
+}
+
+// CurrentSchemaVersion은 저장되는 CollectionResult의 현재 스키마 버전입니다
+// 버전이 없는 파일은 0으로 취급합니다
+const CurrentSchemaVersion = 1
+
 // CollectionResult는 데이터 수집 결과를 나타냅니다
 type CollectionResult struct {
+	SchemaVersion int               `json:"schema_version,omitempty" yaml:"schema_version,omitempty"`
 	Sessions    []SessionData     `json:"sessions" yaml:"sessions"`
 	TotalCount  int               `json:"total_count" yaml:"total_count"`
 	Sources     []CollectionSource `json:"sources" yaml:"sources"`
 	CollectedAt time.Time         `json:"collected_at" yaml:"collected_at"`
 	Duration    time.Duration     `json:"duration" yaml:"duration"`
 	Errors      []string          `json:"errors,omitempty" yaml:"errors,omitempty"`
+	Issues      []CollectionIssue `json:"issues,omitempty" yaml:"issues,omitempty"`
+	Statistics  *CollectionStatistics `json:"statistics,omitempty" yaml:"statistics,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"` // 수집 환경 정보 (--capture-env)
+	ScanStats   []DirScanStats    `json:"scan_stats,omitempty" yaml:"scan_stats,omitempty"` // 세션 디렉토리 스캔 진단
+	IsFallback  bool              `json:"is_fallback" yaml:"is_fallback"` // 모든 세션이 대체(더미) 데이터라 실제로 수집된 것이 없음
+}
+
+// DirScanStats는 세션 디렉토리 한 곳을 스캔한 결과입니다
+// 세션이 수집되지 않았을 때 디렉토리가 없는지, 비어 있는지, 일치하는 파일이 없는지 구분하는 데 씁니다
+type DirScanStats struct {
+	Source       CollectionSource `json:"source" yaml:"source"`
+	Dir          string           `json:"dir" yaml:"dir"`
+	Missing      bool             `json:"missing,omitempty" yaml:"missing,omitempty"`
+	FilesScanned int              `json:"files_scanned" yaml:"files_scanned"` // 디렉토리 아래에서 발견한 파일 수
+	FilesMatched int              `json:"files_matched" yaml:"files_matched"` // 지원 형식과 일치한 파일 수
+	FilesParsed  int              `json:"files_parsed" yaml:"files_parsed"`   // 세션으로 파싱된 파일 수
+	FilesErrored int              `json:"files_errored" yaml:"files_errored"` // 파싱 에러로 세션을 얻지 못한 파일 수
+}
+
+// FilesSkipped는 지원 형식과 일치하지 않아 건너뛴 파일 수를 반환합니다
+func (s DirScanStats) FilesSkipped() int {
+	return s.FilesScanned - s.FilesMatched
+}
+
+// SummarizeScanStats는 디렉토리별 스캔 결과를 소스별로 합산합니다 (소스는 처음 나온 순서 유지, Dir은 비움)
+func SummarizeScanStats(stats []DirScanStats) []DirScanStats {
+	var summaries []DirScanStats
+	index := make(map[CollectionSource]int)
+	for _, s := range stats {
+		i, ok := index[s.Source]
+		if !ok {
+			i = len(summaries)
+			index[s.Source] = i
+			summaries = append(summaries, DirScanStats{Source: s.Source, Missing: true})
+		}
+		summary := &summaries[i]
+		summary.Missing = summary.Missing && s.Missing
+		summary.FilesScanned += s.FilesScanned
+		summary.FilesMatched += s.FilesMatched
+		summary.FilesParsed += s.FilesParsed
+		summary.FilesErrored += s.FilesErrored
+	}
+	return summaries
+}
+
+// Diagnosis는 디렉토리에서 세션을 얻지 못한 이유를 설명합니다 (문제가 없으면 빈 문자열)
+func (s DirScanStats) Diagnosis() string {
+	switch {
+	case s.Missing:
+		return "디렉토리가 존재하지 않습니다"
+	case s.FilesScanned == 0:
+		return "디렉토리가 비어 있습니다"
+	case s.FilesMatched == 0:
+		return fmt.Sprintf("파일 %d개 중 지원하는 형식의 파일이 없습니다", s.FilesScanned)
+	case s.FilesParsed == 0:
+		return fmt.Sprintf("일치한 파일 %d개를 모두 파싱하지 못했습니다", s.FilesMatched)
+	default:
+		return ""
+	}
+}
+
+// IssuePhase는 수집 문제가 발생한 단계를 나타냅니다
+type IssuePhase string
+
+const (
+	IssuePhaseConfig  IssuePhase = "config"  // 소스 설정 또는 collector 생성
+	IssuePhaseCollect IssuePhase = "collect" // collector의 데이터 수집
+	IssuePhaseParse   IssuePhase = "parse"   // 개별 파일/라인 파싱
+)
+
+// IssueSeverity는 수집 문제의 심각도를 나타냅니다
+type IssueSeverity string
+
+const (
+	IssueSeverityWarning IssueSeverity = "warning"
+	IssueSeverityError   IssueSeverity = "error"
+)
+
+// CollectionIssue는 수집 중 발생한 문제를 구조화된 형태로 나타냅니다
+type CollectionIssue struct {
+	Source   CollectionSource `json:"source,omitempty" yaml:"source,omitempty"`
+	Phase    IssuePhase       `json:"phase" yaml:"phase"`
+	Severity IssueSeverity    `json:"severity" yaml:"severity"`
+	Message  string           `json:"message" yaml:"message"`
+}
+
+// AddIssue는 수집 문제를 기록합니다
+// 이전 버전과의 호환을 위해 메시지를 Errors에도 함께 추가합니다
+func (r *CollectionResult) AddIssue(issue CollectionIssue) {
+	r.Issues = append(r.Issues, issue)
+	r.Errors = append(r.Errors, issue.Message)
+}
+
+// ErrorMessages는 수집 문제 메시지 목록을 반환합니다
+// Issues가 없는 이전 버전 결과는 Errors를 그대로 반환합니다
+func (r *CollectionResult) ErrorMessages() []string {
+	if len(r.Issues) == 0 {
+		return r.Errors
+	}
+
+	messages := make([]string, len(r.Issues))
+	for i, issue := range r.Issues {
+		messages[i] = issue.Message
+	}
+	return messages
+}
+
+// UpdateFallback은 세션이 하나 이상 있고 모두 대체(더미) 세션일 때 IsFallback을 설정합니다
+func (r *CollectionResult) UpdateFallback() {
+	r.IsFallback = len(r.Sessions) > 0
+	for _, session := range r.Sessions {
+		if !session.IsFallback() {
+			r.IsFallback = false
+			return
+		}
+	}
+}
+
+// Migrate는 이전 스키마 버전으로 저장된 결과를 현재 버전으로 업그레이드합니다
+// 현재보다 높은 버전은 지원하지 않으므로 에러를 반환합니다
+func (r *CollectionResult) Migrate() error {
+	if r.SchemaVersion > CurrentSchemaVersion {
+		return fmt.Errorf("지원하지 않는 스키마 버전입니다: %d (현재 %d)", r.SchemaVersion, CurrentSchemaVersion)
+	}
+
+	if r.SchemaVersion < 1 {
+		// v0: TotalCount와 Sources가 누락되었을 수 있음
+		if r.TotalCount == 0 {
+			r.TotalCount = len(r.Sessions)
+		}
+		if len(r.Sources) == 0 {
+			seen := make(map[CollectionSource]bool)
+			for _, session := range r.Sessions {
+				if session.Source == "" || seen[session.Source] {
+					continue
+				}
+				seen[session.Source] = true
+				r.Sources = append(r.Sources, session.Source)
+			}
+		}
+	}
+
+	r.SchemaVersion = CurrentSchemaVersion
+	return nil
+}
+
+// CollectionStatistics는 저장 시점에 계산된 수집 데이터의 주요 집계 값입니다
+type CollectionStatistics struct {
+	TotalSessions int                      `json:"total_sessions" yaml:"total_sessions"`
+	TotalMessages int                      `json:"total_messages" yaml:"total_messages"`
+	TotalCommands int                      `json:"total_commands" yaml:"total_commands"`
+	TotalFiles    int                      `json:"total_files" yaml:"total_files"`
+	SourceCounts  map[CollectionSource]int `json:"source_counts,omitempty" yaml:"source_counts,omitempty"`
+	DateRange     *DateRange               `json:"date_range,omitempty" yaml:"date_range,omitempty"`
+}
+
+// NewCollectionStatistics는 세션 목록에서 수집 통계를 계산합니다
+func NewCollectionStatistics(sessions []SessionData) *CollectionStatistics {
+	stats := &CollectionStatistics{
+		TotalSessions: len(sessions),
+		SourceCounts:  make(map[CollectionSource]int),
+	}
+
+	for i, session := range sessions {
+		stats.SourceCounts[session.Source]++
+		stats.TotalMessages += len(session.Messages)
+		stats.TotalCommands += len(session.Commands)
+		stats.TotalFiles += len(session.Files)
+
+		if i == 0 {
+			stats.DateRange = &DateRange{Start: session.Timestamp, End: session.Timestamp}
+			continue
+		}
+		if session.Timestamp.Before(stats.DateRange.Start) {
+			stats.DateRange.Start = session.Timestamp
+		}
+		if session.Timestamp.After(stats.DateRange.End) {
+			stats.DateRange.End = session.Timestamp
+		}
+	}
+
+	return stats
 }
 
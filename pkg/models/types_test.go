@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCollectionSource(t *testing.T) {
@@ -38,6 +39,57 @@ func TestCollectionSource(t *testing.T) {
 	}
 }
 
+func TestParseSource(t *testing.T) {
+	for _, source := range []CollectionSource{SourceClaudeCode, SourceGeminiCLI, SourceAmazonQ} {
+		parsed, err := ParseSource(string(source))
+		require.NoError(t, err)
+		assert.Equal(t, source, parsed)
+	}
+
+	for _, name := range []string{"", "cursor", "Claude_Code", "claude-code"} {
+		_, err := ParseSource(name)
+		require.Error(t, err, name)
+		assert.Contains(t, err.Error(), "알 수 없는 데이터 소스")
+	}
+}
+
+func TestCollectionSource_DisplayName(t *testing.T) {
+	assert.Equal(t, "Claude Code", SourceClaudeCode.DisplayName())
+	assert.Equal(t, "Gemini CLI", SourceGeminiCLI.DisplayName())
+	assert.Equal(t, "Amazon Q", SourceAmazonQ.DisplayName())
+	assert.Equal(t, "cursor", CollectionSource("cursor").DisplayName())
+}
+
+func TestDirScanStats_Diagnosis(t *testing.T) {
+	assert.Equal(t, "디렉토리가 존재하지 않습니다", DirScanStats{Missing: true}.Diagnosis())
+	assert.Equal(t, "디렉토리가 비어 있습니다", DirScanStats{}.Diagnosis())
+	assert.Equal(t, "파일 4개 중 지원하는 형식의 파일이 없습니다", DirScanStats{FilesScanned: 4}.Diagnosis())
+	assert.Equal(t, "일치한 파일 2개를 모두 파싱하지 못했습니다",
+		DirScanStats{FilesScanned: 4, FilesMatched: 2}.Diagnosis())
+	assert.Empty(t, DirScanStats{FilesScanned: 4, FilesMatched: 2, FilesParsed: 1}.Diagnosis())
+}
+
+func TestSectionOrder(t *testing.T) {
+	assert.Equal(t, []CollectionSource{SourceClaudeCode, SourceGeminiCLI, SourceAmazonQ}, SectionOrder(nil))
+	assert.Equal(t, []CollectionSource{SourceGeminiCLI, SourceClaudeCode, SourceAmazonQ},
+		SectionOrder([]CollectionSource{SourceGeminiCLI}))
+	assert.Equal(t, []CollectionSource{SourceAmazonQ, SourceClaudeCode, SourceGeminiCLI},
+		SectionOrder([]CollectionSource{SourceAmazonQ, SourceClaudeCode, SourceGeminiCLI}))
+}
+
+func TestSummarizeScanStats(t *testing.T) {
+	summaries := SummarizeScanStats([]DirScanStats{
+		{Source: SourceGeminiCLI, Dir: "/a", FilesScanned: 100, FilesMatched: 80, FilesParsed: 76, FilesErrored: 4},
+		{Source: SourceAmazonQ, Dir: "/q", Missing: true},
+		{Source: SourceGeminiCLI, Dir: "/b", FilesScanned: 20, FilesMatched: 20, FilesParsed: 19, FilesErrored: 1},
+	})
+
+	require.Len(t, summaries, 2)
+	assert.Equal(t, DirScanStats{Source: SourceGeminiCLI, FilesScanned: 120, FilesMatched: 100, FilesParsed: 95, FilesErrored: 5}, summaries[0])
+	assert.Equal(t, 20, summaries[0].FilesSkipped())
+	assert.Equal(t, DirScanStats{Source: SourceAmazonQ, Missing: true}, summaries[1])
+}
+
 func TestSessionData_JSONSerialization(t *testing.T) {
 	now := time.Now()
 	
@@ -130,6 +182,26 @@ func TestMessage_ValidRoles(t *testing.T) {
 	}
 }
 
+func TestMessage_AttachmentsJSON(t *testing.T) {
+	// 첨부 파일이 없으면 기존 형식과 같게 필드를 생략
+	data, err := json.Marshal(Message{ID: "m1", Role: "user", Content: "hi"})
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "attachments")
+
+	// 필드가 없는 이전 데이터도 그대로 읽힘
+	var legacy Message
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"m1","role":"user","content":"hi"}`), &legacy))
+	assert.Nil(t, legacy.Attachments)
+
+	message := Message{ID: "m2", Role: "user", Attachments: []FileReference{{Path: "/tmp/a.png", Name: "a.png"}}}
+	data, err = json.Marshal(message)
+	require.NoError(t, err)
+
+	var decoded Message
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, message.Attachments[0].Path, decoded.Attachments[0].Path)
+}
+
 func TestFileReference_BasicFields(t *testing.T) {
 	now := time.Now()
 	
@@ -464,4 +536,209 @@ func BenchmarkSessionDataJSONUnmarshal(b *testing.B) {
 			b.Fatal(err)
 		}
 	}
-}
\ No newline at end of file
+}
+func TestNewCollectionStatistics(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	sessions := []SessionData{
+		{
+			Source:    SourceClaudeCode,
+			Timestamp: start.Add(time.Hour),
+			Messages:  make([]Message, 3),
+			Commands:  make([]Command, 2),
+			Files:     make([]FileReference, 1),
+		},
+		{Source: SourceClaudeCode, Timestamp: start, Messages: make([]Message, 1)},
+		{Source: SourceAmazonQ, Timestamp: start.Add(2 * time.Hour), Messages: make([]Message, 2)},
+	}
+
+	stats := NewCollectionStatistics(sessions)
+
+	assert.Equal(t, 3, stats.TotalSessions)
+	assert.Equal(t, 6, stats.TotalMessages)
+	assert.Equal(t, 2, stats.TotalCommands)
+	assert.Equal(t, 1, stats.TotalFiles)
+	assert.Equal(t, map[CollectionSource]int{SourceClaudeCode: 2, SourceAmazonQ: 1}, stats.SourceCounts)
+	assert.Equal(t, &DateRange{Start: start, End: start.Add(2 * time.Hour)}, stats.DateRange)
+
+	empty := NewCollectionStatistics(nil)
+	assert.Equal(t, 0, empty.TotalSessions)
+	assert.Nil(t, empty.DateRange)
+}
+
+func TestCollectionResult_StatisticsBackwardCompatible(t *testing.T) {
+	// 통계가 없는 이전 형식의 파일도 로드 가능
+	legacy := `{"sessions":[{"id":"s1","source":"claude_code","timestamp":"2024-01-01T00:00:00Z","messages":[]}],"total_count":1}`
+
+	var result CollectionResult
+	assert.NoError(t, json.Unmarshal([]byte(legacy), &result))
+	assert.Nil(t, result.Statistics)
+	assert.Equal(t, 1, result.TotalCount)
+
+	// 통계가 없으면 직렬화 결과에도 포함하지 않음
+	data, err := json.Marshal(CollectionResult{})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "statistics")
+}
+
+func TestCollectionResult_Migrate(t *testing.T) {
+	t.Run("unversioned file", func(t *testing.T) {
+		legacy := `{"sessions":[
+			{"id":"s1","source":"gemini_cli","messages":[]},
+			{"id":"s2","source":"claude_code","messages":[]},
+			{"id":"s3","source":"gemini_cli","messages":[]}
+		]}`
+
+		var result CollectionResult
+		assert.NoError(t, json.Unmarshal([]byte(legacy), &result))
+		assert.Equal(t, 0, result.SchemaVersion)
+
+		assert.NoError(t, result.Migrate())
+		assert.Equal(t, CurrentSchemaVersion, result.SchemaVersion)
+		assert.Equal(t, 3, result.TotalCount)
+		assert.Equal(t, []CollectionSource{SourceGeminiCLI, SourceClaudeCode}, result.Sources)
+	})
+
+	t.Run("existing values are kept", func(t *testing.T) {
+		result := CollectionResult{
+			Sessions:   []SessionData{{ID: "s1", Source: SourceClaudeCode}},
+			TotalCount: 5,
+			Sources:    []CollectionSource{SourceAmazonQ},
+		}
+
+		assert.NoError(t, result.Migrate())
+		assert.Equal(t, 5, result.TotalCount)
+		assert.Equal(t, []CollectionSource{SourceAmazonQ}, result.Sources)
+	})
+
+	t.Run("newer version is rejected", func(t *testing.T) {
+		result := CollectionResult{SchemaVersion: CurrentSchemaVersion + 1}
+		assert.Error(t, result.Migrate())
+	})
+}
+
+func TestFilterSessionsByDateRange(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	sessions := []SessionData{
+		{ID: "a", Timestamp: day(1)},
+		{ID: "b", Timestamp: day(5)},
+		{ID: "c", Timestamp: day(10)},
+	}
+
+	assert.Equal(t, sessions, FilterSessionsByDateRange(sessions, nil))
+
+	filtered := FilterSessionsByDateRange(sessions, &DateRange{Start: day(2), End: day(10)})
+	require.Len(t, filtered, 2)
+	assert.Equal(t, "b", filtered[0].ID)
+	assert.Equal(t, "c", filtered[1].ID)
+
+	// 시작만 지정
+	filtered = FilterSessionsByDateRange(sessions, &DateRange{Start: day(6)})
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "c", filtered[0].ID)
+}
+
+func TestCollectionResult_Issues(t *testing.T) {
+	var result CollectionResult
+	result.AddIssue(CollectionIssue{
+		Source:   SourceGeminiCLI,
+		Phase:    IssuePhaseParse,
+		Severity: IssueSeverityWarning,
+		Message:  "line skipped",
+	})
+	result.AddIssue(CollectionIssue{
+		Source:   SourceAmazonQ,
+		Phase:    IssuePhaseConfig,
+		Severity: IssueSeverityError,
+		Message:  "missing config",
+	})
+
+	assert.Equal(t, []string{"line skipped", "missing config"}, result.Errors)
+	assert.Equal(t, []string{"line skipped", "missing config"}, result.ErrorMessages())
+	assert.Equal(t, SourceAmazonQ, result.Issues[1].Source)
+	assert.Equal(t, IssuePhaseConfig, result.Issues[1].Phase)
+
+	// 직렬화 후에도 구조가 유지됨
+	data, err := json.Marshal(result)
+	assert.NoError(t, err)
+	var decoded CollectionResult
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, result.Issues, decoded.Issues)
+}
+
+func TestCollectionResult_ErrorMessagesLegacy(t *testing.T) {
+	// Issues가 없는 이전 형식의 파일은 Errors를 그대로 사용
+	legacy := `{"sessions":[],"errors":["old warning"]}`
+
+	var result CollectionResult
+	assert.NoError(t, json.Unmarshal([]byte(legacy), &result))
+	assert.Empty(t, result.Issues)
+	assert.Equal(t, []string{"old warning"}, result.ErrorMessages())
+}
+
+func TestExportConfig_CheckMinSessions(t *testing.T) {
+	var nilConfig *ExportConfig
+	assert.NoError(t, nilConfig.CheckMinSessions(0))
+	assert.NoError(t, (&ExportConfig{}).CheckMinSessions(0))
+
+	cfg := &ExportConfig{MinSessions: 3}
+	assert.Error(t, cfg.CheckMinSessions(2))
+	assert.NoError(t, cfg.CheckMinSessions(3))
+	assert.NoError(t, cfg.CheckMinSessions(10))
+}
+
+func TestCollectionResult_UpdateFallback(t *testing.T) {
+	fallback := SessionData{ID: "claude-session-fallback", Metadata: map[string]string{"fallback": "true"}}
+	dummy := SessionData{ID: "amazonq-dummy-1", Metadata: map[string]string{"source_type": "amazon_q_dummy"}}
+	real := SessionData{ID: "session-1", Metadata: map[string]string{"source_type": "amazon_q_session"}}
+
+	tests := []struct {
+		name     string
+		sessions []SessionData
+		expected bool
+	}{
+		{"모두 대체 세션", []SessionData{fallback, dummy}, true},
+		{"실제 세션이 섞임", []SessionData{fallback, real}, false},
+		{"실제 세션만", []SessionData{real}, false},
+		{"세션 없음", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &CollectionResult{Sessions: tt.sessions, IsFallback: !tt.expected}
+			result.UpdateFallback()
+			assert.Equal(t, tt.expected, result.IsFallback)
+		})
+	}
+}
+
+func TestCollectionResult_IsFallbackJSON(t *testing.T) {
+	data, err := json.Marshal(CollectionResult{})
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"is_fallback":false`)
+
+	var result CollectionResult
+	require.NoError(t, json.Unmarshal([]byte(`{"sessions":[],"is_fallback":true}`), &result))
+	assert.True(t, result.IsFallback)
+}
+
+func TestMergeMetadata(t *testing.T) {
+	first := map[string]string{"service": "q", "part_index": "0"}
+	merged := MergeMetadata(first, map[string]string{"service": "q", "part_index": "1", "region": "us-east-1"})
+
+	assert.Equal(t, map[string]string{
+		"service":           "q",
+		"part_index":        "0",
+		"merged_part_index": "1",
+		"region":            "us-east-1",
+	}, merged)
+
+	// 충돌 값이 여러 번 나오면 쉼표로 이어 보존
+	merged = MergeMetadata(merged, map[string]string{"part_index": "2"})
+	assert.Equal(t, "1,2", merged["merged_part_index"])
+
+	// 입력 맵은 변경하지 않음
+	assert.Len(t, first, 2)
+
+	assert.Nil(t, MergeMetadata(nil, nil))
+	assert.Equal(t, map[string]string{"a": "1"}, MergeMetadata(nil, map[string]string{"a": "1"}))
+}